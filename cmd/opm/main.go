@@ -9,23 +9,51 @@ import (
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
 	"github.com/open-platform-model/cli/internal/cmd"
+	"github.com/open-platform-model/cli/internal/output"
 )
 
 func main() {
 	rootCmd := cmd.NewRootCmd()
 
-	if err := rootCmd.Execute(); err != nil {
-		// Check if the error contains an ExitError with a specific code
-		var exitErr *opmexit.ExitError
-		if errors.As(err, &exitErr) {
-			// Only print if the command layer hasn't already printed it
-			if !exitErr.Printed {
-				fmt.Fprintln(os.Stderr, err)
-			}
-			os.Exit(exitErr.Code)
-		}
-		// Non-ExitError: unexpected, print it
+	code := exitCode(rootCmd.Execute())
+	// os.Exit skips deferred calls, so the event file is closed here.
+	if err := output.CloseEvents(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		if code == 0 {
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+// exitCode reports err, unless the command layer already has, and returns
+// the process exit code for it.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	// Check if the error contains an ExitError with a specific code
+	var exitErr *opmexit.ExitError
+	if errors.As(err, &exitErr) {
+		// Only report if the command layer hasn't already reported it
+		// (including a diff --exit-code drift result, which is not an error)
+		if !exitErr.Printed {
+			reportError(err)
+		}
+		return exitErr.Code
+	}
+	// Non-ExitError: unexpected, print it
+	reportError(err)
+	return 1
+}
+
+// reportError prints an error the command layer has not reported, in the
+// --error-format the user chose.
+func reportError(err error) {
+	if output.JSONErrors() {
+		output.PrintErrorJSON(err)
+		return
 	}
+	output.EmitError("", err)
+	fmt.Fprintln(os.Stderr, err)
 }
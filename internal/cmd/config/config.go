@@ -17,6 +17,8 @@ func NewConfigCmd(cfg *config.GlobalConfig) *cobra.Command {
 
 	c.AddCommand(NewConfigInitCmd(cfg))
 	c.AddCommand(NewConfigVetCmd(cfg))
+	c.AddCommand(NewConfigViewCmd(cfg))
+	c.AddCommand(NewConfigSetCmd(cfg))
 
 	return c
 }
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	oerrors "github.com/open-platform-model/cli/pkg/errors"
+)
+
+// NewConfigSetCmd creates the config set command.
+func NewConfigSetCmd(_ *config.GlobalConfig) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a value in the config file",
+		Long: `Write one setting to the config file, keeping its comments and the rest
+of its content. The edited file must still pass 'opm config vet'; if it
+would not, nothing is written.
+
+A value set here is still overridden by its environment variable and
+flag; 'opm config view' shows which source wins.
+
+Settable keys:
+  ` + strings.Join(config.SettableKeys(), "\n  ") + `
+
+Examples:
+  # Deploy to staging by default
+  opm config set kubernetes.namespace staging
+
+  # Hide log timestamps
+  opm config set log.timestamps false`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runConfigSet(c, args[0], args[1])
+		},
+		Annotations: map[string]string{
+			cmdutil.SkipConfigLoadAnnotation: "true",
+		},
+	}
+
+	return c
+}
+
+func runConfigSet(c *cobra.Command, key, value string) error {
+	pathResult, err := resolveConfigPath(c)
+	if err != nil {
+		return err
+	}
+	if err := config.SetConfigValue(pathResult.ConfigPath, key, value); err != nil {
+		code := opmexit.ExitValidationError
+		if errors.Is(err, oerrors.ErrNotFound) {
+			code = opmexit.ExitNotFound
+		}
+		return &opmexit.ExitError{Code: code, Err: err}
+	}
+	output.Println(output.FormatCheckmark(fmt.Sprintf("Set %s in %s", key, pathResult.ConfigPath)))
+	return nil
+}
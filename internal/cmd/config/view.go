@@ -0,0 +1,122 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	oerrors "github.com/open-platform-model/cli/pkg/errors"
+)
+
+// NewConfigViewCmd creates the config view command.
+func NewConfigViewCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var outputFlag string
+	var showSecretsFlag bool
+
+	c := &cobra.Command{
+		Use:   "view",
+		Short: "Show the effective configuration",
+		Long: `Show the effective configuration: every setting with the value a command
+gets and where that value came from — a global flag, an environment
+variable, the config file, or the built-in default. Precedence is
+flag > env > config file > default.
+
+The kubernetes settings are also overridden by the --kubeconfig, --context,
+and --namespace flags of each command; view shows the value they fall back to.
+
+Secret values (registry passwords and tokens, and URLs carrying credentials)
+are shown as <redacted> unless --show-secrets is given.
+
+Examples:
+  # Show the effective configuration
+  opm config view
+
+  # As JSON, with an environment override
+  OPM_NAMESPACE=staging opm config view -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runConfigView(c, outputFlag, showSecretsFlag)
+		},
+		Annotations: map[string]string{
+			cmdutil.SkipConfigLoadAnnotation: "true",
+		},
+	}
+
+	c.Flags().StringVarP(&outputFlag, "output", "o", "table", "Output format: table or json")
+	c.Flags().BoolVar(&showSecretsFlag, "show-secrets", false, "Show secret values instead of redacting them")
+
+	return c
+}
+
+func runConfigView(c *cobra.Command, outputFlag string, showSecrets bool) error {
+	if outputFlag != "table" && outputFlag != "json" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid output format %q (valid: table, json)", outputFlag)}
+	}
+
+	pathResult, err := resolveConfigPath(c)
+	if err != nil {
+		return err
+	}
+	flags := map[string]string{}
+	for _, name := range config.SettingFlags() {
+		if f := c.Flag(name); f != nil && f.Changed {
+			flags[name] = f.Value.String()
+		}
+	}
+	settings, err := config.EffectiveSettings(pathResult.ConfigPath, flags)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	}
+	if !showSecrets {
+		settings = config.Redact(settings)
+	}
+
+	if outputFlag == "json" {
+		data, err := json.MarshalIndent(map[string]any{
+			"configFile":       pathResult.ConfigPath,
+			"configFileSource": pathResult.Source,
+			"settings":         settings,
+		}, "", "  ")
+		if err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		output.Println(string(data))
+		return nil
+	}
+
+	output.Println(fmt.Sprintf("Config file: %s (%s)", pathResult.ConfigPath, pathResult.Source))
+	tbl := output.NewTable("KEY", "VALUE", "SOURCE")
+	for _, s := range settings {
+		source := string(s.Source)
+		if s.Source == config.SourceEnv {
+			source += " (" + s.Env + ")"
+		}
+		tbl.Row(s.Key, s.Value, source)
+	}
+	output.Println(strings.TrimRight(tbl.String(), "\n"))
+	return nil
+}
+
+// resolveConfigPath resolves the config file path from the --config flag,
+// OPM_CONFIG, and the default. The config commands skip config loading, so the
+// flag is read from the command rather than from the loaded configuration.
+func resolveConfigPath(c *cobra.Command) (config.ResolveConfigPathResult, error) {
+	flagValue := ""
+	if f := c.Flag("config"); f != nil {
+		flagValue = f.Value.String()
+	}
+	result, err := config.ResolveConfigPath(config.ResolveConfigPathOptions{FlagValue: flagValue})
+	if err != nil {
+		return result, &opmexit.ExitError{
+			Code: opmexit.ExitNotFound,
+			Err:  oerrors.Wrap(oerrors.ErrNotFound, "could not resolve config path"),
+		}
+	}
+	return result, nil
+}
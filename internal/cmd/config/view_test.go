@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opmconfig "github.com/open-platform-model/cli/internal/config"
+)
+
+func TestConfigView_RejectsUnknownOutput(t *testing.T) {
+	setTempHome(t)
+	cmd := NewConfigViewCmd(&opmconfig.GlobalConfig{})
+	cmd.SetArgs([]string{"-o", "yaml"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
+
+func TestConfigView_MissingConfigFile(t *testing.T) {
+	setTempHome(t)
+	os.Unsetenv("OPM_CONFIG")
+
+	cmd := NewConfigViewCmd(&opmconfig.GlobalConfig{})
+	cmd.SetArgs([]string{"-o", "json"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute(), "without a config file, view shows the defaults")
+}
+
+func TestConfigSet(t *testing.T) {
+	tmpHome := setTempHome(t)
+	os.Unsetenv("OPM_CONFIG")
+	writeOpmFile(t, tmpHome, "config.cue", validVetConfig)
+
+	cmd := NewConfigSetCmd(&opmconfig.GlobalConfig{})
+	cmd.SetArgs([]string{"kubernetes.namespace", "staging"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, cmd.Execute())
+
+	content, err := os.ReadFile(filepath.Join(tmpHome, ".opm", "config.cue"))
+	require.NoError(t, err)
+	assert.Regexp(t, `namespace:\s+"staging"`, string(content))
+}
+
+func TestConfigSet_MissingConfigFile(t *testing.T) {
+	setTempHome(t)
+	os.Unsetenv("OPM_CONFIG")
+
+	cmd := NewConfigSetCmd(&opmconfig.GlobalConfig{})
+	cmd.SetArgs([]string{"registry", "localhost:5000"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "opm config init")
+}
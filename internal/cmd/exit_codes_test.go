@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+)
+
+// exitCodeFixture writes an instance and a module, both with values that fail
+// validation, and returns the instance file, the module directory, and the
+// values file.
+func exitCodeFixture(t *testing.T) (instanceFile, moduleDir, valuesFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	cueMod := "module: \"test.example.com/demo@v0\"\nlanguage: version: \"v0.15.0\"\n"
+	config := `#config: {
+	media?: [Name=string]: {
+		type: "pvc" | *"emptyDir"
+	}
+}
+`
+	files := map[string]string{
+		"instance/cue.mod/module.cue": cueMod,
+		"instance/instance.cue": `package demo
+
+kind: "ModuleInstance"
+
+metadata: {
+	name:      "demo-instance"
+	namespace: "demo"
+}
+
+#module: {
+	kind: "Module"
+	metadata: {
+		name:       "demo"
+		modulePath: "test.example.com/demo"
+		version:    "0.1.0"
+	}
+` + "\t" + config + `}
+`,
+		"values.cue": `package demo
+
+values: media: test: "test"
+`,
+		"module/cue.mod/module.cue": cueMod,
+		"module/module.cue": `package demo
+
+metadata: {
+	name:       "demo"
+	modulePath: "test.example.com/demo"
+	version:    "0.1.0"
+}
+
+` + config,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return filepath.Join(dir, "instance", "instance.cue"), filepath.Join(dir, "module"), filepath.Join(dir, "values.cue")
+}
+
+// apiServer answers every request with an API Status of the given code.
+func apiServer(t *testing.T, code int, reason string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprintf(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":%q,"code":%d}`, reason, code)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// unreachableServer returns the URL of a server that is no longer listening.
+func unreachableServer() string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+// writeKubeconfig writes a kubeconfig whose only cluster is server.
+func writeKubeconfig(t *testing.T, server string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	content := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`, server)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// exitCodeOf returns the exit code main would exit with for err.
+func exitCodeOf(err error) int {
+	if err == nil {
+		return opmexit.ExitSuccess
+	}
+	var exitErr *opmexit.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return opmexit.ExitGeneralError
+}
+
+// The README's exit-code contract, checked command by command through the
+// cobra entry points: a validation failure, an unreachable cluster, a
+// request the cluster refuses, and a missing instance each exit with their
+// own code. diff --exit-code keeps 2 for drift and collapses every error to 1.
+func TestRootCmd_ExitCodes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OPM_CONFIG", "")
+	t.Setenv("KUBECONFIG", "")
+	instanceFile, moduleDir, valuesFile := exitCodeFixture(t)
+
+	unreachable := writeKubeconfig(t, unreachableServer())
+	forbidden := writeKubeconfig(t, apiServer(t, http.StatusForbidden, "Forbidden"))
+	notFound := writeKubeconfig(t, apiServer(t, http.StatusNotFound, "NotFound"))
+
+	tests := []struct {
+		name       string
+		args       []string
+		kubeconfig string
+		want       int
+	}{
+		{"instance apply validation", []string{"instance", "apply", instanceFile, "-f", valuesFile}, unreachable, opmexit.ExitValidationError},
+		{"instance apply connectivity", []string{"instance", "apply", instanceFile}, unreachable, opmexit.ExitConnectivityError},
+		{"instance diff validation", []string{"instance", "diff", instanceFile, "-f", valuesFile}, unreachable, opmexit.ExitValidationError},
+		{"instance diff connectivity", []string{"instance", "diff", instanceFile}, unreachable, opmexit.ExitConnectivityError},
+		{"instance diff --exit-code validation", []string{"instance", "diff", instanceFile, "-f", valuesFile, "--exit-code"}, unreachable, opmexit.ExitGeneralError},
+		{"instance diff --exit-code connectivity", []string{"instance", "diff", instanceFile, "--exit-code"}, unreachable, opmexit.ExitGeneralError},
+		{"instance vet validation", []string{"instance", "vet", instanceFile, "-f", valuesFile}, "", opmexit.ExitValidationError},
+		{"module vet validation", []string{"module", "vet", moduleDir, "-f", valuesFile}, "", opmexit.ExitValidationError},
+
+		{"instance delete connectivity", []string{"instance", "delete", "demo-instance", "-n", "demo", "--force"}, unreachable, opmexit.ExitConnectivityError},
+		{"instance delete permission", []string{"instance", "delete", "demo-instance", "-n", "demo", "--force"}, forbidden, opmexit.ExitPermissionDenied},
+		{"instance delete not found", []string{"instance", "delete", "demo-instance", "-n", "demo", "--force"}, notFound, opmexit.ExitNotFound},
+
+		{"instance status connectivity", []string{"instance", "status", "demo-instance", "-n", "demo"}, unreachable, opmexit.ExitConnectivityError},
+		{"instance status permission", []string{"instance", "status", "demo-instance", "-n", "demo"}, forbidden, opmexit.ExitPermissionDenied},
+		{"instance status not found", []string{"instance", "status", "demo-instance", "-n", "demo"}, notFound, opmexit.ExitNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kubernetes.ResetClient()
+			t.Cleanup(kubernetes.ResetClient)
+
+			args := tc.args
+			if tc.kubeconfig != "" {
+				args = append(append([]string{}, args...), "--kubeconfig", tc.kubeconfig)
+			}
+			cmd := NewRootCmd()
+			cmd.SetArgs(args)
+
+			assert.Equal(t, tc.want, exitCodeOf(cmd.Execute()))
+		})
+	}
+}
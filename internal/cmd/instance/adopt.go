@@ -0,0 +1,111 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/adopt"
+)
+
+// NewInstanceAdoptCmd creates the instance adopt command.
+func NewInstanceAdoptCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+
+	var (
+		selectorFlag string
+		kindsFlag    []string
+		dryRunFlag   bool
+	)
+
+	c := &cobra.Command{
+		Use:   "adopt <name>",
+		Short: "Bring existing resources under an instance's management",
+		Long: `Adopt pre-existing cluster resources into a CLI-managed instance.
+
+Adopt lists resources of the given kinds that match the label selector, labels
+them with the instance identity, and records them in the instance's inventory.
+Nothing is rendered, applied, or re-created.
+
+Namespaced kinds are searched in the instance namespace; cluster-scoped kinds
+are searched cluster-wide. Resources already tracked by the instance are
+skipped, as are resources labeled as belonging to another OPM instance.
+
+Adopted resources are tracked like any other: if the next 'opm instance apply'
+does not render them, it prunes them. Add them to the module before applying
+(or apply with --no-prune) to keep them.
+
+Arguments:
+  name    Instance name (use -n / --namespace to scope by namespace)
+
+Examples:
+  # Adopt the legacy Deployment and Service labeled app=jellyfin
+  opm instance adopt jellyfin -n media --selector app=jellyfin --kind Deployment --kind Service
+
+  # Preview what would be adopted
+  opm instance adopt jellyfin -n media --selector app=jellyfin --kind ConfigMap --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceAdopt(args[0], cfg, &kf, namespace, selectorFlag, kindsFlag, dryRunFlag)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace")
+	c.Flags().StringVarP(&selectorFlag, "selector", "l", "", "Label selector resources must match (required)")
+	c.Flags().StringArrayVar(&kindsFlag, "kind", nil, "Resource kind to adopt, e.g. Deployment or Certificate.cert-manager.io (repeatable)")
+	c.Flags().BoolVar(&dryRunFlag, "dry-run", false, "List what would be adopted without changing anything")
+
+	return c
+}
+
+func runInstanceAdopt(name string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag, selector string, kinds []string, dryRun bool) error {
+	ctx := context.Background()
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:         cfg,
+		KubeconfigFlag: kf.Kubeconfig,
+		ContextFlag:    kf.Context,
+		NamespaceFlag:  namespaceFlag,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+	cmdutil.LogResolvedKubernetesConfig(k8sConfig.Namespace.Value, k8sConfig.Kubeconfig.Value, k8sConfig.Context.Value)
+
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		output.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	instanceLog := output.InstanceLogger(name)
+	result, err := adopt.Execute(ctx, adopt.Request{
+		Name:      name,
+		Namespace: k8sConfig.Namespace.Value,
+		Selector:  selector,
+		Kinds:     kinds,
+		DryRun:    dryRun,
+		K8sClient: k8sClient,
+		Log:       instanceLog,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(result.Adopted) == 0:
+		instanceLog.Info(fmt.Sprintf("no resources to adopt (%d already tracked or skipped)", result.Skipped))
+	case dryRun:
+		instanceLog.Info(fmt.Sprintf("dry run: %d resource(s) would be adopted", len(result.Adopted)))
+	default:
+		output.Println(output.FormatCheckmark(fmt.Sprintf("Adopted %d resource(s)", len(result.Adopted))))
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/platform"
 	workflowapply "github.com/open-platform-model/cli/internal/workflow/apply"
@@ -22,14 +23,30 @@ import (
 func NewInstanceApplyCmd(cfg *config.GlobalConfig) *cobra.Command {
 	var rff cmdutil.InstanceFileFlags
 	var kf cmdutil.K8sFlags
+	var vf cmdutil.ValuesFromFlags
+	var df cmdutil.DryRunFlags
 	var namespace string
 
 	var (
-		dryRunFlag   bool
 		createNSFlag bool
 		noPruneFlag  bool
+		pruneFlag    bool
 		forceFlag    bool
 		timeoutFlag  time.Duration
+		pruneOrder   []string
+		pruneMode    string
+		noInventory  bool
+		protectFins  []string
+		concurrency  int
+		failFast     bool
+		showManaged  bool
+		reconcile    bool
+		confirmFlag  bool
+		yesFlag      bool
+		labelPolicy  string
+		forcePrune   bool
+		adoptFlag    bool
+		safetyRatio  float64
 	)
 
 	c := &cobra.Command{
@@ -41,6 +58,13 @@ Apply records what it deployed in a ModuleInstance custom resource, so the
 ModuleInstance CRD must be installed first (run 'opm operator install
 --crds-only'). Apply fails fast with a hint if it is missing or out of date.
 
+With --no-inventory, apply writes no ModuleInstance CR and needs no CRD.
+Previously applied resources are found by a label scan of the instance
+namespace instead, so pruning is weaker: resources whose identity labels were
+removed, or that live in another namespace, are not seen. The instance is also
+invisible to 'opm instance list', 'status', and the operator. Use the flag
+consistently — mixing it with inventory-backed applies leaves a stale CR.
+
 Arguments:
   instance.cue    Path to the instance .cue file (required)
 
@@ -49,26 +73,104 @@ Examples:
   opm instance apply ./jellyfin_instance.cue
 
   # Dry run (skips the cluster gates; no CRD required)
-  opm instance apply ./jellyfin_instance.cue --dry-run`,
+  opm instance apply ./jellyfin_instance.cue --dry-run
+
+  # Client dry run: compare against live objects without calling admission
+  opm instance apply ./jellyfin_instance.cue --dry-run=client
+
+  # Prune stale Ingresses and Services before anything else
+  opm instance apply ./jellyfin_instance.cue --prune-order Ingress,Service
+
+  # Keep stale PVCs (and their data) instead of pruning them
+  opm instance apply ./jellyfin_instance.cue --prune-blacklist-finalizers kubernetes.io/pvc-protection
+
+  # Apply without a ModuleInstance CR (state managed elsewhere, e.g. GitOps)
+  opm instance apply ./jellyfin_instance.cue --no-inventory
+
+  # See which fields other controllers or kubectl also manage after the apply
+  opm instance apply ./jellyfin_instance.cue --show-managed-fields
+
+  # Also remove fields the render dropped that kubectl edit still co-owns
+  opm instance apply ./jellyfin_instance.cue --reconcile
+
+  # Review the changes, including resources that would be pruned, before applying
+  # (the prompt declines when stdin is not a terminal; --yes skips it)
+  opm instance apply ./jellyfin_instance.cue --confirm
+
+  # Refuse to apply unless the module is exactly this registry artifact
+  opm instance apply ./jellyfin_instance.cue --module-digest sha256:3f1c...
+
+  # Take values from a Secret in the cluster (it must hold a single key)
+  opm instance apply ./jellyfin_instance.cue --values-from-secret media/jellyfin-values`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			return runInstanceApply(args[0], cfg, &rff, &kf, namespace, applyFlags{
-				DryRun:   dryRunFlag,
-				CreateNS: createNSFlag,
-				NoPrune:  noPruneFlag,
-				Force:    forceFlag,
-				Timeout:  timeoutFlag,
+				DryRun:              df,
+				CreateNS:            createNSFlag,
+				NoPrune:             noPruneFlag || !pruneFlag,
+				Force:               forceFlag,
+				Timeout:             timeoutFlag,
+				PruneOrder:          pruneOrder,
+				PruneMode:           pruneMode,
+				NoInventory:         noInventory,
+				ProtectedFinalizers: protectFins,
+				Concurrency:         concurrency,
+				FailFast:            failFast,
+				ShowManagedFields:   showManaged,
+				Reconcile:           reconcile,
+				Confirm:             confirmFlag,
+				Yes:                 yesFlag,
+				LabelPolicy:         labelPolicy,
+				ForcePrune:          forcePrune,
+				Adopt:               adoptFlag,
+				PruneSafetyRatio:    safetyRatio,
+				ValuesFrom:          vf,
 			})
 		},
 	}
 
 	rff.AddTo(c)
+	rff.AddStrictTraitsTo(c)
+	rff.AddStrictNamespaceTo(c)
+	rff.AddInstanceUUIDTo(c)
+	vf.AddTo(c)
 	kf.AddTo(c)
+	kf.AddRateLimitTo(c)
+	kf.AddExpectTargetTo(c)
 	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace")
-	c.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Server-side dry run (no changes made)")
-	c.Flags().BoolVar(&createNSFlag, "create-namespace", false, "Create target namespace if it does not exist")
-	c.Flags().BoolVar(&noPruneFlag, "no-prune", false, "Skip stale resource pruning")
+	df.AddTo(c)
+	c.Flags().BoolVar(&createNSFlag, "create-namespace", false, "Create the target namespace if it does not exist; a namespace created this way is deleted with the instance")
+	c.Flags().BoolVar(&pruneFlag, "prune", true,
+		"Prune stale resources; with --prune=false they are reported, left in place, and kept in the inventory")
+	c.Flags().BoolVar(&noPruneFlag, "no-prune", false, "Skip stale resource pruning (same as --prune=false)")
+	c.Flags().Float64Var(&safetyRatio, "prune-safety-ratio", inventory.DefaultPruneSafetyRatio,
+		"Refuse to prune when more than this share of the tracked resources would be deleted (1 disables the check)")
+	c.Flags().BoolVar(&forcePrune, "force-prune", false, "Prune even when --prune-safety-ratio is exceeded")
+	c.Flags().BoolVar(&adoptFlag, "adopt", false,
+		"Take over rendered resources that already exist without OPM labels (by default the apply refuses them)")
+	c.MarkFlagsMutuallyExclusive("prune", "no-prune")
 	c.Flags().BoolVar(&forceFlag, "force", false, "Allow empty render to prune all previously tracked resources")
+	c.Flags().StringSliceVar(&pruneOrder, "prune-order", nil,
+		"Kinds to prune first, in order (e.g. Ingress,Service); others follow in reverse apply order")
+	c.Flags().StringVar(&pruneMode, "prune-mode", inventory.PruneModeDelete,
+		"What to do with stale resources: delete, or detach (remove OPM labels and stop tracking, leaving them running)")
+	c.Flags().StringSliceVar(&protectFins, "prune-blacklist-finalizers", nil,
+		"Never prune stale resources carrying any of these finalizers (e.g. kubernetes.io/pvc-protection)")
+	c.Flags().BoolVar(&noInventory, "no-inventory", false,
+		"Do not read or write the ModuleInstance CR; find previously applied resources by label scan")
+	c.Flags().IntVar(&concurrency, "apply-concurrency", kubernetes.DefaultApplyConcurrency,
+		"Components to apply in parallel within each apply wave (default 1: sequential)")
+	c.Flags().BoolVar(&failFast, "fail-fast", false,
+		"Stop at the first apply wave with a failed resource instead of applying every wave and reporting failures at the end")
+	c.Flags().BoolVar(&showManaged, "show-managed-fields", false,
+		"After applying, report which fields opm-cli and other field managers own on each resource")
+	c.Flags().BoolVar(&reconcile, "reconcile", false,
+		"Also remove fields a previous apply set that the render dropped but another field manager still holds")
+	c.Flags().BoolVar(&confirmFlag, "confirm", false,
+		"Show what the apply would change, including resources it would prune, and ask before applying")
+	c.Flags().BoolVar(&yesFlag, "yes", false, "With --confirm, show the changes but apply without asking")
+	c.Flags().StringVar(&labelPolicy, "label-conflict-policy", kubernetes.LabelConflictOPMWins,
+		"When a live label or annotation differs from the render: opm-wins, keep-existing, or merge (keep only values other tools set)")
 	c.Flags().DurationVar(&timeoutFlag, "timeout", inventory.DefaultReconcileTimeout,
 		"Bound on the operator-reconcile wait (operator-managed instances only)")
 
@@ -77,11 +179,26 @@ Examples:
 
 // applyFlags carries the apply command's behavior flags.
 type applyFlags struct {
-	DryRun   bool
-	CreateNS bool
-	NoPrune  bool
-	Force    bool
-	Timeout  time.Duration
+	DryRun              cmdutil.DryRunFlags
+	CreateNS            bool
+	NoPrune             bool
+	Force               bool
+	Timeout             time.Duration
+	PruneOrder          []string
+	PruneMode           string
+	NoInventory         bool
+	ProtectedFinalizers []string
+	Concurrency         int
+	FailFast            bool
+	ShowManagedFields   bool
+	Reconcile           bool
+	Confirm             bool
+	Yes                 bool
+	LabelPolicy         string
+	ForcePrune          bool
+	Adopt               bool
+	PruneSafetyRatio    float64
+	ValuesFrom          cmdutil.ValuesFromFlags
 }
 
 // runInstanceApply executes the instance apply command.
@@ -89,6 +206,29 @@ func runInstanceApply(instanceFile string, cfg *config.GlobalConfig, rff *cmduti
 	flags applyFlags) error {
 	ctx := context.Background()
 
+	dryRun, clientDryRun, err := flags.DryRun.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := inventory.ValidatePruneMode(flags.PruneMode); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if err := kubernetes.ValidateLabelConflictPolicy(flags.LabelPolicy); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if flags.PruneSafetyRatio < 0 || flags.PruneSafetyRatio > 1 {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("invalid --prune-safety-ratio %g (must be between 0 and 1)", flags.PruneSafetyRatio)}
+	}
+	if flags.Yes && !flags.Confirm {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--yes requires --confirm")}
+	}
+
+	valuesRefs, err := flags.ValuesFrom.Refs()
+	if err != nil {
+		return err
+	}
+
 	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
 		Config:         cfg,
 		KubeconfigFlag: kf.Kubeconfig,
@@ -101,16 +241,32 @@ func runInstanceApply(instanceFile string, cfg *config.GlobalConfig, rff *cmduti
 
 	// Cluster client before render: apply resolves its platform from the
 	// cluster Platform CR by default (0006 D21).
-	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		output.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+	if err := cmdutil.CheckTarget(k8sClient, kf); err != nil {
+		return err
+	}
+
+	valuesDocs, err := cmdutil.FetchValuesDocuments(ctx, k8sClient, valuesRefs)
+	if err != nil {
 		return err
 	}
 
 	result, err := render.FromInstanceFile(ctx, render.InstanceFileOpts{
 		InstanceFilePath: instanceFile,
 		ValuesFiles:      rff.Values,
-		PlatformFlag:     rff.Platform,
+		ValuesYAMLFiles:  rff.ValuesYAML,
+		ValuesJSONFiles:  rff.ValuesJSON,
+		ExpandEnv:        rff.EnvExpansion(),
+		ValuesDocuments:  valuesDocs,
+		PlatformFiles:    rff.Platform,
+		ExpectedDigest:   rff.ModuleDigest,
+		StrictTraits:     rff.StrictTraits,
+		StrictNamespace:  rff.StrictNamespace,
+		InstanceUUID:     rff.InstanceUUID,
 		ClusterPlatform:  platform.ClusterSpecGetterFor(k8sClient.Dynamic),
 		K8sConfig:        k8sConfig,
 		Config:           cfg,
@@ -123,18 +279,47 @@ func runInstanceApply(instanceFile string, cfg *config.GlobalConfig, rff *cmduti
 
 	instanceLog := output.InstanceLogger(result.Instance.Name)
 
-	return workflowapply.Execute(ctx, workflowapply.Request{
+	req := workflowapply.Request{
 		Result:    result,
 		K8sClient: k8sClient,
 		Log:       instanceLog,
 		Options: workflowapply.Options{
-			DryRun:                 flags.DryRun,
+			DryRun:                 dryRun,
+			ClientDryRun:           clientDryRun,
+			ShowServerDiff:         flags.DryRun.ServerDiff,
 			CreateNS:               flags.CreateNS,
 			NoPrune:                flags.NoPrune,
 			Force:                  flags.Force,
 			Timeout:                flags.Timeout,
+			PruneOrder:             flags.PruneOrder,
+			DetachStale:            flags.PruneMode == inventory.PruneModeDetach,
+			NoInventory:            flags.NoInventory,
+			ProtectedFinalizers:    flags.ProtectedFinalizers,
+			Concurrency:            flags.Concurrency,
+			FailFast:               flags.FailFast,
+			ShowManagedFields:      flags.ShowManagedFields,
+			Reconcile:              flags.Reconcile,
+			LabelConflictPolicy:    flags.LabelPolicy,
+			StrictNamespace:        rff.StrictNamespace,
+			ForcePrune:             flags.ForcePrune,
+			Adopt:                  flags.Adopt,
+			PruneSafetyRatio:       flags.PruneSafetyRatio,
 			SuccessUpToDateMessage: "Instance up to date",
 			SuccessAppliedMessage:  "Instance applied",
 		},
-	})
+	}
+	if flags.Confirm && !dryRun {
+		ok, err := workflowapply.Confirm(ctx, req, flags.Yes, cmdutil.Confirm)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			instanceLog.Info("apply canceled")
+			return nil
+		}
+	}
+	progress, finish := cmdutil.NewApplyProgress()
+	defer finish()
+	req.Options.Progress = progress
+	return workflowapply.Execute(ctx, req)
 }
@@ -60,6 +60,9 @@ Examples:
 	}
 
 	rff.AddTo(c)
+	rff.AddStrictTraitsTo(c)
+	rff.AddStrictNamespaceTo(c)
+	rff.AddInstanceUUIDTo(c)
 	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace")
 	c.Flags().StringVar(&nameFlag, "name", "", "Override synthetic instance name (module-directory mode only)")
 	c.Flags().StringVarP(&outputFlag, "output", "o", "yaml", "Output format: yaml, json")
@@ -97,22 +100,41 @@ func runInstanceBuild(buildArg string, cfg *config.GlobalConfig, rff *cmdutil.In
 	var result *render.Result
 	switch {
 	case info.IsDir():
+		if rff.ModuleDigest != "" {
+			return &opmexit.ExitError{
+				Code: opmexit.ExitGeneralError,
+				Err:  fmt.Errorf("--module-digest applies to instance files; a module directory is local source with no registry digest"),
+			}
+		}
 		result, err = render.FromModule(ctx, render.ModuleOpts{
-			ModulePath:   buildArg,
-			ValuesFiles:  rff.Values,
-			Name:         nameFlag,
-			PlatformFlag: rff.Platform, // offline: no cluster read (0006 D21)
-			K8sConfig:    k8sConfig,
-			Config:       cfg,
+			ModulePath:      buildArg,
+			ValuesFiles:     rff.Values,
+			ValuesYAMLFiles: rff.ValuesYAML,
+			ValuesJSONFiles: rff.ValuesJSON,
+			ExpandEnv:       rff.EnvExpansion(),
+			Name:            nameFlag,
+			StrictTraits:    rff.StrictTraits,
+			StrictNamespace: rff.StrictNamespace,
+			InstanceUUID:    rff.InstanceUUID,
+			PlatformFiles:   rff.Platform, // offline: no cluster read (0006 D21)
+			K8sConfig:       k8sConfig,
+			Config:          cfg,
 		})
 	default:
 		if nameFlag != "" {
 			output.Warn("--name is ignored for instance-file builds; it only applies to module-directory builds")
 		}
 		result, err = render.FromInstanceFile(ctx, render.InstanceFileOpts{
-			PlatformFlag:     rff.Platform, // offline: no cluster read (0006 D21)
+			PlatformFiles:    rff.Platform, // offline: no cluster read (0006 D21)
 			InstanceFilePath: buildArg,
 			ValuesFiles:      rff.Values,
+			ValuesYAMLFiles:  rff.ValuesYAML,
+			ValuesJSONFiles:  rff.ValuesJSON,
+			ExpandEnv:        rff.EnvExpansion(),
+			ExpectedDigest:   rff.ModuleDigest,
+			StrictTraits:     rff.StrictTraits,
+			StrictNamespace:  rff.StrictNamespace,
+			InstanceUUID:     rff.InstanceUUID,
 			K8sConfig:        k8sConfig,
 			Config:           cfg,
 		})
@@ -123,5 +145,5 @@ func runInstanceBuild(buildArg string, cfg *config.GlobalConfig, rff *cmdutil.In
 
 	render.ShowOutput(result, render.ShowOutputOpts{Verbose: cfg.Flags.Verbose})
 
-	return render.WriteManifestOutput(result.Resources, outputFormat, split, outDir, result.Instance.Name)
+	return render.WriteManifestOutput(result.Resources, outputFormat, split, false, outDir, result.Instance.Name)
 }
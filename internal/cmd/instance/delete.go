@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
@@ -33,6 +35,12 @@ func NewInstanceDeleteCmd(cfg *config.GlobalConfig) *cobra.Command {
 		forceFlag   bool
 		dryRunFlag  bool
 		timeoutFlag time.Duration
+		noInventory bool
+		waitFlag    bool
+		cascadeFlag string
+		keepInv     bool
+		orphanFlag  bool
+		invFlag     string
 	)
 
 	c := &cobra.Command{
@@ -47,6 +55,33 @@ Arguments:
   name         Instance name (use -n / --namespace to scope by namespace).
   uuid         Instance UUID.
 
+With --no-inventory, the resources to delete are found by a label scan of the
+instance namespace (and cluster-scoped types) instead of the ModuleInstance CR,
+for instances applied with 'opm instance apply --no-inventory'. Resources whose
+identity labels were removed are not found, and no ModuleInstance is deleted.
+An instance whose ModuleInstance inventory cannot be read (a manual edit,
+version skew) falls back to the same label scan with a warning; --inventory
+makes that fatal (require) or uses the label scan outright (ignore).
+
+Deletes use foreground cascading by default (--cascade): each resource stays,
+Terminating, until its dependents are gone. With --wait the command blocks
+until every deleted resource is gone from the cluster (bounded by --timeout),
+and the ModuleInstance CR is removed only once they are. Resources still
+present at the timeout are listed with the finalizers holding them, and the
+ModuleInstance is kept so a re-run can finish the job.
+
+Two modes change what is torn down (CLI-managed instances only; they are
+mutually exclusive):
+  --keep-inventory  Delete the resources but keep the ModuleInstance CR as a
+                    record of the instance for audit.
+  --orphan          Delete nothing: remove the module-instance.opmodel.dev/*
+                    labels and OPM's app.kubernetes.io/managed-by label from
+                    each resource, annotate it with opmodel.dev/orphaned-at,
+                    and delete the ModuleInstance CR.
+                    The resources keep running, no longer tracked by OPM.
+--orphan is unrelated to --cascade=orphan, which deletes the resources but
+leaves their dependents (pods, ReplicaSets) behind.
+
 Examples:
   # Delete by instance.cue file in the current directory
   opm instance delete .
@@ -61,25 +96,87 @@ Examples:
   opm instance delete jellyfin -n media --dry-run
 
   # Skip confirmation prompt
-  opm instance delete jellyfin -n media --force`,
+  opm instance delete jellyfin -n media --force
+
+  # Wait until the StatefulSet's pods are gone before returning
+  opm instance delete postgres -n db --wait --timeout 10m
+
+  # Tear down the workloads but keep the instance record
+  opm instance delete jellyfin -n media --keep-inventory
+
+  # Stop tracking the resources and leave them running
+  opm instance delete jellyfin -n media --orphan
+
+  # Delete an instance applied without a ModuleInstance CR
+  opm instance delete jellyfin -n media --no-inventory`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			return runInstanceDelete(args[0], cfg, &kf, namespace, forceFlag, dryRunFlag, timeoutFlag)
+			propagation, err := parseCascade(cascadeFlag)
+			if err != nil {
+				return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+			}
+			mode, err := parseInventoryMode(invFlag)
+			if err != nil {
+				return err
+			}
+			opts := kubernetes.DeleteOptions{
+				DryRun:            dryRunFlag,
+				PropagationPolicy: propagation,
+				Wait:              waitFlag,
+				Timeout:           timeoutFlag,
+				KeepInventory:     keepInv,
+				Orphan:            orphanFlag,
+			}
+			return runInstanceDelete(args[0], cfg, &kf, namespace, forceFlag, noInventory, mode, opts)
 		},
 	}
 
 	kf.AddTo(c)
+	kf.AddRateLimitTo(c)
 	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace")
 	c.Flags().BoolVar(&forceFlag, "force", false, "Skip confirmation prompt")
 	c.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Preview without deleting")
 	c.Flags().DurationVar(&timeoutFlag, "timeout", inventory.DefaultReconcileTimeout,
-		"Bound on the operator-cleanup wait (operator-managed instances only)")
+		"Bound on --wait, and on the operator-cleanup wait for operator-managed instances")
+	c.Flags().BoolVar(&waitFlag, "wait", false,
+		"Wait until deleted resources and their dependents are gone before removing the ModuleInstance")
+	c.Flags().StringVar(&cascadeFlag, "cascade", "foreground",
+		"Deletion propagation: foreground, background, or orphan")
+	c.Flags().BoolVar(&noInventory, "no-inventory", false,
+		"Find resources by label scan instead of the ModuleInstance CR")
+	c.Flags().BoolVar(&keepInv, "keep-inventory", false,
+		"Delete the resources but keep the ModuleInstance CR as an audit record")
+	c.Flags().BoolVar(&orphanFlag, "orphan", false,
+		"Untrack the resources and leave them running instead of deleting them")
+	addInventoryModeFlag(c, &invFlag)
+	c.MarkFlagsMutuallyExclusive("keep-inventory", "orphan")
+	c.MarkFlagsMutuallyExclusive("keep-inventory", "no-inventory")
+	c.MarkFlagsMutuallyExclusive("inventory", "no-inventory")
+	c.MarkFlagsMutuallyExclusive("orphan", "wait")
 
 	return c
 }
 
-func runInstanceDelete(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string, force, dryRun bool, timeout time.Duration) error {
+// parseCascade maps a --cascade value to a deletion propagation policy.
+func parseCascade(value string) (metav1.DeletionPropagation, error) {
+	switch strings.ToLower(value) {
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "background":
+		return metav1.DeletePropagationBackground, nil
+	case "orphan":
+		return metav1.DeletePropagationOrphan, nil
+	default:
+		return "", fmt.Errorf("invalid --cascade %q: must be foreground, background, or orphan", value)
+	}
+}
+
+// runInstanceDelete resolves the instance and deletes it. opts carries the
+// flag-derived settings (dry-run, propagation, wait); the instance identity
+// and inventory fields are filled in here.
+func runInstanceDelete(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string, force, noInventory bool, mode inventory.ReadMode, opts kubernetes.DeleteOptions) error {
 	ctx := context.Background()
+	dryRun := opts.DryRun
 
 	target, err := cmdutil.ResolveInstanceTarget(identifier, cfg, kf, namespaceFlag)
 	if err != nil {
@@ -91,22 +188,36 @@ func runInstanceDelete(identifier string, cfg *config.GlobalConfig, kf *cmdutil.
 	namespace := target.Namespace
 	instanceLog := output.InstanceLogger(target.LogName)
 
-	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		instanceLog.Error("connecting to cluster", "error", err)
-		return err
+		return cmdutil.MarkPrinted(err)
 	}
 
 	if dryRun {
 		instanceLog.Info("dry run - no changes will be made")
 	} else if !force {
-		if !confirmInstanceDelete(rsf.InstanceName, rsf.InstanceID, namespace) {
+		if !confirmInstanceDelete(rsf.InstanceName, rsf.InstanceID, namespace, opts.Orphan) {
 			instanceLog.Info("deletion canceled")
 			return nil
 		}
 	}
 
-	inv, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, rsf, namespace, instanceLog)
+	if noInventory {
+		selector := inventory.InstanceLabelSelector(rsf.InstanceName, namespace)
+		if rsf.InstanceID != "" {
+			selector = inventory.InstanceUUIDLabelSelector(rsf.InstanceID)
+		}
+		liveResources, err := inventory.DiscoverResourcesByLabels(ctx, k8sClient, selector, namespace)
+		if err != nil {
+			instanceLog.Error("label scan failed", "error", err)
+			return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err, Printed: true}
+		}
+		opts.Source = kubernetes.SourceLabelScan
+		return executeInstanceDelete(ctx, k8sClient, rsf, namespace, nil, liveResources, opts, instanceLog)
+	}
+
+	inv, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, rsf, namespace, mode, instanceLog)
 	if err != nil {
 		return err
 	}
@@ -115,10 +226,20 @@ func runInstanceDelete(identifier string, cfg *config.GlobalConfig, kf *cmdutil.
 	// instance is deleted by deleting its CR and letting the operator's
 	// finalizer prune the workloads.
 	if inventory.ResolveOwnership(inv) == inventory.ModeOperatorOwned {
-		return deleteOperatorOwned(ctx, k8sClient, inv, timeout, dryRun, instanceLog)
+		if opts.KeepInventory || opts.Orphan {
+			return &opmexit.ExitError{
+				Code: opmexit.ExitGeneralError,
+				Err: fmt.Errorf("--keep-inventory and --orphan apply to CLI-managed instances; %q is operator-managed and its ModuleInstance decides cleanup (spec.prune)",
+					inv.Name),
+			}
+		}
+		return deleteOperatorOwned(ctx, k8sClient, inv, opts.Timeout, dryRun, instanceLog)
 	}
 
-	return executeInstanceDelete(ctx, k8sClient, rsf, namespace, inv, liveResources, dryRun, instanceLog)
+	if inventory.FindsByLabels(inv, mode) {
+		opts.Source = kubernetes.SourceLabelScan
+	}
+	return executeInstanceDelete(ctx, k8sClient, rsf, namespace, inv, liveResources, opts, instanceLog)
 }
 
 // deleteOperatorOwned deletes an operator-managed instance by removing its
@@ -197,23 +318,40 @@ func deleteOperatorOwned(ctx context.Context, k8sClient *kubernetes.Client, inv
 }
 
 // executeInstanceDelete deletes the instance's tracked workloads, then the
-// ModuleInstance CR last (after all workloads are gone; skipped on dry-run).
-func executeInstanceDelete(ctx context.Context, k8sClient *kubernetes.Client, rsf *cmdutil.InstanceSelectorFlags, namespace string, inv *inventory.Record, liveResources []*unstructured.Unstructured, dryRun bool, instanceLog *log.Logger) error {
-	instanceLog.Info(fmt.Sprintf("deleting resources in namespace %q", namespace))
-
-	deleteResult, err := kubernetes.Delete(ctx, k8sClient, kubernetes.DeleteOptions{
-		InstanceName:          rsf.InstanceName,
-		Namespace:             namespace,
-		InstanceID:            rsf.InstanceID,
-		DryRun:                dryRun,
-		InventoryLive:         liveResources,
-		InventoryRecordExists: inv != nil,
-	})
+// ModuleInstance CR last (after all workloads are gone; a dry run lists it last
+// in its preview instead). With opts.Wait, "gone" means confirmed absent from
+// the cluster.
+func executeInstanceDelete(ctx context.Context, k8sClient *kubernetes.Client, rsf *cmdutil.InstanceSelectorFlags, namespace string, inv *inventory.Record, liveResources []*unstructured.Unstructured, opts kubernetes.DeleteOptions, instanceLog *log.Logger) error {
+	dryRun := opts.DryRun
+	verb, action := "deleting", "delete"
+	if opts.Orphan {
+		verb, action = "orphaning", "orphan"
+	}
+	instanceLog.Info(fmt.Sprintf("%s resources in namespace %q", verb, namespace))
+	output.EmitPhase(rsf.InstanceName, "delete", fmt.Sprintf("%s resources in namespace %q", verb, namespace))
+
+	// A namespace --create-namespace made holds the ModuleInstance CR, so it
+	// is held back and deleted after the CR. An orphaning delete releases it
+	// like any other resource.
+	var createdNS *unstructured.Unstructured
+	if !opts.Orphan && inv != nil && inventory.TracksCreatedNamespace(inv.Inventory.Entries, namespace) {
+		liveResources, createdNS = splitNamespace(liveResources, namespace)
+	}
+
+	opts.InstanceName = rsf.InstanceName
+	opts.Namespace = namespace
+	opts.InstanceID = rsf.InstanceID
+	opts.InventoryLive = liveResources
+	opts.InventoryRecordExists = inv != nil
+	deleteResult, err := kubernetes.Delete(ctx, k8sClient, opts)
 	if err != nil {
 		instanceLog.Error("delete failed", "error", err)
 		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err, Printed: true}
 	}
 
+	if deleteResult.Retries > 0 {
+		instanceLog.Info(fmt.Sprintf("retried %d time(s) after transient API errors", deleteResult.Retries))
+	}
 	if len(deleteResult.Errors) > 0 {
 		instanceLog.Warn(fmt.Sprintf("%d resource(s) had errors", len(deleteResult.Errors)))
 		for _, e := range deleteResult.Errors {
@@ -221,38 +359,132 @@ func executeInstanceDelete(ctx context.Context, k8sClient *kubernetes.Client, rs
 		}
 	}
 
+	remaining := deleteResult.Remaining()
+	if len(remaining) > 0 {
+		instanceLog.Warn(fmt.Sprintf("%d resource(s) still present after %s", len(remaining), waitTimeout(opts.Timeout)))
+		output.Details(kubernetes.FormatRemaining(remaining))
+	}
+
 	// Delete the ModuleInstance CR last — only after every tracked workload
-	// resource is gone (enhancement 0006 D1). Skipped on dry-run and on partial
-	// failure (so a re-run can retry the remaining workloads).
-	if !dryRun && inv != nil && len(deleteResult.Errors) == 0 {
+	// resource is gone (enhancement 0006 D1). Skipped on dry-run, on partial
+	// failure, and when --wait timed out with resources still terminating (so
+	// a re-run can retry the remaining workloads). --keep-inventory skips it
+	// for good; --orphan removes it like a delete, since the resources it
+	// tracked have been released.
+	if dryRun && !opts.Orphan && inv != nil && !opts.KeepInventory {
+		planned := kubernetes.PlannedDelete{Kind: inventory.KindModuleInstance, Namespace: inv.Namespace, Name: inv.Name, Source: kubernetes.SourceInventory}
+		instanceLog.Info(kubernetes.FormatPlannedDelete(planned))
+		deleteResult.Planned = append(deleteResult.Planned, planned)
+	}
+
+	crDeleted := false
+	if !dryRun && inv != nil && len(deleteResult.Errors) == 0 && len(remaining) == 0 && !opts.KeepInventory {
 		if err := inventory.DeleteCR(ctx, k8sClient, inv.Name, inv.Namespace); err != nil {
 			instanceLog.Warn("could not delete ModuleInstance CR", "error", err)
+		} else {
+			crDeleted = true
 		}
 	}
+	if createdNS != nil {
+		deleteCreatedNamespace(ctx, k8sClient, namespace, dryRun, crDeleted, opts.KeepInventory, instanceLog)
+	}
 
-	if dryRun {
-		instanceLog.Info(fmt.Sprintf("dry run complete: %d resources would be deleted", deleteResult.Deleted))
-	} else {
-		instanceLog.Info("all resources have been deleted")
-		output.Println(output.FormatCheckmark("Instance deleted"))
+	switch {
+	case dryRun && opts.Orphan:
+		instanceLog.Info(fmt.Sprintf("dry run complete: %d resources would be orphaned", deleteResult.Orphaned))
+	case dryRun:
+		instanceLog.Info(fmt.Sprintf("dry run complete: %d resources would be deleted", len(deleteResult.Planned)))
+	case opts.Orphan:
+		if len(deleteResult.Errors) == 0 {
+			output.Println(output.FormatCheckmark(fmt.Sprintf(
+				"Instance orphaned — %d resource(s) left running untracked", deleteResult.Orphaned)))
+		}
+	case len(remaining) == 0:
+		if opts.Wait {
+			instanceLog.Info("all resources are gone from the cluster")
+		} else {
+			instanceLog.Info("all resources have been deleted")
+		}
+		if opts.KeepInventory && inv != nil {
+			output.Println(output.FormatCheckmark(fmt.Sprintf("Instance resources deleted — ModuleInstance %q kept", inv.Name)))
+		} else {
+			output.Println(output.FormatCheckmark("Instance deleted"))
+		}
 	}
 
 	if len(deleteResult.Errors) > 0 {
 		return &opmexit.ExitError{
 			Code:    opmexit.ExitGeneralError,
-			Err:     fmt.Errorf("%d resource(s) failed to delete", len(deleteResult.Errors)),
+			Err:     fmt.Errorf("%d resource(s) failed to %s", len(deleteResult.Errors), action),
+			Printed: true,
+		}
+	}
+	if len(remaining) > 0 {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("timed out after %s waiting for %d resource(s) to be removed; the ModuleInstance was kept — re-run delete to finish",
+				waitTimeout(opts.Timeout), len(remaining)),
 			Printed: true,
 		}
 	}
 	return nil
 }
 
-func confirmInstanceDelete(instanceName, instanceID, namespace string) bool {
+// splitNamespace removes the Namespace named namespace from live, returning
+// it separately; nil when live does not contain it.
+func splitNamespace(live []*unstructured.Unstructured, namespace string) ([]*unstructured.Unstructured, *unstructured.Unstructured) {
+	for i, obj := range live {
+		if obj.GetKind() == "Namespace" && obj.GetAPIVersion() == "v1" && obj.GetName() == namespace {
+			rest := append(slices.Clip(live[:i]), live[i+1:]...)
+			return rest, obj
+		}
+	}
+	return live, nil
+}
+
+// deleteCreatedNamespace deletes the instance namespace OPM created, once the
+// ModuleInstance CR in it is gone. It stays when the CR was kept (on purpose
+// or because the delete did not finish) and when other instances live in it.
+func deleteCreatedNamespace(ctx context.Context, k8sClient *kubernetes.Client, namespace string, dryRun, crDeleted, keepInventory bool, instanceLog *log.Logger) {
+	switch {
+	case dryRun:
+		instanceLog.Info(fmt.Sprintf("namespace %q was created by opm and would be deleted", namespace))
+		return
+	case keepInventory:
+		instanceLog.Info(fmt.Sprintf("namespace %q kept: it holds the kept ModuleInstance", namespace))
+		return
+	case !crDeleted:
+		return
+	}
+	others, err := inventory.DeleteCreatedNamespace(ctx, k8sClient, namespace)
+	switch {
+	case err != nil:
+		instanceLog.Warn("could not delete namespace", "namespace", namespace, "error", err)
+	case others > 0:
+		instanceLog.Warn(fmt.Sprintf("namespace %q kept: %d other instance(s) still deployed in it", namespace, others))
+	default:
+		instanceLog.Info(output.FormatResourceLine("Namespace", "", namespace, output.StatusDeleted))
+	}
+}
+
+// waitTimeout is the bound a waiting delete actually applied.
+func waitTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return kubernetes.DefaultDeleteWaitTimeout
+	}
+	return timeout
+}
+
+func confirmInstanceDelete(instanceName, instanceID, namespace string, orphan bool) bool {
+	action := "Delete all resources"
+	if orphan {
+		action = "Stop tracking (orphan) all resources"
+	}
 	var prompt string
 	if instanceName != "" {
-		prompt = fmt.Sprintf("Delete all resources for instance %q in namespace %q? [y/N]: ", instanceName, namespace)
+		prompt = fmt.Sprintf("%s for instance %q in namespace %q? [y/N]: ", action, instanceName, namespace)
 	} else {
-		prompt = fmt.Sprintf("Delete all resources for instance-id %q in namespace %q? [y/N]: ", instanceID, namespace)
+		prompt = fmt.Sprintf("%s for instance-id %q in namespace %q? [y/N]: ", action, instanceID, namespace)
 	}
 	output.Prompt(prompt)
 	scanner := bufio.NewScanner(os.Stdin)
@@ -1,16 +1,22 @@
 package instance
 
 import (
+	"bytes"
 	"context"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	fakedynamic "k8s.io/client-go/dynamic/fake"
 
+	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/inventory"
 	"github.com/open-platform-model/cli/internal/kubernetes"
@@ -61,6 +67,36 @@ func TestDeleteOperatorOwned_DryRunStillRequiresAReadyOperator(t *testing.T) {
 	assert.Contains(t, err.Error(), "not ready")
 }
 
+// A dry run previews every resource it would delete, with where it was found,
+// and ends with the ModuleInstance CR — the order the real delete follows.
+func TestExecuteInstanceDelete_DryRunListsTheModuleInstanceLast(t *testing.T) {
+	var buf bytes.Buffer
+	output.SetLogWriter(&buf)
+	t.Cleanup(func() { output.SetLogWriter(os.Stderr) })
+
+	rec := &inventory.Record{Name: "podinfo", Namespace: "demo"}
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("config")
+	cm.SetNamespace("demo")
+
+	rsf := &cmdutil.InstanceSelectorFlags{InstanceName: "podinfo"}
+	opts := kubernetes.DeleteOptions{DryRun: true, Source: kubernetes.SourceLabelScan}
+	err := executeInstanceDelete(context.Background(), emptyClusterClient(), rsf, "demo", rec,
+		[]*unstructured.Unstructured{cm}, opts, output.InstanceLogger("podinfo"))
+	require.NoError(t, err)
+
+	out := buf.String()
+	cmLine := strings.Index(out, "ConfigMap/demo/config")
+	crLine := strings.Index(out, "ModuleInstance/demo/podinfo")
+	require.NotEqual(t, -1, cmLine)
+	require.NotEqual(t, -1, crLine)
+	assert.Less(t, cmLine, crLine, "the ModuleInstance is the final item")
+	assert.Contains(t, out, "(label scan)")
+	assert.Contains(t, out, "2 resources would be deleted")
+}
+
 // --force skips the confirmation prompt; it must not reach the readiness
 // guard, since forcing past that guard produces the wedge rather than avoiding
 // it. deleteOperatorOwned takes no force parameter at all — this pins the flag
@@ -71,3 +107,46 @@ func TestDeleteForceFlagIsConfirmationOnly(t *testing.T) {
 	require.NotNil(t, forceFlag)
 	assert.Contains(t, forceFlag.Usage, "confirmation")
 }
+
+func TestParseCascade(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    metav1.DeletionPropagation
+		wantErr bool
+	}{
+		{"foreground", metav1.DeletePropagationForeground, false},
+		{"Background", metav1.DeletePropagationBackground, false},
+		{"orphan", metav1.DeletePropagationOrphan, false},
+		{"cascade", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCascade(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeleteModeFlagsAreExclusive(t *testing.T) {
+	tests := [][]string{
+		{"--keep-inventory", "--orphan"},
+		{"--keep-inventory", "--no-inventory"},
+		{"--orphan", "--wait"},
+	}
+	for _, flags := range tests {
+		t.Run(strings.Join(flags, " "), func(t *testing.T) {
+			cmd := NewInstanceDeleteCmd(&config.GlobalConfig{})
+			cmd.SetArgs(append([]string{"demo"}, flags...))
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "none of the others can be")
+		})
+	}
+}
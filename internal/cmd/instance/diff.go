@@ -2,10 +2,13 @@ package instance
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
+	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
@@ -14,6 +17,7 @@ import (
 	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/platform"
+	workflowapply "github.com/open-platform-model/cli/internal/workflow/apply"
 	"github.com/open-platform-model/cli/internal/workflow/render"
 )
 
@@ -21,36 +25,269 @@ import (
 func NewInstanceDiffCmd(cfg *config.GlobalConfig) *cobra.Command {
 	var rff cmdutil.InstanceFileFlags
 	var kf cmdutil.K8sFlags
+	var vf cmdutil.ValuesFromFlags
 	var namespace string
+	var outputFmt string
+	var diffTool string
+	var exitCode bool
+	var filter kubernetes.DiffFilter
+	var against, againstNamespace string
+	var noInventory bool
+	var invFlag string
+	var expand bool
+	var lastApplied bool
+	var noSmartIgnore bool
+	var noOrphans, orphansOnly bool
+	var baseline string
+	var ignorePaths []string
 
 	c := &cobra.Command{
-		Use:   "diff <instance.cue>",
+		Use:   "diff <instance.cue | name>",
 		Short: "Show differences between instance file and cluster",
 		Long: `Show differences between an instance file and live cluster state.
 
+With --against, compare two deployed instances instead — for example the same
+module in staging and prod. Both sides are read from their inventories and
+resources are paired by component and kind, so differing names and namespaces
+are not reported; only how the resources are configured is. Added and removed
+are read from the positional instance towards the --against instance.
+
+With --no-inventory, the instance's previously applied resources are found by
+a label scan of the instance namespace rather than the ModuleInstance CR, for
+instances applied with 'opm instance apply --no-inventory'.
+
+When several resources of one kind change in exactly the same way — an image
+bump across every Deployment of a module, say — the text output shows the
+change once for the whole group. --expand prints each resource's full diff
+instead.
+
+With --compare-last-applied, each rendered resource is also compared with the
+kubectl.kubernetes.io/last-applied-configuration annotation on its live
+counterpart, when it has one: what OPM changes relative to what kubectl last
+applied, without the defaults and other writers' fields the live object holds.
+Use it to review moving kubectl-managed resources to OPM. The comparison is
+reported in its own section (and as lastApplied in JSON) and does not affect
+--exit-code.
+
+With --baseline inventory, the render is compared with the last apply instead
+of the cluster: the module version and values the ModuleInstance CR records
+are rendered again from the registry, so the diff shows what changed in the
+source since then, whatever has drifted on the cluster. Resources only the
+last apply rendered are reported as orphaned. An instance without an
+inventory falls back to the live comparison.
+
+--ignore-path leaves a field out of the comparison, so a difference only there
+is not reported: spec.replicas under an autoscaler, say, or an annotation a
+controller rewrites. A path is dot-separated keys, with a key that contains
+dots or slashes quoted in brackets and * for any key or list element; prefix
+it with a kind to ignore it on that kind only. The module's
+opmodel.dev/diff-ignore-paths annotation adds comma-separated paths of its own.
+
+The replica count of an autoscaled Deployment or StatefulSet is left out of the
+live comparison without any flag: one a HorizontalPodAutoscaler targets,
+rendered or deployed with the instance, or whose live replicas were last set
+through the scale subresource. --no-smart-ignore compares it anyway.
+
+A change to a field the API server will not update in place — a Deployment's
+selector, a StatefulSet's volumeClaimTemplates, a PVC's storageClassName — is
+marked "recreate" rather than "modified": applying it means deleting and
+recreating the resource, with the downtime and data loss that implies.
+
+--no-orphans compares only the rendered resources and skips looking for
+orphans, for when the inventory is known to be stale. --orphans-only does the
+opposite: it reports only the orphaned resources, without comparing the
+rendered ones, for auditing leftovers before a cleanup. The summary says which
+half ran.
+
+--diff-tool chooses how each modified resource's changes are shown: dyff
+(the default) lists them field by field, unified prints a unified diff of the
+two YAML documents, and jsonpatch prints the JSON merge patch that turns the
+live resource into the rendered one. Which resources count as modified, or
+must be recreated, does not depend on the tool.
+
 Arguments:
-  instance.cue    Path to the instance .cue file (required)
+  instance.cue    Path to the instance .cue file
+  name            Instance name, when --against is set
 
 Examples:
   # Diff an instance file against the cluster
-  opm instance diff ./jellyfin_instance.cue`,
+  opm instance diff ./jellyfin_instance.cue
+
+  # Structured output for automation
+  opm instance diff ./jellyfin_instance.cue -o json
+
+  # List only the keys of changed resources
+  opm instance diff ./jellyfin_instance.cue -o name
+
+  # Only diff Services and ConfigMaps whose names start with "web-"
+  opm instance diff ./jellyfin_instance.cue --kind Service --kind ConfigMap --name 'web-*'
+
+  # Fail a CI job when the cluster has drifted (exit 2 on differences)
+  opm instance diff ./jellyfin_instance.cue --exit-code
+
+  # Show every modified resource in full, without grouping identical changes
+  opm instance diff ./jellyfin_instance.cue --expand
+
+  # Review what OPM would change relative to kubectl's last apply
+  opm instance diff ./jellyfin_instance.cue --compare-last-applied
+
+  # Ignore every Deployment's replica count and a rotating annotation
+  opm instance diff ./jellyfin_instance.cue --ignore-path Deployment:spec.replicas \
+    --ignore-path 'metadata.annotations["example.com/restarted-at"]'
+
+  # List only the resources the next apply would prune
+  opm instance diff ./jellyfin_instance.cue --orphans-only
+
+  # Show changes as a unified diff, for tools that read patches
+  opm instance diff ./jellyfin_instance.cue --diff-tool unified
+
+  # Show what changed in the source since the last apply, ignoring drift
+  opm instance diff ./jellyfin_instance.cue --baseline inventory
+
+  # Compare the staging and prod deployments of an instance
+  opm instance diff jellyfin -n staging --against jellyfin --against-namespace prod`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			return runInstanceDiff(args[0], cfg, &rff, &kf, namespace)
+			mode, err := parseInventoryMode(invFlag)
+			if err != nil {
+				return err
+			}
+			if noInventory {
+				mode = inventory.ReadIgnore
+			}
+			flags := diffFlags{
+				Output:        outputFmt,
+				DiffTool:      diffTool,
+				Baseline:      baseline,
+				IgnorePaths:   ignorePaths,
+				ExitCode:      exitCode,
+				Expand:        expand,
+				LastApplied:   lastApplied,
+				NoSmartIgnore: noSmartIgnore,
+				NoOrphans:     noOrphans,
+				OrphansOnly:   orphansOnly,
+				Mode:          mode,
+				Filter:        filter,
+				ValuesFrom:    vf,
+			}
+			if against != "" {
+				err = runInstanceDiffAgainst(args[0], against, cfg, &kf, namespace, againstNamespace, flags)
+			} else {
+				err = runInstanceDiff(args[0], cfg, &rff, &kf, namespace, flags)
+			}
+			if exitCode {
+				return reserveDriftExitCode(err)
+			}
+			return err
 		},
 	}
 
 	rff.AddTo(c)
+	rff.AddInstanceUUIDTo(c)
+	vf.AddTo(c)
 	kf.AddTo(c)
+	kf.AddRateLimitTo(c)
 	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace")
+	c.Flags().StringVarP(&outputFmt, "output", "o", string(kubernetes.DiffOutputText), "Output format: text, json, name")
+	c.Flags().StringVar(&diffTool, "diff-tool", string(kubernetes.DiffToolDyff),
+		"How to show each modified resource's changes: dyff, unified, jsonpatch")
+	c.Flags().BoolVar(&exitCode, "exit-code", false,
+		"Exit with code 2 when differences are found, 0 when none, 1 on errors")
+	c.Flags().StringArrayVar(&filter.Kinds, "kind", nil, "Only diff resources of this kind (repeatable)")
+	c.Flags().StringArrayVar(&filter.Names, "name", nil, "Only diff resources whose name matches this glob (repeatable)")
+	c.Flags().StringArrayVar(&ignorePaths, "ignore-path", nil,
+		"Leave this field out of the comparison, as [Kind:]path (e.g. Deployment:spec.replicas) (repeatable)")
+	c.Flags().BoolVar(&noSmartIgnore, "no-smart-ignore", false,
+		"Also compare spec.replicas of Deployments and StatefulSets an HPA scales")
+	c.Flags().BoolVar(&noOrphans, "no-orphans", false, "Compare the rendered resources only; do not look for orphans")
+	c.Flags().BoolVar(&orphansOnly, "orphans-only", false, "Report only orphaned resources; do not compare the rendered ones")
+	c.Flags().StringVar(&against, "against", "", "Compare with this deployed instance instead of an instance file")
+	c.Flags().StringVar(&againstNamespace, "against-namespace", "", "Namespace of the --against instance (default: the target namespace)")
+	c.Flags().BoolVar(&noInventory, "no-inventory", false, "Find orphaned resources by label scan instead of the ModuleInstance CR")
+	addInventoryModeFlag(c, &invFlag)
+	c.Flags().BoolVar(&expand, "expand", false, "Show each modified resource's full diff instead of grouping identical changes")
+	c.Flags().BoolVar(&lastApplied, "compare-last-applied", false,
+		"Also compare each resource with its kubectl last-applied-configuration annotation")
+	c.Flags().StringVar(&baseline, "baseline", diffBaselineLive,
+		"Compare the render with: live (the cluster), or inventory (the last apply, re-rendered from its recorded module version and values)")
+	c.MarkFlagsMutuallyExclusive("against", "no-inventory")
+	c.MarkFlagsMutuallyExclusive("against", "baseline")
+	c.MarkFlagsMutuallyExclusive("baseline", "no-inventory")
+	c.MarkFlagsMutuallyExclusive("inventory", "no-inventory")
+	c.MarkFlagsMutuallyExclusive("baseline", "compare-last-applied")
+	c.MarkFlagsMutuallyExclusive("against", "compare-last-applied")
+	c.MarkFlagsMutuallyExclusive("against", "instance-uuid")
+	c.MarkFlagsMutuallyExclusive("against", "values-from-configmap")
+	c.MarkFlagsMutuallyExclusive("against", "values-from-secret")
+	c.MarkFlagsMutuallyExclusive("no-orphans", "orphans-only")
+	for _, scope := range []string{"no-orphans", "orphans-only"} {
+		c.MarkFlagsMutuallyExclusive("against", scope)
+		c.MarkFlagsMutuallyExclusive("baseline", scope)
+	}
 
 	return c
 }
 
+// Values of the --baseline flag.
+const (
+	diffBaselineLive      = "live"
+	diffBaselineInventory = "inventory"
+)
+
+// diffFlags carries the diff command's behavior flags. --against reads only
+// Output, DiffTool, IgnorePaths, ExitCode, Expand, Mode, and Filter.
+type diffFlags struct {
+	Output        string
+	DiffTool      string
+	Baseline      string
+	IgnorePaths   []string
+	ExitCode      bool
+	Expand        bool
+	LastApplied   bool
+	NoSmartIgnore bool
+	NoOrphans     bool
+	OrphansOnly   bool
+	Mode          inventory.ReadMode
+	Filter        kubernetes.DiffFilter
+	ValuesFrom    cmdutil.ValuesFromFlags
+}
+
 // runInstanceDiff executes the instance diff command.
-func runInstanceDiff(instanceFile string, cfg *config.GlobalConfig, rff *cmdutil.InstanceFileFlags, kf *cmdutil.K8sFlags, namespaceFlag string) error { //nolint:gocyclo // orchestration function; complexity is inherent
+func runInstanceDiff(instanceFile string, cfg *config.GlobalConfig, rff *cmdutil.InstanceFileFlags, kf *cmdutil.K8sFlags, namespaceFlag string, flags diffFlags) error { //nolint:gocyclo // orchestration function; complexity is inherent
 	ctx := context.Background()
 
+	format, ok := kubernetes.ParseDiffOutputFormat(flags.Output)
+	if !ok {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("invalid output format %q (valid: text, json, name)", flags.Output),
+		}
+	}
+	if err := flags.Filter.Validate(); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	diffTool, ok := kubernetes.ParseDiffTool(flags.DiffTool)
+	if !ok {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("invalid --diff-tool %q (valid: dyff, unified, jsonpatch)", flags.DiffTool),
+		}
+	}
+	if flags.Baseline != diffBaselineLive && flags.Baseline != diffBaselineInventory {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("invalid --baseline %q (valid: %s, %s)", flags.Baseline, diffBaselineLive, diffBaselineInventory),
+		}
+	}
+	ignorePaths, err := kubernetes.ParseIgnorePaths(flags.IgnorePaths)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	valuesRefs, err := flags.ValuesFrom.Refs()
+	if err != nil {
+		return err
+	}
+
 	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
 		Config:         cfg,
 		KubeconfigFlag: kf.Kubeconfig,
@@ -63,16 +300,27 @@ func runInstanceDiff(instanceFile string, cfg *config.GlobalConfig, rff *cmdutil
 
 	// Cluster client before render: diff follows apply's platform-source
 	// precedence so the diff reflects what apply would do (0006 D21/OQ12).
-	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		output.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	valuesDocs, err := cmdutil.FetchValuesDocuments(ctx, k8sClient, valuesRefs)
+	if err != nil {
 		return err
 	}
 
 	result, err := render.FromInstanceFile(ctx, render.InstanceFileOpts{
 		InstanceFilePath: instanceFile,
 		ValuesFiles:      rff.Values,
-		PlatformFlag:     rff.Platform,
+		ValuesYAMLFiles:  rff.ValuesYAML,
+		ValuesJSONFiles:  rff.ValuesJSON,
+		ExpandEnv:        rff.EnvExpansion(),
+		ValuesDocuments:  valuesDocs,
+		PlatformFiles:    rff.Platform,
+		ExpectedDigest:   rff.ModuleDigest,
+		InstanceUUID:     rff.InstanceUUID,
 		ClusterPlatform:  platform.ClusterSpecGetterFor(k8sClient.Dynamic),
 		K8sConfig:        k8sConfig,
 		Config:           cfg,
@@ -89,58 +337,284 @@ func runInstanceDiff(instanceFile string, cfg *config.GlobalConfig, rff *cmdutil
 		}
 	}
 
+	// An empty render still diffs: inventory resources that are live become
+	// orphans, and orphan-only drift must still trip --exit-code.
 	if len(result.Resources) == 0 {
-		instanceLog.Info("no resources to diff")
-		return nil
+		instanceLog.Info("instance renders no resources")
 	}
 
-	comparer := kubernetes.NewComparer()
+	moduleIgnore, err := kubernetes.ModuleIgnorePaths(result.Module.Annotations)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	}
+	ignorePaths = append(moduleIgnore, ignorePaths...)
 
-	var diffOpts kubernetes.DiffOptions
-	instanceID := result.Instance.UUID
-	if instanceID != "" {
-		// Orphan detection reads status.inventory from the ModuleInstance CR.
-		inv, invErr := inventory.GetRecord(ctx, k8sClient, result.Instance.Name, result.Instance.Namespace)
-		if invErr != nil {
-			instanceLog.Debug("could not read inventory for diff", "error", invErr)
-		} else if inv != nil {
-			liveResources, _, invDiscoverErr := inventory.DiscoverResourcesFromInventory(ctx, k8sClient, inv)
-			if invDiscoverErr != nil {
-				instanceLog.Debug("inventory discovery failed", "error", invDiscoverErr)
-			} else {
-				diffOpts.InventoryLive = liveResources
-			}
+	var diffResult *kubernetes.DiffResult
+	if flags.Baseline == diffBaselineInventory {
+		diffResult, err = diffAgainstInventory(ctx, k8sClient, result, rff, cfg, diffTool, flags.Filter, ignorePaths, instanceLog)
+		if err != nil {
+			return err
 		}
 	}
+	if diffResult == nil {
+		// Orphans come from the same source apply prunes from: the inventory,
+		// or the instance's identity labels under --no-inventory.
+		diffResult, err = workflowapply.Preview(ctx, k8sClient, result, flags.Mode, kubernetes.NewComparerFor(diffTool),
+			kubernetes.DiffOptions{
+				Filter:             flags.Filter,
+				CompareLastApplied: flags.LastApplied,
+				IgnorePaths:        ignorePaths,
+				NoSmartIgnore:      flags.NoSmartIgnore,
+				NoOrphans:          flags.NoOrphans,
+				OrphansOnly:        flags.OrphansOnly,
+			}, instanceLog)
+		if err != nil {
+			instanceLog.Error("diff failed", "error", err)
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+		}
+	}
+
+	for _, w := range diffResult.Warnings {
+		instanceLog.Warn(w)
+	}
+
+	if err := printDiffResult(diffResult, format, flags.Expand); err != nil {
+		return err
+	}
+	if flags.LastApplied && format == kubernetes.DiffOutputText {
+		printLastAppliedDiffs(diffResult)
+	}
 
-	diffResult, err := kubernetes.Diff(ctx, k8sClient, result.Resources, result.Instance.Name, comparer, diffOpts)
+	if diffResult.Recreate > 0 {
+		instanceLog.Warn(fmt.Sprintf("%d resource(s) change an immutable field and cannot be updated in place; "+
+			"they must be deleted and recreated, which interrupts their workloads and loses any data they hold", diffResult.Recreate))
+	}
+
+	if flags.ExitCode && !diffResult.IsEmpty() {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: errDriftDetected, Printed: true}
+	}
+	return nil
+}
+
+// diffAgainstInventory compares the render with the last apply, re-rendered
+// from the module version and values the instance's inventory records. It
+// returns a nil result, after a warning, when the instance has no inventory to
+// compare with, so the caller falls back to the live diff.
+func diffAgainstInventory(ctx context.Context, client *kubernetes.Client, result *render.Result, rff *cmdutil.InstanceFileFlags,
+	cfg *config.GlobalConfig, diffTool kubernetes.DiffTool, filter kubernetes.DiffFilter, ignorePaths []kubernetes.IgnorePath, instanceLog *log.Logger) (*kubernetes.DiffResult, error) {
+	rec, err := inventory.GetRecord(ctx, client, result.Instance.Name, result.Instance.Namespace)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("reading inventory: %w", err)}
+	}
+	if rec == nil || rec.ModuleVersion == "" {
+		instanceLog.Warn("instance has no inventory recording its last apply; comparing with the cluster instead")
+		return nil, nil
+	}
+
+	instanceLog.Info(fmt.Sprintf("rendering last apply: %s@%s with its recorded values", rec.ModulePath, rec.ModuleVersion))
+	last, err := render.FromRecord(ctx, render.RecordOpts{
+		Name:            rec.Name,
+		Namespace:       rec.Namespace,
+		ModulePath:      rec.ModulePath,
+		ModuleVersion:   rec.ModuleVersion,
+		Values:          rec.SpecValues,
+		PlatformFiles:   rff.Platform,
+		ClusterPlatform: platform.ClusterSpecGetterFor(client.Dynamic),
+		Config:          cfg,
+	})
 	if err != nil {
-		instanceLog.Error("diff failed", "error", err)
-		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+		return nil, err
 	}
+	return kubernetes.DiffRendered(result.Resources, last.Resources, kubernetes.WithIgnorePaths(kubernetes.NewComparerFor(diffTool), ignorePaths), filter), nil
+}
 
+// runInstanceDiffAgainst compares the live state of two deployed instances.
+func runInstanceDiffAgainst(name, againstName string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag, againstNamespace string, flags diffFlags) error {
+	ctx := context.Background()
+
+	format, ok := kubernetes.ParseDiffOutputFormat(flags.Output)
+	if !ok {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("invalid output format %q (valid: text, json, name)", flags.Output),
+		}
+	}
+	if err := flags.Filter.Validate(); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	diffTool, ok := kubernetes.ParseDiffTool(flags.DiffTool)
+	if !ok {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("invalid --diff-tool %q (valid: dyff, unified, jsonpatch)", flags.DiffTool),
+		}
+	}
+	ignorePaths, err := kubernetes.ParseIgnorePaths(flags.IgnorePaths)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:         cfg,
+		KubeconfigFlag: kf.Kubeconfig,
+		ContextFlag:    kf.Context,
+		NamespaceFlag:  namespaceFlag,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+	namespace := k8sConfig.Namespace.Value
+	if againstNamespace == "" {
+		againstNamespace = namespace
+	}
+	if name == againstName && namespace == againstNamespace {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--against names the same instance being compared")}
+	}
+
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		output.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	base, err := discoverLiveInstance(ctx, k8sClient, name, namespace, flags.Mode)
+	if err != nil {
+		return err
+	}
+	other, err := discoverLiveInstance(ctx, k8sClient, againstName, againstNamespace, flags.Mode)
+	if err != nil {
+		return err
+	}
+
+	diffResult := kubernetes.DiffLive(*base, *other, kubernetes.WithIgnorePaths(kubernetes.NewComparerFor(diffTool), ignorePaths), flags.Filter)
+
+	instanceLog := output.InstanceLogger(name)
 	for _, w := range diffResult.Warnings {
 		instanceLog.Warn(w)
 	}
 
+	if err := printDiffResult(diffResult, format, flags.Expand); err != nil {
+		return err
+	}
+
+	if flags.ExitCode && !diffResult.IsEmpty() {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: errDriftDetected, Printed: true}
+	}
+	return nil
+}
+
+// discoverLiveInstance reads an instance's live resources via its inventory,
+// as mode directs.
+func discoverLiveInstance(ctx context.Context, client *kubernetes.Client, name, namespace string, mode inventory.ReadMode) (*kubernetes.LiveInstance, error) {
+	rec, err := inventory.GetRecord(ctx, client, name, namespace)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("reading ModuleInstance %q: %w", name, err)}
+	}
+	if rec == nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: fmt.Errorf("instance %q not found in namespace %q", name, namespace)}
+	}
+	live, missing, err := inventory.DiscoverResources(ctx, client, rec, mode)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("discovering resources of %q: %w", name, err)}
+	}
+	if len(missing) > 0 {
+		output.InstanceLogger(name).Warn(fmt.Sprintf("%d inventory resource(s) missing from the cluster; they are left out of the comparison", len(missing)))
+	}
+	return &kubernetes.LiveInstance{Name: name, Resources: live}, nil
+}
+
+// errDriftDetected signals that diff --exit-code found differences.
+var errDriftDetected = errors.New("differences found")
+
+// reserveDriftExitCode narrows diff --exit-code to the git diff contract:
+// 0 no differences, 2 drift, 1 any error. Every other exit code — a render
+// validation failure (2), connectivity (3), permission (4), not found (5) —
+// is collapsed to 1 so CI can tell drift from a broken diff.
+func reserveDriftExitCode(err error) error {
+	var exitErr *opmexit.ExitError
+	if err == nil || !errors.As(err, &exitErr) {
+		return err
+	}
+	if exitErr.Code != opmexit.ExitGeneralError && !errors.Is(err, errDriftDetected) {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: exitErr.Err, Printed: exitErr.Printed}
+	}
+	return err
+}
+
+// printDiffResult writes the diff result in the requested output format.
+// Unless expand is set, text output shows each group of identically changed
+// resources once, at the position of its first member.
+func printDiffResult(diffResult *kubernetes.DiffResult, format kubernetes.DiffOutputFormat, expand bool) error {
+	switch format {
+	case kubernetes.DiffOutputJSON:
+		out, err := kubernetes.FormatDiffJSON(diffResult)
+		if err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		output.Println(out)
+		return nil
+	case kubernetes.DiffOutputName:
+		if names := kubernetes.FormatDiffNames(diffResult); names != "" {
+			output.Println(names)
+		}
+		return nil
+	case kubernetes.DiffOutputText:
+	}
+
 	if diffResult.IsEmpty() {
-		output.Println("No differences found")
+		output.Println(diffResult.SummaryLine())
+		if foreign := kubernetes.FormatForeignOwned(diffResult.ForeignOwned); foreign != "" {
+			output.Println(strings.TrimRight(foreign, "\n"))
+		}
 		return nil
 	}
 
 	output.Println(diffResult.SummaryLine())
 	output.Println("")
 
+	grouped := make(map[string]*kubernetes.DiffGroup)
+	if !expand {
+		groups := kubernetes.GroupIdenticalChanges(diffResult)
+		for i := range groups {
+			for _, key := range groups[i].Keys {
+				grouped[key] = &groups[i]
+			}
+		}
+	}
+
 	for _, rd := range diffResult.Resources {
 		switch rd.State {
 		case kubernetes.ResourceModified:
+			if g, ok := grouped[rd.Key()]; ok {
+				if g.Keys[0] == rd.Key() {
+					printDiffGroup(g)
+				}
+				continue
+			}
+			if rd.AgainstName != "" {
+				output.Println(fmt.Sprintf("--- %s/%s (%s) vs %s (%s) [modified]", rd.Kind, rd.Name, rd.Namespace, rd.AgainstName, rd.AgainstNamespace))
+				output.Println(rd.Diff)
+				continue
+			}
 			if rd.Namespace != "" {
 				output.Println(fmt.Sprintf("--- %s/%s (%s) [modified]", rd.Kind, rd.Name, rd.Namespace))
 			} else {
 				output.Println(fmt.Sprintf("--- %s/%s [modified]", rd.Kind, rd.Name))
 			}
 			output.Println(rd.Diff)
+		case kubernetes.ResourceRecreate:
+			fields := strings.Join(rd.Immutable, ", ")
+			if rd.Namespace != "" {
+				output.Println(fmt.Sprintf("!!! %s/%s (%s) [recreate - immutable %s changed]", rd.Kind, rd.Name, rd.Namespace, fields))
+			} else {
+				output.Println(fmt.Sprintf("!!! %s/%s [recreate - immutable %s changed]", rd.Kind, rd.Name, fields))
+			}
+			output.Println(rd.Diff)
 		case kubernetes.ResourceAdded:
+			if rd.AgainstName != "" {
+				output.Println(fmt.Sprintf("+++ %s/%s (%s) [only in against]", rd.Kind, rd.AgainstName, rd.AgainstNamespace))
+				continue
+			}
 			if rd.Namespace != "" {
 				output.Println(fmt.Sprintf("+++ %s/%s (%s) [new resource]", rd.Kind, rd.Name, rd.Namespace))
 			} else {
@@ -152,10 +626,60 @@ func runInstanceDiff(instanceFile string, cfg *config.GlobalConfig, rff *cmdutil
 			} else {
 				output.Println(fmt.Sprintf("~~~ %s/%s [orphaned - will be removed on next apply]", rd.Kind, rd.Name))
 			}
+		case kubernetes.ResourceRemoved:
+			if rd.Namespace != "" {
+				output.Println(fmt.Sprintf("--- %s/%s (%s) [only in base]", rd.Kind, rd.Name, rd.Namespace))
+			} else {
+				output.Println(fmt.Sprintf("--- %s/%s [only in base]", rd.Kind, rd.Name))
+			}
 		case kubernetes.ResourceUnchanged:
 			// No output for unchanged resources in diff view
 		}
 	}
 
+	if foreign := kubernetes.FormatForeignOwned(diffResult.ForeignOwned); foreign != "" {
+		output.Println(strings.TrimRight(foreign, "\n"))
+	}
 	return nil
 }
+
+// printLastAppliedDiffs writes the --compare-last-applied section: each
+// resource with a last-applied-configuration annotation, and how the render
+// differs from it.
+func printLastAppliedDiffs(diffResult *kubernetes.DiffResult) {
+	var compared int
+	for _, rd := range diffResult.Resources {
+		if rd.LastApplied == nil {
+			continue
+		}
+		if compared == 0 {
+			output.Println("")
+			output.Println("Compared with kubectl last-applied-configuration:")
+		}
+		compared++
+		ref := rd.Kind + "/" + rd.Name
+		if rd.Namespace != "" {
+			ref += " (" + rd.Namespace + ")"
+		}
+		if rd.LastApplied.Diff == "" {
+			output.Println(fmt.Sprintf("=== %s [matches last-applied]", ref))
+			continue
+		}
+		output.Println(fmt.Sprintf("--- %s [differs from last-applied]", ref))
+		output.Println(rd.LastApplied.Diff)
+	}
+	if compared == 0 {
+		output.Println("")
+		output.Println("No resource carries a kubectl last-applied-configuration annotation")
+	}
+}
+
+// printDiffGroup writes one group of identically modified resources: a
+// header naming every member, then the shared changes once.
+func printDiffGroup(g *kubernetes.DiffGroup) {
+	output.Println(fmt.Sprintf("--- %s [modified identically]", g.Header()))
+	for _, c := range g.Changes {
+		output.Println("  " + kubernetes.FormatFieldChange(c))
+	}
+	output.Println("")
+}
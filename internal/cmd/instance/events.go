@@ -7,6 +7,7 @@ import (
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/inventory"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/workflow/query"
 )
@@ -79,13 +80,13 @@ func runInstanceEvents(identifier string, cfg *config.GlobalConfig, kf *cmdutil.
 	logName := target.LogName
 	instanceLog := output.InstanceLogger(logName)
 
-	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		instanceLog.Error("connecting to cluster", "error", err)
-		return err
+		return cmdutil.MarkPrinted(err)
 	}
 
-	_, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, instanceLog)
+	_, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, inventory.ReadPrefer, instanceLog)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,130 @@
+package instance
+
+import (
+	"context"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/query"
+)
+
+// NewInstanceGetCmd creates the instance get command.
+func NewInstanceGetCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+
+	var (
+		outputFlag string
+		anyFlag    bool
+	)
+
+	c := &cobra.Command{
+		Use:   "get <file|name|uuid> <kind/name>",
+		Short: "Print one live resource of an instance",
+		Long: `Print one live resource an OPM instance manages, as the cluster has it.
+
+The resource must be one the instance tracks, so a mistyped name cannot read
+another instance's resource; --any fetches it regardless. The resource is
+kind/name or kind[.group]/namespace/name, where kind is the Kind or its plural
+resource name, matched case-insensitively.
+
+Arguments:
+  file         Path to an instance.cue file or directory containing one.
+               The instance name and namespace are read from the file's metadata.
+               --namespace overrides the namespace found in the file.
+  name         Instance name (use -n / --namespace to scope by namespace).
+  uuid         Instance UUID.
+  kind/name    The resource to print (e.g. Deployment/web, configmaps/web-config).
+
+Examples:
+  # Print a Deployment of the jellyfin instance as YAML
+  opm instance get jellyfin -n media Deployment/jellyfin
+
+  # As JSON
+  opm instance get jellyfin -n media Service/jellyfin -o json
+
+  # Extract one field
+  opm instance get jellyfin -n media Deployment/jellyfin -o jsonpath='{.status.readyReplicas}'
+
+  # Read a resource the instance does not track
+  opm instance get jellyfin -n media ConfigMap/kube-root-ca.crt --any`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceGet(args[0], args[1], cfg, &kf, namespace, outputFlag, anyFlag)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace (default: from config)")
+	c.Flags().StringVarP(&outputFlag, "output", "o", "yaml", "Output format (yaml, json, jsonpath=<expr>)")
+	c.Flags().BoolVar(&anyFlag, "any", false, "Fetch the resource even if the instance does not track it")
+
+	return c
+}
+
+func runInstanceGet(identifier, ref string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag, outputFmt string, anyResource bool) error {
+	ctx := context.Background()
+
+	out, err := query.ParseGetOutput(outputFmt)
+	if err != nil {
+		return err
+	}
+
+	target, err := cmdutil.ResolveInstanceTarget(identifier, cfg, kf, namespaceFlag)
+	if err != nil {
+		return err
+	}
+
+	cmdutil.LogResolvedKubernetesConfig(target.Namespace, target.K8sConfig.Kubeconfig.Value, target.K8sConfig.Context.Value)
+
+	instanceLog := output.InstanceLogger(target.LogName)
+
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		instanceLog.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	if anyResource {
+		obj, err := query.GetAnyResource(ctx, k8sClient, ref, target.Namespace)
+		if err != nil {
+			return selectError(instanceLog, err)
+		}
+		return writeGet(out, obj, instanceLog)
+	}
+
+	inv, liveResources, missingEntries, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, inventory.ReadPrefer, instanceLog)
+	if err != nil {
+		return err
+	}
+	statusOpts := query.BuildStatusOptions(target.Namespace, target.Selector, output.FormatYAML, false, inv, liveResources, missingEntries)
+	obj, err := query.GetTrackedResource(statusOpts, ref)
+	if err != nil {
+		return selectError(instanceLog, err)
+	}
+	return writeGet(out, obj, instanceLog)
+}
+
+// selectError logs a failure to select or fetch the resource and marks it
+// printed.
+func selectError(instanceLog *log.Logger, err error) error {
+	instanceLog.Error("getting resource", "error", err)
+	return cmdutil.MarkPrinted(err)
+}
+
+// writeGet prints obj to stdout.
+func writeGet(out query.GetOutput, obj *unstructured.Unstructured, instanceLog *log.Logger) error {
+	if err := out.Write(obj, os.Stdout); err != nil {
+		instanceLog.Error("printing resource", "error", err)
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+	}
+	return nil
+}
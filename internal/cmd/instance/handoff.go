@@ -100,10 +100,10 @@ func runInstanceHandoff(name string, cfg *config.GlobalConfig, kf *cmdutil.K8sFl
 	}
 	cmdutil.LogResolvedKubernetesConfig(k8sConfig.Namespace.Value, k8sConfig.Kubeconfig.Value, k8sConfig.Context.Value)
 
-	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		output.Error("connecting to cluster", "error", err)
-		return err
+		return cmdutil.MarkPrinted(err)
 	}
 
 	return handoff.Execute(ctx, handoff.Request{
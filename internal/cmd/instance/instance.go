@@ -6,6 +6,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
 )
 
 // NewInstanceCmd creates the instance command group.
@@ -31,11 +33,32 @@ For operations that start from module source, use 'opm module'.`,
 
 	// Cluster-query commands (positional arg = instance name or UUID)
 	c.AddCommand(NewInstanceStatusCmd(cfg))
+	c.AddCommand(NewInstanceGetCmd(cfg))
+	c.AddCommand(NewInstanceRestartCmd(cfg))
 	c.AddCommand(NewInstanceTreeCmd(cfg))
 	c.AddCommand(NewInstanceEventsCmd(cfg))
+	c.AddCommand(NewInstanceLogsCmd(cfg))
 	c.AddCommand(NewInstanceDeleteCmd(cfg))
 	c.AddCommand(NewInstanceListCmd(cfg))
 	c.AddCommand(NewInstanceHandoffCmd(cfg))
+	c.AddCommand(NewInstanceAdoptCmd(cfg))
+	c.AddCommand(NewInstanceInventoryCmd(cfg))
 
 	return c
 }
+
+// addInventoryModeFlag registers --inventory, for the commands that find an
+// instance's resources through its inventory.
+func addInventoryModeFlag(c *cobra.Command, target *string) {
+	c.Flags().StringVar(target, "inventory", string(inventory.ReadPrefer),
+		"An unreadable inventory is fatal (require), falls back to a label scan (prefer), or is skipped for a label scan (ignore)")
+}
+
+// parseInventoryMode parses the --inventory flag.
+func parseInventoryMode(value string) (inventory.ReadMode, error) {
+	mode, err := inventory.ParseReadMode(value)
+	if err != nil {
+		return "", &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	return mode, nil
+}
@@ -1,15 +1,17 @@
 package instance
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
-	"github.com/open-platform-model/cli/internal/inventory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
 )
 
 // --- 8.1 Unit tests for instance render commands ---
@@ -53,12 +55,47 @@ func TestNewInstanceApplyCmd_Flags(t *testing.T) {
 	cmd := NewInstanceApplyCmd(&config.GlobalConfig{})
 	assert.NotNil(t, cmd.Flags().Lookup("dry-run"), "--dry-run flag should be registered")
 	assert.NotNil(t, cmd.Flags().Lookup("values"), "--values/-f flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("no-inventory"), "--no-inventory flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("prune-blacklist-finalizers"), "--prune-blacklist-finalizers flag should be registered")
+	if f := cmd.Flags().Lookup("apply-concurrency"); assert.NotNil(t, f, "--apply-concurrency flag should be registered") {
+		assert.Equal(t, "1", f.DefValue, "parallel apply is opt-in")
+	}
 }
 
 func TestNewInstanceDiffCmd(t *testing.T) {
 	cmd := NewInstanceDiffCmd(&config.GlobalConfig{})
-	assert.Equal(t, "diff <instance.cue>", cmd.Use)
+	assert.Equal(t, "diff <instance.cue | name>", cmd.Use)
 	assert.NotEmpty(t, cmd.Short)
+	for _, name := range []string{"output", "exit-code", "kind", "name", "against", "against-namespace", "no-inventory", "inventory", "baseline", "no-smart-ignore", "diff-tool"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "missing flag %q", name)
+	}
+	assert.Equal(t, diffBaselineLive, cmd.Flags().Lookup("baseline").DefValue)
+}
+
+func TestInstanceDiff_RejectsUnknownBaseline(t *testing.T) {
+	err := runInstanceDiff("instance.cue", &config.GlobalConfig{}, &cmdutil.InstanceFileFlags{}, &cmdutil.K8sFlags{}, "",
+		diffFlags{Output: "text", DiffTool: "dyff", Baseline: "git", Mode: inventory.ReadPrefer})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --baseline "git"`)
+}
+
+func TestReserveDriftExitCode(t *testing.T) {
+	drift := &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: errDriftDetected, Printed: true}
+	validation := &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: errors.New("invalid values"), Printed: true}
+	notFound := &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: errors.New("missing")}
+
+	assert.NoError(t, reserveDriftExitCode(nil))
+	assert.Same(t, drift, reserveDriftExitCode(drift))
+	general := &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: errors.New("boom")}
+	assert.Same(t, general, reserveDriftExitCode(general))
+
+	var exitErr *opmexit.ExitError
+	require.ErrorAs(t, reserveDriftExitCode(validation), &exitErr)
+	assert.Equal(t, opmexit.ExitGeneralError, exitErr.Code)
+	assert.True(t, exitErr.Printed)
+
+	require.ErrorAs(t, reserveDriftExitCode(notFound), &exitErr)
+	assert.Equal(t, opmexit.ExitGeneralError, exitErr.Code, "not found collapses to 1 under --exit-code")
 }
 
 // --- 8.2 Unit tests for instance cluster-query commands ---
@@ -87,6 +124,18 @@ func TestNewInstanceStatusCmd_Flags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("namespace"), "--namespace/-n flag should be registered")
 	assert.NotNil(t, cmd.Flags().Lookup("output"), "--output/-o flag should be registered")
 	assert.NotNil(t, cmd.Flags().Lookup("details"), "--details flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("watch"), "--watch flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("wait-for"), "--wait-for flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("timeout"), "--timeout flag should be registered")
+}
+
+func TestNewInstanceInventoryVerifyCmd(t *testing.T) {
+	cmd := NewInstanceInventoryCmd(&config.GlobalConfig{})
+	verify, _, err := cmd.Find([]string{"verify"})
+	require.NoError(t, err)
+	assert.Equal(t, "verify <file|name|uuid>", verify.Use)
+	assert.NotNil(t, verify.Flags().Lookup("skip-render"), "--skip-render flag should be registered")
+	assert.NotNil(t, verify.Flags().Lookup("namespace"), "--namespace/-n flag should be registered")
 }
 
 func TestNewInstanceDeleteCmd(t *testing.T) {
@@ -95,6 +144,7 @@ func TestNewInstanceDeleteCmd(t *testing.T) {
 	assert.NotEmpty(t, cmd.Short)
 	assert.NotNil(t, cmd.Flags().Lookup("dry-run"), "--dry-run flag should be registered")
 	assert.NotNil(t, cmd.Flags().Lookup("timeout"), "--timeout flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("no-inventory"), "--no-inventory flag should be registered")
 }
 
 // Operator-owned instances are no longer refused by delete — they route to the
@@ -204,7 +254,7 @@ func TestNewInstanceCmd(t *testing.T) {
 	for _, sub := range cmd.Commands() {
 		subcommands[sub.Name()] = true
 	}
-	for _, expected := range []string{"vet", "build", "apply", "diff", "status", "tree", "events", "delete", "list"} {
+	for _, expected := range []string{"vet", "build", "apply", "diff", "status", "get", "tree", "events", "logs", "delete", "restart", "list", "adopt"} {
 		assert.True(t, subcommands[expected], "instance group should have %q subcommand", expected)
 	}
 }
@@ -0,0 +1,353 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/handoff"
+	"github.com/open-platform-model/cli/internal/workflow/query"
+)
+
+// NewInstanceInventoryCmd creates the instance inventory command group.
+func NewInstanceInventoryCmd(cfg *config.GlobalConfig) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "inventory",
+		Short: "Inspect and manage instance inventory records",
+		Long: `Inspect and manage the inventory an instance records on its ModuleInstance
+CR: the list of resources the instance owns, and the digests of the last apply.`,
+	}
+
+	c.AddCommand(NewInstanceInventoryVerifyCmd(cfg))
+	c.AddCommand(NewInstanceInventoryGCCmd(cfg))
+	c.AddCommand(NewInstanceInventoryExportCmd(cfg))
+	c.AddCommand(NewInstanceInventoryImportCmd(cfg))
+
+	return c
+}
+
+// NewInstanceInventoryVerifyCmd creates the instance inventory verify command.
+func NewInstanceInventoryVerifyCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+	var skipRenderFlag bool
+
+	c := &cobra.Command{
+		Use:   "verify <file|name|uuid>",
+		Short: "Check an instance's inventory against itself and the cluster",
+		Long: `Check that an instance's inventory record is consistent. Nothing is changed.
+
+Checks:
+  entries         the stored entry count and digest match the entries, and no
+                  resource is tracked twice
+  live resources  every entry resolves to a live resource
+  labels          the ModuleInstance carries its instance name, namespace, and
+                  managed-by labels
+  render digest   re-rendering spec.module with spec.values from the registry,
+                  against the cluster Platform, reproduces the recorded
+                  lastAppliedRenderDigest (skipped when the module cannot be
+                  fetched or was last applied from local source)
+
+Exits 2 when any check finds a discrepancy.
+
+Arguments:
+  file         Path to an instance.cue file or directory containing one.
+  name         Instance name (use -n / --namespace to scope by namespace).
+  uuid         Instance UUID.
+
+Examples:
+  # Verify an instance's inventory
+  opm instance inventory verify jellyfin -n media
+
+  # Skip the registry re-render
+  opm instance inventory verify jellyfin -n media --skip-render`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceInventoryVerify(args[0], cfg, &kf, namespace, skipRenderFlag)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace (default: from config)")
+	c.Flags().BoolVar(&skipRenderFlag, "skip-render", false, "Do not re-render the module to check the recorded render digest")
+
+	return c
+}
+
+func runInstanceInventoryVerify(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string, skipRender bool) error {
+	ctx := context.Background()
+
+	target, err := cmdutil.ResolveInstanceTarget(identifier, cfg, kf, namespaceFlag)
+	if err != nil {
+		return err
+	}
+
+	cmdutil.LogResolvedKubernetesConfig(target.Namespace, target.K8sConfig.Kubeconfig.Value, target.K8sConfig.Context.Value)
+
+	logName := target.LogName
+	instanceLog := output.InstanceLogger(logName)
+
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		instanceLog.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	rec, live, missing, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, inventory.ReadRequire, instanceLog)
+	if err != nil {
+		return err
+	}
+
+	var renderDigest query.RenderDigestFunc
+	if !skipRender && rec.ModulePath != "" {
+		renderDigest = func(ctx context.Context) (string, error) {
+			instanceLog.Info("re-rendering from the registry", "module", rec.ModulePath+"@"+rec.ModuleVersion)
+			return handoff.VerificationDigest(ctx, handoff.VerificationInput{
+				Client:        k8sClient,
+				Config:        cfg,
+				Name:          rec.Name,
+				Namespace:     rec.Namespace,
+				ModulePath:    rec.ModulePath,
+				ModuleVersion: rec.ModuleVersion,
+				SpecValues:    rec.SpecValues,
+			})
+		}
+	}
+
+	return query.PrintInventoryVerification(query.VerifyInventory(ctx, rec, live, missing, renderDigest), logName)
+}
+
+// NewInstanceInventoryGCCmd creates the instance inventory gc command.
+func NewInstanceInventoryGCCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+	var allNamespaces bool
+	var dryRunFlag bool
+
+	c := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete inventories whose resources are all gone",
+		Long: `Find ModuleInstance inventories left behind by failed or interrupted applies
+and delete them.
+
+An inventory is orphaned when every resource it tracks is confirmed absent
+from the cluster. Inventories with any live resource, any resource that could
+not be read, or no entries at all are kept, as are operator-owned instances.
+The workloads themselves are never touched.
+
+Runs as a dry run by default; pass --dry-run=false to delete.
+
+Examples:
+  # List orphaned inventories in a namespace
+  opm instance inventory gc -n media
+
+  # Delete orphaned inventories across the cluster
+  opm instance inventory gc -A --dry-run=false`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceInventoryGC(cfg, &kf, namespace, allNamespaces, dryRunFlag)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (default from config)")
+	c.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Collect across all namespaces")
+	c.Flags().BoolVar(&dryRunFlag, "dry-run", true, "Report orphaned inventories without deleting them")
+
+	return c
+}
+
+func runInstanceInventoryGC(cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string, allNamespaces, dryRun bool) error {
+	ctx := context.Background()
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:         cfg,
+		KubeconfigFlag: kf.Kubeconfig,
+		ContextFlag:    kf.Context,
+		NamespaceFlag:  namespaceFlag,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+
+	targetNamespace := k8sConfig.Namespace.Value
+	if allNamespaces {
+		targetNamespace = ""
+	} else if err := cmdutil.RequireNamespace(k8sConfig); err != nil {
+		return err
+	}
+
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		return err
+	}
+
+	outcomes, err := inventory.GarbageCollect(ctx, k8sClient, targetNamespace, dryRun)
+	if err == nil || len(outcomes) > 0 {
+		// On a failed delete, still report what was reclaimed before it.
+		query.PrintGarbageCollection(outcomes, dryRun)
+	}
+	if err != nil {
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: fmt.Errorf("collecting inventories: %w", err)}
+	}
+	return nil
+}
+
+// NewInstanceInventoryExportCmd creates the instance inventory export command.
+func NewInstanceInventoryExportCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+
+	c := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Write an instance's inventory record to stdout",
+		Long: `Write an instance's ModuleInstance to stdout as a portable YAML document, for
+backup or for moving the instance to another cluster with
+'opm instance inventory import'.
+
+The document carries the instance's labels, annotations, and spec, plus the
+inventory and last-applied digests from its status. Server-managed metadata and
+operator-written status are left out.
+
+Examples:
+  # Back up an instance's inventory
+  opm instance inventory export jellyfin -n media > jellyfin.inventory.yaml
+
+  # Copy an instance's inventory to another cluster
+  opm instance inventory export jellyfin -n media --context old | \
+    opm instance inventory import --context new`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceInventoryExport(args[0], cfg, &kf, namespace)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (default from config)")
+
+	return c
+}
+
+func runInstanceInventoryExport(name string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string) error {
+	ctx := context.Background()
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:         cfg,
+		KubeconfigFlag: kf.Kubeconfig,
+		ContextFlag:    kf.Context,
+		NamespaceFlag:  namespaceFlag,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+	if err := cmdutil.RequireNamespace(k8sConfig); err != nil {
+		return err
+	}
+
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		return err
+	}
+
+	data, err := inventory.Export(ctx, k8sClient, name, k8sConfig.Namespace.Value)
+	if err != nil {
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: fmt.Errorf("exporting inventory: %w", err)}
+	}
+	output.Print(string(data))
+	return nil
+}
+
+// NewInstanceInventoryImportCmd creates the instance inventory import command.
+func NewInstanceInventoryImportCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var forceFlag bool
+
+	c := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Write an exported inventory record to the cluster",
+		Long: `Create an instance's ModuleInstance from a document written by
+'opm instance inventory export'. Reads stdin when no file is given or the file
+is "-".
+
+The document is checked before anything is written: its identity labels must
+match its name and namespace, and its inventory entries must match the
+recorded count and digest. The instance lands in the namespace the document
+names. An existing ModuleInstance is only overwritten with --force.
+
+Import restores the record only; it does not create the resources the
+inventory tracks. An operator-owned instance is reconciled by the target
+cluster's operator once imported.
+
+Examples:
+  # Restore an instance's inventory from a backup
+  opm instance inventory import jellyfin.inventory.yaml
+
+  # Replace an existing record
+  opm instance inventory import jellyfin.inventory.yaml --force`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			path := "-"
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return runInstanceInventoryImport(path, cfg, &kf, forceFlag)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().BoolVar(&forceFlag, "force", false, "Overwrite an existing ModuleInstance")
+
+	return c
+}
+
+func runInstanceInventoryImport(path string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, force bool) error {
+	ctx := context.Background()
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("reading inventory document: %w", err)}
+	}
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:         cfg,
+		KubeconfigFlag: kf.Kubeconfig,
+		ContextFlag:    kf.Context,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		return err
+	}
+
+	rec, err := inventory.Import(ctx, k8sClient, data, force)
+	switch {
+	case errors.Is(err, inventory.ErrInventoryExists):
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("%w; pass --force to overwrite it", err)}
+	case errors.Is(err, inventory.ErrInvalidExport):
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	case err != nil:
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: fmt.Errorf("importing inventory: %w", err)}
+	}
+
+	output.InstanceLogger(rec.Name).Info("imported inventory",
+		"namespace", rec.Namespace, "resources", len(rec.Inventory.Entries))
+	return nil
+}
@@ -82,7 +82,7 @@ func runInstanceList(cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFl
 		}
 	}
 
-	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+package instance
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/query"
+)
+
+// NewInstanceLogsCmd creates the instance logs command.
+func NewInstanceLogsCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+
+	var (
+		followFlag    bool
+		sinceFlag     time.Duration
+		tailFlag      int64
+		containerFlag string
+	)
+
+	c := &cobra.Command{
+		Use:   "logs <file|name|uuid>",
+		Short: "Show pod logs for an instance",
+		Long: `Show the logs of every pod an OPM instance's workloads run.
+
+Pods are found through the label selectors of the Deployments, StatefulSets,
+DaemonSets, and Jobs in the instance's inventory. Each line is prefixed with
+[component/pod]. A pod with several containers shows its first one, with a
+warning, unless --container names another. With --follow, a stream whose
+container restarts picks up again once it is back.
+
+Arguments:
+  file         Path to an instance.cue file or directory containing one.
+               The instance name and namespace are read from the file's metadata.
+               --namespace overrides the namespace found in the file.
+  name         Instance name (use -n / --namespace to scope by namespace).
+  uuid         Instance UUID.
+
+Examples:
+  # Logs of every pod of the jellyfin instance
+  opm instance logs jellyfin -n media
+
+  # Follow the last 20 lines of each pod (Ctrl-C stops)
+  opm instance logs jellyfin -n media -f --tail 20
+
+  # The last 10 minutes of one container
+  opm instance logs jellyfin -n media --since 10m -c jellyfin`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceLogs(args[0], cfg, &kf, namespace, kubernetes.LogsOptions{
+				Follow:    followFlag,
+				Since:     sinceFlag,
+				Tail:      tailFlag,
+				Container: containerFlag,
+			})
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace (default: from config)")
+	c.Flags().BoolVarP(&followFlag, "follow", "f", false, "Stream new log lines until interrupted")
+	c.Flags().DurationVar(&sinceFlag, "since", 0, "Only show logs newer than this duration (e.g. 10m, 1h)")
+	c.Flags().Int64Var(&tailFlag, "tail", -1, "Lines of recent log to show per container (-1: all)")
+	c.Flags().StringVarP(&containerFlag, "container", "c", "", "Container to show (default: each pod's first container)")
+
+	return c
+}
+
+func runInstanceLogs(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string, opts kubernetes.LogsOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	target, err := cmdutil.ResolveInstanceTarget(identifier, cfg, kf, namespaceFlag)
+	if err != nil {
+		return err
+	}
+
+	instanceLog := output.InstanceLogger(target.LogName)
+
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		instanceLog.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	_, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, inventory.ReadPrefer, instanceLog)
+	if err != nil {
+		return err
+	}
+
+	opts.InventoryLive = liveResources
+	opts.Warn = func(msg string, keyvals ...any) { instanceLog.Warn(msg, keyvals...) }
+	if err := kubernetes.Logs(ctx, k8sClient, opts, os.Stdout); err != nil {
+		instanceLog.Error("streaming logs", "error", err)
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err, Printed: true}
+	}
+	return nil
+}
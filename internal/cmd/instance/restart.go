@@ -0,0 +1,169 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/query"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// NewInstanceRestartCmd creates the instance restart command.
+func NewInstanceRestartCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var kf cmdutil.K8sFlags
+	var namespace string
+
+	var (
+		componentFlags []string
+		nameFlags      []string
+		waitFlag       bool
+		timeoutFlag    time.Duration
+	)
+
+	c := &cobra.Command{
+		Use:   "restart <file|name|uuid>",
+		Short: "Trigger a rolling restart of an instance's workloads",
+		Long: `Trigger a rolling restart of the Deployments, StatefulSets, and DaemonSets an
+OPM instance tracks, as kubectl rollout restart does: the pod template gets a
+kubectl.kubernetes.io/restartedAt annotation, and the workload replaces its
+pods under its update strategy. The annotation is not part of the render, so
+the next apply does not undo it.
+
+--component and --name restrict the restart to workloads of those components
+or with matching names. Other resources they match are skipped with a notice.
+--wait blocks until every restarted workload has rolled out and the instance
+is ready.
+
+Arguments:
+  file         Path to an instance.cue file or directory containing one.
+               The instance name and namespace are read from the file's metadata.
+               --namespace overrides the namespace found in the file.
+  name         Instance name (use -n / --namespace to scope by namespace).
+  uuid         Instance UUID.
+
+Examples:
+  # Restart every workload of the jellyfin instance
+  opm instance restart jellyfin -n media
+
+  # Restart one component and wait for its rollout
+  opm instance restart jellyfin -n media --component server --wait
+
+  # Restart workloads whose names start with "web-"
+  opm instance restart jellyfin -n media --name 'web-*'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runInstanceRestart(args[0], cfg, &kf, namespace, componentFlags, nameFlags, waitFlag, timeoutFlag)
+		},
+	}
+
+	kf.AddTo(c)
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace (default: from config)")
+	c.Flags().StringArrayVar(&componentFlags, "component", nil, "Only restart workloads of this component (repeatable)")
+	c.Flags().StringArrayVar(&nameFlags, "name", nil, "Only restart workloads whose name matches this glob (repeatable)")
+	c.Flags().BoolVar(&waitFlag, "wait", false, "Wait until the restarted workloads have rolled out and the instance is ready")
+	c.Flags().DurationVar(&timeoutFlag, "timeout", defaultStatusWaitTimeout, "Bound on --wait")
+
+	return c
+}
+
+func runInstanceRestart(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag string, components, names []string, wait bool, timeout time.Duration) error {
+	ctx := context.Background()
+
+	for _, p := range names {
+		if _, err := path.Match(p, ""); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid --name pattern %q: %w", p, err)}
+		}
+	}
+
+	target, err := cmdutil.ResolveInstanceTarget(identifier, cfg, kf, namespaceFlag)
+	if err != nil {
+		return err
+	}
+	cmdutil.LogResolvedKubernetesConfig(target.Namespace, target.K8sConfig.Kubeconfig.Value, target.K8sConfig.Context.Value)
+
+	instanceLog := output.InstanceLogger(target.LogName)
+
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
+	if err != nil {
+		instanceLog.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+
+	_, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, inventory.ReadPrefer, instanceLog)
+	if err != nil {
+		return err
+	}
+
+	workloads, skipped := selectRestartTargets(liveResources, components, names)
+	for _, obj := range skipped {
+		instanceLog.Info("not a workload; skipping", "resource", obj.GetKind()+"/"+obj.GetName())
+	}
+	if len(workloads) == 0 {
+		if len(components) > 0 || len(names) > 0 {
+			err := fmt.Errorf("no Deployment, StatefulSet, or DaemonSet of the instance matches --component/--name")
+			instanceLog.Error("nothing to restart", "error", err)
+			return &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: err, Printed: true}
+		}
+		instanceLog.Info("instance has no workloads to restart")
+		return nil
+	}
+
+	restarted, err := kubernetes.Restart(ctx, k8sClient, workloads, time.Now())
+	for _, obj := range restarted {
+		instanceLog.Info(output.FormatResourceLine(obj.GetKind(), obj.GetNamespace(), obj.GetName(), "restarted"))
+	}
+	if err != nil {
+		instanceLog.Error("restart failed", "error", err)
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err, Printed: true}
+	}
+
+	if !wait {
+		return nil
+	}
+	deadline := time.Now().Add(timeout)
+	instanceLog.Info(fmt.Sprintf("waiting up to %s for %d rollouts", timeout, len(restarted)))
+	if err := kubernetes.WaitForRollouts(ctx, k8sClient, restarted, timeout, query.StatusWatchInterval); err != nil {
+		instanceLog.Error("wait failed", "error", err)
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+	}
+	evaluate := query.InstanceStatusEvaluator(k8sClient, target.Selector, target.Namespace, inventory.ReadPrefer, output.FormatTable, false, instanceLog)
+	return query.WaitForInstanceStatus(ctx, evaluate, kubernetes.HealthReady, output.FormatTable,
+		max(time.Until(deadline), time.Second), query.StatusWatchInterval, target.LogName)
+}
+
+// selectRestartTargets returns the workloads among live that the component
+// and name filters match, and the non-workload resources they match. With no
+// filters, every workload is a target and nothing is reported as skipped.
+func selectRestartTargets(live []*unstructured.Unstructured, components, names []string) (workloads, skipped []*unstructured.Unstructured) {
+	filtered := len(components) > 0 || len(names) > 0
+	for _, obj := range live {
+		if len(components) > 0 && !slices.Contains(components, obj.GetLabels()[pkgcore.LabelComponentName]) {
+			continue
+		}
+		if len(names) > 0 && !slices.ContainsFunc(names, func(p string) bool {
+			ok, _ := path.Match(p, obj.GetName()) //nolint:errcheck // patterns are checked up front
+			return ok
+		}) {
+			continue
+		}
+		switch {
+		case kubernetes.IsRestartable(obj):
+			workloads = append(workloads, obj)
+		case filtered:
+			skipped = append(skipped, obj)
+		}
+	}
+	return workloads, skipped
+}
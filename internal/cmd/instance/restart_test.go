@@ -0,0 +1,45 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func TestSelectRestartTargets(t *testing.T) {
+	resource := func(kind, name, component string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(kind)
+		obj.SetName(name)
+		obj.SetLabels(map[string]string{pkgcore.LabelComponentName: component})
+		return obj
+	}
+	live := []*unstructured.Unstructured{
+		resource("Deployment", "web", "frontend"),
+		resource("Service", "web", "frontend"),
+		resource("StatefulSet", "db", "database"),
+		resource("Job", "migrate", "database"),
+	}
+	names := func(objs []*unstructured.Unstructured) []string {
+		var out []string
+		for _, o := range objs {
+			out = append(out, o.GetKind()+"/"+o.GetName())
+		}
+		return out
+	}
+
+	workloads, skipped := selectRestartTargets(live, nil, nil)
+	assert.Equal(t, []string{"Deployment/web", "StatefulSet/db"}, names(workloads))
+	assert.Empty(t, skipped, "unfiltered restarts do not report every non-workload")
+
+	workloads, skipped = selectRestartTargets(live, []string{"frontend"}, nil)
+	assert.Equal(t, []string{"Deployment/web"}, names(workloads))
+	assert.Equal(t, []string{"Service/web"}, names(skipped))
+
+	workloads, skipped = selectRestartTargets(live, nil, []string{"m*", "d*"})
+	assert.Equal(t, []string{"StatefulSet/db"}, names(workloads))
+	assert.Equal(t, []string{"Job/migrate"}, names(skipped))
+}
@@ -2,23 +2,35 @@ package instance
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/workflow/query"
 )
 
+// defaultStatusWaitTimeout bounds status --wait-for.
+const defaultStatusWaitTimeout = 5 * time.Minute
+
 // NewInstanceStatusCmd creates the instance status command.
 func NewInstanceStatusCmd(cfg *config.GlobalConfig) *cobra.Command {
 	var kf cmdutil.K8sFlags
 	var namespace string
 
 	var (
-		outputFlag  string
-		detailsFlag bool
+		outputFlag   string
+		detailsFlag  bool
+		resourceFlag string
+		watchFlag    bool
+		waitForFlag  string
+		timeoutFlag  time.Duration
+		invFlag      string
 	)
 
 	c := &cobra.Command{
@@ -44,24 +56,64 @@ Examples:
   opm instance status jellyfin -n media
 
   # Wide output
-  opm instance status jellyfin -n media -o wide`,
+  opm instance status jellyfin -n media -o wide
+
+  # Drill into one resource: conditions, pods, and recent events
+  opm instance status jellyfin -n media --resource Deployment/jellyfin
+
+  # Follow status until every resource is ready (Ctrl-C stops early)
+  opm instance status jellyfin -n media --watch
+
+  # Gate CI on rollout health: exit 0 once Ready, non-zero after 5 minutes
+  opm instance status jellyfin -n media --wait-for=Ready --timeout 5m
+
+  # Export health gauges for a node-exporter textfile collector (cron)
+  opm instance status jellyfin -n media -o prometheus > /var/lib/node_exporter/opm_jellyfin.prom`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			return runInstanceStatus(args[0], cfg, &kf, namespace, outputFlag, detailsFlag)
+			return runInstanceStatus(args[0], cfg, &kf, namespace, outputFlag, detailsFlag, resourceFlag, watchFlag, waitForFlag, timeoutFlag, invFlag)
 		},
 	}
 
 	kf.AddTo(c)
 	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace (default: from config)")
-	c.Flags().StringVarP(&outputFlag, "output", "o", "table", "Output format (table, wide, yaml, json)")
+	c.Flags().StringVarP(&outputFlag, "output", "o", "table", "Output format (table, wide, yaml, json, prometheus)")
 	c.Flags().BoolVar(&detailsFlag, "details", false, "Show pod-level diagnostics for unhealthy workloads")
+	c.Flags().StringVar(&resourceFlag, "resource", "",
+		"Show detailed status for one tracked resource (kind[.group]/[namespace/]name, e.g. Deployment/web)")
+	c.Flags().BoolVarP(&watchFlag, "watch", "w", false,
+		"Re-evaluate status every 2s until all resources are ready (json: one object per line)")
+	c.Flags().StringVar(&waitForFlag, "wait-for", "",
+		"Block until the aggregate status is Ready (or NotReady), then print it; non-zero exit on --timeout")
+	c.Flags().DurationVar(&timeoutFlag, "timeout", defaultStatusWaitTimeout, "Bound on --wait-for")
+	addInventoryModeFlag(c, &invFlag)
+	c.MarkFlagsMutuallyExclusive("watch", "wait-for")
 
 	return c
 }
 
-func runInstanceStatus(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag, outputFmt string, verbose bool) error {
+func runInstanceStatus(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8sFlags, namespaceFlag, outputFmt string, verbose bool, resourceRef string, watch bool, waitFor string, timeout time.Duration, inventoryFlag string) error {
 	ctx := context.Background()
 
+	mode, err := parseInventoryMode(inventoryFlag)
+	if err != nil {
+		return err
+	}
+
+	if watch && resourceRef != "" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--watch cannot be combined with --resource")}
+	}
+	if waitFor != "" && resourceRef != "" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--wait-for cannot be combined with --resource")}
+	}
+	var wantStatus kubernetes.HealthStatus
+	if waitFor != "" {
+		var err error
+		if wantStatus, err = query.ParseWaitForStatus(waitFor); err != nil {
+			return err
+		}
+	}
+
 	target, err := cmdutil.ResolveInstanceTarget(identifier, cfg, kf, namespaceFlag)
 	if err != nil {
 		return err
@@ -76,18 +128,35 @@ func runInstanceStatus(identifier string, cfg *config.GlobalConfig, kf *cmdutil.
 	if err != nil {
 		return err
 	}
+	if watch && (outputFormat == output.FormatYAML || outputFormat == output.FormatPrometheus) {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--watch supports table, wide, and json output")}
+	}
+	if resourceRef != "" && outputFormat == output.FormatPrometheus {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--resource does not support prometheus output")}
+	}
 
-	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		instanceLog.Error("connecting to cluster", "error", err)
-		return err
+		return cmdutil.MarkPrinted(err)
+	}
+
+	if watch || wantStatus != "" {
+		evaluate := query.InstanceStatusEvaluator(k8sClient, target.Selector, target.Namespace, mode, outputFormat, verbose, instanceLog)
+		if watch {
+			return query.WatchInstanceStatus(ctx, evaluate, outputFormat, query.StatusWatchInterval, logName)
+		}
+		return query.WaitForInstanceStatus(ctx, evaluate, wantStatus, outputFormat, timeout, query.StatusWatchInterval, logName)
 	}
 
-	inv, liveResources, missingEntries, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, instanceLog)
+	inv, liveResources, missingEntries, err := query.ResolveInventory(ctx, k8sClient, target.Selector, target.Namespace, mode, instanceLog)
 	if err != nil {
 		return err
 	}
 
 	statusOpts := query.BuildStatusOptions(target.Namespace, target.Selector, outputFormat, verbose, inv, liveResources, missingEntries)
+	if resourceRef != "" {
+		return query.PrintResourceDetail(ctx, k8sClient, statusOpts, resourceRef, logName)
+	}
 	return query.PrintInstanceStatus(ctx, k8sClient, statusOpts, logName)
 }
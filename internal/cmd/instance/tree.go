@@ -10,6 +10,7 @@ import (
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/inventory"
 	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/workflow/query"
@@ -87,13 +88,13 @@ func runInstanceTree(identifier string, cfg *config.GlobalConfig, kf *cmdutil.K8
 	logName := target.LogName
 	instanceLog := output.InstanceLogger(logName)
 
-	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(target.K8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		instanceLog.Error("connecting to cluster", "error", err)
-		return err
+		return cmdutil.MarkPrinted(err)
 	}
 
-	inv, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, target.Selector, namespace, instanceLog)
+	inv, liveResources, _, err := query.ResolveInventory(ctx, k8sClient, target.Selector, namespace, inventory.ReadPrefer, instanceLog)
 	if err != nil {
 		return err
 	}
@@ -64,7 +64,11 @@ func runInstanceVet(instanceFile string, cfg *config.GlobalConfig, rff *cmdutil.
 	result, err := render.FromInstanceFile(ctx, render.InstanceFileOpts{
 		InstanceFilePath: instanceFile,
 		ValuesFiles:      rff.Values,
-		PlatformFlag:     rff.Platform, // offline: no cluster read (0006 D21)
+		ValuesYAMLFiles:  rff.ValuesYAML,
+		ValuesJSONFiles:  rff.ValuesJSON,
+		ExpandEnv:        rff.EnvExpansion(),
+		PlatformFiles:    rff.Platform, // offline: no cluster read (0006 D21)
+		ExpectedDigest:   rff.ModuleDigest,
 		K8sConfig:        k8sConfig,
 		Config:           cfg,
 	})
@@ -10,10 +10,13 @@ import (
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/platform"
 	workflowapply "github.com/open-platform-model/cli/internal/workflow/apply"
 	"github.com/open-platform-model/cli/internal/workflow/render"
+	"github.com/open-platform-model/cli/pkg/loader"
 )
 
 // NewModuleApplyCmd creates the module apply command.
@@ -26,13 +29,29 @@ import (
 func NewModuleApplyCmd(cfg *config.GlobalConfig) *cobra.Command {
 	var rf cmdutil.RenderFlags
 	var kf cmdutil.K8sFlags
+	var vf cmdutil.ValuesFromFlags
+	var df cmdutil.DryRunFlags
 	var nameFlag string
 
 	var (
-		dryRunFlag   bool
 		createNSFlag bool
 		noPruneFlag  bool
+		pruneFlag    bool
 		forceFlag    bool
+		pruneOrder   []string
+		pruneMode    string
+		noInventory  bool
+		protectFins  []string
+		concurrency  int
+		failFast     bool
+		showManaged  bool
+		reconcile    bool
+		confirmFlag  bool
+		yesFlag      bool
+		labelPolicy  string
+		forcePrune   bool
+		adoptFlag    bool
+		safetyRatio  float64
 	)
 
 	c := &cobra.Command{
@@ -48,6 +67,20 @@ each with its own ModuleInstance CR). The ModuleInstance CRD must be installed
 first (run 'opm operator install --crds-only'). For persistent deploys, author
 a instance.cue file and use 'opm instance apply' instead.
 
+Resources apply in waves by kind (CRDs, then namespaces, then RBAC, config,
+workloads, and so on); each wave finishes before the next starts. An
+opmodel.dev/apply-wave annotation moves a resource into another wave. Within
+a wave, components apply one at a time unless --apply-concurrency allows more
+in parallel. A failed resource does not stop later waves unless --fail-fast
+is set.
+
+Apply is server-side apply with force, so every field the render sets is
+taken back from whoever changed it. A field the render stops setting is
+removed only if opm-cli was its sole owner; --reconcile also removes it when
+another manager (kubectl edit, kubectl apply) co-owns it, making the render
+authoritative. Fields a controller such as an HPA owns outright are never
+touched.
+
 When switching from 'opm module apply' to 'opm instance apply' (or vice versa)
 with a different instance name, delete the previous instance first to avoid
 orphan inventory:
@@ -65,29 +98,153 @@ Examples:
   opm module apply ./my-module --name my-debug
 
   # Dry run against a specific namespace
-  opm module apply ./my-module -n staging --dry-run`,
+  opm module apply ./my-module -n staging --dry-run
+
+  # Server-side dry run, showing what admission and defaulting would change
+  opm module apply ./my-module --dry-run=server --server-diff
+
+  # Override a single value on top of debugValues
+  opm module apply ./my-module --set replicas=3
+
+  # Apply up to four components of a wave in parallel
+  opm module apply ./my-module --apply-concurrency 4
+
+  # Converge fully to the render, removing dropped fields kubectl still holds
+  opm module apply ./my-module --reconcile
+
+  # Stop tracking resources the render dropped, leaving them running
+  opm module apply ./my-module --prune-mode detach
+
+  # Review the changes, including resources that would be pruned, before applying
+  opm module apply ./my-module --confirm
+
+  # Take values from a ConfigMap key in the cluster, on top of a base file
+  opm module apply ./my-module -f base.cue --values-from-configmap config/my-module:values.yaml`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
-			return runModuleApply(args, cfg, &rf, &kf, nameFlag, dryRunFlag, createNSFlag, noPruneFlag, forceFlag)
+			return runModuleApply(args, cfg, &rf, &kf, nameFlag, applyFlags{
+				DryRun:              df,
+				CreateNS:            createNSFlag,
+				NoPrune:             noPruneFlag || !pruneFlag,
+				Force:               forceFlag,
+				PruneOrder:          pruneOrder,
+				PruneMode:           pruneMode,
+				NoInventory:         noInventory,
+				ProtectedFinalizers: protectFins,
+				Concurrency:         concurrency,
+				FailFast:            failFast,
+				ShowManagedFields:   showManaged,
+				Reconcile:           reconcile,
+				Confirm:             confirmFlag,
+				Yes:                 yesFlag,
+				LabelPolicy:         labelPolicy,
+				ForcePrune:          forcePrune,
+				Adopt:               adoptFlag,
+				PruneSafetyRatio:    safetyRatio,
+				ValuesFrom:          vf,
+			})
 		},
 	}
 
 	rf.AddTo(c)
+	rf.AddSetComponentTo(c)
+	rf.AddStrictValuesTo(c)
+	rf.AddStrictTraitsTo(c)
+	rf.AddStrictNamespaceTo(c)
+	rf.AddInstanceUUIDTo(c)
+	vf.AddTo(c)
 	kf.AddTo(c)
+	kf.AddRateLimitTo(c)
+	kf.AddExpectTargetTo(c)
 	c.Flags().StringVar(&nameFlag, "name", "", "Override synthetic instance name")
-	c.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Server-side dry run (no changes made)")
-	c.Flags().BoolVar(&createNSFlag, "create-namespace", false, "Create target namespace if it does not exist")
-	c.Flags().BoolVar(&noPruneFlag, "no-prune", false, "Skip stale resource pruning")
+	df.AddTo(c)
+	c.Flags().BoolVar(&createNSFlag, "create-namespace", false, "Create the target namespace if it does not exist; a namespace created this way is deleted with the instance")
+	c.Flags().BoolVar(&pruneFlag, "prune", true,
+		"Prune stale resources; with --prune=false they are reported, left in place, and kept in the inventory")
+	c.Flags().BoolVar(&noPruneFlag, "no-prune", false, "Skip stale resource pruning (same as --prune=false)")
+	c.Flags().Float64Var(&safetyRatio, "prune-safety-ratio", inventory.DefaultPruneSafetyRatio,
+		"Refuse to prune when more than this share of the tracked resources would be deleted (1 disables the check)")
+	c.Flags().BoolVar(&forcePrune, "force-prune", false, "Prune even when --prune-safety-ratio is exceeded")
+	c.Flags().BoolVar(&adoptFlag, "adopt", false,
+		"Take over rendered resources that already exist without OPM labels (by default the apply refuses them)")
+	c.MarkFlagsMutuallyExclusive("prune", "no-prune")
 	c.Flags().BoolVar(&forceFlag, "force", false, "Allow empty render to prune all previously tracked resources")
+	c.Flags().StringSliceVar(&pruneOrder, "prune-order", nil,
+		"Kinds to prune first, in order (e.g. Ingress,Service); others follow in reverse apply order")
+	c.Flags().StringVar(&pruneMode, "prune-mode", inventory.PruneModeDelete,
+		"What to do with stale resources: delete, or detach (remove OPM labels and stop tracking, leaving them running)")
+	c.Flags().StringSliceVar(&protectFins, "prune-blacklist-finalizers", nil,
+		"Never prune stale resources carrying any of these finalizers (e.g. kubernetes.io/pvc-protection)")
+	c.Flags().BoolVar(&noInventory, "no-inventory", false,
+		"Do not read or write the ModuleInstance CR; find previously applied resources by label scan")
+	c.Flags().IntVar(&concurrency, "apply-concurrency", kubernetes.DefaultApplyConcurrency,
+		"Components to apply in parallel within each apply wave (default 1: sequential)")
+	c.Flags().BoolVar(&failFast, "fail-fast", false,
+		"Stop at the first apply wave with a failed resource instead of applying every wave and reporting failures at the end")
+	c.Flags().BoolVar(&showManaged, "show-managed-fields", false,
+		"After applying, report which fields opm-cli and other field managers own on each resource")
+	c.Flags().BoolVar(&reconcile, "reconcile", false,
+		"Also remove fields a previous apply set that the render dropped but another field manager still holds")
+	c.Flags().BoolVar(&confirmFlag, "confirm", false,
+		"Show what the apply would change, including resources it would prune, and ask before applying")
+	c.Flags().BoolVar(&yesFlag, "yes", false, "With --confirm, show the changes but apply without asking")
+	c.Flags().StringVar(&labelPolicy, "label-conflict-policy", kubernetes.LabelConflictOPMWins,
+		"When a live label or annotation differs from the render: opm-wins, keep-existing, or merge (keep only values other tools set)")
 
 	return c
 }
 
+// applyFlags carries the apply command's behavior flags.
+type applyFlags struct {
+	DryRun              cmdutil.DryRunFlags
+	CreateNS            bool
+	NoPrune             bool
+	Force               bool
+	PruneOrder          []string
+	PruneMode           string
+	NoInventory         bool
+	ProtectedFinalizers []string
+	Concurrency         int
+	FailFast            bool
+	ShowManagedFields   bool
+	Reconcile           bool
+	Confirm             bool
+	Yes                 bool
+	LabelPolicy         string
+	ForcePrune          bool
+	Adopt               bool
+	PruneSafetyRatio    float64
+	ValuesFrom          cmdutil.ValuesFromFlags
+}
+
 // runModuleApply executes the module apply command.
 func runModuleApply(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, kf *cmdutil.K8sFlags,
-	nameFlag string, dryRun, createNS, noPrune, force bool) error {
+	nameFlag string, flags applyFlags) error {
 	ctx := context.Background()
 
+	dryRun, clientDryRun, err := flags.DryRun.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := inventory.ValidatePruneMode(flags.PruneMode); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if err := kubernetes.ValidateLabelConflictPolicy(flags.LabelPolicy); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if flags.PruneSafetyRatio < 0 || flags.PruneSafetyRatio > 1 {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("invalid --prune-safety-ratio %g (must be between 0 and 1)", flags.PruneSafetyRatio)}
+	}
+	if flags.Yes && !flags.Confirm {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--yes requires --confirm")}
+	}
+
+	valuesRefs, err := flags.ValuesFrom.Refs()
+	if err != nil {
+		return err
+	}
+
 	modulePath := cmdutil.ResolveModulePath(args)
 
 	info, statErr := os.Stat(modulePath)
@@ -116,17 +273,35 @@ func runModuleApply(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderF
 
 	// Cluster client before render: apply resolves its platform from the
 	// cluster Platform CR by default (0006 D21).
-	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings)
+	k8sClient, err := cmdutil.NewK8sClient(k8sConfig, cfg.Log.Kubernetes.APIWarnings, kf)
 	if err != nil {
 		output.Error("connecting to cluster", "error", err)
+		return cmdutil.MarkPrinted(err)
+	}
+	if err := cmdutil.CheckTarget(k8sClient, kf); err != nil {
+		return err
+	}
+
+	valuesDocs, err := cmdutil.FetchValuesDocuments(ctx, k8sClient, valuesRefs)
+	if err != nil {
 		return err
 	}
 
 	result, err := render.FromModule(ctx, render.ModuleOpts{
 		ModulePath:      modulePath,
 		ValuesFiles:     rf.Values,
+		ValuesYAMLFiles: rf.ValuesYAML,
+		ValuesJSONFiles: rf.ValuesJSON,
+		ExpandEnv:       rf.EnvExpansion(),
+		ValuesDocuments: valuesDocs,
+		SetValues:       loader.SetValues{Set: rf.Set, SetString: rf.SetString},
+		ComponentSets:   rf.SetComponent,
+		StrictValues:    rf.StrictValues,
+		StrictTraits:    rf.StrictTraits,
+		StrictNamespace: rf.StrictNamespace,
+		InstanceUUID:    rf.InstanceUUID,
 		Name:            nameFlag,
-		PlatformFlag:    rf.Platform,
+		PlatformFiles:   rf.Platform,
 		ClusterPlatform: platform.ClusterSpecGetterFor(k8sClient.Dynamic),
 		K8sConfig:       k8sConfig,
 		Config:          cfg,
@@ -139,17 +314,46 @@ func runModuleApply(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderF
 
 	instanceLog := output.InstanceLogger(result.Instance.Name)
 
-	return workflowapply.Execute(ctx, workflowapply.Request{
+	req := workflowapply.Request{
 		Result:    result,
 		K8sClient: k8sClient,
 		Log:       instanceLog,
 		Options: workflowapply.Options{
 			DryRun:                 dryRun,
-			CreateNS:               createNS,
-			NoPrune:                noPrune,
-			Force:                  force,
+			ClientDryRun:           clientDryRun,
+			ShowServerDiff:         flags.DryRun.ServerDiff,
+			CreateNS:               flags.CreateNS,
+			NoPrune:                flags.NoPrune,
+			Force:                  flags.Force,
+			PruneOrder:             flags.PruneOrder,
+			DetachStale:            flags.PruneMode == inventory.PruneModeDetach,
+			NoInventory:            flags.NoInventory,
+			ProtectedFinalizers:    flags.ProtectedFinalizers,
+			Concurrency:            flags.Concurrency,
+			FailFast:               flags.FailFast,
+			ShowManagedFields:      flags.ShowManagedFields,
+			Reconcile:              flags.Reconcile,
+			LabelConflictPolicy:    flags.LabelPolicy,
+			StrictNamespace:        rf.StrictNamespace,
+			ForcePrune:             flags.ForcePrune,
+			Adopt:                  flags.Adopt,
+			PruneSafetyRatio:       flags.PruneSafetyRatio,
 			SuccessUpToDateMessage: "Instance up to date",
 			SuccessAppliedMessage:  "Instance applied",
 		},
-	})
+	}
+	if flags.Confirm && !dryRun {
+		ok, err := workflowapply.Confirm(ctx, req, flags.Yes, cmdutil.Confirm)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			instanceLog.Info("apply canceled")
+			return nil
+		}
+	}
+	progress, finish := cmdutil.NewApplyProgress()
+	defer finish()
+	req.Options.Progress = progress
+	return workflowapply.Execute(ctx, req)
 }
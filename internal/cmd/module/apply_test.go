@@ -51,15 +51,17 @@ func TestNewModuleApplyCmd_Flags(t *testing.T) {
 	}
 	cases := []flagExpect{
 		{"values", "f", "stringArray", "[]"},
-		{"platform", "", "string", ""},
+		{"platform", "", "stringArray", "[]"},
 		{"name", "", "string", ""},
 		{"namespace", "n", "string", ""},
 		{"kubeconfig", "", "string", ""},
 		{"context", "", "string", ""},
-		{"dry-run", "", "bool", "false"},
+		{"dry-run", "", "string", "none"},
+		{"server-diff", "", "bool", "false"},
 		{"create-namespace", "", "bool", "false"},
 		{"no-prune", "", "bool", "false"},
 		{"force", "", "bool", "false"},
+		{"prune-order", "", "stringSlice", "[]"},
 	}
 
 	for _, c := range cases {
@@ -90,7 +92,7 @@ func TestRunModuleApply_RejectsFileArgument(t *testing.T) {
 	filePath := filepath.Join(dir, "module.cue")
 	require.NoError(t, os.WriteFile(filePath, []byte("package x\n"), 0o644))
 
-	err := runModuleApply([]string{filePath}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, &cmdutil.K8sFlags{}, "", false, false, false, false)
+	err := runModuleApply([]string{filePath}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, &cmdutil.K8sFlags{}, "", applyFlags{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "expects a directory")
 	assert.Contains(t, err.Error(), "opm instance apply", "error must point users at instance apply for files")
@@ -101,7 +103,7 @@ func TestRunModuleApply_RejectsFileArgument(t *testing.T) {
 }
 
 func TestRunModuleApply_MissingPath(t *testing.T) {
-	err := runModuleApply([]string{"/nonexistent/module/dir"}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, &cmdutil.K8sFlags{}, "", false, false, false, false)
+	err := runModuleApply([]string{"/nonexistent/module/dir"}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, &cmdutil.K8sFlags{}, "", applyFlags{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 
@@ -143,7 +145,7 @@ func TestRunModuleApply_PathValidation_TableDriven(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := runModuleApply(tc.args, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, &cmdutil.K8sFlags{}, "", false, false, false, false)
+			err := runModuleApply(tc.args, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, &cmdutil.K8sFlags{}, "", applyFlags{})
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tc.wantContains)
 
@@ -2,8 +2,11 @@ package modulecmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"text/template"
+	"time"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
@@ -11,7 +14,9 @@ import (
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/workflow/render"
+	"github.com/open-platform-model/cli/pkg/loader"
 )
 
 // NewModuleBuildCmd creates the module build command.
@@ -19,11 +24,7 @@ func NewModuleBuildCmd(cfg *config.GlobalConfig) *cobra.Command {
 	var rf cmdutil.RenderFlags
 	var nameFlag string
 
-	var (
-		outputFlag string
-		splitFlag  bool
-		outDirFlag string
-	)
+	var flags buildFlags
 
 	c := &cobra.Command{
 		Use:   "build [path]",
@@ -42,24 +43,106 @@ Examples:
   # Build a specific module with custom values
   opm module build ./my-module -f overrides.cue
 
+  # Read values piped from another tool, on top of a base file
+  render-values | opm module build ./my-module -f base.cue -f -
+
+  # Override individual values (replaces, rather than unifies with, -f values)
+  opm module build ./my-module -f overrides.cue --set replicas=3 --set-string image.tag=1.10
+
+  # Override a field of one component only
+  opm module build ./my-module --set-component web.spec.replicas=3
+
+  # Fail instead of warning when a trait is not handled by any transformer
+  opm module build ./my-module --strict-traits
+
+  # Give up on a render that takes longer than two minutes (CI)
+  opm module build ./my-module --render-timeout 2m
+
   # Build with a custom synthetic instance name
-  opm module build ./my-module --name my-debug`,
+  opm module build ./my-module --name my-debug
+
+  # Inspect the output of a single component; errors in the others are not reported
+  opm module build ./my-module --show-only web
+
+  # Build only the components listed in a file (one name per line)
+  opm module build ./my-module --components-from-file components.txt
+
+  # Emit a single JSON List for kubectl
+  opm module build ./my-module -o json --list
+
+  # Write one file per resource, in a subdirectory per component
+  opm module build ./my-module --split --split-layout component --out-dir ./rendered
+
+  # Render through a Go template: once over the set (.items), or per resource
+  opm module build ./my-module -o template=report.tmpl
+  opm module build ./my-module -o template=line.tmpl --template-per-resource
+
+Summary:
+  After rendering, build reports how many resources each component produced
+  and the total, on stderr. With -o json the summary is written to stderr as a
+  single JSON object ({"components":[{"component":..,"resources":..}],"total":..})
+  so stdout stays a valid manifest document. --quiet drops the summary; as -o
+  json implies --quiet, pass --verbose to get the JSON summary.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			return runModuleBuild(args, cfg, &rf, nameFlag, outputFlag, splitFlag, outDirFlag)
+			return runModuleBuild(args, cfg, &rf, nameFlag, flags)
 		},
 	}
 
 	rf.AddTo(c)
+	rf.AddSetComponentTo(c)
+	rf.AddStrictValuesTo(c)
+	rf.AddStrictTraitsTo(c)
+	rf.AddStrictNamespaceTo(c)
+	rf.AddInstanceUUIDTo(c)
 	c.Flags().StringVar(&nameFlag, "name", "", "Override synthetic instance name")
-	c.Flags().StringVarP(&outputFlag, "output", "o", "yaml", "Output format: yaml, json")
-	c.Flags().BoolVar(&splitFlag, "split", false, "Write separate files per resource")
-	c.Flags().StringVar(&outDirFlag, "out-dir", "./manifests", "Directory for split output")
+	c.Flags().StringVarP(&flags.Output, "output", "o", "yaml", "Output format: yaml, json, or template=<file> (a Go text/template)")
+	c.Flags().BoolVar(&flags.TemplatePerResource, "template-per-resource", false,
+		"With -o template=<file>, run the template once per resource instead of once over the set")
+	c.Flags().BoolVar(&flags.List, "list", false, "With -o json, wrap resources in a v1 List instead of a bare array")
+	c.Flags().BoolVar(&flags.Split, "split", false, "Write separate files per resource")
+	c.Flags().StringVar(&flags.OutDir, "out-dir", "./manifests", "Directory for split output")
+	c.Flags().StringVar(&flags.SplitLayout, "split-layout", splitLayoutFlat,
+		"With --split, file layout: flat (one directory) or component (a subdirectory per component)")
+	c.Flags().BoolVar(&flags.Force, "force", false, "With --split-layout component, write even if --out-dir holds files this build would not produce")
+	c.Flags().StringVar(&flags.ComponentsFile, "components-from-file", "",
+		"Render only the components listed in this file (one name per line, # comments allowed)")
+	c.Flags().StringArrayVar(&flags.ShowOnly, "show-only", nil, "Render only this component (repeatable); other components are not compiled")
+	c.Flags().DurationVar(&flags.RenderTimeout, "render-timeout", 0,
+		"Fail the render when matching and executing transformers takes longer than this (e.g. 2m); 0 waits indefinitely")
+	c.Flags().StringVar(&flags.PrintMetadata, "print-metadata", "",
+		"After the manifests, write the instance identity, module version, and the render and inventory digests apply would record, as JSON: to stderr, or with =<file> to that file")
+	c.Flags().Lookup("print-metadata").NoOptDefVal = "-"
+	c.MarkFlagsMutuallyExclusive("show-only", "components-from-file")
 
 	return c
 }
 
-func runModuleBuild(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, nameFlag, outputFmt string, split bool, outDir string) error {
+// Values of --split-layout.
+const (
+	splitLayoutFlat      = "flat"
+	splitLayoutComponent = "component"
+)
+
+// buildFlags carries the build command's output flags.
+type buildFlags struct {
+	Output         string
+	Split          bool
+	OutDir         string
+	SplitLayout    string
+	Force          bool
+	List           bool
+	ComponentsFile string
+	ShowOnly       []string
+	RenderTimeout  time.Duration
+	// PrintMetadata is --print-metadata: "-" for stderr, else a file path;
+	// empty when not given.
+	PrintMetadata string
+
+	TemplatePerResource bool
+}
+
+func runModuleBuild(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, nameFlag string, flags buildFlags) error {
 	ctx := context.Background()
 
 	modulePath := cmdutil.ResolveModulePath(args)
@@ -78,9 +161,47 @@ func runModuleBuild(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderF
 		}
 	}
 
-	outputFormat, err := render.ParseManifestOutputFormat(outputFmt)
+	templatePath, isTemplate, err := output.ParseTemplateFormat(flags.Output)
 	if err != nil {
-		return err
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	var tmpl *template.Template
+	outputFormat := output.FormatYAML
+	if isTemplate {
+		if flags.Split || flags.List {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("-o template=<file> writes to stdout; it cannot be combined with --split or --list")}
+		}
+		if tmpl, err = output.LoadManifestTemplate(templatePath); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+	} else {
+		if flags.TemplatePerResource {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--template-per-resource requires -o template=<file>")}
+		}
+		if outputFormat, err = render.ParseManifestOutputFormat(flags.Output); err != nil {
+			return err
+		}
+	}
+	if flags.List && (outputFormat != output.FormatJSON || flags.Split) {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--list requires -o json and stdout output")}
+	}
+
+	switch flags.SplitLayout {
+	case splitLayoutFlat, splitLayoutComponent:
+	default:
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("invalid --split-layout %q (valid: %s, %s)", flags.SplitLayout, splitLayoutFlat, splitLayoutComponent)}
+	}
+	if flags.Force && (!flags.Split || flags.SplitLayout != splitLayoutComponent) {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--force requires --split --split-layout component")}
+	}
+
+	components := flags.ShowOnly
+	if flags.ComponentsFile != "" {
+		components, err = render.ReadComponentList(flags.ComponentsFile)
+		if err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
 	}
 
 	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
@@ -92,18 +213,84 @@ func runModuleBuild(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderF
 	}
 
 	result, err := render.FromModule(ctx, render.ModuleOpts{
-		ModulePath:   modulePath,
-		ValuesFiles:  rf.Values,
-		Name:         nameFlag,
-		PlatformFlag: rf.Platform, // offline: no cluster read (0006 D21)
-		K8sConfig:    k8sConfig,
-		Config:       cfg,
+		ModulePath:      modulePath,
+		ValuesFiles:     rf.Values,
+		ValuesYAMLFiles: rf.ValuesYAML,
+		ValuesJSONFiles: rf.ValuesJSON,
+		ExpandEnv:       rf.EnvExpansion(),
+		SetValues:       loader.SetValues{Set: rf.Set, SetString: rf.SetString},
+		ComponentSets:   rf.SetComponent,
+		StrictValues:    rf.StrictValues,
+		StrictTraits:    rf.StrictTraits,
+		StrictNamespace: rf.StrictNamespace,
+		InstanceUUID:    rf.InstanceUUID,
+		RenderTimeout:   flags.RenderTimeout,
+		Name:            nameFlag,
+		Components:      components,
+		PlatformFiles:   rf.Platform, // offline: no cluster read (0006 D21)
+		K8sConfig:       k8sConfig,
+		Config:          cfg,
 	})
 	if err != nil {
 		return err
 	}
 
 	render.ShowOutput(result, render.ShowOutputOpts{Verbose: cfg.Flags.Verbose})
+	if err := showBuildSummary(result, outputFormat, tmpl != nil); err != nil {
+		return err
+	}
+
+	switch {
+	case tmpl != nil:
+		err = render.WriteManifestTemplate(result.Resources, tmpl, flags.TemplatePerResource)
+	case flags.Split && flags.SplitLayout == splitLayoutComponent:
+		err = render.WriteManifestDir(result.Resources, outputFormat, flags.OutDir, flags.Force, result.Instance.Name)
+	default:
+		err = render.WriteManifestOutput(result.Resources, outputFormat, flags.Split, flags.List, flags.OutDir, result.Instance.Name)
+	}
+	if err != nil || flags.PrintMetadata == "" {
+		return err
+	}
+	return writeBuildMetadata(result, flags.PrintMetadata)
+}
 
-	return render.WriteManifestOutput(result.Resources, outputFormat, split, outDir, result.Instance.Name)
+// writeBuildMetadata writes the --print-metadata document to stderr ("-")
+// or to the file path.
+func writeBuildMetadata(result *render.Result, path string) error {
+	meta := render.NewBuildMetadata(result)
+	if path == "-" {
+		if err := render.WriteBuildMetadata(os.Stderr, meta); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("writing build metadata: %w", err)}
+	}
+	if err := render.WriteBuildMetadata(f, meta); err != nil {
+		_ = f.Close()
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if err := f.Close(); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("writing build metadata: %w", err)}
+	}
+	return nil
+}
+
+// showBuildSummary reports the per-component resource counts on stderr: as log
+// lines, or as one JSON object when the manifests are JSON. Quiet logging
+// drops either.
+func showBuildSummary(result *render.Result, outputFormat output.Format, isTemplate bool) error {
+	summary := render.Summarize(result)
+	if isTemplate || outputFormat != output.FormatJSON {
+		render.LogSummary(result.Instance.Name, summary)
+		return nil
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("marshaling build summary: %w", err)}
+	}
+	output.Report(string(data))
+	return nil
 }
@@ -28,6 +28,15 @@ func TestNewModuleBuildCmd_Flags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("platform"))
 	assert.NotNil(t, cmd.Flags().Lookup("split"), "--split flag should be registered")
 	assert.NotNil(t, cmd.Flags().Lookup("out-dir"), "--out-dir flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("split-layout"), "--split-layout flag should be registered")
+	assert.Nil(t, cmd.Flags().Lookup("output-dir"), "--output-dir is folded into --split --split-layout component")
+	assert.NotNil(t, cmd.Flags().Lookup("force"), "--force flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("show-only"), "--show-only flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("render-timeout"), "--render-timeout flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("instance-uuid"), "--instance-uuid flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("print-metadata"), "--print-metadata flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("set"), "--set flag should be registered")
+	assert.NotNil(t, cmd.Flags().Lookup("set-string"), "--set-string flag should be registered")
 }
 
 func TestRunModuleBuild_RejectsFileArgument(t *testing.T) {
@@ -35,14 +44,14 @@ func TestRunModuleBuild_RejectsFileArgument(t *testing.T) {
 	filePath := filepath.Join(dir, "module.cue")
 	require.NoError(t, os.WriteFile(filePath, []byte("package x\n"), 0o644))
 
-	err := runModuleBuild([]string{filePath}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", "yaml", false, "")
+	err := runModuleBuild([]string{filePath}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", buildFlags{Output: "yaml"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "expects a directory")
 	assert.Contains(t, err.Error(), "opm instance build")
 }
 
 func TestRunModuleBuild_MissingPath(t *testing.T) {
-	err := runModuleBuild([]string{"/nonexistent/module/dir"}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", "yaml", false, "")
+	err := runModuleBuild([]string{"/nonexistent/module/dir"}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", buildFlags{Output: "yaml"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
@@ -62,7 +71,25 @@ func TestRunModuleBuild_DefaultsToCwd(t *testing.T) {
 	// "." is a directory — module build should attempt synthesis (and fail
 	// because there is no module package). We assert it does NOT fail with
 	// the "expects a directory" error path.
-	err = runModuleBuild(nil, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", "yaml", false, "")
+	err = runModuleBuild(nil, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", buildFlags{Output: "yaml"})
 	require.Error(t, err)
 	assert.NotContains(t, err.Error(), "expects a directory")
 }
+
+func TestRunModuleBuild_ListRequiresJSON(t *testing.T) {
+	dir := t.TempDir()
+	err := runModuleBuild([]string{dir}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", buildFlags{Output: "yaml", List: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--list requires -o json")
+}
+
+func TestRunModuleBuild_TemplateFlags(t *testing.T) {
+	dir := t.TempDir()
+	err := runModuleBuild([]string{dir}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", buildFlags{Output: "yaml", TemplatePerResource: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--template-per-resource requires -o template=<file>")
+
+	err = runModuleBuild([]string{dir}, &config.GlobalConfig{}, &cmdutil.RenderFlags{}, "", buildFlags{Output: "template=x.tmpl", Split: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --split or --list")
+}
@@ -0,0 +1,113 @@
+package modulecmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/render"
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+// NewModuleExplainCmd creates the module explain command.
+func NewModuleExplainCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var rf cmdutil.RenderFlags
+	var nameFlag, outputFlag string
+
+	c := &cobra.Command{
+		Use:   "explain <component> [path]",
+		Short: "Explain which transformers rendered a component, and with what inputs",
+		Long: `Explain how one component of a module renders. The module is compiled with
+only that component, offline, exactly as 'opm module build' would, and the
+report shows:
+
+  - the component's labels, resources, and traits: the inputs matching uses
+  - each transformer that matched, and each one that did not with the
+    required labels the component is missing
+  - declared resources or traits no transformer on the platform requires,
+    with the versions of them the platform does handle
+  - the #context and #component.spec a matched transformer receives
+  - the resources each matched transformer produced
+
+A component that matches no transformer is not an error here: the report says
+what the platform's transformers would need.
+
+Arguments:
+  component   Name of the component to explain
+  path        Path to a module package directory (default: current directory)
+
+Examples:
+  # Why did web render a Deployment?
+  opm module explain web
+
+  # Explain with the values a deployment uses, as JSON
+  opm module explain web ./my-module -f prod.cue -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runModuleExplain(args[0], args[1:], cfg, &rf, nameFlag, outputFlag)
+		},
+	}
+
+	rf.AddTo(c)
+	rf.AddSetComponentTo(c)
+	c.Flags().StringVar(&nameFlag, "name", "", "Override synthetic instance name")
+	c.Flags().StringVarP(&outputFlag, "output", "o", "text", "Output format: text or json")
+
+	return c
+}
+
+func runModuleExplain(component string, args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, nameFlag, outputFlag string) error {
+	if outputFlag != "text" && outputFlag != "json" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid output format %q (valid: text, json)", outputFlag)}
+	}
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:        cfg,
+		NamespaceFlag: rf.Namespace,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+
+	result, err := render.FromModule(context.Background(), render.ModuleOpts{
+		ModulePath:      cmdutil.ResolveModulePath(args),
+		ValuesFiles:     rf.Values,
+		ValuesYAMLFiles: rf.ValuesYAML,
+		ValuesJSONFiles: rf.ValuesJSON,
+		ExpandEnv:       rf.EnvExpansion(),
+		SetValues:       loader.SetValues{Set: rf.Set, SetString: rf.SetString},
+		ComponentSets:   rf.SetComponent,
+		Name:            nameFlag,
+		Components:      []string{component},
+		AllowUnmatched:  true,
+		PlatformFiles:   rf.Platform, // offline: no cluster read (0006 D21)
+		K8sConfig:       k8sConfig,
+		Config:          cfg,
+	})
+	if err != nil {
+		return err
+	}
+
+	explanation, err := render.Explain(result, component)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	}
+
+	var buf bytes.Buffer
+	if outputFlag == "json" {
+		err = render.WriteExplanationJSON(&buf, explanation)
+	} else {
+		err = render.WriteExplanation(&buf, explanation)
+	}
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	output.Print(buf.String())
+	return nil
+}
@@ -0,0 +1,23 @@
+package modulecmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/internal/config"
+)
+
+func TestNewModuleExplainCmd_Args(t *testing.T) {
+	cmd := NewModuleExplainCmd(&config.GlobalConfig{})
+	require.Error(t, cmd.Args(cmd, nil), "a component name is required")
+	require.NoError(t, cmd.Args(cmd, []string{"web", "./my-module"}))
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+}
+
+func TestRunModuleExplain_RejectsUnknownOutput(t *testing.T) {
+	err := runModuleExplain("web", nil, &config.GlobalConfig{}, nil, "", "yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
@@ -16,7 +16,8 @@ func NewModuleCmd(cfg *config.GlobalConfig) *cobra.Command {
 		Long: `Work with OPM modules.
 
 		Use this command group when you are starting from module source: initialize a
-		module or validate it.
+		module, validate it, vendor its dependencies for offline use, or package it
+		for a registry.
 
 		For rendering and deploying, use 'opm instance build' or 'opm instance apply'.`,
 	}
@@ -24,7 +25,12 @@ func NewModuleCmd(cfg *config.GlobalConfig) *cobra.Command {
 	c.AddCommand(NewModuleInitCmd(cfg))
 	c.AddCommand(NewModuleVetCmd(cfg))
 	c.AddCommand(NewModuleBuildCmd(cfg))
+	c.AddCommand(NewModuleTemplateCmd(cfg))
+	c.AddCommand(NewModuleExplainCmd(cfg))
+	c.AddCommand(NewModuleTreeCmd(cfg))
 	c.AddCommand(NewModuleApplyCmd(cfg))
+	c.AddCommand(NewModuleVendorCmd(cfg))
+	c.AddCommand(NewModulePackageCmd(cfg))
 
 	return c
 }
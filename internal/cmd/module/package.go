@@ -0,0 +1,158 @@
+package modulecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/render"
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+// packageFlags holds the flags of module package.
+type packageFlags struct {
+	Tag  string
+	Push bool
+	Sign bool
+	Out  string
+}
+
+// cosignBinary is the signing tool --sign runs.
+const cosignBinary = "cosign"
+
+// NewModulePackageCmd creates the module package command.
+func NewModulePackageCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var flags packageFlags
+
+	c := &cobra.Command{
+		Use:   "package [path]",
+		Short: "Package a module as an OCI artifact, optionally pushing it",
+		Long: `Package the CUE module containing path as a module OCI artifact, the way
+'cue mod publish' does: a zip of the module directory, published as a
+manifest whose layers are the zip and cue.mod/module.cue. Modules packaged
+this way load like any other with 'opm instance build'.
+
+The module is validated first, as 'opm module vet --values-only' would, and
+is not packaged when that fails. The artifact's manifest digest is printed
+on stdout; it is the same digest the registry reports after --push, so it
+can be pinned with --module-digest before the push happens.
+
+--sign signs the pushed artifact with cosign, which must be on PATH and
+configured (keyless or with COSIGN_KEY); it requires --push.
+
+Arguments:
+  path    Path to the module directory (default: current directory)
+
+Examples:
+  # Compute the digest a push of v1.2.0 would produce
+  opm module package ./my-module --tag v1.2.0
+
+  # Push to the configured registry and sign the result
+  opm module package ./my-module --tag v1.2.0 --push --sign
+
+  # Keep the module zip, e.g. to attach it to a release
+  opm module package --tag v1.2.0 --out my-module-v1.2.0.zip`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runModulePackage(c.Context(), args, cfg, flags)
+		},
+	}
+
+	c.Flags().StringVar(&flags.Tag, "tag", "", "Module version to package, a canonical semantic version matching the module's major version (e.g. v1.2.0)")
+	c.Flags().BoolVar(&flags.Push, "push", false, "Push the artifact to the configured registry (--registry, CUE_REGISTRY)")
+	c.Flags().BoolVar(&flags.Sign, "sign", false, "Sign the pushed artifact with cosign (requires --push)")
+	c.Flags().StringVar(&flags.Out, "out", "", "Also write the module zip to this file")
+
+	return c
+}
+
+func runModulePackage(ctx context.Context, args []string, cfg *config.GlobalConfig, flags packageFlags) error {
+	switch {
+	case flags.Tag == "":
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--tag is required")}
+	case flags.Sign && !flags.Push:
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--sign requires --push: only a pushed artifact can be signed")}
+	case flags.Push && cfg.Offline:
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("--push cannot be used with --offline")}
+	}
+
+	modulePath := cmdutil.ResolveModulePath(args)
+	if err := cmdutil.ValidateModuleInputPath(modulePath); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	abs, err := filepath.Abs(modulePath)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving module path: %w", err)}
+	}
+	moduleRoot := loader.ModuleRootFrom(abs)
+	if moduleRoot == "" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("%s is not inside a CUE module (no cue.mod/module.cue)", modulePath)}
+	}
+
+	report, err := vetModuleValues(modulePath, cfg.Offline, &cmdutil.RenderFlags{}, nil)
+	if err != nil {
+		return err
+	}
+	if len(report.failures) > 0 {
+		return reportVet(report, "text")
+	}
+
+	pkg, err := loader.PackageModule(ctx, moduleRoot, flags.Tag)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("packaging module: %w", err)}
+	}
+	output.Info("packaged module", "module", pkg.Version.String(), "size", len(pkg.Zip))
+
+	if flags.Out != "" {
+		if err := os.WriteFile(flags.Out, pkg.Zip, 0o644); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("writing module zip: %w", err)}
+		}
+		output.Info("wrote module zip", "file", flags.Out)
+	}
+
+	if flags.Push {
+		ref, err := loader.PushModule(ctx, cfg.Registry, pkg)
+		if err != nil {
+			if regErr := render.RegistryLoadError(err, cfg.Registry); regErr != nil {
+				return regErr
+			}
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		output.Info("pushed module", "ref", ref)
+
+		if flags.Sign {
+			if err := signArtifact(ctx, ref); err != nil {
+				return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+			}
+			output.Info("signed module", "ref", ref)
+		}
+	}
+
+	output.Println(pkg.Digest)
+	return nil
+}
+
+// signArtifact signs the artifact at ref, host/repository@digest, with
+// cosign. Signing by digest means the signature covers exactly the pushed
+// manifest, not whatever the tag points at later.
+func signArtifact(ctx context.Context, ref string) error {
+	bin, err := exec.LookPath(cosignBinary)
+	if err != nil {
+		return fmt.Errorf("--sign needs %s on PATH: %w", cosignBinary, err)
+	}
+	cmd := exec.CommandContext(ctx, bin, "sign", "--yes", ref)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("signing %s: %w", ref, err)
+	}
+	return nil
+}
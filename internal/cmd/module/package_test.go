@@ -0,0 +1,35 @@
+package modulecmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+)
+
+func TestModulePackage_FlagChecks(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.GlobalConfig
+		args []string
+		want string
+	}{
+		{name: "tag required", args: []string{"."}, want: "--tag is required"},
+		{name: "sign requires push", args: []string{".", "--tag", "v1.0.0", "--sign"}, want: "--sign requires --push"},
+		{name: "push is online", cfg: config.GlobalConfig{Offline: true}, args: []string{".", "--tag", "v1.0.0", "--push"}, want: "--offline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewModulePackageCmd(&tt.cfg)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			var exitErr *opmexit.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, opmexit.ExitGeneralError, exitErr.Code)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
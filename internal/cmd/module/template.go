@@ -0,0 +1,59 @@
+package modulecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+)
+
+// NewModuleTemplateCmd creates the module template command: module build
+// without any of its cluster-facing flags, for users who expect a
+// "helm template"-style command.
+func NewModuleTemplateCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var rf cmdutil.RenderFlags
+	var nameFlag string
+	var flags buildFlags
+
+	c := &cobra.Command{
+		Use:   "template [path]",
+		Short: "Render a module to manifests; never contacts a cluster",
+		Long: `Render an OPM module package to Kubernetes manifests. Render only: template
+never builds a Kubernetes client and never reads a kubeconfig, so it runs in a
+sandboxed CI job with no cluster access and no kubeconfig at all.
+
+It shares the render pipeline with 'opm module build': the manifests are the
+ones 'opm module apply' would send for the same values, namespace, and
+platform. Apply reads its platform from the cluster's Platform resource by
+default; template cannot, so pass the same file with --platform when the
+cluster's platform changes the output.
+
+Arguments:
+  path    Path to a module package directory (default: current directory)
+
+Examples:
+  # Render the current module using debugValues
+  opm module template
+
+  # Render for a namespace with custom values
+  opm module template ./my-module -n staging -f values.cue --set replicas=3
+
+  # Render as a JSON List for kubectl
+  opm module template ./my-module -o json --list`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runModuleBuild(args, cfg, &rf, nameFlag, flags)
+		},
+	}
+
+	rf.AddTo(c)
+	rf.AddSetComponentTo(c)
+	c.Flags().StringVar(&nameFlag, "name", "", "Override synthetic instance name")
+	c.Flags().StringVarP(&flags.Output, "output", "o", "yaml", "Output format: yaml or json")
+	c.Flags().BoolVar(&flags.List, "list", false, "With -o json, wrap resources in a v1 List instead of a bare array")
+	c.Flags().BoolVar(&flags.Split, "split", false, "Write separate files per resource")
+	c.Flags().StringVar(&flags.OutDir, "out-dir", "./manifests", "Directory for split output")
+	flags.SplitLayout = splitLayoutFlat
+
+	return c
+}
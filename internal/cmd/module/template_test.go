@@ -0,0 +1,20 @@
+package modulecmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-platform-model/cli/internal/config"
+)
+
+func TestNewModuleTemplateCmd_Flags(t *testing.T) {
+	cmd := NewModuleTemplateCmd(&config.GlobalConfig{})
+	assert.Equal(t, "template [path]", cmd.Use)
+	for _, name := range []string{"namespace", "values", "set", "set-string", "output", "list", "platform"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "--%s should be registered", name)
+	}
+	for _, name := range []string{"kubeconfig", "context", "as"} {
+		assert.Nil(t, cmd.Flags().Lookup(name), "--%s would imply cluster access", name)
+	}
+}
@@ -0,0 +1,97 @@
+package modulecmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/render"
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+// NewModuleTreeCmd creates the module tree command.
+func NewModuleTreeCmd(cfg *config.GlobalConfig) *cobra.Command {
+	var rf cmdutil.RenderFlags
+	var nameFlag, outputFlag string
+
+	c := &cobra.Command{
+		Use:   "tree [path]",
+		Short: "Show the resources each component of a module renders",
+		Long: `Render a module and show what it produces as a tree: the module, its
+components, and the Kubernetes resources each component rendered. A component
+that matches several transformers lists the resources of all of them, each
+labeled with its transformer; a component that rendered nothing is shown too.
+
+Like 'opm module template', tree never contacts a cluster. For the resources
+an instance has deployed, with their health, use 'opm instance tree'.
+
+Arguments:
+  path    Path to a module package directory (default: current directory)
+
+Examples:
+  # Map the current module
+  opm module tree
+
+  # Map it with production values, as JSON
+  opm module tree ./my-module -f prod.cue -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runModuleTree(args, cfg, &rf, nameFlag, outputFlag)
+		},
+	}
+
+	rf.AddTo(c)
+	rf.AddSetComponentTo(c)
+	c.Flags().StringVar(&nameFlag, "name", "", "Override synthetic instance name")
+	c.Flags().StringVarP(&outputFlag, "output", "o", "text", "Output format: text or json")
+
+	return c
+}
+
+func runModuleTree(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, nameFlag, outputFlag string) error {
+	if outputFlag != "text" && outputFlag != "json" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid output format %q (valid: text, json)", outputFlag)}
+	}
+
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:        cfg,
+		NamespaceFlag: rf.Namespace,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
+
+	result, err := render.FromModule(context.Background(), render.ModuleOpts{
+		ModulePath:      cmdutil.ResolveModulePath(args),
+		ValuesFiles:     rf.Values,
+		ValuesYAMLFiles: rf.ValuesYAML,
+		ValuesJSONFiles: rf.ValuesJSON,
+		ExpandEnv:       rf.EnvExpansion(),
+		SetValues:       loader.SetValues{Set: rf.Set, SetString: rf.SetString},
+		ComponentSets:   rf.SetComponent,
+		Name:            nameFlag,
+		PlatformFiles:   rf.Platform, // offline: no cluster read (0006 D21)
+		K8sConfig:       k8sConfig,
+		Config:          cfg,
+	})
+	if err != nil {
+		return err
+	}
+
+	tree := render.BuildModuleTree(result)
+	if outputFlag == "json" {
+		data, err := render.FormatModuleTreeJSON(tree)
+		if err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		output.Println(data)
+		return nil
+	}
+	output.Println(render.FormatModuleTree(tree))
+	return nil
+}
@@ -0,0 +1,25 @@
+package modulecmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/internal/config"
+)
+
+func TestNewModuleTreeCmd_Flags(t *testing.T) {
+	cmd := NewModuleTreeCmd(&config.GlobalConfig{})
+	assert.Equal(t, "tree [path]", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+	for _, name := range []string{"kubeconfig", "context"} {
+		assert.Nil(t, cmd.Flags().Lookup(name), "--%s would imply cluster access", name)
+	}
+}
+
+func TestRunModuleTree_RejectsUnknownOutput(t *testing.T) {
+	err := runModuleTree(nil, &config.GlobalConfig{}, nil, "", "yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
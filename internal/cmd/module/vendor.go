@@ -0,0 +1,85 @@
+package modulecmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+
+	"cuelang.org/go/mod/module"
+	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/render"
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+// NewModuleVendorCmd creates the module vendor command.
+func NewModuleVendorCmd(cfg *config.GlobalConfig) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "vendor [path]",
+		Short: "Fetch module dependencies into the local CUE cache",
+		Long: `Fetch every dependency pinned in a module's cue.mod/module.cue into the
+local CUE module cache ($CUE_CACHE_DIR, default <user cache dir>/cue), so later
+commands can run with --offline and never contact a registry.
+
+Dependencies that are already cached are not fetched again. Dependencies
+replaced with a local directory in cue.mod/local-module.cue are skipped.
+
+Arguments:
+  path    Path to module directory (default: current directory)
+
+Examples:
+  # Populate the cache once, with registry access
+  opm module vendor ./my-module
+
+  # Then build without network access
+  opm module build ./my-module --offline`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runModuleVendor(c, args, cfg)
+		},
+	}
+
+	return c
+}
+
+func runModuleVendor(c *cobra.Command, args []string, cfg *config.GlobalConfig) error {
+	if cfg.Offline {
+		return &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("--offline cannot be used with 'opm module vendor', which fetches from the registry"),
+		}
+	}
+
+	modulePath := cmdutil.ResolveModulePath(args)
+	if err := cmdutil.ValidateModuleInputPath(modulePath); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	abs, err := filepath.Abs(modulePath)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving module path: %w", err)}
+	}
+
+	var fetched, cached int
+	err = loader.FetchDependencies(c.Context(), loader.ModuleRootFrom(abs), cfg.Registry, func(mv module.Version, wasCached bool) {
+		if wasCached {
+			cached++
+			output.Println(output.FormatVetCheck(mv.String(), "cached"))
+			return
+		}
+		fetched++
+		output.Println(output.FormatVetCheck(mv.String(), "fetched"))
+	})
+	if err != nil {
+		if regErr := render.RegistryLoadError(err, cfg.Registry); regErr != nil {
+			return regErr
+		}
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+
+	output.Println(fmt.Sprintf("%d fetched, %d already cached", fetched, cached))
+	return nil
+}
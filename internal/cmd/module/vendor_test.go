@@ -0,0 +1,30 @@
+package modulecmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+)
+
+func TestNewModuleVendorCmd(t *testing.T) {
+	cmd := NewModuleVendorCmd(&config.GlobalConfig{})
+
+	assert.Equal(t, "vendor [path]", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.Contains(t, cmd.Long, "--offline")
+}
+
+func TestModuleVendor_RejectsOffline(t *testing.T) {
+	cmd := NewModuleVendorCmd(&config.GlobalConfig{Offline: true, Registry: "none"})
+	cmd.SetArgs([]string{t.TempDir()})
+
+	err := cmd.Execute()
+	var exitErr *opmexit.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, opmexit.ExitGeneralError, exitErr.Code)
+	assert.Contains(t, err.Error(), "--offline cannot be used")
+}
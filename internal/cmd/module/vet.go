@@ -1,19 +1,27 @@
 package modulecmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/internal/workflow/render"
 	"github.com/open-platform-model/cli/pkg/loader"
 	"github.com/open-platform-model/cli/pkg/validate"
 )
@@ -21,15 +29,31 @@ import (
 // NewModuleVetCmd creates the module vet command.
 func NewModuleVetCmd(cfg *config.GlobalConfig) *cobra.Command {
 	var rf cmdutil.RenderFlags
+	var flags vetFlags
 
 	c := &cobra.Command{
 		Use:   "vet [path]",
 		Short: "Validate module without generating manifests",
-		Long: `Validate an OPM module's config inputs without generating manifests.
+		Long: `Validate an OPM module without writing manifests.
 
-	This command validates the module's #config contract using either the module's
-	debugValues (default) or explicit values files passed with -f/--values.
-	It does not render resources, resolve providers, or validate instance files.
+	Validation runs in two stages and reports every problem it finds, with its
+	path and source position, before failing:
+
+	  1. Values: the module's debugValues (default) or the values files passed
+	     with -f/--values must be concrete and satisfy the module's #config.
+	  2. Render: the module is compiled against the platform, offline, exactly
+	     as 'opm module build' would. Every CUE error, every component no
+	     transformer matches, every declared primitive no transformer requires,
+	     and every failing transform is reported; traits no matched transformer
+	     consumes are reported as warnings.
+
+	The render stage runs only once the values are valid, and needs a platform:
+	--platform files or the local default platform. Pass --values-only to stop
+	after the first stage.
+
+	With -o json the diagnostics are written to stdout as a JSON array, each with
+	severity, message, path, file, line, and column, for editors and pre-commit
+	hooks.
 
 	Arguments:
 	  path    Path to module directory (default: current directory)
@@ -42,41 +66,150 @@ func NewModuleVetCmd(cfg *config.GlobalConfig) *cobra.Command {
 	  opm module vet ./my-module -f prod-values.cue
 
 	  # Validate by merging multiple values files
-	  opm module vet ./my-module -f base.cue -f prod.cue`,
+	  opm module vet ./my-module -f base.cue -f prod.cue
+
+	  # Validate with individual values overridden
+	  opm module vet ./my-module -f prod-values.cue --set replicas=3 --set-string image.tag=1.10
+
+	  # Check values only, without rendering against the platform
+	  opm module vet ./my-module --values-only
+
+	  # Emit machine-readable diagnostics for a pre-commit hook
+	  opm module vet ./my-module -o json`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			return runVet(args, &rf)
+			return runVet(args, cfg, &rf, flags)
 		},
 	}
 
 	rf.AddTo(c)
+	c.Flags().StringVarP(&flags.Output, "output", "o", "text", "Output format: text or json")
+	c.Flags().BoolVar(&flags.ValuesOnly, "values-only", false, "Validate values against #config only; do not render against the platform")
 
 	return c
 }
 
-func runVet(args []string, rf *cmdutil.RenderFlags) error {
+// vetFlags carries the vet command's own flags.
+type vetFlags struct {
+	Output     string
+	ValuesOnly bool
+}
+
+func runVet(args []string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, flags vetFlags) error {
+	if flags.Output != "text" && flags.Output != "json" {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid output format %q (valid: text, json)", flags.Output)}
+	}
 	modulePath := cmdutil.ResolveModulePath(args)
-	return runVetModuleOnly(modulePath, rf)
+
+	var stdinValues *loader.ValuesDocument
+	if slices.Contains(rf.Values, loader.StdinValuesPath) {
+		if err := loader.CheckValuesPaths(rf.Values); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("reading values from stdin: %w", err)}
+		}
+		// Expanded once here: both vet stages read these bytes.
+		if data, err = rf.EnvExpansion().Expand("<stdin>", data); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		stdinValues = &loader.ValuesDocument{Name: "<stdin>", Data: data}
+	}
+
+	report, err := vetModuleValues(modulePath, cfg.Offline, rf, stdinValues)
+	if err != nil {
+		return err
+	}
+	if len(report.failures) == 0 && !flags.ValuesOnly {
+		if err := vetModuleRender(modulePath, cfg, rf, stdinValues, report); err != nil {
+			return err
+		}
+	}
+	return reportVet(report, flags.Output)
+}
+
+// vetReport accumulates the diagnostics of every vet stage, and a one-line
+// summary per failed stage for the command's error.
+type vetReport struct {
+	log      *log.Logger
+	diags    []render.Diagnostic
+	failures []string
+}
+
+func (r *vetReport) fail(summary string, diags []render.Diagnostic) {
+	r.failures = append(r.failures, summary)
+	r.diags = append(r.diags, diags...)
+}
+
+func reportVet(report *vetReport, format string) error {
+	if format == "json" {
+		var buf bytes.Buffer
+		if err := render.WriteDiagnosticsJSON(&buf, report.diags); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		output.Print(buf.String())
+	} else if len(report.diags) > 0 {
+		var buf strings.Builder
+		if err := render.WriteDiagnostics(&buf, report.diags); err != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+		errorCount := 0
+		for _, d := range report.diags {
+			if d.Severity == render.SeverityError {
+				errorCount++
+			}
+		}
+		msg := fmt.Sprintf("module vet: %d error(s), %d warning(s)", errorCount, len(report.diags)-errorCount)
+		if errorCount > 0 {
+			output.Error(msg)
+		} else {
+			output.Warn(msg)
+		}
+		output.Details(strings.TrimRight(buf.String(), "\n"))
+	}
+
+	if len(report.failures) > 0 {
+		return &opmexit.ExitError{
+			Code:    opmexit.ExitValidationError,
+			Err:     errors.New(strings.Join(report.failures, "; ")),
+			Printed: format != "json",
+		}
+	}
+	return nil
 }
 
-// runVetModuleOnly validates a module directory without an instance.cue.
-// It loads the module CUE package, validates the schema, and checks that the
-// values (from -f flag or debugValues field) satisfy #config.
+// vetModuleValues validates a module directory's values without an
+// instance.cue: it loads the module CUE package, then checks that the values
+// (from -f flag or debugValues field) are concrete and satisfy #config. Every
+// problem is collected into the report; only failures that leave nothing to
+// validate (an unreadable module, no values at all) are returned as errors.
 // No instance wrapper, engine render, or cluster connection is required.
-func runVetModuleOnly(modulePath string, rf *cmdutil.RenderFlags) error {
+func vetModuleValues(modulePath string, offline bool, rf *cmdutil.RenderFlags, stdinValues *loader.ValuesDocument) (*vetReport, error) {
 	cueCtx := cuecontext.New()
+	sets := loader.SetValues{Set: rf.Set, SetString: rf.SetString}
 
 	if err := cmdutil.ValidateModuleInputPath(modulePath); err != nil {
-		return &opmexit.ExitError{
+		return nil, &opmexit.ExitError{
 			Code: opmexit.ExitGeneralError,
 			Err:  err,
 		}
 	}
 
+	if err := render.CheckCoreCompatibility(modulePath); err != nil {
+		return nil, err
+	}
+	if err := render.CheckOfflineCache(modulePath, offline); err != nil {
+		return nil, err
+	}
+
 	// Load and structurally validate the module CUE package.
 	modVal, err := loader.LoadModulePackage(cueCtx, modulePath)
 	if err != nil {
-		return &opmexit.ExitError{
+		if regErr := render.RegistryLoadError(err, os.Getenv("CUE_REGISTRY")); regErr != nil {
+			return nil, regErr
+		}
+		return nil, &opmexit.ExitError{
 			Code: opmexit.ExitGeneralError,
 			Err:  fmt.Errorf("loading module: %w", err),
 		}
@@ -89,63 +222,166 @@ func runVetModuleOnly(modulePath string, rf *cmdutil.RenderFlags) error {
 			modName = name
 		}
 	}
-	moduleLog := output.InstanceLogger(modName)
+	report := &vetReport{log: output.InstanceLogger(modName)}
 
 	// Resolve the values to validate against #config.
 	valuesVals := make([]cue.Value, 0, len(rf.Values))
 	var valuesDetail string
 
-	if len(rf.Values) > 0 {
+	if len(rf.Values) > 0 || len(rf.ValuesYAML) > 0 || len(rf.ValuesJSON) > 0 {
+		if err := loader.CheckValuesPaths(rf.Values); err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
 		basenames := make([]string, 0, len(rf.Values))
 		for _, valuesFile := range rf.Values {
-			valuesVal, loadErr := loader.LoadValuesFile(cueCtx, valuesFile)
+			var valuesVal cue.Value
+			var loadErr error
+			if valuesFile == loader.StdinValuesPath && stdinValues != nil {
+				valuesVal, loadErr = loader.LoadValuesDocument(cueCtx, *stdinValues)
+				basenames = append(basenames, stdinValues.Name)
+			} else {
+				valuesVal, loadErr = loader.LoadValuesFile(cueCtx, valuesFile, rf.EnvExpansion())
+				basenames = append(basenames, filepath.Base(valuesFile))
+			}
 			if loadErr != nil {
-				return &opmexit.ExitError{
-					Code: opmexit.ExitGeneralError,
-					Err:  fmt.Errorf("loading values file %q: %w", valuesFile, loadErr),
-				}
+				report.fail(fmt.Sprintf("loading values file %q failed", valuesFile), render.ErrorDiagnostics(loadErr))
+				continue
 			}
 			valuesVals = append(valuesVals, valuesVal)
-			basenames = append(basenames, filepath.Base(valuesFile))
+		}
+		for _, set := range []struct {
+			flag  string
+			paths []string
+			load  func(*cue.Context, []string, loader.EnvExpansion) (cue.Value, error)
+		}{
+			{"--values-yaml", rf.ValuesYAML, loader.LoadValuesYAMLFiles},
+			{"--values-json", rf.ValuesJSON, loader.LoadValuesJSONFiles},
+		} {
+			if len(set.paths) == 0 {
+				continue
+			}
+			mergedVal, loadErr := set.load(cueCtx, set.paths, rf.EnvExpansion())
+			if loadErr != nil {
+				report.fail(fmt.Sprintf("loading %s files failed", set.flag), render.ErrorDiagnostics(loadErr))
+			} else {
+				valuesVals = append(valuesVals, mergedVal)
+			}
+			for _, path := range set.paths {
+				basenames = append(basenames, filepath.Base(path))
+			}
 		}
 		valuesDetail = strings.Join(basenames, ", ")
 	} else {
 		debugVal := modVal.LookupPath(cue.ParsePath("debugValues"))
-		if !debugVal.Exists() {
-			return &opmexit.ExitError{
+		switch {
+		case debugVal.Exists():
+			valuesVals = append(valuesVals, debugVal)
+			valuesDetail = "debugValues"
+		case sets.IsEmpty():
+			return nil, &opmexit.ExitError{
 				Code: opmexit.ExitValidationError,
-				Err:  fmt.Errorf("module does not define debugValues - add debugValues or provide values with -f"),
+				Err:  fmt.Errorf("module does not define debugValues - add debugValues or provide values with -f or --set"),
 			}
 		}
-		valuesVals = append(valuesVals, debugVal)
-		valuesDetail = "debugValues"
+	}
+	if len(report.failures) > 0 {
+		return report, nil
 	}
 
 	for _, valuesVal := range valuesVals {
 		if err := valuesVal.Validate(cue.Concrete(true)); err != nil {
-			cmdutil.PrintValidationError(valuesDetail+" not concrete", err)
-			return &opmexit.ExitError{
-				Code:    opmexit.ExitValidationError,
-				Err:     fmt.Errorf("%s values are not fully concrete", valuesDetail),
-				Printed: true,
+			report.fail(fmt.Sprintf("%s values are not fully concrete", valuesDetail), render.ErrorDiagnostics(err))
+		}
+	}
+	if len(report.failures) > 0 {
+		return report, nil
+	}
+
+	// --set overrides replace values rather than unify with them, so they
+	// are applied to the merged values, which are then validated as one.
+	if !sets.IsEmpty() {
+		var base cue.Value
+		for i, v := range valuesVals {
+			if i == 0 {
+				base = v
+				continue
 			}
+			base = base.Unify(v)
+		}
+		overridden, setErr := loader.ApplySetValues(cueCtx, base, sets)
+		if setErr != nil {
+			report.fail("--set overrides do not apply", render.ErrorDiagnostics(setErr))
+			return report, nil
+		}
+		valuesVals = []cue.Value{overridden}
+		if valuesDetail == "" {
+			valuesDetail = "--set"
+		} else {
+			valuesDetail += " + --set"
 		}
 	}
 
 	configVal := modVal.LookupPath(cue.ParsePath("#config"))
 	if configVal.Exists() {
 		if _, cfgErr := validate.Config(configVal, valuesVals, "module", modName); cfgErr != nil {
-			cmdutil.PrintValidationError("values do not satisfy #config", cfgErr)
-			return &opmexit.ExitError{
-				Code:    opmexit.ExitValidationError,
-				Err:     cfgErr,
-				Printed: true,
-			}
+			report.fail("values do not satisfy #config", render.ErrorDiagnostics(cfgErr))
+			return report, nil
 		}
 	}
 
-	moduleLog.Info(output.FormatVetCheck("Values satisfy #config", valuesDetail))
-	moduleLog.Info(output.FormatCheckmark("Module config valid"))
+	report.log.Info(output.FormatVetCheck("Values satisfy #config", valuesDetail))
+	report.log.Info(output.FormatCheckmark("Module config valid"))
+
+	return report, nil
+}
+
+// vetModuleRender compiles the module against the platform, offline, and adds
+// every render error and unhandled primitive to the report. Errors that stop
+// vet from rendering at all — no platform, an unreachable registry — are
+// returned instead.
+func vetModuleRender(modulePath string, cfg *config.GlobalConfig, rf *cmdutil.RenderFlags, stdinValues *loader.ValuesDocument, report *vetReport) error {
+	k8sConfig, err := config.ResolveKubernetes(config.ResolveKubernetesOptions{
+		Config:        cfg,
+		NamespaceFlag: rf.Namespace,
+	})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("resolving kubernetes config: %w", err)}
+	}
 
+	opts := render.ModuleOpts{
+		ModulePath:      modulePath,
+		ValuesFiles:     rf.Values,
+		ValuesYAMLFiles: rf.ValuesYAML,
+		ValuesJSONFiles: rf.ValuesJSON,
+		ExpandEnv:       rf.EnvExpansion(),
+		SetValues:       loader.SetValues{Set: rf.Set, SetString: rf.SetString},
+		DeferErrors:     true,
+		PlatformFiles:   rf.Platform, // offline: no cluster read (0006 D21)
+		K8sConfig:       k8sConfig,
+		Config:          cfg,
+	}
+	// Stdin was drained by the values stage; the render reads the same bytes.
+	if stdinValues != nil {
+		opts.ValuesFiles = slices.DeleteFunc(slices.Clone(rf.Values), func(p string) bool { return p == loader.StdinValuesPath })
+		opts.ValuesDocuments = []loader.ValuesDocument{*stdinValues}
+	}
+
+	result, err := render.FromModule(context.Background(), opts)
+	if err != nil {
+		var exitErr *opmexit.ExitError
+		if errors.As(err, &exitErr) && exitErr.Code == opmexit.ExitValidationError {
+			report.fail("render failed", render.ErrorDiagnostics(exitErr.Err))
+			return nil
+		}
+		return err
+	}
+
+	diags := render.MatchDiagnostics(result)
+	if render.HasErrors(diags) {
+		report.fail("components declare primitives the platform does not handle", diags)
+		return nil
+	}
+	report.diags = append(report.diags, diags...)
+	report.log.Info(output.FormatVetCheck("Components render on the platform", fmt.Sprintf("%d resources", result.ResourceCount())))
 	return nil
 }
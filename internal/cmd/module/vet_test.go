@@ -22,6 +22,27 @@ func TestNewModuleVetCmd(t *testing.T) {
 	// Args validation is set to MaximumNArgs(1) but not directly testable
 }
 
+func TestNewModuleVetCmd_Flags(t *testing.T) {
+	cmd := NewModuleVetCmd(&config.GlobalConfig{})
+
+	outputFlag := cmd.Flags().Lookup("output")
+	require.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "text", outputFlag.DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("values-only"))
+}
+
+func TestModVet_RejectsUnknownOutputFormat(t *testing.T) {
+	cmd := NewModuleVetCmd(&config.GlobalConfig{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{t.TempDir(), "-o", "yaml"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid output format "yaml"`)
+}
+
 func TestNewModuleVetCmd_NoLocalVerboseFlag(t *testing.T) {
 	cmd := NewModuleVetCmd(&config.GlobalConfig{})
 
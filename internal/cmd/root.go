@@ -12,6 +12,7 @@ import (
 	cmdoperator "github.com/open-platform-model/cli/internal/cmd/operator"
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 )
 
@@ -24,7 +25,14 @@ func NewRootCmd() *cobra.Command {
 		configFlag     string
 		registryFlag   string
 		verboseFlag    bool
+		quietFlag      bool
 		timestampsFlag bool
+		eventsFlag     string
+		eventsFileFlag string
+		errorFmtFlag   string
+		colorFlag      string
+		registryCreds  config.RegistryCredentials
+		offlineFlag    bool
 	)
 
 	rootCmd := &cobra.Command{
@@ -34,19 +42,50 @@ func NewRootCmd() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.SetErrorFormat(errorFmtFlag); err != nil {
+				return err
+			}
+			if err := output.SetupEvents(eventsFlag, eventsFileFlag); err != nil {
+				return err
+			}
+			if err := output.SetColorMode(colorFlag); err != nil {
+				return err
+			}
+			quiet := quietFlag || (!verboseFlag && machineOutput(cmd))
 			if cmd.Annotations[cmdutil.SkipConfigLoadAnnotation] == "true" {
-				output.SetupLogging(output.LogConfig{Verbose: verboseFlag})
+				output.SetupLogging(output.LogConfig{Verbose: verboseFlag, Quiet: quiet})
 				return nil
 			}
-			return initializeConfig(cmd, &cfg, configFlag, registryFlag, verboseFlag, timestampsFlag)
+			return initializeConfig(cmd, &cfg, configFlag, registryFlag, registryCreds, offlineFlag, verboseFlag, quiet, timestampsFlag)
 		},
 	}
 
 	// Add global flags
 	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Path to config file (env: OPM_CONFIG)")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false,
+		"Log errors only; implied by -o json unless --verbose is given")
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
 	rootCmd.PersistentFlags().StringVar(&registryFlag, "registry", "", "CUE registry URL (env: OPM_REGISTRY)")
+	rootCmd.PersistentFlags().StringVar(&registryCreds.Host, "registry-host", "",
+		"Registry host the --registry-* credentials are sent to (default: the opmodel.dev registry host) (env: OPM_REGISTRY_HOST)")
+	rootCmd.PersistentFlags().StringVar(&registryCreds.Username, "registry-username", "",
+		"Username for the CUE registry (env: OPM_REGISTRY_USERNAME)")
+	rootCmd.PersistentFlags().StringVar(&registryCreds.Password, "registry-password", "",
+		"Password for the CUE registry (env: OPM_REGISTRY_PASSWORD)")
+	rootCmd.PersistentFlags().StringVar(&registryCreds.Token, "registry-token", "",
+		"Bearer token for the CUE registry (env: OPM_REGISTRY_TOKEN)")
+	rootCmd.PersistentFlags().BoolVar(&offlineFlag, "offline", false,
+		"Resolve modules from the local CUE module cache only; never contact a registry (env: OPM_OFFLINE)")
 	rootCmd.PersistentFlags().BoolVar(&timestampsFlag, "timestamps", true, "Show timestamps in log output")
+	rootCmd.PersistentFlags().StringVar(&eventsFlag, "output-events", "",
+		"Emit a machine-readable event stream (jsonl) to stderr, or to --output-events-file")
+	rootCmd.PersistentFlags().StringVar(&eventsFileFlag, "output-events-file", "",
+		"Write the --output-events stream to this file instead of stderr")
+	rootCmd.PersistentFlags().StringVar(&errorFmtFlag, "error-format", output.ErrorFormatPretty,
+		"Validation error format: pretty, or json (an array of {path, message, file, line, column} on stderr)")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", output.ColorAuto,
+		"Colorize output: auto (only on a terminal, and not when NO_COLOR is set), always, or never")
 
 	// Add subcommands — sub-packages receive *config.GlobalConfig for dependency injection.
 	rootCmd.AddCommand(NewVersionCmd(&cfg))
@@ -59,21 +98,25 @@ func NewRootCmd() *cobra.Command {
 }
 
 // initializeConfig sets up logging and loads configuration into cfg.
-func initializeConfig(cmd *cobra.Command, cfg *config.GlobalConfig, configFlag, registryFlag string, verboseFlag, timestampsFlag bool) error {
+func initializeConfig(cmd *cobra.Command, cfg *config.GlobalConfig, configFlag, registryFlag string, registryCreds config.RegistryCredentials, offlineFlag, verboseFlag, quietFlag, timestampsFlag bool) error {
 	// Set raw flag values on cfg before loading
 	cfg.Flags = config.GlobalFlags{
 		Config:     configFlag,
 		Registry:   registryFlag,
 		Verbose:    verboseFlag,
+		Quiet:      quietFlag,
 		Timestamps: timestampsFlag,
+		Offline:    offlineFlag,
 	}
 
 	// Load configuration — sets cfg.ConfigPath, cfg.Registry, cfg.Kubernetes,
 	// cfg.Log, cfg.CueContext based on flag > env > config precedence (single
 	// pass; no providers — enhancement 0006 D39).
 	err := config.Load(cfg, config.LoaderOptions{
-		RegistryFlag: registryFlag,
-		ConfigFlag:   configFlag,
+		RegistryFlag:        registryFlag,
+		ConfigFlag:          configFlag,
+		RegistryCredentials: registryCreds,
+		Offline:             offlineFlag,
 	})
 	if err != nil {
 		// Config file exists but is invalid - fail immediately
@@ -84,6 +127,7 @@ func initializeConfig(cmd *cobra.Command, cfg *config.GlobalConfig, configFlag,
 	// Build LogConfig with precedence: flag > config > default(true)
 	logCfg := output.LogConfig{
 		Verbose: verboseFlag,
+		Quiet:   quietFlag,
 	}
 
 	// Resolve timestamps: flag (if explicitly set) > config > default (nil = true)
@@ -95,6 +139,7 @@ func initializeConfig(cmd *cobra.Command, cfg *config.GlobalConfig, configFlag,
 	// else: nil means SetupLogging defaults to true
 
 	output.SetupLogging(logCfg)
+	kubernetes.SetReadyConditionTypes(cfg.Kubernetes.ReadyConditions)
 
 	// Log base config resolution at DEBUG level
 	if verboseFlag {
@@ -106,3 +151,10 @@ func initializeConfig(cmd *cobra.Command, cfg *config.GlobalConfig, configFlag,
 
 	return nil
 }
+
+// machineOutput reports whether cmd writes a machine-readable format (-o json)
+// to stdout, where human progress chatter only gets in the way of a parser.
+func machineOutput(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup("output")
+	return f != nil && f.Value.String() == "json"
+}
@@ -96,3 +96,26 @@ language: version: "v0.15.0"
 	require.Error(t, err)
 	assert.NotContains(t, err.Error(), "configuration error:")
 }
+
+func TestRootCmd_QuietAndVerboseAreExclusive(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"version", "-q", "-v"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "none of the others can be")
+}
+
+func TestMachineOutput(t *testing.T) {
+	cmd := NewRootCmd()
+	vet, _, err := cmd.Find([]string{"module", "vet"})
+	require.NoError(t, err)
+
+	assert.False(t, machineOutput(vet), "text is human output")
+	require.NoError(t, vet.Flags().Set("output", "json"))
+	assert.True(t, machineOutput(vet))
+
+	version, _, err := cmd.Find([]string{"version"})
+	require.NoError(t, err)
+	assert.False(t, machineOutput(version), "no -o flag")
+}
@@ -0,0 +1,31 @@
+package cmdutil
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// Confirm prints prompt on stderr and reads a yes/no answer from stdin;
+// only "y" or "yes" confirm. When stdin is not a terminal it declines without
+// prompting, so a piped or CI run never proceeds on an answer nobody gave.
+func Confirm(prompt string) bool {
+	if !stdinIsTerminal() {
+		output.Warn("stdin is not a terminal; declining (pass --yes to proceed non-interactively)")
+		return false
+	}
+	output.Prompt(prompt)
+	scanner := bufio.NewScanner(os.Stdin)
+	if scanner.Scan() {
+		answer := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		return answer == "y" || answer == "yes"
+	}
+	return false
+}
+
+func stdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
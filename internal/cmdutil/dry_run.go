@@ -0,0 +1,41 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+)
+
+// DryRunFlags holds the apply commands' dry-run flags (--dry-run,
+// --server-diff).
+type DryRunFlags struct {
+	Mode       string
+	ServerDiff bool
+}
+
+// AddTo registers the dry-run flags on the given cobra command. A bare
+// --dry-run is a server dry run, as it was when the flag was a boolean.
+func (f *DryRunFlags) AddTo(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.Mode, "dry-run", kubernetes.DryRunNone,
+		"Preview without changing anything: server (validated by the API server and its admission webhooks) or client (compared with the live objects, nothing sent)")
+	cmd.Flags().Lookup("dry-run").NoOptDefVal = kubernetes.DryRunServer
+	cmd.Flags().BoolVar(&f.ServerDiff, "server-diff", false,
+		"With --dry-run=server, show how the server's view of each resource differs from the render (defaulted and mutated fields)")
+}
+
+// Resolve validates the flags and reports whether the run is a dry run and,
+// if so, whether it is client-side.
+func (f *DryRunFlags) Resolve() (dryRun, client bool, err error) {
+	mode, err := kubernetes.ParseDryRun(f.Mode)
+	if err != nil {
+		return false, false, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if f.ServerDiff && mode != kubernetes.DryRunServer {
+		return false, false, &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("--server-diff requires --dry-run=server")}
+	}
+	return mode != kubernetes.DryRunNone, mode == kubernetes.DryRunClient, nil
+}
@@ -0,0 +1,23 @@
+package cmdutil
+
+import (
+	"errors"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+)
+
+// MarkPrinted returns err as an *ExitError marked printed, for a command that
+// has just logged it: main then exits with its code without reporting it a
+// second time. An err that is not an *ExitError exits ExitGeneralError. A nil
+// err stays nil.
+func MarkPrinted(err error) error {
+	if err == nil {
+		return nil
+	}
+	var exitErr *opmexit.ExitError
+	if errors.As(err, &exitErr) {
+		exitErr.Printed = true
+		return err
+	}
+	return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+}
@@ -1,12 +1,17 @@
 package cmdutil_test
 
 import (
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
 	"testing"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -39,6 +44,12 @@ func TestExitCodeFromK8sError(t *testing.T) {
 			err:      apierrors.NewServiceUnavailable("test"),
 			wantCode: opmexit.ExitConnectivityError,
 		},
+		{
+			name: "connection refused",
+			err: &url.Error{Op: "Get", URL: "https://cluster.example.com:6443",
+				Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			wantCode: opmexit.ExitConnectivityError,
+		},
 		{
 			name:     "other k8s error",
 			err:      apierrors.NewBadRequest("test"),
@@ -53,3 +64,20 @@ func TestExitCodeFromK8sError(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkPrinted(t *testing.T) {
+	assert.NoError(t, cmdutil.MarkPrinted(nil))
+
+	coded := &opmexit.ExitError{Code: opmexit.ExitConnectivityError, Err: errors.New("dial tcp: connection refused")}
+	err := cmdutil.MarkPrinted(coded)
+	var exitErr *opmexit.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, opmexit.ExitConnectivityError, exitErr.Code, "the code is kept")
+	assert.True(t, exitErr.Printed)
+
+	err = cmdutil.MarkPrinted(errors.New("boom"))
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, opmexit.ExitGeneralError, exitErr.Code)
+	assert.True(t, exitErr.Printed)
+	assert.EqualError(t, err, "boom")
+}
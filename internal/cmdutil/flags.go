@@ -6,38 +6,195 @@ package cmdutil
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 
 	"github.com/spf13/cobra"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/pkg/loader"
 )
 
 // RenderFlags holds flags common to commands that render modules
 // (apply, build, vet).
 type RenderFlags struct {
-	Values       []string
+	Values []string
+	// ValuesYAML are --values-yaml files: YAML values, merged last-wins
+	// among themselves and then unified with the -f files.
+	ValuesYAML []string
+	// ValuesJSON are --values-json files, merged like ValuesYAML.
+	ValuesJSON []string
+	// ExpandEnv and AllowEmptyEnv are --expand-env and --allow-empty-env
+	// (see EnvExpansion).
+	ExpandEnv     bool
+	AllowEmptyEnv bool
+	// Set and SetString are --set/--set-string path=value overrides applied
+	// on top of the values files (or debugValues).
+	Set       []string
+	SetString []string
+	// SetComponent are --set-component "<component>.<path>=value" overrides
+	// scoped to one component (build and apply only; see AddSetComponentTo).
+	SetComponent []string
+	// StrictValues fails the render on values fields #config does not
+	// declare (build and apply only; see AddStrictValuesTo).
+	StrictValues bool
+	// StrictTraits is --strict-traits: nil when not given, so the module's
+	// own default applies (build and apply only; see AddStrictTraitsTo).
+	StrictTraits *bool
+	// StrictNamespace fails the render on resources a transformer put
+	// outside --namespace, or cluster-scoped resources it gave a namespace
+	// (build and apply only; see AddStrictNamespaceTo).
+	StrictNamespace bool
+	// InstanceUUID replaces the instance's derived UUID (build and apply
+	// only; see AddInstanceUUIDTo).
+	InstanceUUID string
 	Namespace    string
 	InstanceName string
-	// Platform is the --platform local override file (0006 D21; highest
-	// platform-source precedence). Supersedes the retired --provider flag.
-	Platform string
+	// Platform is the --platform local override files, a base platform and
+	// its overlays (0006 D21; highest platform-source precedence). Supersedes
+	// the retired --provider flag.
+	Platform []string
 }
 
 // AddTo registers the render flags on the given cobra command.
 func (f *RenderFlags) AddTo(cmd *cobra.Command) {
 	cmd.Flags().StringArrayVarP(&f.Values, "values", "f", nil,
-		"Additional values files (can be repeated)")
+		"Additional values files, or - for stdin (can be repeated)")
+	addValuesYAMLFlag(cmd, &f.ValuesYAML)
+	addValuesJSONFlag(cmd, &f.ValuesJSON)
+	addExpandEnvFlags(cmd, &f.ExpandEnv, &f.AllowEmptyEnv)
+	cmd.Flags().StringArrayVar(&f.Set, "set", nil,
+		"Override a value, e.g. db.port=5432 or ports[0]=80; types are inferred (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.SetString, "set-string", nil,
+		"Override a value as a string, e.g. tag=1.10 (can be repeated)")
 	cmd.Flags().StringVarP(&f.Namespace, "namespace", "n", "",
 		"Target namespace")
 	cmd.Flags().StringVar(&f.InstanceName, "instance-name", "",
 		"Instance name (default: module name)") // Was: --release-name (enhancement 0002 D-X4.2)
-	cmd.Flags().StringVar(&f.Platform, "platform", "",
-		"Path to a local platform file (overrides the cluster Platform and ~/.opm/platform.cue)")
+	cmd.Flags().StringArrayVar(&f.Platform, "platform", nil,
+		"Path to a local platform file (overrides the cluster Platform and ~/.opm/platform.cue); repeat to overlay further catalogs, later files winning")
+}
+
+// AddSetComponentTo registers --set-component, for the commands that render
+// components (vet validates values only and does not take it).
+func (f *RenderFlags) AddSetComponentTo(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.SetComponent, "set-component", nil,
+		"Override a field of one component, e.g. web.spec.replicas=3; types are inferred (can be repeated)")
+}
+
+// AddStrictValuesTo registers --strict-values, on by default, for the
+// commands that render a module for output or a cluster.
+func (f *RenderFlags) AddStrictValuesTo(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.StrictValues, "strict-values", true,
+		"Fail on values fields the module's #config does not declare; --strict-values=false admits them where #config is open")
+}
+
+// AddStrictTraitsTo registers --strict-traits, for the commands that render a
+// module for output or a cluster.
+func (f *RenderFlags) AddStrictTraitsTo(cmd *cobra.Command) {
+	addStrictTraitsFlag(cmd, &f.StrictTraits)
+}
+
+// AddStrictNamespaceTo registers --strict-namespace, for the commands that
+// render a module for output or a cluster.
+func (f *RenderFlags) AddStrictNamespaceTo(cmd *cobra.Command) {
+	addStrictNamespaceFlag(cmd, &f.StrictNamespace)
+}
+
+// AddInstanceUUIDTo registers --instance-uuid, for the commands that render
+// an instance for output or a cluster.
+func (f *RenderFlags) AddInstanceUUIDTo(cmd *cobra.Command) {
+	addInstanceUUIDFlag(cmd, &f.InstanceUUID)
+}
+
+// EnvExpansion returns the values-file interpolation the flags select.
+func (f *RenderFlags) EnvExpansion() loader.EnvExpansion {
+	return loader.EnvExpansion{Enabled: f.ExpandEnv, AllowEmpty: f.AllowEmptyEnv}
+}
+
+// EnvExpansion returns the values-file interpolation the flags select.
+func (f *InstanceFileFlags) EnvExpansion() loader.EnvExpansion {
+	return loader.EnvExpansion{Enabled: f.ExpandEnv, AllowEmpty: f.AllowEmptyEnv}
+}
+
+func addValuesYAMLFlag(cmd *cobra.Command, target *[]string) {
+	cmd.Flags().StringArrayVar(target, "values-yaml", nil,
+		"Additional YAML values files, Helm-style: documents and files merge in order, later values winning (can be repeated)")
+}
+
+func addValuesJSONFlag(cmd *cobra.Command, target *[]string) {
+	cmd.Flags().StringArrayVar(target, "values-json", nil,
+		"Additional JSON values files, one object each: files merge in order, later values winning (can be repeated)")
+}
+
+func addExpandEnvFlags(cmd *cobra.Command, expand, allowEmpty *bool) {
+	cmd.Flags().BoolVar(expand, "expand-env", false,
+		"Replace ${VAR} in values files with environment variables before compiling them ($${VAR} for a literal); off by default to keep renders hermetic")
+	cmd.Flags().BoolVar(allowEmpty, "allow-empty-env", false,
+		"With --expand-env, expand unset variables to an empty string instead of failing")
+}
+
+func addInstanceUUIDFlag(cmd *cobra.Command, target *string) {
+	cmd.Flags().StringVar(target, "instance-uuid", "",
+		"Use this UUID as the instance's identity instead of the one derived from its module, name, and namespace (e.g. to adopt resources another tool created)")
+}
+
+func addStrictNamespaceFlag(cmd *cobra.Command, target *bool) {
+	cmd.Flags().BoolVar(target, "strict-namespace", false,
+		"Fail when a transformer puts a resource outside --namespace, or gives a cluster-scoped resource a namespace, instead of correcting it")
 }
 
+func addStrictTraitsFlag(cmd *cobra.Command, target **bool) {
+	cmd.Flags().Var(optionalBool{target: target}, "strict-traits",
+		"Fail the render on traits no matched transformer consumes instead of warning (default: the module's opmodel.dev/strict-traits annotation, else false)")
+	cmd.Flags().Lookup("strict-traits").NoOptDefVal = "true"
+}
+
+// optionalBool is a boolean flag value that stays nil until the flag is
+// given, so an unset flag can defer to a default from elsewhere.
+type optionalBool struct {
+	target **bool
+}
+
+func (o optionalBool) String() string {
+	if o.target == nil || *o.target == nil {
+		return ""
+	}
+	return strconv.FormatBool(**o.target)
+}
+
+func (o optionalBool) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*o.target = &b
+	return nil
+}
+
+func (o optionalBool) Type() string { return "bool" }
+
 // K8sFlags holds flags for Kubernetes cluster connection
 // (apply, delete, status).
 type K8sFlags struct {
 	Kubeconfig string
 	Context    string
+
+	// As, AsGroups, and AsUID impersonate a user, groups, and UID for every
+	// API request (--as, --as-group, --as-uid).
+	As       string
+	AsGroups []string
+	AsUID    string
+
+	// QPS and Burst are the client-side rate limits; registered only by
+	// AddRateLimitTo.
+	QPS   float32
+	Burst int
+
+	// ExpectContext and ExpectServer guard against deploying to the wrong
+	// cluster (--expect-context, --expect-cluster-server); registered only
+	// by AddExpectTargetTo and checked by CheckTarget.
+	ExpectContext string
+	ExpectServer  string
 }
 
 // AddTo registers the Kubernetes connection flags on the given cobra command.
@@ -46,6 +203,32 @@ func (f *K8sFlags) AddTo(cmd *cobra.Command) {
 		"Path to kubeconfig file")
 	cmd.Flags().StringVar(&f.Context, "context", "",
 		"Kubernetes context to use")
+	cmd.Flags().StringVar(&f.As, "as", "",
+		"Username to impersonate for the operation")
+	cmd.Flags().StringArrayVar(&f.AsGroups, "as-group", nil,
+		"Group to impersonate for the operation (can be repeated)")
+	cmd.Flags().StringVar(&f.AsUID, "as-uid", "",
+		"UID to impersonate for the operation")
+}
+
+// AddRateLimitTo registers --kube-qps and --kube-burst on the given cobra
+// command. Used by the commands that issue many API calls in a burst
+// (apply, delete, diff).
+func (f *K8sFlags) AddRateLimitTo(cmd *cobra.Command) {
+	cmd.Flags().Float32Var(&f.QPS, "kube-qps", kubernetes.DefaultQPS,
+		"Client-side API request rate limit in queries per second (negative disables)")
+	cmd.Flags().IntVar(&f.Burst, "kube-burst", kubernetes.DefaultBurst,
+		"Client-side API request burst above --kube-qps")
+}
+
+// AddExpectTargetTo registers --expect-context and --expect-cluster-server on
+// the given cobra command. Used by the commands that change cluster state
+// (apply).
+func (f *K8sFlags) AddExpectTargetTo(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.ExpectContext, "expect-context", "",
+		"Fail unless the resolved kubeconfig context has this name")
+	cmd.Flags().StringVar(&f.ExpectServer, "expect-cluster-server", "",
+		"Fail unless the resolved cluster API server is this URL")
 }
 
 // InstanceSelectorFlags holds flags for identifying an instance on the cluster
@@ -104,17 +287,63 @@ type InstanceFileFlags struct {
 	// Values are additional values CUE files (-f/--values flag).
 	// When empty, values.cue next to the instance file is used if it exists.
 	Values []string
-	// Platform is the --platform local override file (0006 D21; highest
-	// platform-source precedence). Supersedes the retired --provider flag.
-	Platform string
+	// ValuesYAML are --values-yaml files: YAML values, merged last-wins
+	// among themselves and then unified with the -f files.
+	ValuesYAML []string
+	// ValuesJSON are --values-json files, merged like ValuesYAML.
+	ValuesJSON []string
+	// ExpandEnv and AllowEmptyEnv are --expand-env and --allow-empty-env
+	// (see EnvExpansion).
+	ExpandEnv     bool
+	AllowEmptyEnv bool
+	// Platform is the --platform local override files, a base platform and
+	// its overlays (0006 D21; highest platform-source precedence). Supersedes
+	// the retired --provider flag.
+	Platform []string
+	// ModuleDigest is the --module-digest pin: the OCI manifest digest the
+	// instance's module must resolve to.
+	ModuleDigest string
+	// StrictTraits is --strict-traits: nil when not given, so the module's
+	// own default applies (build and apply only; see AddStrictTraitsTo).
+	StrictTraits *bool
+	// StrictNamespace fails the render on resources a transformer put
+	// outside --namespace, or cluster-scoped resources it gave a namespace
+	// (build and apply only; see AddStrictNamespaceTo).
+	StrictNamespace bool
+	// InstanceUUID replaces the instance's derived UUID (build, diff, and
+	// apply only; see AddInstanceUUIDTo).
+	InstanceUUID string
+}
+
+// AddStrictTraitsTo registers --strict-traits, for the commands that render an
+// instance for output or a cluster.
+func (f *InstanceFileFlags) AddStrictTraitsTo(cmd *cobra.Command) {
+	addStrictTraitsFlag(cmd, &f.StrictTraits)
+}
+
+// AddStrictNamespaceTo registers --strict-namespace, for the commands that
+// render an instance for output or a cluster.
+func (f *InstanceFileFlags) AddStrictNamespaceTo(cmd *cobra.Command) {
+	addStrictNamespaceFlag(cmd, &f.StrictNamespace)
+}
+
+// AddInstanceUUIDTo registers --instance-uuid, for the commands that render
+// an instance for output or a cluster.
+func (f *InstanceFileFlags) AddInstanceUUIDTo(cmd *cobra.Command) {
+	addInstanceUUIDFlag(cmd, &f.InstanceUUID)
 }
 
 // AddTo registers the instance file flags on the given cobra command.
 func (f *InstanceFileFlags) AddTo(cmd *cobra.Command) {
 	cmd.Flags().StringArrayVarP(&f.Values, "values", "f", nil,
-		"Additional values files (can be repeated; default: values.cue next to the instance file)")
-	cmd.Flags().StringVar(&f.Platform, "platform", "",
-		"Path to a local platform file (overrides the cluster Platform and ~/.opm/platform.cue)")
+		"Additional values files, or - for stdin (can be repeated; default: values.cue next to the instance file)")
+	addValuesYAMLFlag(cmd, &f.ValuesYAML)
+	addValuesJSONFlag(cmd, &f.ValuesJSON)
+	addExpandEnvFlags(cmd, &f.ExpandEnv, &f.AllowEmptyEnv)
+	cmd.Flags().StringArrayVar(&f.Platform, "platform", nil,
+		"Path to a local platform file (overrides the cluster Platform and ~/.opm/platform.cue); repeat to overlay further catalogs, later files winning")
+	cmd.Flags().StringVar(&f.ModuleDigest, "module-digest", "",
+		"Require the module to resolve to this OCI manifest digest (sha256:...)")
 }
 
 // uuidPattern matches a UUID v4/v5: 8-4-4-4-12 lowercase hex digits.
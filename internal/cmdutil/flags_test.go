@@ -31,9 +31,33 @@ func TestRenderFlags_AddTo(t *testing.T) {
 	require.Nil(t, cmd.Flags().Lookup("provider"), "--provider is retired (0006 D21)")
 	platformFlag := cmd.Flags().Lookup("platform")
 	require.NotNil(t, platformFlag)
-	assert.Equal(t, "", platformFlag.DefValue)
+	assert.Equal(t, "[]", platformFlag.DefValue)
 }
 
+func TestRenderFlags_AddStrictTraitsTo(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want *bool
+	}{
+		{name: "unset defers to the module", args: nil, want: nil},
+		{name: "bare flag", args: []string{"--strict-traits"}, want: boolPtr(true)},
+		{name: "explicit false", args: []string{"--strict-traits=false"}, want: boolPtr(false)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var rf RenderFlags
+			cmd := &cobra.Command{Use: "test"}
+			rf.AddStrictTraitsTo(cmd)
+
+			require.NoError(t, cmd.ParseFlags(tc.args))
+			assert.Equal(t, tc.want, rf.StrictTraits)
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
 func TestK8sFlags_AddTo(t *testing.T) {
 	var kf K8sFlags
 	cmd := &cobra.Command{Use: "test"}
@@ -46,6 +70,23 @@ func TestK8sFlags_AddTo(t *testing.T) {
 	ctxFlag := cmd.Flags().Lookup("context")
 	require.NotNil(t, ctxFlag)
 	assert.Equal(t, "", ctxFlag.DefValue)
+
+	require.NoError(t, cmd.ParseFlags([]string{"--as", "alice", "--as-group", "dev", "--as-group", "qa", "--as-uid", "42"}))
+	assert.Equal(t, "alice", kf.As)
+	assert.Equal(t, []string{"dev", "qa"}, kf.AsGroups)
+	assert.Equal(t, "42", kf.AsUID)
+}
+
+func TestK8sFlags_AddRateLimitTo(t *testing.T) {
+	var kf K8sFlags
+	cmd := &cobra.Command{Use: "test"}
+	kf.AddTo(cmd)
+	require.Nil(t, cmd.Flags().Lookup("kube-qps"), "rate limits are opt-in per command")
+
+	kf.AddRateLimitTo(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--kube-qps", "20"}))
+	assert.Equal(t, float32(20), kf.QPS)
+	assert.Equal(t, 100, kf.Burst)
 }
 
 func TestInstanceSelectorFlags_AddTo(t *testing.T) {
@@ -152,7 +193,7 @@ func TestInstanceFileFlags_AddTo(t *testing.T) {
 	require.Nil(t, cmd.Flags().Lookup("provider"), "--provider is retired (0006 D21)")
 	platformFlag := cmd.Flags().Lookup("platform")
 	require.NotNil(t, platformFlag)
-	assert.Equal(t, "", platformFlag.DefValue)
+	assert.Equal(t, "[]", platformFlag.DefValue)
 
 	valuesFlag := cmd.Flags().Lookup("values")
 	require.NotNil(t, valuesFlag)
@@ -234,3 +275,39 @@ func TestFlagGroupComposition(t *testing.T) {
 		assert.NotNil(t, flag, "flag %q should be registered", name)
 	}
 }
+
+func TestDryRunFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantDryRun bool
+		wantClient bool
+		wantErr    string
+	}{
+		{name: "off by default"},
+		{name: "bare flag is a server dry run", args: []string{"--dry-run"}, wantDryRun: true},
+		{name: "client", args: []string{"--dry-run=client"}, wantDryRun: true, wantClient: true},
+		{name: "boolean spelling still works", args: []string{"--dry-run=true"}, wantDryRun: true},
+		{name: "server diff with server dry run", args: []string{"--dry-run=server", "--server-diff"}, wantDryRun: true},
+		{name: "unknown mode", args: []string{"--dry-run=maybe"}, wantErr: "invalid --dry-run"},
+		{name: "server diff needs a server dry run", args: []string{"--dry-run=client", "--server-diff"}, wantErr: "requires --dry-run=server"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var df DryRunFlags
+			cmd := &cobra.Command{Use: "test"}
+			df.AddTo(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			dryRun, client, err := df.Resolve()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDryRun, dryRun)
+			assert.Equal(t, tt.wantClient, client)
+		})
+	}
+}
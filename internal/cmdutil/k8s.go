@@ -1,31 +1,70 @@
 package cmdutil
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"strings"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
 )
 
 // NewK8sClient creates a Kubernetes client from pre-resolved Kubernetes configuration.
 // All values in k8sConfig must already be resolved via config.ResolveKubernetes —
 // no further precedence resolution is performed here or inside the client.
-// Returns an *ExitError with ExitConnectivityError on failure.
-func NewK8sClient(k8sConfig *config.ResolvedKubernetesConfig, apiWarnings string) (*kubernetes.Client, error) {
-	client, err := kubernetes.NewClient(kubernetes.ClientOptions{
+// kf supplies the client-side rate limits (--kube-qps, --kube-burst; zero
+// values take the kubernetes package defaults) and the impersonation flags;
+// nil uses neither. Returns an *ExitError with ExitConnectivityError on failure.
+func NewK8sClient(k8sConfig *config.ResolvedKubernetesConfig, apiWarnings string, kf *K8sFlags) (*kubernetes.Client, error) {
+	opts := kubernetes.ClientOptions{
 		Kubeconfig:  k8sConfig.Kubeconfig.Value,
 		Context:     k8sConfig.Context.Value,
 		APIWarnings: apiWarnings,
-	})
+	}
+	if kf != nil {
+		opts.QPS, opts.Burst = kf.QPS, kf.Burst
+		opts.Impersonate = rest.ImpersonationConfig{UserName: kf.As, Groups: kf.AsGroups, UID: kf.AsUID}
+	}
+	client, err := kubernetes.NewClient(opts)
 	if err != nil {
 		return nil, &opmexit.ExitError{Code: opmexit.ExitConnectivityError, Err: err}
 	}
 	return client, nil
 }
 
+// CheckTarget verifies the client's cluster against --expect-context and
+// --expect-cluster-server, before anything is read from or written to it.
+// On a terminal it also names the target, so an interactive apply always
+// shows where it is about to deploy. A mismatch is an *ExitError.
+func CheckTarget(client *kubernetes.Client, kf *K8sFlags) error {
+	server := client.RestConfig.Host
+	if output.StdoutIsTerminal() {
+		output.Info("target cluster", "context", client.Context, "server", server)
+	}
+	if kf.ExpectContext != "" && kf.ExpectContext != client.Context {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf(
+			"resolved kubeconfig context is %q, not the expected %q (--expect-context); check --context, --kubeconfig, and KUBECONFIG",
+			client.Context, kf.ExpectContext)}
+	}
+	if kf.ExpectServer != "" && !sameServer(kf.ExpectServer, server) {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf(
+			"resolved cluster server is %q, not the expected %q (--expect-cluster-server); check --context, --kubeconfig, and KUBECONFIG",
+			server, kf.ExpectServer)}
+	}
+	return nil
+}
+
+// sameServer compares API server URLs, ignoring case and a trailing slash.
+func sameServer(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "/"), strings.TrimSuffix(b, "/"))
+}
+
 // RequireNamespace returns an error if the resolved namespace is empty (i.e.
 // no namespace was provided via flag, environment variable, or config file).
 // Call this in commands that cannot derive their namespace from an instance
@@ -40,14 +79,18 @@ func RequireNamespace(k8sConfig *config.ResolvedKubernetesConfig) error {
 	return nil
 }
 
-// ExitCodeFromK8sError maps Kubernetes API errors to exit codes.
+// ExitCodeFromK8sError maps Kubernetes API errors to exit codes. A request
+// that never reached the API server — connection refused, a DNS failure, a
+// dial or TLS handshake timeout — is a connectivity error like a server
+// timeout, so scripts can tell an unreachable cluster from a failed request.
 func ExitCodeFromK8sError(err error) int {
+	var netErr net.Error
 	switch {
 	case apierrors.IsNotFound(err):
 		return opmexit.ExitNotFound
 	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
 		return opmexit.ExitPermissionDenied
-	case apierrors.IsServerTimeout(err), apierrors.IsServiceUnavailable(err):
+	case apierrors.IsServerTimeout(err), apierrors.IsServiceUnavailable(err), errors.As(err, &netErr):
 		return opmexit.ExitConnectivityError
 	default:
 		return opmexit.ExitGeneralError
@@ -7,8 +7,10 @@ import (
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
 	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
 )
 
 func TestNewK8sClient_InvalidKubeconfig(t *testing.T) {
@@ -17,10 +19,27 @@ func TestNewK8sClient_InvalidKubeconfig(t *testing.T) {
 		Kubeconfig: config.ResolvedField{Value: "/nonexistent/path/kubeconfig", Source: config.SourceFlag},
 		Context:    config.ResolvedField{Value: "nonexistent-context", Source: config.SourceFlag},
 	}
-	_, err := NewK8sClient(k8sConfig, "")
+	_, err := NewK8sClient(k8sConfig, "", nil)
 
 	require.Error(t, err)
 	var exitErr *opmexit.ExitError
 	require.True(t, errors.As(err, &exitErr))
 	assert.Equal(t, opmexit.ExitConnectivityError, exitErr.Code)
 }
+
+func TestCheckTarget(t *testing.T) {
+	client := &kubernetes.Client{Context: "staging", RestConfig: &rest.Config{Host: "https://staging.example.com:6443"}}
+
+	require.NoError(t, CheckTarget(client, &K8sFlags{}))
+	require.NoError(t, CheckTarget(client, &K8sFlags{ExpectContext: "staging", ExpectServer: "https://STAGING.example.com:6443/"}))
+
+	err := CheckTarget(client, &K8sFlags{ExpectContext: "prod"})
+	var exitErr *opmexit.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, opmexit.ExitGeneralError, exitErr.Code)
+	assert.Contains(t, err.Error(), `resolved kubeconfig context is "staging", not the expected "prod"`)
+
+	err = CheckTarget(client, &K8sFlags{ExpectServer: "https://prod.example.com:6443"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--expect-cluster-server")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/template"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
@@ -24,8 +25,9 @@ func ParseManifestOutputFormat(outputFmt string) (output.Format, error) {
 	return outputFormat, nil
 }
 
-// WriteManifestOutput writes manifests either to stdout or split files.
-func WriteManifestOutput(resources []*unstructured.Unstructured, outputFormat output.Format, split bool, outDir, instanceName string) error {
+// WriteManifestOutput writes manifests either to stdout or split files. list
+// wraps stdout JSON in a v1 List.
+func WriteManifestOutput(resources []*unstructured.Unstructured, outputFormat output.Format, split, list bool, outDir, instanceName string) error {
 	instanceLog := output.InstanceLogger(instanceName)
 	if split {
 		splitOpts := output.SplitOptions{OutDir: outDir, Format: outputFormat}
@@ -36,13 +38,33 @@ func WriteManifestOutput(resources []*unstructured.Unstructured, outputFormat ou
 		return nil
 	}
 
-	manifestOpts := output.ManifestOptions{Format: outputFormat, Writer: os.Stdout}
+	manifestOpts := output.ManifestOptions{Format: outputFormat, Writer: os.Stdout, List: list}
 	if err := output.WriteManifests(resources, manifestOpts); err != nil {
 		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("writing manifests: %w", err)}
 	}
 	return nil
 }
 
+// WriteManifestDir writes one file per resource under dir, in a subdirectory
+// per component (--split-layout component).
+func WriteManifestDir(resources []*unstructured.Unstructured, outputFormat output.Format, dir string, force bool, instanceName string) error {
+	written, err := output.WriteManifestDir(resources, output.DirOptions{Dir: dir, Format: outputFormat, Force: force})
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("writing manifests to directory: %w", err)}
+	}
+	output.InstanceLogger(instanceName).Info(fmt.Sprintf("wrote %d resources to %s", len(written), dir))
+	return nil
+}
+
+// WriteManifestTemplate renders manifests through a Go template to stdout
+// (-o template=<file>).
+func WriteManifestTemplate(resources []*unstructured.Unstructured, tmpl *template.Template, perResource bool) error {
+	if err := output.WriteTemplate(resources, tmpl, perResource, os.Stdout); err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("writing template output: %w", err)}
+	}
+	return nil
+}
+
 // FormatApplySummary builds a human-readable summary of apply results.
 func FormatApplySummary(r *kubernetes.ApplyResult) string {
 	var parts []string
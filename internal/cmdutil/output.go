@@ -20,6 +20,11 @@ import (
 //
 // For generic errors, it falls back to the standard key-value log format.
 func PrintValidationError(msg string, err error) {
+	if output.JSONErrors() {
+		output.PrintErrorJSON(err)
+		return
+	}
+
 	var configErr *pkgerrors.ConfigError
 	if errors.As(err, &configErr) {
 		printGrouped(msg, configErr.GroupedErrors())
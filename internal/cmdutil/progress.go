@@ -0,0 +1,35 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// NewApplyProgress returns a kubernetes.ApplyOptions.Progress reporter that
+// draws a progress bar on stderr, and the func that clears the bar once the
+// apply is done. Under --quiet, or -o json which implies it, both are no-ops
+// and the reporter is nil.
+func NewApplyProgress() (func(kubernetes.ApplyProgress), func()) {
+	if output.Quiet() {
+		return nil, func() {}
+	}
+	bar := output.NewProgressBar("applied")
+	return func(p kubernetes.ApplyProgress) {
+		bar.Update(p.Done, p.Total, applyProgressItem(p))
+	}, bar.Finish
+}
+
+// applyProgressItem names the resource a progress step completed, with its
+// wave when the apply has more than one.
+func applyProgressItem(p kubernetes.ApplyProgress) string {
+	item := p.Kind + "/" + p.Name
+	if p.Waves > 1 {
+		item += fmt.Sprintf(", wave %d/%d", p.Index, p.Waves)
+	}
+	if p.Err != nil {
+		item += ", failed"
+	}
+	return item
+}
@@ -0,0 +1,21 @@
+package cmdutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+)
+
+func TestApplyProgressItem(t *testing.T) {
+	p := kubernetes.ApplyProgress{Done: 1, Total: 3, Index: 1, Waves: 1, Kind: "Deployment", Name: "web"}
+	assert.Equal(t, "Deployment/web", applyProgressItem(p))
+
+	p.Index, p.Waves = 2, 3
+	assert.Equal(t, "Deployment/web, wave 2/3", applyProgressItem(p))
+
+	p.Err = errors.New("forbidden")
+	assert.Equal(t, "Deployment/web, wave 2/3, failed", applyProgressItem(p))
+}
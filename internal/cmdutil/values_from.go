@@ -0,0 +1,64 @@
+package cmdutil
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+// ValuesFromFlags holds the flags that read values from the cluster
+// (--values-from-configmap, --values-from-secret). Registered only on the
+// commands that already hold a cluster client.
+type ValuesFromFlags struct {
+	ConfigMaps []string
+	Secrets    []string
+}
+
+// AddTo registers the cluster values flags on the given cobra command.
+func (f *ValuesFromFlags) AddTo(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.ConfigMaps, "values-from-configmap", nil,
+		"Read values from a ConfigMap key, as namespace/name[:key]; CUE, JSON, or YAML (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.Secrets, "values-from-secret", nil,
+		"Read values from a Secret key, as namespace/name[:key]; CUE, JSON, or YAML (can be repeated)")
+}
+
+// Refs parses every reference, ConfigMaps first. Call it before connecting so
+// a malformed reference fails without touching the cluster.
+func (f *ValuesFromFlags) Refs() ([]kubernetes.ValuesRef, error) {
+	var refs []kubernetes.ValuesRef
+	for _, kind := range []struct {
+		name string
+		refs []string
+	}{{kubernetes.ValuesKindConfigMap, f.ConfigMaps}, {kubernetes.ValuesKindSecret, f.Secrets}} {
+		for _, s := range kind.refs {
+			ref, err := kubernetes.ParseValuesRef(kind.name, s)
+			if err != nil {
+				return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// FetchValuesDocuments reads every reference from the cluster, in order, as
+// values documents for the render's value merge. API errors keep their exit
+// codes: a missing object is ExitNotFound, a forbidden read
+// ExitPermissionDenied.
+func FetchValuesDocuments(ctx context.Context, client *kubernetes.Client, refs []kubernetes.ValuesRef) ([]loader.ValuesDocument, error) {
+	docs := make([]loader.ValuesDocument, 0, len(refs))
+	for _, ref := range refs {
+		data, err := kubernetes.FetchValues(ctx, client, ref)
+		if err != nil {
+			return nil, &opmexit.ExitError{Code: ExitCodeFromK8sError(err), Err: err}
+		}
+		output.Debug("read values from cluster", "source", ref.String(), "bytes", len(data))
+		docs = append(docs, loader.ValuesDocument{Name: ref.String(), Data: data})
+	}
+	return docs, nil
+}
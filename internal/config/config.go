@@ -18,6 +18,10 @@ type KubernetesConfig struct {
 	// Namespace is the default namespace for operations.
 	// Env: OPM_NAMESPACE, Default: "default"
 	Namespace string `json:"namespace,omitempty"`
+
+	// ReadyConditions overrides, per kind (or "Kind.group"), the condition
+	// types that report readiness for custom resources.
+	ReadyConditions map[string][]string `json:"readyConditions,omitempty"`
 }
 
 // LogKubernetesConfig contains Kubernetes-related logging settings.
@@ -50,8 +54,13 @@ type GlobalFlags struct {
 	Registry string
 	// Verbose is the --verbose flag value.
 	Verbose bool
+	// Quiet is the --quiet flag value, or true when a machine output format
+	// (-o json) implies it.
+	Quiet bool
 	// Timestamps is the --timestamps flag value.
 	Timestamps bool
+	// Offline is the --offline flag value.
+	Offline bool
 }
 
 // GlobalConfig is the single consolidated runtime configuration type.
@@ -71,6 +80,10 @@ type GlobalConfig struct {
 	// Set by config.Load.
 	ConfigPath string
 
+	// Offline restricts module resolution to the local CUE module cache.
+	// Set by config.Load from --offline or OPM_OFFLINE; Registry is then "none".
+	Offline bool
+
 	// Flags holds the raw CLI flag values as set by the user.
 	Flags GlobalFlags
 }
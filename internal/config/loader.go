@@ -4,6 +4,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"cuelang.org/go/cue"
@@ -27,8 +28,19 @@ type LoaderOptions struct {
 	RegistryFlag string
 	// ConfigFlag is the --config flag value.
 	ConfigFlag string
+	// RegistryCredentials are the --registry-host/--registry-username/
+	// --registry-password/--registry-token flag values; unset fields fall back
+	// to env.
+	RegistryCredentials RegistryCredentials
+	// Offline is the --offline flag value; OPM_OFFLINE also enables it.
+	Offline bool
 }
 
+// offlineRegistry is the CUE_REGISTRY value for offline mode: no module path
+// resolves to a registry, so a dependency missing from the CUE module cache
+// fails instead of being downloaded.
+const offlineRegistry = "none"
+
 // Load loads the OPM configuration into cfg, applying precedence rules.
 //
 // Loading is single-pass: ~/.opm/config.cue is import-free scalar data
@@ -37,7 +49,11 @@ type LoaderOptions struct {
 // afterwards. There is no registry-bootstrap pre-pass.
 //
 // Load sets: cfg.ConfigPath, cfg.Registry, cfg.Kubernetes, cfg.Log,
-// cfg.CueContext. The caller sets cfg.Flags before or after calling Load.
+// cfg.CueContext, cfg.Offline. The caller sets cfg.Flags before or after
+// calling Load. Explicit registry credentials are installed for one host of
+// the resolved registry (see ApplyRegistryCredentials). In offline mode the
+// registry is forced to "none" — for this process's CUE_REGISTRY too — so
+// modules resolve from the local CUE module cache only.
 func Load(cfg *GlobalConfig, opts LoaderOptions) error {
 	// Step 1: Resolve config path
 	configPathResult, err := ResolveConfigPath(ResolveConfigPathOptions{
@@ -73,9 +89,42 @@ func Load(cfg *GlobalConfig, opts LoaderOptions) error {
 		"source", registryResult.Source,
 	)
 
+	// Step 4: Install explicit registry credentials, if any.
+	creds, err := ResolveRegistryCredentials(opts.RegistryCredentials)
+	if err != nil {
+		return err
+	}
+	if err := ApplyRegistryCredentials(cfg.Registry, creds); err != nil {
+		return err
+	}
+	if !creds.IsEmpty() {
+		host, _ := CredentialsHost(cfg.Registry, creds.Host) //nolint:errcheck // ApplyRegistryCredentials already succeeded
+		output.Debug("using explicit registry credentials",
+			"host", host,
+			"token", creds.Token != "",
+		)
+	}
+
+	// Step 5: Offline mode overrides whatever registry was resolved.
+	cfg.Offline = opts.Offline || envBool("OPM_OFFLINE")
+	if cfg.Offline {
+		cfg.Registry = offlineRegistry
+		if err := os.Setenv("CUE_REGISTRY", offlineRegistry); err != nil {
+			return fmt.Errorf("setting CUE_REGISTRY: %w", err)
+		}
+		output.Debug("offline mode: resolving modules from the local CUE cache only")
+	}
+
 	return nil
 }
 
+// envBool reports whether the environment variable key is set to a true
+// value ("1", "true", ...). Unset or unparseable values are false.
+func envBool(key string) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	return err == nil && v
+}
+
 // loadConfigFile parses configPath, validates it against the embedded schema,
 // and populates cfg's config-file fields. It returns the registry value
 // declared in the file (empty when absent) for precedence resolution by the
@@ -192,6 +241,12 @@ func extractConfigInto(cfg *GlobalConfig, value cue.Value) {
 				cfg.Kubernetes.Namespace = str
 			}
 		}
+		if readyVal := k8sValue.LookupPath(cue.ParsePath("readyConditions")); readyVal.Exists() {
+			var ready map[string][]string
+			if err := readyVal.Decode(&ready); err == nil {
+				cfg.Kubernetes.ReadyConditions = ready
+			}
+		}
 	}
 
 	// Extract log config
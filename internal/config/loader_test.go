@@ -83,6 +83,25 @@ func TestLoad_RegistryFlagPrecedence(t *testing.T) {
 	assert.Equal(t, "flag-registry.example.com", cfg.Registry)
 }
 
+func TestLoad_Offline(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OPM_REGISTRY", "env-registry.example.com")
+	t.Setenv("CUE_REGISTRY", "")
+	os.Unsetenv("OPM_CONFIG")
+
+	var cfg GlobalConfig
+	require.NoError(t, Load(&cfg, LoaderOptions{Offline: true}))
+
+	assert.True(t, cfg.Offline)
+	assert.Equal(t, "none", cfg.Registry, "offline overrides the resolved registry")
+	assert.Equal(t, "none", os.Getenv("CUE_REGISTRY"), "direct CUE loads are offline too")
+
+	t.Setenv("OPM_OFFLINE", "true")
+	var fromEnv GlobalConfig
+	require.NoError(t, Load(&fromEnv, LoaderOptions{}))
+	assert.True(t, fromEnv.Offline)
+}
+
 func TestLoad_RegistryFromConfigFile(t *testing.T) {
 	// Single-pass: the registry comes out of the parsed file, no bootstrap
 	// pre-pass. The package clause must be accepted (existing user files
@@ -146,6 +165,28 @@ config: {
 	assert.Nil(t, cfg.Log.Timestamps, "Log.Timestamps should be nil when not configured")
 }
 
+func TestLoadConfigFile_ReadyConditions(t *testing.T) {
+	configPath := writeConfig(t, `package config
+
+config: {
+	kubernetes: {
+		readyConditions: {
+			"Cluster.postgresql.cnpg.io": ["Ready"]
+			Certificate: ["Ready", "Issued"]
+		}
+	}
+}
+`)
+
+	var cfg GlobalConfig
+	_, err := loadConfigFile(&cfg, configPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Cluster.postgresql.cnpg.io": {"Ready"},
+		"Certificate":                {"Ready", "Issued"},
+	}, cfg.Kubernetes.ReadyConditions)
+}
+
 func TestLoadConfigFile_LogTimestampsInvalidType(t *testing.T) {
 	configPath := writeConfig(t, `package config
 
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultCUERegistryHost is the registry CUE resolves modules against when
+// CUE_REGISTRY is unset.
+const defaultCUERegistryHost = "registry.cue.works"
+
+// opmModulePrefix is the module path prefix whose registry mapping names the
+// credentials host when the registry spans several hosts.
+const opmModulePrefix = "opmodel.dev"
+
+// RegistryCredentials are explicit credentials for the module registry, from
+// --registry-username/--registry-password or --registry-token. They take
+// precedence over `cue login` and Docker config.json entries for one host —
+// --registry-host, or the one the resolved registry implies (see
+// CredentialsHost); all other hosts, such as a public fallback registry, keep
+// their usual credentials.
+type RegistryCredentials struct {
+	// Host is the registry host the credentials are sent to.
+	// Env: OPM_REGISTRY_HOST
+	Host string
+	// Username and Password authenticate with basic auth.
+	// Env: OPM_REGISTRY_USERNAME, OPM_REGISTRY_PASSWORD
+	Username string
+	Password string
+	// Token is a bearer token sent as-is, e.g. a short-lived CI token.
+	// Env: OPM_REGISTRY_TOKEN
+	Token string
+}
+
+// IsEmpty reports whether no credentials were given. A host alone is not a
+// credential.
+func (c RegistryCredentials) IsEmpty() bool {
+	return c.Username == "" && c.Password == "" && c.Token == ""
+}
+
+// ResolveRegistryCredentials fills each unset field of flags from its
+// environment variable (flag > env) and checks the combination: a username
+// needs a password, and a token excludes both.
+func ResolveRegistryCredentials(flags RegistryCredentials) (RegistryCredentials, error) {
+	creds := flags
+	if creds.Host == "" {
+		creds.Host = os.Getenv("OPM_REGISTRY_HOST")
+	}
+	if creds.Username == "" {
+		creds.Username = os.Getenv("OPM_REGISTRY_USERNAME")
+	}
+	if creds.Password == "" {
+		creds.Password = os.Getenv("OPM_REGISTRY_PASSWORD")
+	}
+	if creds.Token == "" {
+		creds.Token = os.Getenv("OPM_REGISTRY_TOKEN")
+	}
+
+	switch {
+	case creds.Token != "" && (creds.Username != "" || creds.Password != ""):
+		return RegistryCredentials{}, fmt.Errorf("--registry-token cannot be combined with --registry-username/--registry-password")
+	case creds.Username != "" && creds.Password == "":
+		return RegistryCredentials{}, fmt.Errorf("--registry-username requires --registry-password (or OPM_REGISTRY_PASSWORD)")
+	case creds.Password != "" && creds.Username == "":
+		return RegistryCredentials{}, fmt.Errorf("--registry-password requires --registry-username (or OPM_REGISTRY_USERNAME)")
+	}
+	return creds, nil
+}
+
+// RegistryHosts returns the registry hosts named by a CUE_REGISTRY-style
+// registry value, e.g. "opmodel.dev=ghcr.io/open-platform-model,localhost:5000"
+// yields ghcr.io and localhost:5000. An empty value means CUE's default
+// registry; "none" entries name no host.
+func RegistryHosts(registry string) []string {
+	if strings.TrimSpace(registry) == "" {
+		return []string{defaultCUERegistryHost}
+	}
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(registry, ",") {
+		_, host := registryEntryHost(entry)
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// registryEntryHost splits one CUE_REGISTRY entry into its module prefix
+// (empty for the catch-all entry) and the host it maps to (empty for "none").
+func registryEntryHost(entry string) (prefix, host string) {
+	entry = strings.TrimSpace(entry)
+	if p, location, ok := strings.Cut(entry, "="); ok {
+		prefix, entry = p, location
+	}
+	entry, _, _ = strings.Cut(entry, "+") // +insecure / +secure
+	host, _, _ = strings.Cut(entry, "/")
+	if host == "none" {
+		host = ""
+	}
+	return prefix, host
+}
+
+// CredentialsHost returns the one registry host explicit credentials are
+// sent to: explicit when set; otherwise the registry's only host, or, when it
+// spans several, the host of its opmodel.dev mapping. Sending private
+// credentials to every host would leak them to a public fallback registry, so
+// a registry with several hosts and no opmodel.dev mapping needs
+// --registry-host.
+func CredentialsHost(registry, explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	hosts := RegistryHosts(registry)
+	if len(hosts) == 1 {
+		return hosts[0], nil
+	}
+	for _, entry := range strings.Split(registry, ",") {
+		if prefix, host := registryEntryHost(entry); prefix == opmModulePrefix && host != "" {
+			return host, nil
+		}
+	}
+	return "", fmt.Errorf("registry %q spans hosts %s; set --registry-host (or OPM_REGISTRY_HOST) to the one the credentials are for",
+		registry, strings.Join(hosts, ", "))
+}
+
+// ApplyRegistryCredentials makes creds the credentials for their host (see
+// CredentialsHost) in all CUE module loads of this process. CUE resolves
+// registry auth through ociauth, whose highest-priority source is the inline
+// JSON in DOCKER_AUTH_CONFIG, so the credentials are layered into that
+// variable — over any value the user already set — and inherited by every
+// load. Empty creds leave the environment untouched.
+func ApplyRegistryCredentials(registry string, creds RegistryCredentials) error {
+	if creds.IsEmpty() {
+		return nil
+	}
+	host, err := CredentialsHost(registry, creds.Host)
+	if err != nil {
+		return err
+	}
+	authConfig, err := registryAuthConfig(os.Getenv("DOCKER_AUTH_CONFIG"), host, creds)
+	if err != nil {
+		return err
+	}
+	return os.Setenv("DOCKER_AUTH_CONFIG", authConfig)
+}
+
+// registryAuthConfig returns existing (a Docker config JSON document, possibly
+// empty) with creds set for host. The host also gets an empty credHelpers
+// entry: ociauth consults credential helpers — including a global credsStore
+// from ~/.docker/config.json — before auths, and an explicit empty helper is
+// what makes it fall through to the credentials given here.
+func registryAuthConfig(existing, host string, creds RegistryCredentials) (string, error) {
+	doc := map[string]any{}
+	if strings.TrimSpace(existing) != "" {
+		if err := json.Unmarshal([]byte(existing), &doc); err != nil {
+			return "", fmt.Errorf("parsing DOCKER_AUTH_CONFIG: %w", err)
+		}
+	}
+	auths, _ := doc["auths"].(map[string]any)
+	if auths == nil {
+		auths = map[string]any{}
+	}
+	helpers, _ := doc["credHelpers"].(map[string]any)
+	if helpers == nil {
+		helpers = map[string]any{}
+	}
+
+	entry := map[string]any{}
+	if creds.Token != "" {
+		entry["registrytoken"] = creds.Token
+	} else {
+		entry["auth"] = base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))
+	}
+	auths[host] = entry
+	helpers[host] = ""
+	doc["auths"] = auths
+	doc["credHelpers"] = helpers
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("encoding DOCKER_AUTH_CONFIG: %w", err)
+	}
+	return string(data), nil
+}
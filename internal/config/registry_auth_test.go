@@ -0,0 +1,164 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelabs.dev/go/oci/ociregistry/ociauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryHosts(t *testing.T) {
+	tests := []struct {
+		registry string
+		want     []string
+	}{
+		{registry: "", want: []string{"registry.cue.works"}},
+		{registry: "localhost:5000", want: []string{"localhost:5000"}},
+		{registry: "localhost:5000+insecure", want: []string{"localhost:5000"}},
+		{
+			registry: "opmodel.dev=ghcr.io/open-platform-model,registry.cue.works",
+			want:     []string{"ghcr.io", "registry.cue.works"},
+		},
+		{
+			registry: "opmodel.dev=ghcr.io/a, example.com=ghcr.io/b+secure, other.dev=none",
+			want:     []string{"ghcr.io"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			assert.Equal(t, tt.want, RegistryHosts(tt.registry))
+		})
+	}
+}
+
+func TestCredentialsHost(t *testing.T) {
+	tests := []struct {
+		registry string
+		explicit string
+		want     string
+		wantErr  bool
+	}{
+		{registry: "", want: "registry.cue.works"},
+		{registry: "ghcr.io/org", want: "ghcr.io"},
+		{registry: "opmodel.dev=ghcr.io/org,registry.cue.works", want: "ghcr.io"},
+		{registry: "opmodel.dev=ghcr.io/org,registry.cue.works", explicit: "quay.io", want: "quay.io"},
+		{registry: "example.com=ghcr.io/org,registry.cue.works", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry+"|"+tt.explicit, func(t *testing.T) {
+			got, err := CredentialsHost(tt.registry, tt.explicit)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "--registry-host")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Private credentials must reach only the opmodel.dev host, never the public
+// fallback registry.
+func TestApplyRegistryCredentials_ScopedToOneHost(t *testing.T) {
+	t.Setenv("DOCKER_AUTH_CONFIG", "")
+	require.NoError(t, ApplyRegistryCredentials("opmodel.dev=ghcr.io/org,registry.cue.works", RegistryCredentials{Token: "private"}))
+
+	cf, err := ociauth.LoadWithEnv(nil, []string{"HOME=" + t.TempDir(), "DOCKER_AUTH_CONFIG=" + os.Getenv("DOCKER_AUTH_CONFIG")})
+	require.NoError(t, err)
+	ghcr, err := cf.EntryForRegistry("ghcr.io")
+	require.NoError(t, err)
+	assert.Equal(t, "private", ghcr.AccessToken)
+	public, err := cf.EntryForRegistry("registry.cue.works")
+	require.NoError(t, err)
+	assert.Empty(t, public.AccessToken, "the fallback registry gets no credentials")
+}
+
+func TestResolveRegistryCredentials(t *testing.T) {
+	t.Run("flag beats env", func(t *testing.T) {
+		t.Setenv("OPM_REGISTRY_USERNAME", "env-user")
+		t.Setenv("OPM_REGISTRY_PASSWORD", "env-pass")
+		creds, err := ResolveRegistryCredentials(RegistryCredentials{Username: "flag-user"})
+		require.NoError(t, err)
+		assert.Equal(t, RegistryCredentials{Username: "flag-user", Password: "env-pass"}, creds)
+	})
+
+	t.Run("token from env", func(t *testing.T) {
+		t.Setenv("OPM_REGISTRY_TOKEN", "tok")
+		creds, err := ResolveRegistryCredentials(RegistryCredentials{})
+		require.NoError(t, err)
+		assert.Equal(t, "tok", creds.Token)
+	})
+
+	for name, flags := range map[string]RegistryCredentials{
+		"username without password": {Username: "u"},
+		"password without username": {Password: "p"},
+		"token with username":       {Username: "u", Password: "p", Token: "t"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ResolveRegistryCredentials(flags)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// The generated DOCKER_AUTH_CONFIG must win over the user's Docker config —
+// including a global credsStore, which ociauth consults before auths — for
+// the registry hosts, and leave every other host alone.
+func TestRegistryAuthConfig_OverridesDockerConfig(t *testing.T) {
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".docker"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".docker", "config.json"), []byte(`{
+		"credsStore": "does-not-exist",
+		"auths": {"ghcr.io": {"username": "stale", "password": "stale"}}
+	}`), 0o600))
+
+	existing := `{"auths": {"quay.io": {"registrytoken": "quay-token"}}}`
+
+	tests := []struct {
+		name  string
+		creds RegistryCredentials
+		want  ociauth.ConfigEntry
+	}{
+		{
+			name:  "basic auth",
+			creds: RegistryCredentials{Username: "ci", Password: "s3cret"},
+			want:  ociauth.ConfigEntry{Username: "ci", Password: "s3cret"},
+		},
+		{
+			name:  "bearer token",
+			creds: RegistryCredentials{Token: "bearer"},
+			want:  ociauth.ConfigEntry{AccessToken: "bearer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authConfig, err := registryAuthConfig(existing, "ghcr.io", tt.creds)
+			require.NoError(t, err)
+
+			cf, err := ociauth.LoadWithEnv(nil, []string{"HOME=" + home, "DOCKER_AUTH_CONFIG=" + authConfig})
+			require.NoError(t, err)
+
+			entry, err := cf.EntryForRegistry("ghcr.io")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, entry)
+
+			quay, err := cf.EntryForRegistry("quay.io")
+			require.NoError(t, err)
+			assert.Equal(t, "quay-token", quay.AccessToken, "existing DOCKER_AUTH_CONFIG entries are kept")
+		})
+	}
+}
+
+func TestRegistryAuthConfig_InvalidExisting(t *testing.T) {
+	_, err := registryAuthConfig("{not json", "ghcr.io", RegistryCredentials{Token: "t"})
+	assert.ErrorContains(t, err, "DOCKER_AUTH_CONFIG")
+}
+
+func TestApplyRegistryCredentials_EmptyLeavesEnv(t *testing.T) {
+	t.Setenv("DOCKER_AUTH_CONFIG", "unchanged")
+	require.NoError(t, ApplyRegistryCredentials("ghcr.io", RegistryCredentials{}))
+	assert.Equal(t, "unchanged", os.Getenv("DOCKER_AUTH_CONFIG"))
+}
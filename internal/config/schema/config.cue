@@ -42,6 +42,11 @@ package schema
 	// Env: OPM_NAMESPACE, Default: "default"
 	// Must be RFC-1123 compliant (lowercase alphanumeric and hyphens).
 	namespace?: string & =~"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+
+	// readyConditions overrides, per kind, the status condition types that
+	// report readiness for custom resources (default: Ready, then Available).
+	// Keys are a kind or a group-qualified kind, e.g. "Cluster.postgresql.cnpg.io".
+	readyConditions?: [string]: [...string]
 }
 
 // #LogConfig contains logging-related settings.
@@ -0,0 +1,328 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+
+	oerrors "github.com/open-platform-model/cli/pkg/errors"
+)
+
+// RedactedValue replaces a secret value in `opm config view` output.
+const RedactedValue = "<redacted>"
+
+// Setting is one configuration key with its effective value and where that
+// value came from.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	// Env is the environment variable that overrides the key, if any.
+	Env string `json:"env,omitempty"`
+	// Secret marks a value that is redacted unless asked for.
+	Secret bool `json:"secret,omitempty"`
+}
+
+// settingSpec describes one key of the effective configuration.
+type settingSpec struct {
+	key string
+	// env and flag are the environment variable and global flag that
+	// override the key; empty when there is none.
+	env, flag string
+	def       string
+	secret    bool
+	// file is set for keys the config file holds; settable for the ones
+	// `opm config set` writes, with kind the CUE kind of their value.
+	file, settable bool
+	kind           cue.Kind
+}
+
+// settingSpecs lists the effective configuration in display order. Kubernetes
+// keys are also overridden by per-command flags (--kubeconfig, --context,
+// --namespace); view reports the value a command gets without them.
+var settingSpecs = []settingSpec{
+	{key: "registry", env: "OPM_REGISTRY", flag: "registry", file: true, settable: true, kind: cue.StringKind},
+	{key: "kubernetes.kubeconfig", env: "OPM_KUBECONFIG", def: defaultKubeconfig, file: true, settable: true, kind: cue.StringKind},
+	{key: "kubernetes.context", env: "OPM_CONTEXT", file: true, settable: true, kind: cue.StringKind},
+	{key: "kubernetes.namespace", env: "OPM_NAMESPACE", def: "default", file: true, settable: true, kind: cue.StringKind},
+	{key: "kubernetes.readyConditions", file: true},
+	{key: "log.timestamps", flag: "timestamps", def: "true", file: true, settable: true, kind: cue.BoolKind},
+	{key: "log.kubernetes.apiWarnings", def: APIWarningsWarn, file: true, settable: true, kind: cue.StringKind},
+	{key: "offline", env: "OPM_OFFLINE", flag: "offline", def: "false"},
+	{key: "registryCredentials.host", env: "OPM_REGISTRY_HOST", flag: "registry-host"},
+	{key: "registryCredentials.username", env: "OPM_REGISTRY_USERNAME", flag: "registry-username"},
+	{key: "registryCredentials.password", env: "OPM_REGISTRY_PASSWORD", flag: "registry-password", secret: true},
+	{key: "registryCredentials.token", env: "OPM_REGISTRY_TOKEN", flag: "registry-token", secret: true},
+}
+
+// SettingFlags returns the global flag names that override a setting, so the
+// caller can pass the ones the user set to EffectiveSettings.
+func SettingFlags() []string {
+	var flags []string
+	for _, s := range settingSpecs {
+		if s.flag != "" {
+			flags = append(flags, s.flag)
+		}
+	}
+	return flags
+}
+
+// SettableKeys returns the keys `opm config set` accepts.
+func SettableKeys() []string {
+	var keys []string
+	for _, s := range settingSpecs {
+		if s.settable {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
+
+// EffectiveSettings resolves every configuration key with the CLI's
+// precedence — flag > env > config file > default — and records which of them
+// supplied the value. flags maps the global flag names the user set (see
+// SettingFlags) to their values. A missing config file contributes nothing.
+func EffectiveSettings(configPath string, flags map[string]string) ([]Setting, error) {
+	fileValues, err := readFileSettings(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]Setting, 0, len(settingSpecs))
+	for _, spec := range settingSpecs {
+		s := Setting{Key: spec.key, Env: spec.env, Value: spec.def, Source: SourceDefault}
+		if v, ok := fileValues[spec.key]; ok {
+			s.Value, s.Source = v, SourceConfig
+		}
+		if spec.env != "" {
+			if v := os.Getenv(spec.env); v != "" {
+				s.Value, s.Source = v, SourceEnv
+			}
+		}
+		if v, ok := flags[spec.flag]; ok && spec.flag != "" {
+			s.Value, s.Source = v, SourceFlag
+		}
+		s.Secret = spec.secret || looksSecret(s.Value)
+		settings = append(settings, s)
+	}
+	return settings, nil
+}
+
+// credentialsInURL matches a URL carrying a password in its userinfo.
+var credentialsInURL = regexp.MustCompile(`://[^/@\s:]+:[^/@\s]+@`)
+
+// looksSecret reports whether a value that is not a credential by key still
+// carries one, as a registry URL with user:password@ does.
+func looksSecret(value string) bool {
+	return credentialsInURL.MatchString(value)
+}
+
+// Redact returns settings with every secret value replaced by RedactedValue.
+// An unset secret stays empty, so view still shows that it is unset.
+func Redact(settings []Setting) []Setting {
+	out := make([]Setting, len(settings))
+	for i, s := range settings {
+		if s.Secret && s.Value != "" {
+			s.Value = RedactedValue
+		}
+		out[i] = s
+	}
+	return out
+}
+
+// readFileSettings returns the config file's value for each key it sets,
+// strings as-is and anything else as JSON.
+func readFileSettings(configPath string) (map[string]string, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	value := cuecontext.New().CompileBytes(content, cue.Filename(configPath))
+	if value.Err() != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", configPath, value.Err())
+	}
+	configValue := value.LookupPath(cue.ParsePath("config"))
+
+	values := map[string]string{}
+	for _, spec := range settingSpecs {
+		if !spec.file {
+			continue
+		}
+		v := configValue.LookupPath(cue.ParsePath(spec.key))
+		if !v.Exists() || v.Validate(cue.Concrete(true)) != nil {
+			continue
+		}
+		if str, err := v.String(); err == nil {
+			values[spec.key] = str
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from config file: %w", spec.key, err)
+		}
+		values[spec.key] = string(data)
+	}
+	return values, nil
+}
+
+// SetConfigValue writes key = value into the config file at configPath,
+// keeping its comments and the rest of its content. The value is parsed as the
+// key's kind (a string, or true/false for a bool), and the edited file must
+// still pass schema validation, or nothing is written. The file must exist:
+// `opm config init` creates it.
+func SetConfigValue(configPath, key, value string) error {
+	spec, ok := settableSpec(key)
+	if !ok {
+		return &oerrors.DetailError{
+			Type:    configErrType,
+			Message: fmt.Sprintf("%q is not a settable configuration key", key),
+			Hint:    "Settable keys: " + strings.Join(SettableKeys(), ", "),
+			Cause:   oerrors.ErrValidation,
+		}
+	}
+	lit, err := settingLiteral(spec, value)
+	if err != nil {
+		return &oerrors.DetailError{
+			Type:    configErrType,
+			Message: err.Error(),
+			Cause:   oerrors.ErrValidation,
+		}
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &oerrors.DetailError{
+				Type:     "not found",
+				Message:  "configuration file not found",
+				Location: configPath,
+				Hint:     "Run 'opm config init' to create default configuration",
+				Cause:    oerrors.ErrNotFound,
+			}
+		}
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	file, err := parser.ParseFile(configPath, content, parser.ParseComments)
+	if err != nil {
+		return &oerrors.DetailError{
+			Type:     configErrType,
+			Message:  err.Error(),
+			Location: configPath,
+			Hint:     "Run 'opm config vet' to check for configuration errors",
+			Cause:    oerrors.ErrValidation,
+		}
+	}
+
+	configStruct := topLevelStruct(file, "config")
+	if configStruct == nil {
+		configStruct = &ast.StructLit{}
+		file.Decls = append(file.Decls, &ast.Field{Label: ast.NewIdent("config"), Value: configStruct})
+	}
+	if err := setStructField(configStruct, strings.Split(key, "."), lit); err != nil {
+		return fmt.Errorf("setting %s: %w", key, err)
+	}
+
+	updated, err := format.Node(file)
+	if err != nil {
+		return fmt.Errorf("formatting config file: %w", err)
+	}
+	ctx := cuecontext.New()
+	compiled := ctx.CompileBytes(updated, cue.Filename(configPath))
+	if compiled.Err() != nil {
+		return fmt.Errorf("setting %s: %w", key, compiled.Err())
+	}
+	if err := validateConfigSchema(ctx, compiled, configPath); err != nil {
+		return err
+	}
+	if err := os.WriteFile(configPath, updated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	return nil
+}
+
+func settableSpec(key string) (settingSpec, bool) {
+	for _, s := range settingSpecs {
+		if s.key == key && s.settable {
+			return s, true
+		}
+	}
+	return settingSpec{}, false
+}
+
+// settingLiteral parses value as the CUE literal spec's kind takes.
+func settingLiteral(spec settingSpec, value string) (ast.Expr, error) {
+	if spec.kind == cue.BoolKind {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s takes true or false, not %q", spec.key, value)
+		}
+		return ast.NewBool(b), nil
+	}
+	return ast.NewString(value), nil
+}
+
+// topLevelStruct returns the struct value of the file's first top-level field
+// named name, or nil.
+func topLevelStruct(file *ast.File, name string) *ast.StructLit {
+	for _, decl := range file.Decls {
+		if f, ok := decl.(*ast.Field); ok && fieldName(f) == name {
+			if st, ok := f.Value.(*ast.StructLit); ok {
+				return st
+			}
+		}
+	}
+	return nil
+}
+
+// setStructField sets the field at path below st to value, creating the
+// missing structs on the way. An optional field (context?: string) becomes a
+// regular one.
+func setStructField(st *ast.StructLit, path []string, value ast.Expr) error {
+	for _, elt := range st.Elts {
+		f, ok := elt.(*ast.Field)
+		if !ok || fieldName(f) != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			f.Value = value
+			f.Constraint = token.ILLEGAL
+			return nil
+		}
+		inner, ok := f.Value.(*ast.StructLit)
+		if !ok {
+			return fmt.Errorf("%s is not a struct literal in the config file", path[0])
+		}
+		return setStructField(inner, path[1:], value)
+	}
+
+	for i := len(path) - 1; i > 0; i-- {
+		value = &ast.StructLit{Elts: []ast.Decl{&ast.Field{Label: ast.NewIdent(path[i]), Value: value}}}
+	}
+	st.Elts = append(st.Elts, &ast.Field{Label: ast.NewIdent(path[0]), Value: value})
+	return nil
+}
+
+func fieldName(f *ast.Field) string {
+	name, _, err := ast.LabelName(f.Label)
+	if err != nil {
+		return ""
+	}
+	return name
+}
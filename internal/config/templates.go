@@ -33,6 +33,13 @@ config: {
 		// namespace is the default namespace for operations.
 		// Override with --namespace flag or OPM_NAMESPACE env var.
 		namespace: "default"
+
+		// readyConditions overrides, per kind, the status condition types
+		// that report readiness for custom resources in instance status.
+		// Default: Ready, then Available. Keys may be group-qualified.
+		// readyConditions: {
+		// 	"Cluster.postgresql.cnpg.io": ["Ready"]
+		// }
 	}
 
 	// log controls logging behavior.
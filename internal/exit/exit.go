@@ -2,14 +2,24 @@ package exit
 
 import "fmt"
 
-// Exit codes per contracts/exit-codes.md.
+// Exit codes. They are a stable contract, documented in the README: scripts
+// branch on them, so a code is never renumbered or reused.
 const (
-	ExitSuccess           = 0
-	ExitGeneralError      = 1
-	ExitValidationError   = 2
+	ExitSuccess = 0
+	// ExitGeneralError is any failure without a more specific code,
+	// including a partial failure of an apply or delete.
+	ExitGeneralError = 1
+	// ExitValidationError is an invalid module, instance, or values. It is
+	// also the drift result of diff --exit-code, which collapses every other
+	// error to ExitGeneralError.
+	ExitValidationError = 2
+	// ExitConnectivityError is a cluster that could not be reached or timed
+	// out.
 	ExitConnectivityError = 3
-	ExitPermissionDenied  = 4
-	ExitNotFound          = 5
+	// ExitPermissionDenied is a request the cluster or a registry refused.
+	ExitPermissionDenied = 4
+	// ExitNotFound is a missing instance or tracked resource.
+	ExitNotFound = 5
 )
 
 // ExitError wraps an error with an exit code.
@@ -0,0 +1,84 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// DefaultStatusWriteAttempts is how many times UpdateStatus writes before it
+// gives up on an instance another process keeps changing.
+const DefaultStatusWriteAttempts = 5
+
+// ConcurrentUpdateError reports an inventory write that lost every attempt to
+// a concurrent writer of the same ModuleInstance.
+type ConcurrentUpdateError struct {
+	Name      string
+	Namespace string
+	Attempts  int
+}
+
+func (e *ConcurrentUpdateError) Error() string {
+	return fmt.Sprintf("ModuleInstance %s/%s is being modified by another process: the inventory write conflicted %d times in a row; "+
+		"retry once the other apply has finished", e.Namespace, e.Name, e.Attempts)
+}
+
+// UpdateStatus is ApplyStatus with optimistic concurrency, for writers that
+// planned in.Inventory against base — the inventory they read before
+// applying. Each attempt re-reads the CR. When another writer has moved the
+// inventory past base.Revision since, the entries it added that in does not
+// track are merged in — they are live resources this write neither applied
+// nor pruned, and dropping them would orphan them — and the revision is
+// advanced past theirs. The write carries the re-read resourceVersion, so a
+// writer landing in between turns it into a Conflict and another attempt.
+// maxAttempts <= 0 means DefaultStatusWriteAttempts.
+func UpdateStatus(ctx context.Context, client *kubernetes.Client, in StatusInput, base Inventory, maxAttempts int) error {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultStatusWriteAttempts
+	}
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		cur, err := GetRecord(ctx, client, in.Name, in.Namespace)
+		if err != nil {
+			return err
+		}
+		write := in
+		if cur != nil {
+			write.ResourceVersion = cur.ResourceVersion
+			if cur.Inventory.Revision > base.Revision {
+				write.Inventory = mergeConcurrentInventory(in.Inventory, base, cur.Inventory)
+				output.Debug("inventory changed since it was read; merged the concurrent write",
+					"name", in.Name, "read", base.Revision, "current", cur.Inventory.Revision)
+			}
+		}
+		err = ApplyStatus(ctx, client, write)
+		if err == nil || !apierrors.IsConflict(err) {
+			return err
+		}
+		output.Debug("inventory write conflicted; retrying", "name", in.Name, "attempt", attempt)
+	}
+	return &ConcurrentUpdateError{Name: in.Name, Namespace: in.Namespace, Attempts: maxAttempts}
+}
+
+// mergeConcurrentInventory returns ours with the entries concurrent gained
+// over base appended, at the revision after concurrent's.
+func mergeConcurrentInventory(ours, base, concurrent Inventory) Inventory {
+	entries := append([]InventoryEntry(nil), ours.Entries...)
+	for _, e := range concurrent.Entries {
+		if slices.ContainsFunc(base.Entries, func(x InventoryEntry) bool { return IdentityEqual(x, e) }) ||
+			slices.ContainsFunc(entries, func(x InventoryEntry) bool { return IdentityEqual(x, e) }) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return Inventory{
+		Revision: concurrent.Revision + 1,
+		Digest:   ComputeDigest(entries),
+		Count:    len(entries),
+		Entries:  entries,
+	}
+}
@@ -0,0 +1,161 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+)
+
+// newStatusServerClient serves one ModuleInstance with the API server's
+// resourceVersion semantics for status writes: every write bumps the
+// resourceVersion, and a write carrying a stale one is a Conflict. conflict,
+// when set, may force a Conflict on a write whose precondition holds — the
+// stand-in for a writer landing between the read and the write.
+func newStatusServerClient(t *testing.T, inv Inventory, conflict func(cr *unstructured.Unstructured) bool) *kubernetes.Client {
+	t.Helper()
+
+	cr := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": APIVersionModuleInstance,
+		"kind":       KindModuleInstance,
+		"metadata":   map[string]any{"name": "podinfo", "namespace": "demo", "resourceVersion": "1"},
+		"status":     map[string]any{"inventory": inventoryToWire(inv)},
+	}}
+	bump := func() {
+		rv, _ := strconv.Atoi(cr.GetResourceVersion())
+		cr.SetResourceVersion(strconv.Itoa(rv + 1))
+	}
+
+	client := newDynamicClient()
+	fake, ok := client.Dynamic.(*dynamicfake.FakeDynamicClient)
+	require.True(t, ok, "expected a fake dynamic client")
+
+	fake.PrependReactor("get", ResourceModuleInstances, func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, cr.DeepCopy(), nil
+	})
+	fake.PrependReactor("patch", ResourceModuleInstances, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(k8stesting.PatchAction)
+		require.True(t, ok)
+		body := map[string]any{}
+		require.NoError(t, json.Unmarshal(patch.GetPatch(), &body))
+		applied := &unstructured.Unstructured{Object: body}
+
+		if rv := applied.GetResourceVersion(); rv != "" && rv != cr.GetResourceVersion() || conflict != nil && conflict(cr) {
+			return true, nil, apierrors.NewConflict(ModuleInstanceGVR.GroupResource(), patch.GetName(),
+				fmt.Errorf("the object has been modified"))
+		}
+		cr.Object["status"] = body["status"]
+		bump()
+		return true, cr.DeepCopy(), nil
+	})
+	return client
+}
+
+func testEntry(name string) InventoryEntry {
+	return InventoryEntry{Group: "apps", Kind: "Deployment", Namespace: "demo", Name: name, Version: "v1"}
+}
+
+// planned is the inventory a writer that read base and applied extra would
+// record.
+func planned(base Inventory, extra ...InventoryEntry) Inventory {
+	entries := append(append([]InventoryEntry(nil), base.Entries...), extra...)
+	return Inventory{Revision: base.Revision + 1, Digest: ComputeDigest(entries), Count: len(entries), Entries: entries}
+}
+
+func TestUpdateStatus_ConcurrentWritersKeepEachOthersEntries(t *testing.T) {
+	base := Inventory{Revision: 1, Entries: []InventoryEntry{testEntry("shared")}}
+	client := newStatusServerClient(t, base, nil)
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+	for i := range writers {
+		wg.Go(func() {
+			in := StatusInput{Name: "podinfo", Namespace: "demo", Inventory: planned(base, testEntry(fmt.Sprintf("w%d", i)))}
+			errs[i] = UpdateStatus(context.Background(), client, in, base, writers)
+		})
+	}
+	wg.Wait()
+	for i, err := range errs {
+		require.NoError(t, err, "writer %d", i)
+	}
+
+	rec, err := GetRecord(context.Background(), client, "podinfo", "demo")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, 1+writers, rec.Inventory.Revision, "every write advances the revision once")
+	assert.Len(t, rec.Inventory.Entries, 1+writers, "no writer's entry was lost")
+	for i := range writers {
+		assert.Contains(t, rec.Inventory.Entries, testEntry(fmt.Sprintf("w%d", i)))
+	}
+	assert.Equal(t, ComputeDigest(rec.Inventory.Entries), rec.Inventory.Digest)
+}
+
+// A writer landing between the re-read and the write is a Conflict; the retry
+// re-reads, merges what that writer added, and lands.
+func TestUpdateStatus_ConflictRereadsAndMerges(t *testing.T) {
+	base := Inventory{Revision: 1, Entries: []InventoryEntry{testEntry("shared")}}
+	landed := false
+	client := newStatusServerClient(t, base, func(cr *unstructured.Unstructured) bool {
+		if landed {
+			return false
+		}
+		landed = true
+		cr.Object["status"] = map[string]any{"inventory": inventoryToWire(planned(base, testEntry("other")))}
+		rv, _ := strconv.Atoi(cr.GetResourceVersion())
+		cr.SetResourceVersion(strconv.Itoa(rv + 1))
+		return true
+	})
+
+	in := StatusInput{Name: "podinfo", Namespace: "demo", Inventory: planned(base, testEntry("ours"))}
+	require.NoError(t, UpdateStatus(context.Background(), client, in, base, 0))
+
+	rec, err := GetRecord(context.Background(), client, "podinfo", "demo")
+	require.NoError(t, err)
+	assert.Equal(t, 3, rec.Inventory.Revision)
+	assert.ElementsMatch(t, []InventoryEntry{testEntry("shared"), testEntry("ours"), testEntry("other")}, rec.Inventory.Entries)
+}
+
+func TestUpdateStatus_GivesUpAfterMaxAttempts(t *testing.T) {
+	base := Inventory{Revision: 1}
+	attempts := 0
+	client := newStatusServerClient(t, base, func(*unstructured.Unstructured) bool {
+		attempts++
+		return true
+	})
+
+	in := StatusInput{Name: "podinfo", Namespace: "demo", Inventory: planned(base, testEntry("ours"))}
+	err := UpdateStatus(context.Background(), client, in, base, 3)
+
+	var concurrent *ConcurrentUpdateError
+	require.ErrorAs(t, err, &concurrent)
+	assert.Equal(t, 3, concurrent.Attempts)
+	assert.Equal(t, 3, attempts)
+	assert.Contains(t, err.Error(), "demo/podinfo is being modified by another process")
+}
+
+// Entries the writer pruned (present in base, absent from ours) stay pruned
+// even when a concurrent writer still lists them.
+func TestMergeConcurrentInventory_KeepsOurPrunes(t *testing.T) {
+	base := Inventory{Revision: 1, Entries: []InventoryEntry{testEntry("kept"), testEntry("pruned")}}
+	ours := planned(Inventory{Revision: 1, Entries: []InventoryEntry{testEntry("kept")}})
+	concurrent := planned(base, testEntry("theirs"))
+
+	got := mergeConcurrentInventory(ours, base, concurrent)
+	assert.Equal(t, 3, got.Revision)
+	assert.Equal(t, []InventoryEntry{testEntry("kept"), testEntry("theirs")}, got.Entries)
+	assert.Equal(t, 2, got.Count)
+	assert.Equal(t, ComputeDigest(got.Entries), got.Digest)
+}
@@ -58,6 +58,13 @@ const (
 	AnnotationSource = "module-instance.opmodel.dev/source"
 	// SourceLocal is the AnnotationSource value stamped for local renders.
 	SourceLocal = "local"
+	// AnnotationModuleDigest records the OCI manifest digest the last apply's
+	// module was verified against (--module-digest). Absent when the apply
+	// was not digest-pinned.
+	AnnotationModuleDigest = "module-instance.opmodel.dev/module-digest"
+	// AnnotationLastOperation records the operation ID of the last apply
+	// that wrote the CR, for correlating it with that run's logs and events.
+	AnnotationLastOperation = "module-instance.opmodel.dev/last-operation"
 )
 
 // LabelInstanceUUID is the label the render stamps on every resource carrying
@@ -2,14 +2,20 @@ package inventory
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 
 	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 )
 
 // DiscoverResourcesFromInventory fetches the live state of each resource
@@ -52,3 +58,152 @@ func DiscoverResourcesFromInventory(ctx context.Context, client *kubernetes.Clie
 
 	return live, missing, nil
 }
+
+// InstanceLabelSelector returns the label selector matching resources stamped
+// with an instance's identity labels.
+func InstanceLabelSelector(name, namespace string) string {
+	return labels.SelectorFromSet(labels.Set{
+		pkgcore.LabelModuleInstanceName:      name,
+		pkgcore.LabelModuleInstanceNamespace: namespace,
+	}).String()
+}
+
+// InstanceUUIDLabelSelector returns the label selector matching resources
+// stamped with an instance's identity UUID.
+func InstanceUUIDLabelSelector(uuid string) string {
+	return labels.SelectorFromSet(labels.Set{pkgcore.LabelModuleInstanceUUID: uuid}).String()
+}
+
+// labelScanSkipped lists the types a render never produces but that carry an
+// instance's labels once controllers copy them down from rendered objects.
+var labelScanSkipped = map[schema.GroupResource]bool{
+	{Group: "discovery.k8s.io", Resource: "endpointslices"}: true,
+	{Resource: "endpoints"}:                                 true,
+	{Resource: "pods"}:                                      true,
+	{Group: "apps", Resource: "replicasets"}:                true,
+	{Resource: "events"}:                                    true,
+	{Group: "events.k8s.io", Resource: "events"}:            true,
+}
+
+// DiscoverResourcesByLabels finds an instance's resources without an
+// inventory, by listing every listable API type with the label selector —
+// the --no-inventory fallback. Namespaced types are listed in namespace only;
+// cluster-scoped types cluster-wide. This costs one LIST per API type and
+// misses anything whose identity labels were removed, or that lives in
+// another namespace, which is why the inventory is the default.
+//
+// Types the caller may not list are skipped. OPM's own CRs (ModuleInstance,
+// Platform) carry the same labels and are never returned. Neither are objects
+// a controller owns, nor the controller-created types in labelScanSkipped:
+// EndpointSlices copy their Service's labels and Pods inherit the pod
+// template's, so treating them as instance resources would prune or delete
+// them on every stateless run.
+func DiscoverResourcesByLabels(ctx context.Context, client *kubernetes.Client, selector, namespace string) ([]*unstructured.Unstructured, error) {
+	lists, err := discovery.ServerPreferredResources(client.Clientset.Discovery())
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("discovering API resources: %w", err)
+	}
+
+	var found []*unstructured.Unstructured
+	for _, list := range lists {
+		gv, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			gvr := gv.WithResource(r.Name)
+			if gvr.GroupResource() == ModuleInstanceGVR.GroupResource() || gvr.GroupResource() == PlatformGVR.GroupResource() {
+				continue
+			}
+			if labelScanSkipped[gvr.GroupResource()] {
+				continue
+			}
+
+			ns := ""
+			if r.Namespaced {
+				ns = namespace
+			}
+			items, listErr := client.ResourceClient(gvr, ns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if listErr != nil {
+				if apierrors.IsForbidden(listErr) || apierrors.IsNotFound(listErr) || apierrors.IsMethodNotSupported(listErr) {
+					output.Debug("skipping unlistable type in label scan", "resource", gvr.String(), "err", listErr)
+					continue
+				}
+				return nil, fmt.Errorf("listing %s: %w", gvr.Resource, listErr)
+			}
+			for i := range items.Items {
+				obj := &items.Items[i]
+				if metav1.GetControllerOf(obj) != nil {
+					continue
+				}
+				obj.SetGroupVersionKind(gv.WithKind(r.Kind))
+				found = append(found, obj)
+			}
+		}
+	}
+	return found, nil
+}
+
+// ReadMode is how a command looking up an instance's resources treats its
+// inventory (--inventory).
+type ReadMode string
+
+const (
+	// ReadRequire fails on an unreadable inventory.
+	ReadRequire ReadMode = "require"
+	// ReadPrefer uses the inventory, falling back to a label scan, with a
+	// warning, when it cannot be read. The default.
+	ReadPrefer ReadMode = "prefer"
+	// ReadIgnore always finds the resources by label scan.
+	ReadIgnore ReadMode = "ignore"
+)
+
+// ParseReadMode parses an --inventory value.
+func ParseReadMode(s string) (ReadMode, error) {
+	switch m := ReadMode(s); m {
+	case ReadRequire, ReadPrefer, ReadIgnore:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid --inventory %q: must be %s, %s, or %s", s, ReadRequire, ReadPrefer, ReadIgnore)
+	}
+}
+
+// UnreadableInventoryError reports an inventory ReadRequire refused.
+type UnreadableInventoryError struct {
+	Name      string
+	Namespace string
+	Problem   string
+}
+
+func (e *UnreadableInventoryError) Error() string {
+	return fmt.Sprintf("the inventory of ModuleInstance %s/%s cannot be read: %s; "+
+		"re-run with --inventory=prefer to find the instance's resources by label scan", e.Namespace, e.Name, e.Problem)
+}
+
+// FindsByLabels reports whether DiscoverResources finds rec's resources by
+// label scan rather than from its inventory, when it does not fail.
+func FindsByLabels(rec *Record, mode ReadMode) bool {
+	return mode == ReadIgnore || rec.InventoryProblem != ""
+}
+
+// DiscoverResources finds the live resources of rec's instance as mode
+// directs: from the inventory, as DiscoverResourcesFromInventory does, or
+// by a scan of the instance's identity labels (DiscoverResourcesByLabels).
+// A label scan reports no missing entries.
+func DiscoverResources(ctx context.Context, client *kubernetes.Client, rec *Record, mode ReadMode) (live []*unstructured.Unstructured, missing []InventoryEntry, err error) {
+	switch {
+	case mode == ReadIgnore:
+	case rec.InventoryProblem == "":
+		return DiscoverResourcesFromInventory(ctx, client, rec)
+	case mode == ReadRequire:
+		return nil, nil, &UnreadableInventoryError{Name: rec.Name, Namespace: rec.Namespace, Problem: rec.InventoryProblem}
+	default:
+		output.Warn("inventory cannot be read; finding resources by label scan instead",
+			"name", rec.Name, "namespace", rec.Namespace, "problem", rec.InventoryProblem)
+	}
+	live, err = DiscoverResourcesByLabels(ctx, client, InstanceLabelSelector(rec.Name, rec.Namespace), rec.Namespace)
+	return live, nil, err
+}
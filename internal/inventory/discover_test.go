@@ -0,0 +1,167 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func labeledObject(apiVersion, kind, namespace, name, instance string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name},
+	}}
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	if instance != "" {
+		obj.SetLabels(map[string]string{
+			pkgcore.LabelModuleInstanceName:      instance,
+			pkgcore.LabelModuleInstanceNamespace: "media",
+		})
+	}
+	return obj
+}
+
+// newLabelScanClient builds a client whose discovery serves ConfigMaps,
+// Namespaces, EndpointSlices, and ModuleInstances, and whose dynamic client holds objs.
+func newLabelScanClient(objs ...*unstructured.Unstructured) *kubernetes.Client {
+	runtimeObjs := make([]runtime.Object, len(objs))
+	for i, o := range objs {
+		runtimeObjs[i] = o
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "configmaps"}:                                "ConfigMapList",
+			{Version: "v1", Resource: "namespaces"}:                                "NamespaceList",
+			{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}: "EndpointSliceList",
+			ModuleInstanceGVR: "ModuleInstanceList",
+		}, runtimeObjs...)
+
+	list := []string{"get", "list"}
+	cs := k8sfake.NewClientset()
+	cs.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: list},
+			{Name: "namespaces", Kind: "Namespace", Verbs: list},
+			{Name: "namespaces/status", Kind: "Namespace", Verbs: list},
+			{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: []string{"create"}},
+		}},
+		{GroupVersion: "discovery.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true, Verbs: list},
+		}},
+		{GroupVersion: APIVersionModuleInstance, APIResources: []metav1.APIResource{
+			{Name: ResourceModuleInstances, Kind: KindModuleInstance, Namespaced: true, Verbs: list},
+		}},
+	}
+	return &kubernetes.Client{Dynamic: dyn, Clientset: cs}
+}
+
+func TestInstanceLabelSelector(t *testing.T) {
+	assert.Equal(t,
+		pkgcore.LabelModuleInstanceName+"=jellyfin,"+pkgcore.LabelModuleInstanceNamespace+"=media",
+		InstanceLabelSelector("jellyfin", "media"))
+	assert.Equal(t, pkgcore.LabelModuleInstanceUUID+"=uuid-1", InstanceUUIDLabelSelector("uuid-1"))
+}
+
+func TestDiscoverResourcesByLabels(t *testing.T) {
+	client := newLabelScanClient(
+		labeledObject("v1", "ConfigMap", "media", "web-config", "jellyfin"),
+		labeledObject("v1", "ConfigMap", "media", "other-config", "radarr"),
+		labeledObject("v1", "ConfigMap", "elsewhere", "stray-config", "jellyfin"),
+		labeledObject("v1", "ConfigMap", "media", "unlabeled", ""),
+		labeledObject("v1", "Namespace", "", "media", "jellyfin"),
+		labeledObject(APIVersionModuleInstance, KindModuleInstance, "media", "jellyfin", "jellyfin"),
+	)
+
+	found, err := DiscoverResourcesByLabels(context.Background(), client, InstanceLabelSelector("jellyfin", "media"), "media")
+	require.NoError(t, err)
+
+	var got []string
+	for _, obj := range found {
+		got = append(got, obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName())
+	}
+	assert.ElementsMatch(t, []string{
+		"ConfigMap/media/web-config",
+		"Namespace//media",
+	}, got, "only labeled resources in the namespace or cluster scope; the ModuleInstance itself is skipped")
+}
+
+func TestDiscoverResourcesByLabels_SkipsControllerOwned(t *testing.T) {
+	controller := true
+	owner := []metav1.OwnerReference{{APIVersion: "v1", Kind: "Service", Name: "web", UID: "svc-uid", Controller: &controller}}
+
+	slice := labeledObject("discovery.k8s.io/v1", "EndpointSlice", "media", "web-abc12", "jellyfin")
+	slice.SetOwnerReferences(owner)
+	ownedConfig := labeledObject("v1", "ConfigMap", "media", "generated-config", "jellyfin")
+	ownedConfig.SetOwnerReferences(owner)
+
+	client := newLabelScanClient(
+		labeledObject("v1", "ConfigMap", "media", "web-config", "jellyfin"),
+		slice,
+		ownedConfig,
+	)
+
+	found, err := DiscoverResourcesByLabels(context.Background(), client, InstanceLabelSelector("jellyfin", "media"), "media")
+	require.NoError(t, err)
+
+	var got []string
+	for _, obj := range found {
+		got = append(got, obj.GetKind()+"/"+obj.GetName())
+	}
+	assert.Equal(t, []string{"ConfigMap/web-config"}, got,
+		"the labelled EndpointSlice and any controller-owned object are not instance resources")
+}
+
+func TestDiscoverResources_ReadModes(t *testing.T) {
+	ctx := context.Background()
+	client := newLabelScanClient(labeledObject("v1", "ConfigMap", "media", "web-config", "jellyfin"))
+	tracked := &Record{Name: "jellyfin", Namespace: "media", Inventory: Inventory{Entries: []InventoryEntry{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "media", Name: "gone"},
+	}}}
+	broken := &Record{Name: "jellyfin", Namespace: "media", InventoryProblem: "status.inventory is a string, not an object"}
+
+	live, missing, err := DiscoverResources(ctx, client, tracked, ReadPrefer)
+	require.NoError(t, err)
+	assert.Empty(t, live)
+	assert.Len(t, missing, 1, "a readable inventory is used as is")
+
+	live, missing, err = DiscoverResources(ctx, client, broken, ReadPrefer)
+	require.NoError(t, err)
+	require.Len(t, live, 1, "an unreadable inventory falls back to the label scan")
+	assert.Equal(t, "web-config", live[0].GetName())
+	assert.Empty(t, missing)
+
+	_, _, err = DiscoverResources(ctx, client, broken, ReadRequire)
+	var unreadable *UnreadableInventoryError
+	require.ErrorAs(t, err, &unreadable)
+	assert.Contains(t, err.Error(), "status.inventory is a string")
+	assert.Contains(t, err.Error(), "--inventory=prefer")
+
+	live, missing, err = DiscoverResources(ctx, client, tracked, ReadIgnore)
+	require.NoError(t, err)
+	require.Len(t, live, 1, "ignore label-scans even a readable inventory")
+	assert.Empty(t, missing)
+}
+
+func TestParseReadMode(t *testing.T) {
+	for _, s := range []string{"require", "prefer", "ignore"} {
+		mode, err := ParseReadMode(s)
+		require.NoError(t, err)
+		assert.Equal(t, ReadMode(s), mode)
+	}
+	_, err := ParseReadMode("optional")
+	assert.ErrorContains(t, err, `invalid --inventory "optional"`)
+}
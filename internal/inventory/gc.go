@@ -0,0 +1,84 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+)
+
+// GCOutcome is GarbageCollect's verdict on one ModuleInstance CR.
+type GCOutcome struct {
+	Name      string
+	Namespace string
+
+	// Entries is the number of resources the inventory tracks.
+	Entries int
+
+	// Orphaned is set when every tracked resource is gone from the cluster.
+	Orphaned bool
+
+	// Deleted is set when the orphaned CR was removed (never under dry-run).
+	Deleted bool
+
+	// Reason explains why a CR was kept.
+	Reason string
+}
+
+// GarbageCollect finds ModuleInstance CRs left behind by failed or
+// interrupted applies — records whose tracked resources no longer exist — and,
+// unless dryRun is set, deletes them. Pass "" as namespace to scan the whole
+// cluster.
+//
+// A CR is only ever collected when it is CLI-owned and every one of its
+// inventory entries is confirmed NotFound. A CR with any live entry, any
+// entry that could not be read, an inventory that is malformed, or an empty
+// inventory is kept: none of those prove the instance is gone. Operator-owned CRs are left to the operator.
+func GarbageCollect(ctx context.Context, client *kubernetes.Client, namespace string, dryRun bool) ([]GCOutcome, error) {
+	records, err := ListRecords(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]GCOutcome, 0, len(records))
+	for _, rec := range records {
+		o := GCOutcome{Name: rec.Name, Namespace: rec.Namespace, Entries: len(rec.Inventory.Entries)}
+		o.Orphaned, o.Reason = orphaned(ctx, client, rec)
+		if o.Orphaned && !dryRun {
+			if err := DeleteCR(ctx, client, rec.Name, rec.Namespace); err != nil {
+				return outcomes, err
+			}
+			o.Deleted = true
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes, nil
+}
+
+// orphaned reports whether every resource rec tracks is gone, or why rec
+// must be kept.
+func orphaned(ctx context.Context, client *kubernetes.Client, rec *Record) (bool, string) {
+	if ResolveOwnership(rec) == ModeOperatorOwned {
+		return false, "operator-owned"
+	}
+	if rec.InventoryProblem != "" {
+		// Malformed entries are dropped on read, so the entries left say
+		// nothing about the resources the instance really owns.
+		return false, "inventory cannot be read: " + rec.InventoryProblem
+	}
+	total := len(rec.Inventory.Entries)
+	if total == 0 {
+		return false, "inventory is empty"
+	}
+	live, missing, err := DiscoverResourcesFromInventory(ctx, client, rec)
+	if err != nil {
+		return false, err.Error()
+	}
+	if len(live) > 0 {
+		return false, fmt.Sprintf("%d of %d resources live", len(live), total)
+	}
+	if unread := total - len(missing); unread > 0 {
+		return false, fmt.Sprintf("%d of %d resources could not be read", unread, total)
+	}
+	return true, ""
+}
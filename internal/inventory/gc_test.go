@@ -0,0 +1,114 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkginventory "github.com/open-platform-model/cli/pkg/inventory"
+)
+
+// trackingInstanceObj is a CLI-owned ModuleInstance whose inventory tracks the
+// named ConfigMaps in namespace demo.
+func trackingInstanceObj(name string, configMaps ...string) *unstructured.Unstructured {
+	obj := moduleInstanceObj(name, "uuid-"+name)
+	inv := pkginventory.Inventory{Revision: 1, Count: len(configMaps)}
+	for _, cm := range configMaps {
+		inv.Entries = append(inv.Entries, pkginventory.InventoryEntry{Kind: "ConfigMap", Version: "v1", Namespace: "demo", Name: cm})
+	}
+	obj.Object["status"].(map[string]any)["inventory"] = inventoryToWire(inv)
+	return obj
+}
+
+func configMapObj(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": name, "namespace": "demo"},
+	}}
+}
+
+func gcFixture() []*unstructured.Unstructured {
+	operatorOwned := trackingInstanceObj("operated", "gone-op")
+	operatorOwned.Object["spec"] = map[string]any{"owner": OwnerOperator}
+	return []*unstructured.Unstructured{
+		trackingInstanceObj("orphan", "gone-a", "gone-b"),
+		trackingInstanceObj("partial", "gone-c", "live-a"),
+		trackingInstanceObj("empty"),
+		operatorOwned,
+		configMapObj("live-a"),
+	}
+}
+
+func TestGarbageCollect_DryRun(t *testing.T) {
+	ctx := context.Background()
+	client := newDynamicClient(gcFixture()...)
+
+	outcomes, err := GarbageCollect(ctx, client, "demo", true)
+	require.NoError(t, err)
+
+	byName := map[string]GCOutcome{}
+	for _, o := range outcomes {
+		byName[o.Name] = o
+	}
+	require.Len(t, byName, 4)
+
+	assert.True(t, byName["orphan"].Orphaned)
+	assert.False(t, byName["orphan"].Deleted, "dry-run deletes nothing")
+	assert.Equal(t, 2, byName["orphan"].Entries)
+
+	assert.False(t, byName["partial"].Orphaned, "a partially live instance is never collected")
+	assert.Equal(t, "1 of 2 resources live", byName["partial"].Reason)
+	assert.Equal(t, "inventory is empty", byName["empty"].Reason)
+	assert.Equal(t, "operator-owned", byName["operated"].Reason)
+
+	rec, err := GetRecord(ctx, client, "orphan", "demo")
+	require.NoError(t, err)
+	assert.NotNil(t, rec)
+}
+
+func TestGarbageCollect_DeletesOnlyOrphans(t *testing.T) {
+	ctx := context.Background()
+	client := newDynamicClient(gcFixture()...)
+
+	outcomes, err := GarbageCollect(ctx, client, "demo", false)
+	require.NoError(t, err)
+
+	var deleted []string
+	for _, o := range outcomes {
+		if o.Deleted {
+			deleted = append(deleted, o.Name)
+		}
+	}
+	assert.Equal(t, []string{"orphan"}, deleted)
+
+	records, err := ListRecords(ctx, client, "demo")
+	require.NoError(t, err)
+	var remaining []string
+	for _, r := range records {
+		remaining = append(remaining, r.Name)
+	}
+	assert.Equal(t, []string{"empty", "operated", "partial"}, remaining)
+}
+
+func TestGarbageCollect_KeepsUnreadableInventory(t *testing.T) {
+	ctx := context.Background()
+	malformed := trackingInstanceObj("malformed", "gone-a")
+	inv := malformed.Object["status"].(map[string]any)["inventory"].(map[string]any)
+	inv["entries"] = append(inv["entries"].([]any), map[string]any{"kind": "ConfigMap"})
+	client := newDynamicClient(malformed)
+
+	outcomes, err := GarbageCollect(ctx, client, "demo", false)
+	require.NoError(t, err)
+	require.Len(t, outcomes, 1)
+	assert.False(t, outcomes[0].Orphaned, "the entry that was dropped on read may still be live")
+	assert.False(t, outcomes[0].Deleted)
+	assert.Equal(t, "inventory cannot be read: status.inventory.entries[1] has no kind or name", outcomes[0].Reason)
+
+	rec, err := GetRecord(ctx, client, "malformed", "demo")
+	require.NoError(t, err)
+	assert.NotNil(t, rec)
+}
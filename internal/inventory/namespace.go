@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+)
+
+// CreatedNamespaceEntry is the inventory entry recording that an apply with
+// --create-namespace created the instance's namespace. It carries no
+// component, which tells it apart from a Namespace the render itself contains.
+func CreatedNamespaceEntry(name string) InventoryEntry {
+	return InventoryEntry{Kind: "Namespace", Version: "v1", Name: name}
+}
+
+// TracksCreatedNamespace reports whether entries record namespace name as
+// created by OPM.
+func TracksCreatedNamespace(entries []InventoryEntry, name string) bool {
+	want := CreatedNamespaceEntry(name)
+	return slices.ContainsFunc(entries, func(e InventoryEntry) bool { return IdentityEqual(e, want) })
+}
+
+// DeleteCreatedNamespace deletes a namespace OPM created for an instance,
+// after the instance's own ModuleInstance has been removed from it. Deleting a
+// namespace deletes everything in it, so the namespace is kept while any other
+// ModuleInstance remains there; the number of those is returned, with nothing
+// deleted. A namespace already gone is not an error.
+func DeleteCreatedNamespace(ctx context.Context, client *kubernetes.Client, name string) (int, error) {
+	others, err := ListRecords(ctx, client, name)
+	if err != nil {
+		return 0, fmt.Errorf("checking namespace %q for other instances: %w", name, err)
+	}
+	if len(others) > 0 {
+		return len(others), nil
+	}
+	err = client.Clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("deleting namespace %q: %w", name, err)
+	}
+	return 0, nil
+}
@@ -0,0 +1,56 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTracksCreatedNamespace(t *testing.T) {
+	entries := []InventoryEntry{{Kind: "ConfigMap", Version: "v1", Namespace: "demo", Name: "web"}}
+	assert.False(t, TracksCreatedNamespace(entries, "demo"))
+
+	rendered := append(entries, InventoryEntry{Kind: "Namespace", Version: "v1", Name: "demo", Component: "web"})
+	assert.False(t, TracksCreatedNamespace(rendered, "demo"), "a rendered Namespace was not created by --create-namespace")
+
+	assert.True(t, TracksCreatedNamespace(append(entries, CreatedNamespaceEntry("demo")), "demo"))
+}
+
+func TestDeleteCreatedNamespace(t *testing.T) {
+	ctx := context.Background()
+	namespace := func() *k8sfake.Clientset {
+		return k8sfake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo"}})
+	}
+
+	t.Run("keeps a namespace other instances live in", func(t *testing.T) {
+		client := newDynamicClient(moduleInstanceObj("other", "uuid-2"))
+		cs := namespace()
+		client.Clientset = cs
+
+		others, err := DeleteCreatedNamespace(ctx, client, "demo")
+		require.NoError(t, err)
+		assert.Equal(t, 1, others)
+		_, err = cs.CoreV1().Namespaces().Get(ctx, "demo", metav1.GetOptions{})
+		require.NoError(t, err, "the namespace must survive")
+	})
+
+	t.Run("deletes an empty namespace", func(t *testing.T) {
+		client := newDynamicClient()
+		cs := namespace()
+		client.Clientset = cs
+
+		others, err := DeleteCreatedNamespace(ctx, client, "demo")
+		require.NoError(t, err)
+		assert.Zero(t, others)
+		_, err = cs.CoreV1().Namespaces().Get(ctx, "demo", metav1.GetOptions{})
+		require.Error(t, err)
+
+		_, err = DeleteCreatedNamespace(ctx, client, "demo")
+		require.NoError(t, err, "an already deleted namespace is not an error")
+	})
+}
@@ -168,3 +168,36 @@ func TestApplySpec_ProvenanceAnnotation(t *testing.T) {
 	metadata, _ = rec.body["metadata"].(map[string]any)
 	assert.NotContains(t, metadata, "annotations", "a registry render must clear the annotation")
 }
+
+// The verified module digest is stamped only when the apply was pinned.
+func TestApplySpec_ModuleDigestAnnotation(t *testing.T) {
+	client, rec := newApplyPatchClient(t, 1)
+	_, err := ApplySpec(context.Background(), client, SpecInput{
+		Name: "podinfo", Namespace: "demo", Owner: OwnerCLI,
+		ModulePath: "p", ModuleVersion: "v", ModuleDigest: "sha256:abc",
+	})
+	require.NoError(t, err)
+
+	metadata, ok := rec.body["metadata"].(map[string]any)
+	require.True(t, ok)
+	annotations, ok := metadata["annotations"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "sha256:abc", annotations[AnnotationModuleDigest])
+	assert.NotContains(t, annotations, AnnotationSource)
+	assert.NotContains(t, annotations, AnnotationLastOperation)
+}
+
+// The apply's operation ID is recorded so the CR correlates with the run.
+func TestApplySpec_LastOperationAnnotation(t *testing.T) {
+	client, rec := newApplyPatchClient(t, 1)
+	_, err := ApplySpec(context.Background(), client, SpecInput{
+		Name: "podinfo", Namespace: "demo", Owner: OwnerCLI,
+		ModulePath: "p", ModuleVersion: "v", OperationID: "op-1",
+	})
+	require.NoError(t, err)
+
+	metadata, _ := rec.body["metadata"].(map[string]any)
+	annotations, _ := metadata["annotations"].(map[string]any)
+	assert.Equal(t, "op-1", annotations[AnnotationLastOperation])
+	assert.Equal(t, "op-1", recordFromUnstructured(&unstructured.Unstructured{Object: rec.body}).LastOperation)
+}
@@ -13,6 +13,9 @@ type Record struct {
 	Name      string
 	Namespace string
 
+	// Labels is the CR's metadata.labels.
+	Labels map[string]string
+
 	// Owner is the CR's spec.owner marker ("cli", "operator", or empty). An
 	// empty value on an existing CR means operator-managed by the operator's
 	// defaulting contract; see ResolveOwnership.
@@ -41,6 +44,11 @@ type Record struct {
 	// Inventory is the CR's status.inventory block.
 	Inventory pkginventory.Inventory
 
+	// InventoryProblem says why status.inventory could not be read — a
+	// manual edit or version skew — and is empty when it was. Inventory is
+	// then empty or partial; see DiscoverResources.
+	InventoryProblem string
+
 	// LastApplied* mirror the CLI-owned status digest set.
 	LastAppliedRenderDigest string
 	LastAppliedSourceDigest string
@@ -51,6 +59,19 @@ type Record struct {
 	// (module-instance.opmodel.dev/source: local) on the CR.
 	SourceLocal bool
 
+	// ModuleDigest is the OCI manifest digest the last apply's module was
+	// verified against (module-instance.opmodel.dev/module-digest), or empty
+	// when that apply was not digest-pinned.
+	ModuleDigest string
+
+	// LastOperation is the operation ID of the last apply that wrote the CR
+	// (module-instance.opmodel.dev/last-operation), or empty.
+	LastOperation string
+
+	// ResourceVersion is the CR's metadata.resourceVersion when it was read:
+	// the precondition UpdateStatus writes against.
+	ResourceVersion string
+
 	// Generation is the CR's metadata.generation — the spec revision the API
 	// server assigned. Compared against ObservedGeneration to tell whether the
 	// operator has caught up with the latest write.
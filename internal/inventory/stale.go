@@ -3,7 +3,10 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,16 +49,82 @@ func ApplyComponentRenameSafetyCheck(stale, current []InventoryEntry) []Inventor
 	return filtered
 }
 
-// PreApplyExistenceCheck verifies that resources do not conflict with existing
-// cluster state on a first-time apply (no previous inventory).
+// DefaultPruneSafetyRatio is the largest share of an instance's tracked
+// resources one apply prunes without --force-prune.
+const DefaultPruneSafetyRatio = 0.5
+
+// PruneSafetyError is returned by CheckPruneSafety when an apply would prune
+// more of the instance's tracked resources than the safety ratio allows.
+type PruneSafetyError struct {
+	// Stale is the set the apply would have deleted.
+	Stale []InventoryEntry
+	// Tracked is the number of resources the previous inventory tracked.
+	Tracked int
+	// Ratio is the safety ratio that was exceeded.
+	Ratio float64
+}
+
+func (e *PruneSafetyError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "refusing to prune %d of %d tracked resources (%.0f%%, above the %.0f%% prune safety ratio):",
+		len(e.Stale), e.Tracked, 100*float64(len(e.Stale))/float64(e.Tracked), 100*e.Ratio)
+	for _, entry := range e.Stale {
+		b.WriteString("\n  " + entry.Kind + "/" + entry.Name)
+		if entry.Namespace != "" {
+			b.WriteString(" (" + entry.Namespace + ")")
+		}
+	}
+	b.WriteString("\nthe rendered resources were applied and the stale ones are still tracked; " +
+		"if the deletions are intended, re-run with --force-prune")
+	return b.String()
+}
+
+// CheckPruneSafety guards against mass deletion the component-rename check
+// cannot recognise — a module restructured so most resource names change, a
+// values typo that disables most components. It returns a *PruneSafetyError
+// when stale is more than ratio of the tracked resources. A share exactly at
+// the ratio passes. An empty previous inventory, or nothing stale, always
+// passes; a ratio of 1 or more disables the check.
+func CheckPruneSafety(stale []InventoryEntry, tracked int, ratio float64) error {
+	if len(stale) == 0 || tracked == 0 || ratio >= 1 {
+		return nil
+	}
+	if float64(len(stale)) <= ratio*float64(tracked) {
+		return nil
+	}
+	return &PruneSafetyError{Stale: stale, Tracked: tracked, Ratio: ratio}
+}
+
+// UnmanagedResourcesError reports rendered resources that already exist
+// without OPM's managed-by label — created by kubectl or another tool. Applying
+// would take them over silently, so apply refuses unless told to adopt them.
+type UnmanagedResourcesError struct {
+	Entries []InventoryEntry
+}
+
+func (e *UnmanagedResourcesError) Error() string {
+	refs := make([]string, len(e.Entries))
+	for i, entry := range e.Entries {
+		refs[i] = entry.Kind + "/" + entry.Name
+		if entry.Namespace != "" {
+			refs[i] += " (" + entry.Namespace + ")"
+		}
+	}
+	return fmt.Sprintf("%d resource(s) already exist and are not managed by OPM: %s; re-run with --adopt to bring them under this instance",
+		len(e.Entries), strings.Join(refs, ", "))
+}
+
+// PreApplyExistenceCheck verifies that resources about to join an instance do
+// not conflict with existing cluster state, and returns those that exist
+// without being managed by OPM.
 //
-// For each rendered resource entry, a GET is performed:
+// For each entry, a GET is performed:
 //   - If the resource exists with a deletionTimestamp → error (terminating)
-//   - If the resource exists without OPM managed-by label → error (untracked)
+//   - If the resource exists without OPM managed-by label → unmanaged
 //   - If the resource does not exist → OK
 //
-// This check should be skipped entirely when a previous inventory exists.
-func PreApplyExistenceCheck(ctx context.Context, client *kubernetes.Client, entries []InventoryEntry) error {
+// Entries the instance already tracks need no check: they are its own.
+func PreApplyExistenceCheck(ctx context.Context, client *kubernetes.Client, entries []InventoryEntry) (unmanaged []InventoryEntry, err error) {
 	for _, entry := range entries {
 		gvr := schema.GroupVersionResource{
 			Group:    entry.Group,
@@ -79,7 +148,7 @@ func PreApplyExistenceCheck(ctx context.Context, client *kubernetes.Client, entr
 
 		// Check for terminating resources
 		if obj.GetDeletionTimestamp() != nil {
-			return fmt.Errorf("resource %s/%s in namespace %q is terminating (deletionTimestamp set) — wait for deletion to complete before applying",
+			return nil, fmt.Errorf("resource %s/%s in namespace %q is terminating (deletionTimestamp set) — wait for deletion to complete before applying",
 				entry.Kind, entry.Name, entry.Namespace)
 		}
 
@@ -88,33 +157,142 @@ func PreApplyExistenceCheck(ctx context.Context, client *kubernetes.Client, entr
 		// legacy open-platform-model) for backward compatibility.
 		labels := unstrObj.GetLabels()
 		if !pkgcore.IsOPMManagedBy(labels[pkgcore.LabelManagedBy]) {
-			return fmt.Errorf("resource %s/%s in namespace %q already exists and is not managed by OPM — use --force to proceed",
-				entry.Kind, entry.Name, entry.Namespace)
+			unmanaged = append(unmanaged, entry)
 		}
 	}
-	return nil
+	return unmanaged, nil
 }
 
-// PruneStaleResources deletes the stale resources from the cluster.
-// Resources are deleted in reverse weight order (highest weight first).
-// Namespace resources are excluded unless explicitly included.
-// 404 (not found) errors are treated as success (idempotent).
-func PruneStaleResources(ctx context.Context, client *kubernetes.Client, stale []InventoryEntry) error {
-	if len(stale) == 0 {
+// AnnotationPrune lets a module opt a resource out of pruning: a live object
+// annotated opmodel.dev/prune: "false" is never deleted as stale, so a data
+// store can outlive the component that created it.
+const AnnotationPrune = "opmodel.dev/prune"
+
+// SplitProtected separates the stale entries that must not be pruned from
+// those safe to prune. An entry is protected when its live object carries one
+// of the given finalizers (--prune-blacklist-finalizers) — deleting it would
+// only mark it terminating and arm the finalizer, for a PVC the first step
+// towards losing its data — or is annotated opmodel.dev/prune: "false". The
+// caller keeps protected entries tracked instead of pruning them.
+//
+// An object that cannot be read is treated as protected: an unverified entry
+// is not pruned. An object that no longer exists is prunable (the delete is a
+// no-op).
+func SplitProtected(ctx context.Context, client *kubernetes.Client, stale []InventoryEntry, finalizers []string) (prunable, protected []InventoryEntry) {
+	for _, entry := range stale {
+		gvr := schema.GroupVersionResource{
+			Group:    entry.Group,
+			Version:  entry.Version,
+			Resource: kubernetes.KindToResource(entry.Kind),
+		}
+		obj, err := client.ResourceClient(gvr, entry.Namespace).Get(ctx, entry.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			prunable = append(prunable, entry)
+		case err != nil:
+			output.Warn("could not read stale resource; not pruning it",
+				"kind", entry.Kind, "name", entry.Name, "err", err)
+			protected = append(protected, entry)
+		case obj.GetAnnotations()[AnnotationPrune] == "false":
+			protected = append(protected, entry)
+		case slices.ContainsFunc(obj.GetFinalizers(), func(f string) bool { return slices.Contains(finalizers, f) }):
+			protected = append(protected, entry)
+		default:
+			prunable = append(prunable, entry)
+		}
+	}
+	return prunable, protected
+}
+
+// PruneOptions configures PruneStaleResources.
+type PruneOptions struct {
+	// Order lists kinds to delete first, in the given order (--prune-order).
+	// Kinds not listed follow in the default reverse-weight order. Matching is
+	// case-insensitive. Empty means reverse-weight order only.
+	Order []string
+	// InstanceName labels the prune events on the --output-events stream.
+	InstanceName string
+	// Detach relabels each stale resource out of OPM management instead of
+	// deleting it (--prune-mode detach); see kubernetes.OrphanResource. The
+	// resource keeps running, and Namespaces are detached like any other kind.
+	Detach bool
+}
+
+// Prune modes (--prune-mode): what apply does with a stale resource.
+const (
+	PruneModeDelete = "delete"
+	PruneModeDetach = "detach"
+)
+
+// ValidatePruneMode checks a --prune-mode value; empty means delete.
+func ValidatePruneMode(mode string) error {
+	switch mode {
+	case "", PruneModeDelete, PruneModeDetach:
 		return nil
 	}
+	return fmt.Errorf("invalid --prune-mode %q (valid: %s, %s)", mode, PruneModeDelete, PruneModeDetach)
+}
+
+// SortForPrune returns stale entries in deletion order: kinds named in order
+// first, in that sequence, then the rest in reverse weight order (the inverse
+// of apply order, so dependents go before their dependencies). The sort is
+// stable, so entries of equal rank keep their inventory order.
+func SortForPrune(stale []InventoryEntry, order []string) []InventoryEntry {
+	rank := make(map[string]int, len(order))
+	for i, kind := range order {
+		key := strings.ToLower(kind)
+		if _, dup := rank[key]; !dup {
+			rank[key] = i
+		}
+	}
+	rankOf := func(e InventoryEntry) int {
+		if r, ok := rank[strings.ToLower(e.Kind)]; ok {
+			return r
+		}
+		return len(order)
+	}
 
-	// Sort in reverse weight order (highest weight deleted first)
 	sorted := make([]InventoryEntry, len(stale))
 	copy(sorted, stale)
 	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := rankOf(sorted[i]), rankOf(sorted[j])
+		if ri != rj {
+			return ri < rj
+		}
 		wi := resourceorder.GetWeight(schema.GroupVersionKind{Group: sorted[i].Group, Version: sorted[i].Version, Kind: sorted[i].Kind})
 		wj := resourceorder.GetWeight(schema.GroupVersionKind{Group: sorted[j].Group, Version: sorted[j].Version, Kind: sorted[j].Kind})
 		return wi > wj // descending
 	})
+	return sorted
+}
+
+// PruneStaleResources deletes the stale resources from the cluster, or with
+// opts.Detach relabels them out of OPM management and leaves them running.
+// Resources are handled in reverse weight order (highest weight first), after
+// any kinds named in opts.Order. Namespace resources are never deleted.
+// 404 (not found) errors are treated as success (idempotent).
+func PruneStaleResources(ctx context.Context, client *kubernetes.Client, stale []InventoryEntry, opts PruneOptions) error {
+	if len(stale) == 0 {
+		return nil
+	}
+
+	sorted := SortForPrune(stale, opts.Order)
 
 	var errs []error
 	for _, entry := range sorted {
+		if opts.Detach {
+			if err := detachStaleResource(ctx, client, entry); err != nil {
+				output.Warn("failed to detach stale resource",
+					"kind", entry.Kind, "name", entry.Name, "err", err)
+				output.EmitResource(opts.InstanceName, entry.Kind, entry.Namespace, entry.Name, "", err)
+				errs = append(errs, fmt.Errorf("detaching %s/%s: %w", entry.Kind, entry.Name, err))
+				continue
+			}
+			output.Debug("detached stale resource", "kind", entry.Kind, "namespace", entry.Namespace, "name", entry.Name)
+			output.EmitResource(opts.InstanceName, entry.Kind, entry.Namespace, entry.Name, output.StatusOrphaned, nil)
+			continue
+		}
+
 		// Exclude Namespace resources from pruning by default
 		if entry.Kind == "Namespace" && entry.Group == "" {
 			output.Debug("skipping Namespace pruning", "name", entry.Name)
@@ -135,11 +313,13 @@ func PruneStaleResources(ctx context.Context, client *kubernetes.Client, stale [
 		if err != nil && !apierrors.IsNotFound(err) {
 			output.Warn("failed to prune stale resource",
 				"kind", entry.Kind, "name", entry.Name, "err", err)
+			output.EmitResource(opts.InstanceName, entry.Kind, entry.Namespace, entry.Name, "", err)
 			errs = append(errs, fmt.Errorf("deleting %s/%s: %w", entry.Kind, entry.Name, err))
 			continue
 		}
 
 		output.Debug("pruned stale resource", "kind", entry.Kind, "namespace", entry.Namespace, "name", entry.Name)
+		output.EmitResource(opts.InstanceName, entry.Kind, entry.Namespace, entry.Name, output.StatusPruned, nil)
 	}
 
 	if len(errs) > 0 {
@@ -147,3 +327,21 @@ func PruneStaleResources(ctx context.Context, client *kubernetes.Client, stale [
 	}
 	return nil
 }
+
+// detachStaleResource reads the live object of entry and relabels it out of
+// OPM management. An object that no longer exists needs nothing.
+func detachStaleResource(ctx context.Context, client *kubernetes.Client, entry InventoryEntry) error {
+	gvr := schema.GroupVersionResource{
+		Group:    entry.Group,
+		Version:  entry.Version,
+		Resource: kubernetes.KindToResource(entry.Kind),
+	}
+	obj, err := client.ResourceClient(gvr, entry.Namespace).Get(ctx, entry.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return kubernetes.OrphanResource(ctx, client, obj, time.Now())
+}
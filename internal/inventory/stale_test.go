@@ -1,11 +1,18 @@
 package inventory
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/open-platform-model/cli/internal/kubernetes"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 )
 
 // helper to build an InventoryEntry quickly
@@ -142,6 +149,45 @@ func TestComponentRenameSafetyCheck_EmptyStale_NoOp(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestCheckPruneSafety(t *testing.T) {
+	stale := func(n int) []InventoryEntry {
+		entries := make([]InventoryEntry, n)
+		for i := range entries {
+			entries[i] = entry("", "ConfigMap", "ns", fmt.Sprintf("cm-%d", i), "web")
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name    string
+		stale   int
+		tracked int
+		ratio   float64
+		refused bool
+	}{
+		{name: "exactly at the ratio passes", stale: 2, tracked: 4, ratio: 0.5},
+		{name: "just above the ratio is refused", stale: 3, tracked: 5, ratio: 0.5, refused: true},
+		{name: "empty previous set", stale: 0, tracked: 0, ratio: 0.5},
+		{name: "all-new set prunes nothing", stale: 0, tracked: 3, ratio: 0.5},
+		{name: "pruning everything is refused", stale: 3, tracked: 3, ratio: 0.5, refused: true},
+		{name: "ratio of one disables the check", stale: 3, tracked: 3, ratio: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckPruneSafety(stale(tc.stale), tc.tracked, tc.ratio)
+			if !tc.refused {
+				assert.NoError(t, err)
+				return
+			}
+			var safetyErr *PruneSafetyError
+			require.ErrorAs(t, err, &safetyErr)
+			assert.Len(t, safetyErr.Stale, tc.stale)
+			assert.Contains(t, err.Error(), "ConfigMap/cm-0 (ns)")
+			assert.Contains(t, err.Error(), "--force-prune")
+		})
+	}
+}
+
 func TestComponentRenameSafetyCheck_SameComponent_NotFiltered(t *testing.T) {
 	// Same K8s resource, same component → this is a genuine change, not a rename
 	stale := []InventoryEntry{
@@ -177,3 +223,176 @@ func TestKindToResource(t *testing.T) {
 		})
 	}
 }
+
+// --- SortForPrune ---
+
+func TestSortForPrune_DefaultReverseWeight(t *testing.T) {
+	stale := []InventoryEntry{
+		entry("", "ConfigMap", "ns", "cfg", "web"),
+		entry("apps", "Deployment", "ns", "app", "web"),
+		entry("networking.k8s.io", "Ingress", "ns", "ing", "web"),
+		entry("", "Service", "ns", "svc", "web"),
+	}
+
+	sorted := SortForPrune(stale, nil)
+	kinds := make([]string, len(sorted))
+	for i, e := range sorted {
+		kinds[i] = e.Kind
+	}
+	assert.Equal(t, []string{"Ingress", "Deployment", "Service", "ConfigMap"}, kinds)
+	assert.Equal(t, "ConfigMap", stale[0].Kind, "input slice must not be reordered")
+}
+
+func TestSortForPrune_OrderOverride(t *testing.T) {
+	stale := []InventoryEntry{
+		entry("", "ConfigMap", "ns", "cfg", "web"),
+		entry("apps", "Deployment", "ns", "app", "web"),
+		entry("networking.k8s.io", "Ingress", "ns", "ing", "web"),
+		entry("", "Service", "ns", "svc", "web"),
+	}
+
+	sorted := SortForPrune(stale, []string{"configmap", "Service"})
+	kinds := make([]string, len(sorted))
+	for i, e := range sorted {
+		kinds[i] = e.Kind
+	}
+	assert.Equal(t, []string{"ConfigMap", "Service", "Ingress", "Deployment"}, kinds)
+}
+
+// --- SplitProtected ---
+
+func TestSplitProtected(t *testing.T) {
+	pvc := func(name string, finalizers ...string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata":   map[string]any{"name": name, "namespace": "ns"},
+		}}
+		obj.SetFinalizers(finalizers)
+		return obj
+	}
+	client := newDynamicClient(
+		pvc("data", "kubernetes.io/pvc-protection"),
+		pvc("scratch"),
+		pvc("other", "example.com/cleanup"),
+	)
+	stale := []InventoryEntry{
+		entry("", "PersistentVolumeClaim", "ns", "data", "db"),
+		entry("", "PersistentVolumeClaim", "ns", "scratch", "db"),
+		entry("", "PersistentVolumeClaim", "ns", "other", "db"),
+		entry("", "PersistentVolumeClaim", "ns", "gone", "db"),
+	}
+
+	prunable, protected := SplitProtected(context.Background(), client, stale, []string{"kubernetes.io/pvc-protection"})
+	assert.Equal(t, []InventoryEntry{stale[0]}, protected)
+	assert.Equal(t, []InventoryEntry{stale[1], stale[2], stale[3]}, prunable)
+}
+
+func TestSplitProtected_NoFinalizersPrunesAll(t *testing.T) {
+	stale := []InventoryEntry{entry("", "PersistentVolumeClaim", "ns", "data", "db")}
+	prunable, protected := SplitProtected(context.Background(), newDynamicClient(), stale, nil)
+	assert.Equal(t, stale, prunable)
+	assert.Empty(t, protected)
+}
+
+func TestSplitProtected_PruneAnnotation(t *testing.T) {
+	cm := func(name, prune string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "ns"},
+		}}
+		if prune != "" {
+			obj.SetAnnotations(map[string]string{AnnotationPrune: prune})
+		}
+		return obj
+	}
+	client := newDynamicClient(cm("keep", "false"), cm("explicit", "true"), cm("plain", ""))
+	stale := []InventoryEntry{
+		entry("", "ConfigMap", "ns", "keep", "db"),
+		entry("", "ConfigMap", "ns", "explicit", "db"),
+		entry("", "ConfigMap", "ns", "plain", "db"),
+	}
+
+	prunable, protected := SplitProtected(context.Background(), client, stale, nil)
+	assert.Equal(t, []InventoryEntry{stale[0]}, protected, "the annotation protects without any finalizer configured")
+	assert.Equal(t, []InventoryEntry{stale[1], stale[2]}, prunable)
+}
+
+// --- PruneStaleResources ---
+
+func TestPruneStaleResources_Detach(t *testing.T) {
+	cm := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "cfg",
+			"namespace": "ns",
+			"labels": map[string]any{
+				pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+				pkgcore.LabelModuleInstanceName:      "demo",
+				pkgcore.LabelModuleInstanceNamespace: "ns",
+				"app":                                "web",
+			},
+		},
+	}}
+	client := newDynamicClient(cm)
+	stale := []InventoryEntry{
+		entry("", "ConfigMap", "ns", "cfg", "web"),
+		entry("", "ConfigMap", "ns", "gone", "web"),
+	}
+
+	err := PruneStaleResources(context.Background(), client, stale, PruneOptions{Detach: true})
+	require.NoError(t, err)
+
+	live, err := client.ResourceClient(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, "ns").
+		Get(context.Background(), "cfg", metav1.GetOptions{})
+	require.NoError(t, err, "a detached resource keeps running")
+	assert.Equal(t, map[string]string{"app": "web"}, live.GetLabels())
+	assert.NotEmpty(t, live.GetAnnotations()[kubernetes.AnnotationOrphanedAt])
+}
+
+func TestValidatePruneMode(t *testing.T) {
+	for _, mode := range []string{"", PruneModeDelete, PruneModeDetach} {
+		assert.NoError(t, ValidatePruneMode(mode), mode)
+	}
+	assert.ErrorContains(t, ValidatePruneMode("orphan"), "valid: delete, detach")
+}
+
+// --- PreApplyExistenceCheck ---
+
+func TestPreApplyExistenceCheck_ReturnsUnmanaged(t *testing.T) {
+	plain := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1", "kind": "ConfigMap",
+		"metadata": map[string]any{"name": "plain", "namespace": "ns"},
+	}}
+	managed := plain.DeepCopy()
+	managed.SetName("managed")
+	managed.SetLabels(map[string]string{pkgcore.LabelManagedBy: pkgcore.LabelManagedByValue})
+	client := newDynamicClient(plain, managed)
+
+	unmanaged, err := PreApplyExistenceCheck(context.Background(), client, []InventoryEntry{
+		entry("", "ConfigMap", "ns", "plain", "app"),
+		entry("", "ConfigMap", "ns", "managed", "app"),
+		entry("", "ConfigMap", "ns", "absent", "app"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []InventoryEntry{entry("", "ConfigMap", "ns", "plain", "app")}, unmanaged)
+
+	msg := (&UnmanagedResourcesError{Entries: unmanaged}).Error()
+	assert.Contains(t, msg, "1 resource(s) already exist and are not managed by OPM: ConfigMap/plain (ns)")
+	assert.Contains(t, msg, "--adopt")
+}
+
+func TestPreApplyExistenceCheck_TerminatingIsAnError(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1", "kind": "ConfigMap",
+		"metadata": map[string]any{"name": "going", "namespace": "ns"},
+	}}
+	now := metav1.Now()
+	obj.SetDeletionTimestamp(&now)
+	client := newDynamicClient(obj)
+
+	_, err := PreApplyExistenceCheck(context.Background(), client, []InventoryEntry{entry("", "ConfigMap", "ns", "going", "app")})
+	assert.ErrorContains(t, err, "is terminating")
+}
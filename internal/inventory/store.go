@@ -107,6 +107,11 @@ type SpecInput struct {
 	// SourceLocal stamps the render-provenance annotation when true; when false
 	// the annotation is omitted so SSA field ownership removes any prior value.
 	SourceLocal bool
+	// ModuleDigest stamps the verified module digest annotation; empty omits
+	// it, removing any prior value the same way.
+	ModuleDigest string
+	// OperationID stamps the last-operation annotation; empty omits it.
+	OperationID string
 }
 
 // ApplySpec server-side-applies the complete CLI-owned ModuleInstance spec
@@ -145,8 +150,18 @@ func ApplySpec(ctx context.Context, client *kubernetes.Client, in SpecInput) (in
 		}
 	}
 
+	annotations := map[string]string{}
 	if in.SourceLocal {
-		obj.SetAnnotations(map[string]string{AnnotationSource: SourceLocal})
+		annotations[AnnotationSource] = SourceLocal
+	}
+	if in.ModuleDigest != "" {
+		annotations[AnnotationModuleDigest] = in.ModuleDigest
+	}
+	if in.OperationID != "" {
+		annotations[AnnotationLastOperation] = in.OperationID
+	}
+	if len(annotations) > 0 {
+		obj.SetAnnotations(annotations)
 	}
 
 	applied, err := ssaApplyReturning(ctx, client, obj, in.Name, in.Namespace)
@@ -169,10 +184,21 @@ type StatusInput struct {
 	LastAppliedSourceDigest string
 	LastAppliedConfigDigest string
 	LastAppliedAt           string
+	// ResourceVersion, when set, makes the write conditional: it fails with
+	// a Conflict unless the CR is still at this metadata.resourceVersion.
+	// See UpdateStatus.
+	ResourceVersion string
 }
 
+// maxStatusBytes caps the encoded CLI-owned status. etcd refuses objects
+// over 1.5MiB by default, and the CR also carries the spec, the
+// operator-written status, and managedFields, so the inventory gets 1MiB.
+const maxStatusBytes = 1 << 20
+
 // ApplyStatus server-side-applies the CLI-owned status subset on the status
-// subresource with field manager opm-cli.
+// subresource with field manager opm-cli. A status over maxStatusBytes is
+// refused before it is sent: the API server would reject it with an opaque
+// "request is too large", after the resources were already applied.
 func ApplyStatus(ctx context.Context, client *kubernetes.Client, in StatusInput) error {
 	status := map[string]any{
 		"inventory": inventoryToWire(in.Inventory),
@@ -182,6 +208,9 @@ func ApplyStatus(ctx context.Context, client *kubernetes.Client, in StatusInput)
 	setIfNotEmpty(status, "lastAppliedSourceDigest", in.LastAppliedSourceDigest)
 	setIfNotEmpty(status, "lastAppliedConfigDigest", in.LastAppliedConfigDigest)
 	setIfNotEmpty(status, "lastAppliedAt", in.LastAppliedAt)
+	if err := checkStatusSize(in.Name, len(in.Inventory.Entries), status); err != nil {
+		return err
+	}
 
 	obj := &unstructured.Unstructured{Object: map[string]any{
 		"apiVersion": APIVersionModuleInstance,
@@ -192,6 +221,9 @@ func ApplyStatus(ctx context.Context, client *kubernetes.Client, in StatusInput)
 		},
 		"status": status,
 	}}
+	if in.ResourceVersion != "" {
+		obj.SetResourceVersion(in.ResourceVersion)
+	}
 
 	if err := ssaApply(ctx, client, obj, in.Name, in.Namespace, "status"); err != nil {
 		return err
@@ -200,6 +232,19 @@ func ApplyStatus(ctx context.Context, client *kubernetes.Client, in StatusInput)
 	return nil
 }
 
+// checkStatusSize fails when status encodes to more than maxStatusBytes.
+func checkStatusSize(name string, entries int, status map[string]any) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshaling ModuleInstance %q status: %w", name, err)
+	}
+	if len(data) <= maxStatusBytes {
+		return nil
+	}
+	return fmt.Errorf("the inventory of ModuleInstance %q is too large to store: %d resources encode to %d bytes, over the %d byte limit; "+
+		"split the module's components across several instances so each tracks fewer resources", name, entries, len(data), maxStatusBytes)
+}
+
 // DeleteCR deletes the ModuleInstance CR. NotFound is treated as success
 // (idempotent delete).
 func DeleteCR(ctx context.Context, client *kubernetes.Client, name, namespace string) error {
@@ -240,6 +285,7 @@ func recordFromUnstructured(obj *unstructured.Unstructured) *Record {
 	rec := &Record{
 		Name:                    obj.GetName(),
 		Namespace:               obj.GetNamespace(),
+		Labels:                  obj.GetLabels(),
 		Owner:                   nestedString(obj.Object, "spec", "owner"),
 		ModulePath:              nestedString(obj.Object, "spec", "module", "path"),
 		ModuleVersion:           nestedString(obj.Object, "spec", "module", "version"),
@@ -248,6 +294,7 @@ func recordFromUnstructured(obj *unstructured.Unstructured) *Record {
 		LastAppliedSourceDigest: nestedString(obj.Object, "status", "lastAppliedSourceDigest"),
 		LastAppliedConfigDigest: nestedString(obj.Object, "status", "lastAppliedConfigDigest"),
 		LastAppliedAt:           nestedString(obj.Object, "status", "lastAppliedAt"),
+		ResourceVersion:         obj.GetResourceVersion(),
 		Generation:              obj.GetGeneration(),
 		Conditions:              conditionsFromUnstructured(obj),
 	}
@@ -273,10 +320,13 @@ func recordFromUnstructured(obj *unstructured.Unstructured) *Record {
 	} else {
 		rec.Inventory = pkginventory.Inventory{Entries: []pkginventory.InventoryEntry{}}
 	}
+	rec.InventoryProblem = inventoryProblem(obj)
 
 	if obj.GetAnnotations()[AnnotationSource] == SourceLocal {
 		rec.SourceLocal = true
 	}
+	rec.ModuleDigest = obj.GetAnnotations()[AnnotationModuleDigest]
+	rec.LastOperation = obj.GetAnnotations()[AnnotationLastOperation]
 	return rec
 }
 
@@ -299,6 +349,39 @@ func interpretableInventory(obj *unstructured.Unstructured) bool {
 	return ok
 }
 
+// inventoryProblem describes what makes a CR's status.inventory unreadable,
+// or returns "" when it is absent or well-formed. It is stricter than
+// interpretableInventory: an inventory that reads as an object but drops
+// entries is still a problem for a command looking for every resource.
+func inventoryProblem(obj *unstructured.Unstructured) string {
+	raw, found, err := unstructured.NestedFieldNoCopy(obj.Object, "status", "inventory")
+	if err != nil || !found {
+		return ""
+	}
+	inv, ok := raw.(map[string]any)
+	if !ok {
+		return fmt.Sprintf("status.inventory is a %T, not an object", raw)
+	}
+	rawEntries, found := inv["entries"]
+	if !found || rawEntries == nil {
+		return ""
+	}
+	entries, ok := rawEntries.([]any)
+	if !ok {
+		return fmt.Sprintf("status.inventory.entries is a %T, not a list", rawEntries)
+	}
+	for i, e := range entries {
+		em, ok := e.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("status.inventory.entries[%d] is a %T, not an object", i, e)
+		}
+		if wireString(em, "kind") == "" || wireString(em, "name") == "" {
+			return fmt.Sprintf("status.inventory.entries[%d] has no kind or name", i)
+		}
+	}
+	return ""
+}
+
 // moduleRef builds the spec.module reference document. Shared by the full spec
 // apply and the thin-editor spec edit so the two writers cannot drift.
 func moduleRef(path, version string) map[string]any {
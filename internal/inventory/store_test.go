@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,7 +28,7 @@ func TestRecordFromUnstructured_FullMapping(t *testing.T) {
 		"metadata": map[string]any{
 			"name":        "podinfo",
 			"namespace":   "demo",
-			"annotations": map[string]any{AnnotationSource: SourceLocal},
+			"annotations": map[string]any{AnnotationSource: SourceLocal, AnnotationModuleDigest: "sha256:module"},
 		},
 		"spec": map[string]any{
 			"owner":  OwnerCLI,
@@ -56,6 +57,7 @@ func TestRecordFromUnstructured_FullMapping(t *testing.T) {
 	assert.Equal(t, "0.1.0", rec.ModuleVersion)
 	assert.Equal(t, "uuid-1", rec.InstanceUUID)
 	assert.True(t, rec.SourceLocal)
+	assert.Equal(t, "sha256:module", rec.ModuleDigest)
 	assert.Equal(t, "sha256:render", rec.LastAppliedRenderDigest)
 	assert.Equal(t, 2, rec.Inventory.Revision)
 	require.Len(t, rec.Inventory.Entries, 1)
@@ -72,6 +74,30 @@ func TestRecordFromUnstructured_EmptyStatusYieldsEmptyInventory(t *testing.T) {
 	assert.False(t, rec.SourceLocal)
 }
 
+func TestRecordFromUnstructured_InventoryProblem(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		inventory any
+		problem   string
+	}{
+		{name: "absent"},
+		{name: "well-formed", inventory: map[string]any{"entries": []any{map[string]any{"kind": "Service", "name": "web"}}}},
+		{name: "no entries", inventory: map[string]any{"revision": int64(1)}},
+		{name: "not an object", inventory: "corrupt", problem: "status.inventory is a string, not an object"},
+		{name: "entries not a list", inventory: map[string]any{"entries": "x"}, problem: "status.inventory.entries is a string, not a list"},
+		{name: "entry not an object", inventory: map[string]any{"entries": []any{"x"}}, problem: "status.inventory.entries[0] is a string, not an object"},
+		{name: "entry without a name", inventory: map[string]any{"entries": []any{map[string]any{"kind": "Service"}}}, problem: "status.inventory.entries[0] has no kind or name"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := moduleInstanceObj("a", "uuid-a")
+			if tc.inventory != nil {
+				obj.Object["status"].(map[string]any)["inventory"] = tc.inventory
+			}
+			assert.Equal(t, tc.problem, recordFromUnstructured(obj).InventoryProblem)
+		})
+	}
+}
+
 func TestListRecords_SkipsMalformedInventory(t *testing.T) {
 	ctx := context.Background()
 	good := moduleInstanceObj("good", "uuid-good")
@@ -131,6 +157,34 @@ func TestDeleteCR_RemovesExisting(t *testing.T) {
 	assert.Nil(t, rec)
 }
 
+func TestApplyStatus_RefusesAnOversizedInventory(t *testing.T) {
+	client, rec := newApplyPatchClient(t, 1)
+	entries := make([]InventoryEntry, 20000)
+	for i := range entries {
+		entries[i] = InventoryEntry{Group: "apps", Kind: "Deployment", Namespace: "demo", Name: fmt.Sprintf("deployment-with-a-long-name-%05d", i)}
+	}
+
+	err := ApplyStatus(context.Background(), client, StatusInput{
+		Name: "podinfo", Namespace: "demo",
+		Inventory: Inventory{Revision: 1, Count: len(entries), Entries: entries},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "20000 resources")
+	assert.Contains(t, err.Error(), "split the module's components")
+	assert.Nil(t, rec.body, "nothing is sent to the API server")
+}
+
+func TestApplyStatus_WritesAnInventoryUnderTheLimit(t *testing.T) {
+	client, rec := newApplyPatchClient(t, 1)
+	entries := []InventoryEntry{{Group: "apps", Kind: "Deployment", Namespace: "demo", Name: "podinfo"}}
+
+	require.NoError(t, ApplyStatus(context.Background(), client, StatusInput{
+		Name: "podinfo", Namespace: "demo",
+		Inventory: Inventory{Revision: 1, Count: 1, Entries: entries},
+	}))
+	assert.NotNil(t, rec.body)
+}
+
 func moduleInstanceObj(name, uuid string) *unstructured.Unstructured {
 	return &unstructured.Unstructured{Object: map[string]any{
 		"apiVersion": APIVersionModuleInstance,
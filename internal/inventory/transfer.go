@@ -0,0 +1,168 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// exportedStatusFields are the status fields an export carries: the ones the
+// CLI owns. Operator-written status (conditions, observedGeneration, history)
+// describes the source cluster's reconciles and is left behind.
+var exportedStatusFields = []string{
+	"inventory",
+	"instanceUUID",
+	"lastAppliedRenderDigest",
+	"lastAppliedSourceDigest",
+	"lastAppliedConfigDigest",
+	"lastAppliedAt",
+}
+
+// ErrInvalidExport is returned by Import for a document that is not a
+// well-formed, internally consistent ModuleInstance export.
+var ErrInvalidExport = errors.New("invalid inventory document")
+
+// ErrInventoryExists is returned by Import when the target ModuleInstance
+// already exists and overwriting was not requested.
+var ErrInventoryExists = errors.New("inventory already exists")
+
+// Export reads an instance's ModuleInstance CR and returns it as a portable
+// YAML document: its name, namespace, labels, and annotations, the full spec,
+// and the CLI-owned status — the inventory and the last-applied digests.
+// Server-managed metadata and operator-written status are dropped, so the
+// document can be imported into another cluster. A missing CR is returned as
+// the API server's NotFound error.
+func Export(ctx context.Context, client *kubernetes.Client, name, namespace string) ([]byte, error) {
+	obj, err := client.ResourceClient(ModuleInstanceGVR, namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting ModuleInstance %q: %w", name, err)
+	}
+
+	metadata := map[string]any{
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	doc := map[string]any{
+		"apiVersion": APIVersionModuleInstance,
+		"kind":       KindModuleInstance,
+		"metadata":   metadata,
+	}
+	if spec, ok := obj.Object["spec"].(map[string]any); ok {
+		doc["spec"] = spec
+	}
+	if status, ok := obj.Object["status"].(map[string]any); ok {
+		exported := map[string]any{}
+		for _, f := range exportedStatusFields {
+			if v, ok := status[f]; ok {
+				exported[f] = v
+			}
+		}
+		doc["status"] = exported
+	}
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling ModuleInstance %q: %w", name, err)
+	}
+	return data, nil
+}
+
+// Import writes an exported ModuleInstance document to the cluster: the
+// metadata and spec first, then the CLI-owned status on the status
+// subresource. The document is validated before anything is written: its
+// identity labels must match its name and namespace, and its inventory must be
+// internally consistent (see VerifyLabels and VerifyEntries), or Import fails
+// with ErrInvalidExport. An existing ModuleInstance of the same name is only
+// overwritten when force is set; otherwise ErrInventoryExists is returned.
+//
+// Import restores the record only. It does not create the tracked resources;
+// an operator-owned instance is reconciled by the target cluster's operator
+// as soon as its spec lands.
+func Import(ctx context.Context, client *kubernetes.Client, data []byte, force bool) (*Record, error) {
+	obj, err := decodeExport(data)
+	if err != nil {
+		return nil, err
+	}
+	rec := recordFromUnstructured(obj)
+	name, namespace := rec.Name, rec.Namespace
+
+	existing, err := GetRecord(ctx, client, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !force {
+		return nil, fmt.Errorf("ModuleInstance %s/%s: %w", namespace, name, ErrInventoryExists)
+	}
+
+	status, hasStatus := obj.Object["status"].(map[string]any)
+	delete(obj.Object, "status")
+	if err := ssaApply(ctx, client, obj, name, namespace); err != nil {
+		return nil, err
+	}
+	if hasStatus {
+		statusObj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": APIVersionModuleInstance,
+			"kind":       KindModuleInstance,
+			"metadata":   map[string]any{"name": name, "namespace": namespace},
+			"status":     status,
+		}}
+		if err := ssaApply(ctx, client, statusObj, name, namespace, "status"); err != nil {
+			return nil, err
+		}
+	}
+	output.Debug("imported ModuleInstance", "name", name, "namespace", namespace, "entries", len(rec.Inventory.Entries))
+	return rec, nil
+}
+
+// decodeExport parses and validates an exported ModuleInstance document.
+func decodeExport(data []byte) (*unstructured.Unstructured, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("%w: document is empty", ErrInvalidExport)
+	}
+	normalized, err := jsonNormalizeMap(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	obj := &unstructured.Unstructured{Object: normalized}
+
+	if obj.GetAPIVersion() != APIVersionModuleInstance || obj.GetKind() != KindModuleInstance {
+		return nil, fmt.Errorf("%w: document is %s %s, want %s %s", ErrInvalidExport,
+			obj.GetAPIVersion(), obj.GetKind(), APIVersionModuleInstance, KindModuleInstance)
+	}
+	if obj.GetName() == "" || obj.GetNamespace() == "" {
+		return nil, fmt.Errorf("%w: metadata.name and metadata.namespace must be set", ErrInvalidExport)
+	}
+	if !interpretableInventory(obj) {
+		return nil, fmt.Errorf("%w: status.inventory is malformed", ErrInvalidExport)
+	}
+
+	rec := recordFromUnstructured(obj)
+	problems := VerifyLabels(rec)
+	if len(rec.Inventory.Entries) > 0 && rec.InstanceUUID == "" {
+		problems = append(problems, "status.instanceUUID is missing")
+	}
+	problems = append(problems, VerifyEntries(rec)...)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s/%s is inconsistent: %s", ErrInvalidExport,
+			obj.GetNamespace(), obj.GetName(), strings.Join(problems, "; "))
+	}
+	return obj, nil
+}
@@ -0,0 +1,149 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+	pkginventory "github.com/open-platform-model/cli/pkg/inventory"
+)
+
+// exportableInstanceObj is a ModuleInstance as read back from a cluster:
+// labelled, with a consistent inventory, plus server- and operator-written
+// fields an export must drop.
+func exportableInstanceObj() *unstructured.Unstructured {
+	entries := []pkginventory.InventoryEntry{{Kind: "ConfigMap", Version: "v1", Namespace: "demo", Name: "web"}}
+	obj := moduleInstanceObj("podinfo", "uuid-1")
+	obj.SetLabels(map[string]string{
+		pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+		pkgcore.LabelModuleInstanceName:      "podinfo",
+		pkgcore.LabelModuleInstanceNamespace: "demo",
+	})
+	obj.SetResourceVersion("42")
+	obj.SetUID("abc")
+	obj.Object["spec"] = map[string]any{
+		"owner":  OwnerCLI,
+		"module": moduleRef("opmodel.dev/modules/podinfo@v0", "0.1.0"),
+	}
+	obj.Object["status"] = map[string]any{
+		"instanceUUID":       "uuid-1",
+		"observedGeneration": int64(3),
+		"conditions":         []any{map[string]any{"type": "Ready", "status": "True"}},
+		"inventory": inventoryToWire(pkginventory.Inventory{
+			Revision: 2, Count: 1, Digest: ComputeDigest(entries), Entries: entries,
+		}),
+	}
+	return obj
+}
+
+func TestExport(t *testing.T) {
+	client := newDynamicClient(exportableInstanceObj())
+
+	data, err := Export(context.Background(), client, "podinfo", "demo")
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &doc))
+	meta := doc["metadata"].(map[string]any)
+	assert.Equal(t, "podinfo", meta["name"])
+	assert.NotContains(t, meta, "resourceVersion")
+	assert.NotContains(t, meta, "uid")
+
+	status := doc["status"].(map[string]any)
+	assert.Contains(t, status, "inventory")
+	assert.Equal(t, "uuid-1", status["instanceUUID"])
+	assert.NotContains(t, status, "conditions", "operator-written status stays behind")
+	assert.NotContains(t, status, "observedGeneration")
+
+	_, err = decodeExport(data)
+	require.NoError(t, err, "an export must import cleanly")
+}
+
+func TestExport_NotFound(t *testing.T) {
+	_, err := Export(context.Background(), newDynamicClient(), "missing", "demo")
+	require.Error(t, err)
+}
+
+func TestDecodeExport_RejectsInconsistentDocuments(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(obj *unstructured.Unstructured)
+		want   string
+	}{
+		{
+			name:   "wrong kind",
+			mutate: func(obj *unstructured.Unstructured) { obj.SetKind("Secret") },
+			want:   "want " + APIVersionModuleInstance,
+		},
+		{
+			name: "label names another instance",
+			mutate: func(obj *unstructured.Unstructured) {
+				labels := obj.GetLabels()
+				labels[pkgcore.LabelModuleInstanceName] = "other"
+				obj.SetLabels(labels)
+			},
+			want: `is "other", want "podinfo"`,
+		},
+		{
+			name: "inventory digest does not match its entries",
+			mutate: func(obj *unstructured.Unstructured) {
+				require.NoError(t, unstructured.SetNestedField(obj.Object, "sha256:stale", "status", "inventory", "digest"))
+			},
+			want: "entries hash to",
+		},
+		{
+			name: "missing instance UUID",
+			mutate: func(obj *unstructured.Unstructured) {
+				unstructured.RemoveNestedField(obj.Object, "status", "instanceUUID")
+			},
+			want: "instanceUUID is missing",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := exportableInstanceObj()
+			tc.mutate(obj)
+			data, err := yaml.Marshal(obj.Object)
+			require.NoError(t, err)
+
+			_, err = decodeExport(data)
+			require.ErrorIs(t, err, ErrInvalidExport)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	data, err := Export(context.Background(), newDynamicClient(exportableInstanceObj()), "podinfo", "demo")
+	require.NoError(t, err)
+
+	t.Run("writes spec then status", func(t *testing.T) {
+		client := newDynamicClient()
+		var subresources []string
+		client.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("patch", ResourceModuleInstances,
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				subresources = append(subresources, action.GetSubresource())
+				return true, &unstructured.Unstructured{}, nil
+			})
+
+		rec, err := Import(context.Background(), client, data, false)
+		require.NoError(t, err)
+		assert.Equal(t, "podinfo", rec.Name)
+		assert.Len(t, rec.Inventory.Entries, 1)
+		assert.Equal(t, []string{"", "status"}, subresources)
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		client := newDynamicClient(exportableInstanceObj())
+		_, err := Import(context.Background(), client, data, false)
+		require.ErrorIs(t, err, ErrInventoryExists)
+	})
+}
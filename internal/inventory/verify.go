@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// VerifyEntries checks a record's inventory against itself: the stored count
+// and digest must match the entries, and no resource may be tracked twice.
+// Each returned string describes one discrepancy. A count or digest the writer
+// did not record is not checked.
+func VerifyEntries(rec *Record) []string {
+	var problems []string
+	inv := rec.Inventory
+
+	if inv.Count != 0 && inv.Count != len(inv.Entries) {
+		problems = append(problems, fmt.Sprintf("status.inventory.count is %d but %d entries are recorded", inv.Count, len(inv.Entries)))
+	}
+	if inv.Digest != "" {
+		if computed := ComputeDigest(inv.Entries); computed != inv.Digest {
+			problems = append(problems, fmt.Sprintf("status.inventory.digest is %s but the entries hash to %s", inv.Digest, computed))
+		}
+	}
+
+	for i, e := range inv.Entries {
+		for _, prev := range inv.Entries[:i] {
+			if K8sIdentityEqual(prev, e) {
+				problems = append(problems, fmt.Sprintf("%s is tracked more than once", DescribeEntry(e)))
+				break
+			}
+		}
+	}
+	return problems
+}
+
+// VerifyLabels checks the CR's identity labels: the instance name and
+// namespace labels must match its metadata, and managed-by must name an OPM
+// actor. Each returned string describes one discrepancy.
+func VerifyLabels(rec *Record) []string {
+	var problems []string
+	want := map[string]string{
+		pkgcore.LabelModuleInstanceName:      rec.Name,
+		pkgcore.LabelModuleInstanceNamespace: rec.Namespace,
+	}
+	for _, key := range []string{pkgcore.LabelModuleInstanceName, pkgcore.LabelModuleInstanceNamespace} {
+		got, ok := rec.Labels[key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("label %s is missing (want %q)", key, want[key]))
+		case got != want[key]:
+			problems = append(problems, fmt.Sprintf("label %s is %q, want %q", key, got, want[key]))
+		}
+	}
+	if managedBy, ok := rec.Labels[pkgcore.LabelManagedBy]; !ok {
+		problems = append(problems, fmt.Sprintf("label %s is missing", pkgcore.LabelManagedBy))
+	} else if !pkgcore.IsOPMManagedBy(managedBy) {
+		problems = append(problems, fmt.Sprintf("label %s is %q, which is not an OPM actor", pkgcore.LabelManagedBy, managedBy))
+	}
+	return problems
+}
+
+// VerifyOwnership checks that each live resource of an instance still carries
+// the labels tying it to the instance: its instance UUID label (when the
+// record knows the UUID) and an OPM managed-by label. A resource another tool
+// adopted — Helm relabelling it, or a manual edit that dropped the labels —
+// is returned as foreign-owned, with why. live is expected to hold the
+// instance's inventory resources, as DiscoverResourcesFromInventory returns.
+func VerifyOwnership(rec *Record, live []*unstructured.Unstructured) []kubernetes.ForeignOwnedResource {
+	var foreign []kubernetes.ForeignOwnedResource
+	for _, obj := range live {
+		if reason := foreignOwnership(rec.InstanceUUID, obj.GetLabels()); reason != "" {
+			foreign = append(foreign, kubernetes.ForeignOwnedResource{
+				Kind:      obj.GetKind(),
+				Name:      obj.GetName(),
+				Namespace: obj.GetNamespace(),
+				Reason:    reason,
+			})
+		}
+	}
+	return foreign
+}
+
+// foreignOwnership describes why labels no longer tie a resource to the
+// instance with the given UUID, or returns "" when they do.
+func foreignOwnership(instanceUUID string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return "has no labels"
+	}
+	var reasons []string
+	if instanceUUID != "" {
+		switch uuid, ok := labels[pkgcore.LabelModuleInstanceUUID]; {
+		case !ok:
+			reasons = append(reasons, fmt.Sprintf("label %s is missing", pkgcore.LabelModuleInstanceUUID))
+		case uuid != instanceUUID:
+			reasons = append(reasons, fmt.Sprintf("label %s is %q, want %q", pkgcore.LabelModuleInstanceUUID, uuid, instanceUUID))
+		}
+	}
+	switch managedBy, ok := labels[pkgcore.LabelManagedBy]; {
+	case !ok:
+		reasons = append(reasons, fmt.Sprintf("label %s is missing", pkgcore.LabelManagedBy))
+	case !pkgcore.IsOPMManagedBy(managedBy):
+		reasons = append(reasons, fmt.Sprintf("managed by %q", managedBy))
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -0,0 +1,106 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func TestVerifyEntries(t *testing.T) {
+	entries := []InventoryEntry{
+		{Kind: "Deployment", Namespace: "apps", Name: "web", Version: "v1", Group: "apps"},
+		{Kind: "Service", Namespace: "apps", Name: "web", Version: "v1"},
+	}
+	rec := &Record{Inventory: Inventory{Entries: entries, Count: 2, Digest: ComputeDigest(entries)}}
+	assert.Empty(t, VerifyEntries(rec))
+
+	rec.Inventory.Count = 3
+	rec.Inventory.Digest = "sha256:stale"
+	rec.Inventory.Entries = append(rec.Inventory.Entries, InventoryEntry{Kind: "Service", Namespace: "apps", Name: "web", Version: "v1", Component: "other"})
+	problems := VerifyEntries(rec)
+	assert.Len(t, problems, 2)
+	assert.Contains(t, problems[0], "status.inventory.digest is sha256:stale")
+	assert.Equal(t, "Service/apps/web is tracked more than once", problems[1])
+
+	// A writer that recorded no count or digest is not held to one.
+	assert.Empty(t, VerifyEntries(&Record{Inventory: Inventory{Entries: entries}}))
+}
+
+func TestVerifyLabels(t *testing.T) {
+	rec := &Record{Name: "demo", Namespace: "apps", Labels: map[string]string{
+		pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+		pkgcore.LabelModuleInstanceName:      "demo",
+		pkgcore.LabelModuleInstanceNamespace: "apps",
+	}}
+	assert.Empty(t, VerifyLabels(rec))
+
+	rec.Labels[pkgcore.LabelModuleInstanceName] = "other"
+	delete(rec.Labels, pkgcore.LabelModuleInstanceNamespace)
+	rec.Labels[pkgcore.LabelManagedBy] = "helm"
+	assert.Equal(t, []string{
+		`label module-instance.opmodel.dev/name is "other", want "demo"`,
+		`label module-instance.opmodel.dev/namespace is missing (want "apps")`,
+		`label app.kubernetes.io/managed-by is "helm", which is not an OPM actor`,
+	}, VerifyLabels(rec))
+}
+
+func TestVerifyOwnership(t *testing.T) {
+	labels := func(uuid, managedBy string) map[string]string {
+		l := map[string]string{}
+		if uuid != "" {
+			l[pkgcore.LabelModuleInstanceUUID] = uuid
+		}
+		if managedBy != "" {
+			l[pkgcore.LabelManagedBy] = managedBy
+		}
+		return l
+	}
+
+	tests := []struct {
+		name   string
+		uuid   string // the instance UUID the record knows
+		labels map[string]string
+		want   string // the reason, or "" when the resource is still ours
+	}{
+		{name: "cli managed", uuid: "uuid-1", labels: labels("uuid-1", pkgcore.LabelManagedByValue)},
+		{name: "controller managed", uuid: "uuid-1", labels: labels("uuid-1", pkgcore.LabelManagedByControllerValue)},
+		{name: "legacy OPM value", uuid: "uuid-1", labels: labels("uuid-1", pkgcore.LabelManagedByLegacyValue)},
+		{name: "relabelled by Helm", uuid: "uuid-1", labels: labels("uuid-1", "Helm"), want: `managed by "Helm"`},
+		{name: "relabelled by kubectl", uuid: "uuid-1", labels: labels("uuid-1", "kubectl"), want: `managed by "kubectl"`},
+		{name: "managed-by removed", uuid: "uuid-1", labels: labels("uuid-1", ""),
+			want: "label app.kubernetes.io/managed-by is missing"},
+		{name: "uuid removed", uuid: "uuid-1", labels: labels("", pkgcore.LabelManagedByValue),
+			want: "label module-instance.opmodel.dev/uuid is missing"},
+		{name: "uuid of another instance", uuid: "uuid-1", labels: labels("uuid-2", pkgcore.LabelManagedByValue),
+			want: `label module-instance.opmodel.dev/uuid is "uuid-2", want "uuid-1"`},
+		{name: "both changed", uuid: "uuid-1", labels: labels("uuid-2", "Helm"),
+			want: `label module-instance.opmodel.dev/uuid is "uuid-2", want "uuid-1"; managed by "Helm"`},
+		{name: "no labels", uuid: "uuid-1", want: "has no labels"},
+		{name: "no recorded uuid checks managed-by only", labels: labels("uuid-2", pkgcore.LabelManagedByValue)},
+		{name: "no recorded uuid, foreign managed-by", labels: labels("uuid-2", "Helm"), want: `managed by "Helm"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "cfg", "namespace": "apps"},
+			}}
+			obj.SetLabels(tc.labels)
+			rec := &Record{Name: "web", Namespace: "apps", InstanceUUID: tc.uuid}
+
+			foreign := VerifyOwnership(rec, []*unstructured.Unstructured{obj})
+			if tc.want == "" {
+				assert.Empty(t, foreign)
+				return
+			}
+			assert.Equal(t, []kubernetes.ForeignOwnedResource{
+				{Kind: "ConfigMap", Name: "cfg", Namespace: "apps", Reason: tc.want},
+			}, foreign)
+		})
+	}
+}
@@ -4,18 +4,89 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/charmbracelet/log"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 )
 
+// DefaultApplyConcurrency is the default number of components applied in
+// parallel within an apply wave (--apply-concurrency). One keeps apply
+// strictly sequential in render order; parallelism is opt-in.
+const DefaultApplyConcurrency = 1
+
 // ApplyOptions configures an apply operation.
 type ApplyOptions struct {
 	// DryRun performs a server-side dry run without persisting changes.
 	DryRun bool
+
+	// ClientDryRun sends no writes at all: each resource is compared with
+	// its live object to report whether it would be created, configured,
+	// or left unchanged (--dry-run=client).
+	ClientDryRun bool
+
+	// Concurrency is the number of components applied in parallel within
+	// one apply wave. Zero or one applies everything sequentially.
+	Concurrency int
+
+	// CRDEstablishTimeout bounds the wait, before a custom resource is
+	// applied, for a CRD applied earlier in the same run to become
+	// Established. Zero means DefaultCRDEstablishTimeout.
+	CRDEstablishTimeout time.Duration
+
+	// FailFast stops the apply after the first wave with a failed resource;
+	// later waves are skipped. Without it every wave is applied and the
+	// failures are reported together at the end.
+	FailFast bool
+
+	// ReportOwnership collects each applied resource's field ownership from
+	// the managedFields the server returns (--show-managed-fields).
+	ReportOwnership bool
+
+	// MaxRetries is how many times each resource's apply is retried after a
+	// conflict, server timeout, or throttling response. Zero means
+	// DefaultMaxRetries; negative disables retries.
+	MaxRetries int
+
+	// Reconcile removes fields a previous apply set that this render no
+	// longer sets but another field manager still holds, so the live object
+	// converges to the render (--reconcile). See removeReleasedFields.
+	Reconcile bool
+
+	// LabelConflictPolicy decides which value a label or annotation takes
+	// when the render and the live resource disagree on it
+	// (--label-conflict-policy). Empty means LabelConflictOPMWins.
+	LabelConflictPolicy string
+
+	// Progress, when set, is called as each resource's apply completes, in
+	// completion order. With Concurrency above one it is called from several
+	// goroutines, one call at a time.
+	Progress func(ApplyProgress)
+}
+
+// ApplyProgress reports one completed resource of an apply.
+type ApplyProgress struct {
+	// Done counts the resources completed so far, this one included, out of
+	// Total in the whole apply.
+	Done, Total int
+
+	// Wave is the apply wave the resource belongs to, the Index-th of
+	// Waves waves (from 1).
+	Wave, Index, Waves int
+
+	Kind, Namespace, Name string
+
+	// Err is the resource's apply error, nil on success.
+	Err error
 }
 
 // ApplyResult contains the outcome of an apply operation.
@@ -34,6 +105,29 @@ type ApplyResult struct {
 
 	// Errors contains per-resource errors (non-fatal).
 	Errors []resourceError
+
+	// Ownership is the field ownership of each applied resource, in render
+	// order. Set only with ApplyOptions.ReportOwnership.
+	Ownership []ResourceOwnership
+
+	// Retries is the total number of retries made after transient API
+	// errors, across all resources.
+	Retries int
+
+	// Reconciled is the number of stale fields removed across all
+	// resources. Set only with ApplyOptions.Reconcile.
+	Reconciled int
+
+	// Waves is the outcome of each wave that ran, lowest first.
+	Waves []WaveResult
+
+	// DryRunViews is the API server's view of each resource it accepted
+	// in a server dry run, in render order. Set only with DryRun.
+	DryRunViews []DryRunView
+
+	// Skipped is the number of resources not attempted because an earlier
+	// wave failed under ApplyOptions.FailFast.
+	Skipped int
 }
 
 // resourceError captures an error for a specific resource.
@@ -55,62 +149,314 @@ func (e *resourceError) Error() string {
 }
 
 // Apply performs server-side apply for a set of rendered resources.
-// Resources are assumed to be already ordered by weight (from RenderResult).
 // instanceName is used for logging only.
+//
+// Resources apply in waves (see ResourceWave): by default one per ordering
+// weight, so CRDs precede their custom resources, namespaces precede
+// everything namespaced, and Secrets and ConfigMaps precede the workloads
+// that mount them. Each wave is a barrier: nothing in it starts until the
+// previous wave has finished. Within a wave, resources are grouped by
+// component and up to opts.Concurrency components apply at once, each in its
+// own render order. Results are reported, and errors aggregated, in render
+// order once a wave completes. With opts.FailFast a wave with a failure ends
+// the apply. An invalid wave annotation is returned as an error before
+// anything is applied.
+//
+// A custom resource whose CRD is part of the same set is not applied until
+// that CRD is Established, so a first apply does not race the API server's
+// registration of the kind. If the wait times out, the custom resources are
+// applied anyway and retried while the kind is still missing.
 func Apply(ctx context.Context, client *Client, resources []*unstructured.Unstructured, instanceName string, opts ApplyOptions) (*ApplyResult, error) {
+	waves, err := applyWaves(resources)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &ApplyResult{}
 	instanceLog := output.InstanceLogger(instanceName)
+	crds := releaseCRDs(resources)
+	appliedCRDs := make(map[string]bool)
+	progress := &progressCounter{report: opts.Progress, total: len(resources), waves: len(waves)}
 
-	for _, res := range resources {
-		kind := res.GetKind()
-		name := res.GetName()
-		ns := res.GetNamespace()
-
-		// Apply the resource
-		status, err := ApplyOne(ctx, client, res, opts)
-		if err != nil {
-			instanceLog.Warn(fmt.Sprintf("applying %s/%s: %v", kind, name, err))
-			result.Errors = append(result.Errors, resourceError{
-				Kind:      kind,
-				Name:      name,
-				Namespace: ns,
-				Err:       err,
-			})
-			continue
+	for w, wave := range waves {
+		if opts.FailFast && len(result.Errors) > 0 {
+			for _, rest := range waves[w:] {
+				result.Skipped += len(rest.resources)
+			}
+			instanceLog.Warn(fmt.Sprintf("skipping %d resource(s) in later waves after a failure (fail-fast)", result.Skipped))
+			break
 		}
 
-		result.Applied++
-		switch status {
-		case output.StatusCreated:
-			result.Created++
-		case output.StatusConfigured:
-			result.Configured++
-		case output.StatusUnchanged:
-			result.Unchanged++
+		bucket := wave.resources
+		start := time.Now()
+		progress.wave, progress.index = wave.wave, w+1
+		outcomes := applyBucket(ctx, client, bucket, opts, awaitCRDs(ctx, client, bucket, crds, appliedCRDs, opts, instanceLog), progress)
+		waveResult := WaveResult{Wave: wave.wave, Resources: len(bucket), Duration: time.Since(start)}
+
+		for i, res := range bucket {
+			kind := res.GetKind()
+			name := res.GetName()
+			ns := res.GetNamespace()
+			status, err := outcomes[i].status, outcomes[i].err
+			result.Retries += outcomes[i].retries
+			result.Reconciled += outcomes[i].reconciled
+
+			if err != nil {
+				instanceLog.Warn(fmt.Sprintf("applying %s/%s: %v", kind, name, err))
+				output.EmitResource(instanceName, kind, ns, name, "", err)
+				result.Errors = append(result.Errors, resourceError{
+					Kind:      kind,
+					Name:      name,
+					Namespace: ns,
+					Err:       err,
+				})
+				waveResult.Failed++
+				continue
+			}
+
+			result.Applied++
+			if _, ok := servedKind(res); ok {
+				appliedCRDs[name] = true
+			}
+			switch status {
+			case output.StatusCreated:
+				result.Created++
+			case output.StatusConfigured:
+				result.Configured++
+			case output.StatusUnchanged:
+				result.Unchanged++
+			}
+			instanceLog.Info(output.FormatResourceLine(kind, ns, name, status))
+			if len(outcomes[i].conceded) > 0 {
+				instanceLog.Info(fmt.Sprintf("%s/%s: kept existing %s (label conflict policy %s)",
+					kind, name, strings.Join(outcomes[i].conceded, ", "), opts.LabelConflictPolicy))
+			}
+			output.EmitResource(instanceName, kind, ns, name, status, nil)
+			if opts.DryRun && outcomes[i].applied != nil {
+				result.DryRunViews = append(result.DryRunViews, DryRunView{Rendered: res, Server: outcomes[i].applied})
+			}
+			if opts.ReportOwnership && outcomes[i].applied != nil {
+				result.Ownership = append(result.Ownership, ownershipFromObject(outcomes[i].applied))
+			}
 		}
-		instanceLog.Info(output.FormatResourceLine(kind, ns, name, status))
+
+		result.Waves = append(result.Waves, waveResult)
+		instanceLog.Debug(fmt.Sprintf("wave %d: %d resource(s) in %s", waveResult.Wave, waveResult.Resources,
+			waveResult.Duration.Round(time.Millisecond)), "failed", waveResult.Failed)
 	}
 
 	return result, nil
 }
 
+// awaitCRDs waits, before bucket is applied, for the CRDs of any custom
+// resources in it that were applied earlier in this run to become
+// Established, and returns the kinds whose custom resources should be retried
+// while still unserved. Each CRD is waited for at most once: after a timeout
+// its custom resources fall back to those retries (see applyBucket). A dry
+// run of either kind registers no CRD, so it neither waits nor retries.
+func awaitCRDs(ctx context.Context, client *Client, bucket []*unstructured.Unstructured, crds map[schema.GroupKind]string,
+	appliedCRDs map[string]bool, opts ApplyOptions, instanceLog *log.Logger) map[schema.GroupKind]string {
+	if opts.DryRun || opts.ClientDryRun {
+		return nil
+	}
+	var names []string
+	for _, res := range bucket {
+		name, ok := crds[res.GroupVersionKind().GroupKind()]
+		if ok && appliedCRDs[name] && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return crds
+	}
+	for _, name := range names {
+		delete(appliedCRDs, name)
+	}
+	timeout := opts.CRDEstablishTimeout
+	if timeout <= 0 {
+		timeout = DefaultCRDEstablishTimeout
+	}
+	instanceLog.Debug(fmt.Sprintf("waiting for CRD %s to become established", strings.Join(names, ", ")))
+	if err := waitForCRDs(ctx, client, names, timeout); err != nil {
+		instanceLog.Warn(fmt.Sprintf("%v; applying its custom resources with retries", err))
+	}
+	// The new kinds are missing from the cached discovery; map them afresh.
+	client.InvalidateMappings()
+	return crds
+}
+
+// applyOutcome is the result of applying one resource of a wave.
+type applyOutcome struct {
+	status string
+	err    error
+	// applied is the server's view of the object after the apply.
+	applied *unstructured.Unstructured
+	// retries is the number of retries the apply needed.
+	retries int
+	// ownedBefore is what opm-cli owned before the apply, recorded only
+	// with ApplyOptions.Reconcile.
+	ownedBefore [][]string
+	// reconciled is the number of released fields removed after the apply.
+	reconciled int
+	// conceded lists the labels and annotations that kept their live value
+	// under ApplyOptions.LabelConflictPolicy.
+	conceded []string
+}
+
+// progressCounter numbers completed resources for ApplyOptions.Progress
+// across the waves of one apply.
+type progressCounter struct {
+	mu     sync.Mutex
+	report func(ApplyProgress)
+	done   int
+	total  int
+	waves  int
+	// wave and index are the wave being applied; set between waves only.
+	wave, index int
+}
+
+// step reports res as completed with err.
+func (p *progressCounter) step(res *unstructured.Unstructured, err error) {
+	if p == nil || p.report == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.report(ApplyProgress{
+		Done: p.done, Total: p.total,
+		Wave: p.wave, Index: p.index, Waves: p.waves,
+		Kind: res.GetKind(), Namespace: res.GetNamespace(), Name: res.GetName(),
+		Err: err,
+	})
+}
+
+// applyBucket applies one wave's resources and returns an outcome per
+// resource, in bucket order. Each component's resources apply sequentially;
+// components run concurrently up to opts.Concurrency. Each completed resource
+// is reported to progress.
+//
+// Custom resources of a kind in crds are also retried while the API server
+// does not serve the kind yet.
+func applyBucket(ctx context.Context, client *Client, bucket []*unstructured.Unstructured, opts ApplyOptions, crds map[schema.GroupKind]string, progress *progressCounter) []applyOutcome {
+	outcomes := make([]applyOutcome, len(bucket))
+
+	// Group resource indices by component, keeping first-seen order.
+	var groups [][]int
+	byComponent := make(map[string]int)
+	for i, res := range bucket {
+		component := res.GetLabels()[pkgcore.LabelComponentName]
+		g, ok := byComponent[component]
+		if !ok {
+			g = len(groups)
+			byComponent[component] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	applyGroup := func(indices []int) {
+		for _, i := range indices {
+			_, dependent := crds[bucket[i].GroupVersionKind().GroupKind()]
+			outcomes[i] = applyOne(ctx, client, bucket[i], opts, dependent)
+			progress.step(bucket[i], outcomes[i].err)
+		}
+	}
+
+	if opts.Concurrency <= 1 || len(groups) == 1 {
+		for _, g := range groups {
+			applyGroup(g)
+		}
+		return outcomes
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, opts.Concurrency)
+	for _, g := range groups {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(indices []int) {
+			defer func() { <-sem; wg.Done() }()
+			applyGroup(indices)
+		}(g)
+	}
+	wg.Wait()
+	return outcomes
+}
+
 // ApplyOne performs server-side apply for a single resource.
 // Returns the status of the operation (created, configured, or unchanged).
+// Transient API errors are retried as configured by opts.MaxRetries.
 func ApplyOne(ctx context.Context, client *Client, obj *unstructured.Unstructured, opts ApplyOptions) (string, error) {
-	gvr := GVRFromUnstructured(obj)
+	out := applyOne(ctx, client, obj, opts, false)
+	return out.status, out.err
+}
+
+// applyOne is ApplyOne that also returns the server's view of the applied
+// object and the retries it took. With retryMissingKind, the apply is also
+// retried while the API server does not serve obj's kind. With opts.Reconcile, released fields are
+// removed in a second, separately retried step once the apply has succeeded,
+// so a retry never re-reads ownership the apply has already changed.
+func applyOne(ctx context.Context, client *Client, obj *unstructured.Unstructured, opts ApplyOptions, retryMissingKind bool) applyOutcome {
+	var out applyOutcome
+	maxRetries := resolveMaxRetries(opts.MaxRetries)
+	describe := obj.GetKind() + "/" + obj.GetName()
+	retryable := isRetryable
+	if retryMissingKind {
+		retryable = func(err error) bool { return isRetryable(err) || isMissingKind(err) }
+	}
+	retries, err := withRetryIf(ctx, maxRetries, describe, retryable, func() error {
+		var err error
+		if opts.ClientDryRun {
+			out, err = clientDryRunOnce(ctx, client, obj)
+			return err
+		}
+		out, err = applyOnce(ctx, client, obj, opts)
+		return err
+	})
+	out.retries, out.err = retries, err
+	if err != nil || !opts.Reconcile || out.ownedBefore == nil || out.applied == nil {
+		return out
+	}
+
+	retries, out.err = withRetry(ctx, maxRetries, describe, func() error {
+		n, reconciled, err := removeReleasedFields(ctx, client, out.applied, out.ownedBefore, opts.DryRun)
+		if err != nil {
+			return err
+		}
+		out.reconciled, out.applied = n, reconciled
+		return nil
+	})
+	out.retries += retries
+	if out.err != nil {
+		out.err = fmt.Errorf("removing released fields: %w", out.err)
+	} else if out.reconciled > 0 && out.status == output.StatusUnchanged {
+		out.status = output.StatusConfigured
+	}
+	return out
+}
+
+// applyOnce makes one server-side apply attempt for obj.
+func applyOnce(ctx context.Context, client *Client, obj *unstructured.Unstructured, opts ApplyOptions) (applyOutcome, error) {
+	gvr := client.GVRFor(obj)
 	ns := obj.GetNamespace()
 
 	// Check if resource already exists to determine status after apply.
 	var existingVersion string
 	existing, err := client.ResourceClient(gvr, ns).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	var out applyOutcome
 	if err == nil {
 		existingVersion = existing.GetResourceVersion()
+		if opts.Reconcile {
+			out.ownedBefore = opmOwnedFields(existing)
+		}
+		obj, out.conceded = resolveMetadataConflicts(obj, existing, opts.LabelConflictPolicy)
 	}
 	// If GET fails (NotFound or other), existingVersion stays empty -> "created"
 
 	data, err := json.Marshal(obj)
 	if err != nil {
-		return "", fmt.Errorf("marshaling resource: %w", err)
+		return out, fmt.Errorf("marshaling resource: %w", err)
 	}
 
 	patchOpts := metav1.PatchOptions{
@@ -127,15 +473,18 @@ func ApplyOne(ctx context.Context, client *Client, obj *unstructured.Unstructure
 	)
 
 	if patchErr != nil {
-		return "", patchErr
+		return out, patchErr
 	}
+	out.applied = result
 
 	// Determine status from before/after comparison.
-	if existingVersion == "" {
-		return output.StatusCreated, nil
-	}
-	if result != nil && result.GetResourceVersion() == existingVersion {
-		return output.StatusUnchanged, nil
+	switch {
+	case existingVersion == "":
+		out.status = output.StatusCreated
+	case result != nil && result.GetResourceVersion() == existingVersion:
+		out.status = output.StatusUnchanged
+	default:
+		out.status = output.StatusConfigured
 	}
-	return output.StatusConfigured, nil
+	return out, nil
 }
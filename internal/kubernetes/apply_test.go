@@ -1 +1,231 @@
 package kubernetes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func componentObject(apiVersion, kind, name, component string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": "media"},
+	}}
+	obj.SetLabels(map[string]string{pkgcore.LabelComponentName: component})
+	return obj
+}
+
+// applyRecorder is a fake cluster that records the order in which patches
+// start and finish and the peak number in flight. Each patch holds for a
+// moment so concurrent patches overlap. The hold happens outside the fake
+// client, whose reactors run under a single lock.
+type applyRecorder struct {
+	mu       sync.Mutex
+	events   []string
+	inFlight int
+	peak     int
+	fail     map[string]bool
+}
+
+func (r *applyRecorder) client() *Client {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get := action.(k8stesting.GetAction)
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: get.GetResource().Resource}, get.GetName())
+	})
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.PatchAction).GetName()
+		if r.fail[name] {
+			return true, nil, errors.New("admission webhook denied the request")
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetName(name)
+		obj.SetResourceVersion("1")
+		return true, obj, nil
+	})
+	return &Client{Dynamic: recordingDynamic{Interface: dyn, rec: r}}
+}
+
+func (r *applyRecorder) record(event string, delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	r.inFlight += delta
+	r.peak = max(r.peak, r.inFlight)
+}
+
+type recordingDynamic struct {
+	dynamic.Interface
+	rec *applyRecorder
+}
+
+func (d recordingDynamic) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return recordingResource{NamespaceableResourceInterface: d.Interface.Resource(gvr), rec: d.rec}
+}
+
+type recordingResource struct {
+	dynamic.NamespaceableResourceInterface
+	rec *applyRecorder
+}
+
+func (r recordingResource) Namespace(ns string) dynamic.ResourceInterface {
+	return recordingNamespaced{ResourceInterface: r.NamespaceableResourceInterface.Namespace(ns), rec: r.rec}
+}
+
+type recordingNamespaced struct {
+	dynamic.ResourceInterface
+	rec *applyRecorder
+}
+
+func (r recordingNamespaced) Patch(ctx context.Context, name string, pt types.PatchType, data []byte,
+	opts metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	r.rec.record("start "+name, 1)
+	time.Sleep(50 * time.Millisecond)
+	defer r.rec.record("end "+name, -1)
+	return r.ResourceInterface.Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+// position returns the index of event in r.events, or -1.
+func (r *applyRecorder) position(event string) int {
+	for i, e := range r.events {
+		if e == event {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestApply_ConcurrentWithinBucketsOnly(t *testing.T) {
+	resources := []*unstructured.Unstructured{
+		componentObject("v1", "ConfigMap", "web-config", "web"),
+		componentObject("v1", "ConfigMap", "db-config", "db"),
+		componentObject("apps/v1", "Deployment", "web", "web"),
+		componentObject("apps/v1", "StatefulSet", "db", "db"),
+		componentObject("apps/v1", "Deployment", "worker", "worker"),
+	}
+	rec := &applyRecorder{fail: map[string]bool{"db": true}}
+
+	result, err := Apply(context.Background(), rec.client(), resources, "demo", ApplyOptions{Concurrency: 4})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, rec.peak, "the three workload components apply at once")
+	for _, cm := range []string{"end web-config", "end db-config"} {
+		for _, wl := range []string{"start web", "start db", "start worker"} {
+			assert.Less(t, rec.position(cm), rec.position(wl), "%s before %s: buckets are barriers", cm, wl)
+		}
+	}
+
+	assert.Equal(t, 4, result.Applied)
+	assert.Equal(t, 4, result.Created)
+	require.Len(t, result.Errors, 1, "a failing component does not stop the others")
+	assert.Equal(t, "db", result.Errors[0].Name)
+}
+
+func TestApply_ComponentResourcesStaySequential(t *testing.T) {
+	resources := []*unstructured.Unstructured{
+		componentObject("apps/v1", "Deployment", "web-a", "web"),
+		componentObject("apps/v1", "Deployment", "web-b", "web"),
+	}
+	rec := &applyRecorder{}
+
+	_, err := Apply(context.Background(), rec.client(), resources, "demo", ApplyOptions{Concurrency: 4})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, rec.peak)
+	assert.Equal(t, []string{"start web-a", "end web-a", "start web-b", "end web-b"}, rec.events)
+}
+
+func TestApply_ZeroConcurrencyIsSequential(t *testing.T) {
+	resources := []*unstructured.Unstructured{
+		componentObject("apps/v1", "Deployment", "web", "web"),
+		componentObject("apps/v1", "Deployment", "worker", "worker"),
+	}
+	rec := &applyRecorder{}
+
+	_, err := Apply(context.Background(), rec.client(), resources, "demo", ApplyOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, rec.peak)
+}
+
+func TestApply_Progress(t *testing.T) {
+	resources := []*unstructured.Unstructured{
+		componentObject("v1", "ConfigMap", "web-config", "web"),
+		componentObject("apps/v1", "Deployment", "web", "web"),
+		componentObject("apps/v1", "Deployment", "worker", "worker"),
+	}
+	rec := &applyRecorder{fail: map[string]bool{"worker": true}}
+
+	var steps []ApplyProgress
+	_, err := Apply(context.Background(), rec.client(), resources, "demo", ApplyOptions{
+		Concurrency: 4,
+		Progress:    func(p ApplyProgress) { steps = append(steps, p) },
+	})
+	require.NoError(t, err)
+
+	require.Len(t, steps, 3)
+	assert.Equal(t, ApplyProgress{Done: 1, Total: 3, Wave: 15, Index: 1, Waves: 2, Kind: "ConfigMap", Namespace: "media", Name: "web-config"}, steps[0])
+	for i, p := range steps {
+		assert.Equal(t, i+1, p.Done, "steps are numbered in completion order")
+	}
+	for _, p := range steps[1:] {
+		assert.Equal(t, 2, p.Index)
+		if p.Name == "worker" {
+			assert.Error(t, p.Err)
+		} else {
+			assert.NoError(t, p.Err)
+		}
+	}
+}
+
+func TestApply_WaveFailure(t *testing.T) {
+	resources := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			componentObject("v1", "Secret", "creds", "web"),
+			componentObject("v1", "ConfigMap", "settings", "web"),
+			componentObject("apps/v1", "Deployment", "web", "web"),
+		}
+	}
+
+	t.Run("fail-fast skips later waves", func(t *testing.T) {
+		rec := &applyRecorder{fail: map[string]bool{"creds": true}}
+		result, err := Apply(context.Background(), rec.client(), resources(), "demo", ApplyOptions{FailFast: true})
+		require.NoError(t, err)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, 1, result.Applied, "the rest of the failing wave still applies")
+		assert.Equal(t, 1, result.Skipped)
+		require.Len(t, result.Waves, 1)
+		assert.Equal(t, WaveResult{Wave: 15, Resources: 2, Failed: 1}, WaveResult{
+			Wave: result.Waves[0].Wave, Resources: result.Waves[0].Resources, Failed: result.Waves[0].Failed,
+		})
+		assert.Equal(t, -1, rec.position("start web"))
+	})
+
+	t.Run("fail-on-end applies every wave", func(t *testing.T) {
+		rec := &applyRecorder{fail: map[string]bool{"creds": true}}
+		result, err := Apply(context.Background(), rec.client(), resources(), "demo", ApplyOptions{})
+		require.NoError(t, err)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, 2, result.Applied)
+		assert.Zero(t, result.Skipped)
+		require.Len(t, result.Waves, 2)
+		assert.Positive(t, result.Waves[1].Duration)
+	})
+}
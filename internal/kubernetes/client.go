@@ -4,20 +4,36 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 	oerrors "github.com/open-platform-model/cli/pkg/errors"
 )
 
+// ErrNamespaceTerminating is returned by EnsureNamespace when the namespace
+// exists but is being deleted: nothing can be created in it until it is gone.
+var ErrNamespaceTerminating = errors.New("namespace is terminating")
+
+// DefaultQPS and DefaultBurst are the client-side rate limits used when
+// ClientOptions leaves them zero. client-go's own defaults (5 QPS, burst 10)
+// throttle a parallel apply of a large release before the API server does.
+const (
+	DefaultQPS   float32 = 50
+	DefaultBurst int     = 100
+)
+
 // ClientOptions configures Kubernetes client creation.
 // All fields must be pre-resolved by the caller (via config.ResolveKubernetes).
 // No further precedence resolution is performed inside the client.
@@ -33,6 +49,19 @@ type ClientOptions struct {
 	// APIWarnings controls how K8s API warnings are handled.
 	// Valid values: "warn", "debug", "suppress". Default: "warn"
 	APIWarnings string
+
+	// QPS is the sustained request rate the client allows itself.
+	// Zero means DefaultQPS; negative disables client-side rate limiting.
+	QPS float32
+
+	// Burst is the number of requests allowed above QPS in a short spike.
+	// Zero means DefaultBurst.
+	Burst int
+
+	// Impersonate makes every request as this user, groups, and UID
+	// (--as, --as-group, --as-uid), for checking what an RBAC subject may do.
+	// The zero value acts as the kubeconfig user.
+	Impersonate rest.ImpersonationConfig
 }
 
 // Client wraps Kubernetes API clients for OPM operations.
@@ -45,6 +74,16 @@ type Client struct {
 
 	// RestConfig is the underlying REST configuration.
 	RestConfig *rest.Config
+
+	// Context is the kubeconfig context the client was built from: the
+	// --context value, or the kubeconfig's current-context.
+	Context string
+
+	// mapper resolves kinds to resources from cached discovery (see GVRFor);
+	// rediscovered holds the kinds that have already forced a rediscovery.
+	mapperMu     sync.Mutex
+	mapper       meta.RESTMapper
+	rediscovered map[schema.GroupKind]bool
 }
 
 // cachedClient stores the singleton client for reuse within a command.
@@ -54,7 +93,9 @@ var (
 )
 
 // NewClient creates a Kubernetes client with the given options.
-// The client is cached for reuse within the same command invocation.
+// The client is cached for reuse within the same command invocation, and a
+// cached client ignores the options of later calls: callers that change them
+// between invocations (QPS and Burst included) must call ResetClient first.
 func NewClient(opts ClientOptions) (*Client, error) {
 	clientMu.Lock()
 	defer clientMu.Unlock()
@@ -63,7 +104,7 @@ func NewClient(opts ClientOptions) (*Client, error) {
 		return cachedClient, nil
 	}
 
-	restConfig, err := buildRestConfig(opts)
+	restConfig, contextName, err := buildRestConfig(opts)
 	if err != nil {
 		return nil, fmt.Errorf("building kubernetes config: %w",
 			oerrors.Wrap(oerrors.ErrConnectivity, err.Error()))
@@ -75,6 +116,10 @@ func NewClient(opts ClientOptions) (*Client, error) {
 		warningLevel = "warn" // default
 	}
 	restConfig.WarningHandler = &opmWarningHandler{level: warningLevel, logger: outputWarningLogger{}}
+	restConfig.QPS, restConfig.Burst = rateLimits(opts)
+	if opts.Impersonate.UserName != "" || len(opts.Impersonate.Groups) > 0 || opts.Impersonate.UID != "" {
+		restConfig.Impersonate = opts.Impersonate
+	}
 
 	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
@@ -92,6 +137,7 @@ func NewClient(opts ClientOptions) (*Client, error) {
 		Dynamic:    dynamicClient,
 		Clientset:  clientset,
 		RestConfig: restConfig,
+		Context:    contextName,
 	}
 
 	return cachedClient, nil
@@ -99,10 +145,16 @@ func NewClient(opts ClientOptions) (*Client, error) {
 
 // EnsureNamespace checks if a namespace exists and creates it if missing.
 // Returns true if the namespace was created, false if it already existed.
+// A created namespace is labeled as managed by OPM. A namespace that exists
+// but is terminating is an ErrNamespaceTerminating error, rather than an
+// apply that would hang or fail resource by resource.
 // When dryRun is true, the namespace is not actually created.
 func (c *Client) EnsureNamespace(ctx context.Context, name string, dryRun bool) (bool, error) {
-	_, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	existing, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 	if err == nil {
+		if existing.Status.Phase == corev1.NamespaceTerminating || existing.DeletionTimestamp != nil {
+			return false, fmt.Errorf("namespace %q: %w; wait for its deletion to finish, then retry", name, ErrNamespaceTerminating)
+		}
 		return false, nil // already exists
 	}
 
@@ -116,7 +168,8 @@ func (c *Client) EnsureNamespace(ctx context.Context, name string, dryRun bool)
 
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name:   name,
+			Labels: map[string]string{pkgcore.LabelManagedBy: pkgcore.LabelManagedByValue},
 		},
 	}
 	_, err = c.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
@@ -131,17 +184,31 @@ func (c *Client) EnsureNamespace(ctx context.Context, name string, dryRun bool)
 	return true, nil
 }
 
-// ResetClient clears the cached client. Used for testing.
+// ResetClient clears the cached client, so the next NewClient builds one from
+// its options. Used for testing and when client options change.
 func ResetClient() {
 	clientMu.Lock()
 	defer clientMu.Unlock()
 	cachedClient = nil
 }
 
-// buildRestConfig builds a REST config from pre-resolved options.
+// rateLimits returns the QPS and burst for opts, filling in the defaults.
+func rateLimits(opts ClientOptions) (float32, int) {
+	qps, burst := opts.QPS, opts.Burst
+	if qps == 0 {
+		qps = DefaultQPS
+	}
+	if burst == 0 {
+		burst = DefaultBurst
+	}
+	return qps, burst
+}
+
+// buildRestConfig builds a REST config from pre-resolved options, and returns
+// the name of the kubeconfig context it was built from.
 // Kubeconfig and Context must already be resolved by the caller (via config.ResolveKubernetes).
 // When Kubeconfig is empty, client-go's default discovery applies (KUBECONFIG env / ~/.kube/config).
-func buildRestConfig(opts ClientOptions) (*rest.Config, error) {
+func buildRestConfig(opts ClientOptions) (*rest.Config, string, error) {
 	var loadingRules *clientcmd.ClientConfigLoadingRules
 	if opts.Kubeconfig != "" {
 		loadingRules = &clientcmd.ClientConfigLoadingRules{
@@ -162,5 +229,15 @@ func buildRestConfig(opts ClientOptions) (*rest.Config, error) {
 		overrides,
 	)
 
-	return clientConfig.ClientConfig()
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	contextName := opts.Context
+	if contextName == "" {
+		if raw, rawErr := clientConfig.RawConfig(); rawErr == nil {
+			contextName = raw.CurrentContext
+		}
+	}
+	return restConfig, contextName, nil
 }
@@ -2,12 +2,24 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 )
 
 func TestEnsureNamespace(t *testing.T) {
@@ -25,6 +37,7 @@ func TestEnsureNamespace(t *testing.T) {
 		ns, err := fakeClientset.CoreV1().Namespaces().Get(ctx, "my-ns", metav1.GetOptions{})
 		assert.NoError(t, err)
 		assert.Equal(t, "my-ns", ns.Name)
+		assert.Equal(t, pkgcore.LabelManagedByValue, ns.Labels[pkgcore.LabelManagedBy])
 	})
 
 	t.Run("returns false when namespace exists", func(t *testing.T) {
@@ -38,6 +51,18 @@ func TestEnsureNamespace(t *testing.T) {
 		assert.False(t, created)
 	})
 
+	t.Run("fails on a terminating namespace", func(t *testing.T) {
+		fakeClientset := fake.NewSimpleClientset(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "going-ns"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+		})
+		client := &Client{Clientset: fakeClientset}
+
+		created, err := client.EnsureNamespace(ctx, "going-ns", false)
+		assert.ErrorIs(t, err, ErrNamespaceTerminating)
+		assert.False(t, created)
+	})
+
 	t.Run("dry run does not create namespace", func(t *testing.T) {
 		fakeClientset := fake.NewSimpleClientset()
 		client := &Client{Clientset: fakeClientset}
@@ -55,9 +80,107 @@ func TestEnsureNamespace(t *testing.T) {
 func TestBuildRestConfig_InvalidPath(t *testing.T) {
 	// buildRestConfig with a nonexistent kubeconfig path should return an error.
 	// Values are treated as pre-resolved — no further env/precedence resolution occurs.
-	_, err := buildRestConfig(ClientOptions{
+	_, _, err := buildRestConfig(ClientOptions{
 		Kubeconfig: "/nonexistent/path/kubeconfig",
 		Context:    "nonexistent-context",
 	})
 	assert.Error(t, err, "expected error for nonexistent kubeconfig path")
 }
+
+func TestBuildRestConfig_ContextName(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: a
+  cluster: {server: "https://a.example.com"}
+- name: b
+  cluster: {server: "https://b.example.com"}
+contexts:
+- name: a
+  context: {cluster: a, user: u}
+- name: b
+  context: {cluster: b, user: u}
+current-context: a
+users:
+- name: u
+  user: {token: secret}
+`), 0o600))
+
+	restConfig, contextName, err := buildRestConfig(ClientOptions{Kubeconfig: kubeconfig})
+	require.NoError(t, err)
+	assert.Equal(t, "a", contextName, "the kubeconfig's current-context")
+	assert.Equal(t, "https://a.example.com", restConfig.Host)
+
+	restConfig, contextName, err = buildRestConfig(ClientOptions{Kubeconfig: kubeconfig, Context: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, "b", contextName)
+	assert.Equal(t, "https://b.example.com", restConfig.Host)
+}
+
+func TestRateLimits(t *testing.T) {
+	qps, burst := rateLimits(ClientOptions{})
+	assert.Equal(t, DefaultQPS, qps)
+	assert.Equal(t, DefaultBurst, burst)
+
+	qps, burst = rateLimits(ClientOptions{QPS: 10, Burst: 20})
+	assert.Equal(t, float32(10), qps)
+	assert.Equal(t, 20, burst)
+
+	qps, _ = rateLimits(ClientOptions{QPS: -1})
+	assert.Equal(t, float32(-1), qps, "negative QPS is passed through to disable rate limiting")
+}
+
+// TestNewClient_ImpersonatedForbiddenApply runs an apply as an impersonated
+// user against an API server that denies it: the impersonation headers reach
+// the server, and the resource error carries the server's verb and resource.
+func TestNewClient_ImpersonatedForbiddenApply(t *testing.T) {
+	var gotUser string
+	var gotGroups []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = r.Header.Get("Impersonate-User")
+		gotGroups = r.Header.Values("Impersonate-Group")
+		verb := strings.ToLower(r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,`+
+			`"details":{"name":"web","group":"apps","kind":"deployments"},`+
+			`"message":"deployments.apps \"web\" is forbidden: User \"%s\" cannot %s resource \"deployments\" in API group \"apps\" in the namespace \"media\""}`,
+			gotUser, verb)
+	}))
+	defer srv.Close()
+
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster: {server: "`+srv.URL+`"}
+contexts:
+- name: test
+  context: {cluster: test, user: test}
+current-context: test
+users:
+- name: test
+  user: {token: secret}
+`), 0o600))
+
+	ResetClient()
+	t.Cleanup(ResetClient)
+	client, err := NewClient(ClientOptions{
+		Kubeconfig:  kubeconfig,
+		Impersonate: rest.ImpersonationConfig{UserName: "alice", Groups: []string{"dev", "qa"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", client.RestConfig.Impersonate.UserName)
+
+	result, err := Apply(context.Background(), client, []*unstructured.Unstructured{
+		makeUnstructured("apps/v1", "Deployment", "web", "media"),
+	}, "demo", ApplyOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "alice", gotUser)
+	assert.Equal(t, []string{"dev", "qa"}, gotGroups)
+	assert.Contains(t, result.Errors[0].Error(), `User "alice" cannot patch resource "deployments" in API group "apps"`)
+	assert.True(t, apierrors.IsForbidden(result.Errors[0].Err))
+}
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultCRDEstablishTimeout bounds the wait for a CRD applied in the same
+// run to become Established when ApplyOptions.CRDEstablishTimeout is zero.
+const DefaultCRDEstablishTimeout = time.Minute
+
+// crdPollInterval is how often a CRD wait re-reads the CRDs. A variable so
+// tests can poll without sleeping.
+var crdPollInterval = time.Second
+
+// crdGroupKind identifies a CustomResourceDefinition resource.
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// crdGVR is the resource a CustomResourceDefinition is read through.
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// CRDEstablished reports whether a CustomResourceDefinition has the
+// Established=True condition: the API server serves its kind.
+func CRDEstablished(crd *unstructured.Unstructured) bool {
+	conditions, found, err := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil || !found {
+		return false
+	}
+	for _, raw := range conditions {
+		c, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if c["type"] == "Established" && c["status"] == conditionStatusTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// servedKind returns the group and kind a CustomResourceDefinition defines,
+// and false when obj is not a CRD or does not name both.
+func servedKind(obj *unstructured.Unstructured) (schema.GroupKind, bool) {
+	if obj.GroupVersionKind().GroupKind() != crdGroupKind {
+		return schema.GroupKind{}, false
+	}
+	group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")        //nolint:errcheck // absent is handled below
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind") //nolint:errcheck // absent is handled below
+	if group == "" || kind == "" {
+		return schema.GroupKind{}, false
+	}
+	return schema.GroupKind{Group: group, Kind: kind}, true
+}
+
+// releaseCRDs maps each kind a CRD in resources defines to that CRD's name,
+// keeping only kinds some other resource in the set is an instance of: only
+// those are worth waiting for.
+func releaseCRDs(resources []*unstructured.Unstructured) map[schema.GroupKind]string {
+	defined := make(map[schema.GroupKind]string)
+	for _, res := range resources {
+		if gk, ok := servedKind(res); ok {
+			defined[gk] = res.GetName()
+		}
+	}
+	used := make(map[schema.GroupKind]string)
+	for _, res := range resources {
+		gk := res.GroupVersionKind().GroupKind()
+		if name, ok := defined[gk]; ok {
+			used[gk] = name
+		}
+	}
+	return used
+}
+
+// waitForCRDs polls the named CRDs until each is Established or timeout
+// elapses. A timeout is returned as an error naming the CRDs still pending.
+func waitForCRDs(ctx context.Context, client *Client, names []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := names
+	for {
+		var still []string
+		for _, name := range pending {
+			crd, err := client.ResourceClient(crdGVR, "").Get(ctx, name, metav1.GetOptions{})
+			if err != nil || !CRDEstablished(crd) {
+				still = append(still, name)
+			}
+		}
+		if len(still) == 0 {
+			return nil
+		}
+		pending = still
+
+		timer := time.NewTimer(crdPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			sort.Strings(pending)
+			return fmt.Errorf("timed out after %s waiting for CRD %s to become established", timeout, strings.Join(pending, ", "))
+		case <-timer.C:
+		}
+	}
+}
+
+// isMissingKind reports whether err is the API server not (yet) serving the
+// requested kind: what applying a custom resource returns while its CRD is
+// still being registered.
+func isMissingKind(err error) bool {
+	return apierrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func widgetCRD(established bool) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "widgets.example.com"},
+		"spec": map[string]any{
+			"group": "example.com",
+			"names": map[string]any{"kind": "Widget", "plural": "widgets"},
+		},
+	}}
+	if established {
+		obj.Object["status"] = map[string]any{"conditions": []any{
+			map[string]any{"type": "Established", "status": "True"},
+		}}
+	}
+	return obj
+}
+
+// crdCluster is a fake cluster that establishes the widget CRD after a number
+// of reads, and rejects widgets with NotFound until it is established.
+type crdCluster struct {
+	mu          sync.Mutex
+	readsToWait int
+	established bool
+	events      []string
+}
+
+func (c *crdCluster) client() *Client {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get := action.(k8stesting.GetAction)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if get.GetResource().Resource == "customresourcedefinitions" {
+			if c.readsToWait == 0 {
+				c.established = true
+			}
+			c.readsToWait--
+			return true, widgetCRD(c.established), nil
+		}
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: get.GetResource().Resource}, get.GetName())
+	})
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if patch.GetResource().Resource == "widgets" && !c.established {
+			c.events = append(c.events, "rejected "+patch.GetName())
+			return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "example.com", Resource: "widgets"}, patch.GetName())
+		}
+		c.events = append(c.events, "applied "+patch.GetName())
+		obj := &unstructured.Unstructured{}
+		obj.SetName(patch.GetName())
+		obj.SetResourceVersion("1")
+		return true, obj, nil
+	})
+	return &Client{Dynamic: dyn}
+}
+
+func fastCRDPoll(t *testing.T) {
+	t.Helper()
+	prev := crdPollInterval
+	crdPollInterval = time.Millisecond
+	t.Cleanup(func() { crdPollInterval = prev })
+}
+
+func crdRelease() []*unstructured.Unstructured {
+	return []*unstructured.Unstructured{widgetCRD(false), componentObject("example.com/v1", "Widget", "w", "a")}
+}
+
+func TestApply_WaitsForReleaseCRD(t *testing.T) {
+	fastCRDPoll(t)
+	cluster := &crdCluster{readsToWait: 3}
+
+	result, err := Apply(context.Background(), cluster.client(), crdRelease(), "demo", ApplyOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []string{"applied widgets.example.com", "applied w"}, cluster.events)
+	assert.Zero(t, result.Retries)
+}
+
+func TestApply_CRDWaitTimeoutFallsBackToRetry(t *testing.T) {
+	fastCRDPoll(t)
+	noRetryDelay(t)
+	cluster := &crdCluster{readsToWait: 1000}
+
+	result, err := Apply(context.Background(), cluster.client(), crdRelease(), "demo", ApplyOptions{
+		CRDEstablishTimeout: 5 * time.Millisecond,
+		MaxRetries:          1,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1, "the kind never became served")
+	assert.True(t, apierrors.IsNotFound(result.Errors[0].Err))
+	assert.Equal(t, 1, result.Retries)
+}
+
+func TestApply_DryRunSkipsCRDWait(t *testing.T) {
+	cluster := &crdCluster{readsToWait: 1000}
+
+	_, err := Apply(context.Background(), cluster.client(), crdRelease(), "demo", ApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"applied widgets.example.com", "rejected w"}, cluster.events)
+}
+
+func TestReleaseCRDs(t *testing.T) {
+	resources := append(crdRelease(), componentObject("v1", "ConfigMap", "cm", "a"))
+	assert.Equal(t, map[schema.GroupKind]string{{Group: "example.com", Kind: "Widget"}: "widgets.example.com"}, releaseCRDs(resources))
+	assert.Empty(t, releaseCRDs(crdRelease()[:1]), "a CRD without instances in the set is not waited for")
+}
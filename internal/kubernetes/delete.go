@@ -2,17 +2,56 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 	"github.com/open-platform-model/cli/pkg/resourceorder"
 
+	"github.com/charmbracelet/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/open-platform-model/cli/internal/output"
 )
 
+// AnnotationOrphanedAt records, as an RFC 3339 timestamp, when an orphaning
+// delete released a resource from its instance.
+const AnnotationOrphanedAt = "opmodel.dev/orphaned-at"
+
+// instanceLabelPrefix is the prefix of the instance identity labels an
+// orphaning delete removes.
+const instanceLabelPrefix = "module-instance.opmodel.dev/"
+
+// DefaultDeleteWaitTimeout bounds a waiting Delete when the caller states no
+// timeout.
+const DefaultDeleteWaitTimeout = 5 * time.Minute
+
+// deleteWaitPollInterval is how often a waiting Delete re-checks whether the
+// deleted resources are gone.
+const deleteWaitPollInterval = 2 * time.Second
+
+// Final per-resource states reported in DeleteResult.States.
+const (
+	// DeleteStateDeleted: the delete was accepted; Delete did not wait.
+	DeleteStateDeleted = "deleted"
+	// DeleteStateGone: the resource was confirmed absent from the API server.
+	DeleteStateGone = "gone"
+	// DeleteStateTerminating: the resource still existed when the wait ended,
+	// typically held by a finalizer or by foreground deletion of dependents.
+	DeleteStateTerminating = "terminating"
+	// DeleteStateFailed: the delete (or orphan patch) request itself failed.
+	DeleteStateFailed = "failed"
+	// DeleteStateOrphaned: the resource was untracked and left running.
+	DeleteStateOrphaned = "orphaned"
+)
+
 // DeleteOptions configures a delete operation.
 type DeleteOptions struct {
 	// InstanceName is the instance name to delete.
@@ -35,11 +74,48 @@ type DeleteOptions struct {
 	// returns noResourcesFoundError.
 	InventoryLive []*unstructured.Unstructured
 
+	// Source is how the caller found InventoryLive: SourceInventory or
+	// SourceLabelScan. It labels each entry of a dry run's preview. Empty
+	// means SourceInventory.
+	Source string
+
 	// InventoryRecordExists indicates a ModuleInstance CR is present for the
 	// instance. When true, an empty InventoryLive is not treated as
 	// "not found" — the caller deletes the CR itself (last) after Delete
 	// returns.
 	InventoryRecordExists bool
+
+	// PropagationPolicy is the garbage-collection policy of each delete. Empty
+	// means Foreground: an owner stays (Terminating) until its dependents —
+	// a StatefulSet's pods, a Deployment's ReplicaSets — are gone.
+	PropagationPolicy metav1.DeletionPropagation
+
+	// Wait blocks after the deletes are issued until every deleted resource
+	// is absent from the API server, or Timeout elapses. Under foreground
+	// propagation an owner's absence also means its dependents are gone.
+	Wait bool
+
+	// Timeout bounds Wait. Zero means DefaultDeleteWaitTimeout.
+	Timeout time.Duration
+
+	// KeepInventory retains the ModuleInstance CR after the resources are
+	// deleted, as a record of the instance for audit. Delete never removes
+	// the CR itself; this tells the caller not to either. Mutually exclusive
+	// with Orphan.
+	KeepInventory bool
+
+	// Orphan untracks the resources instead of deleting them: the
+	// module-instance.opmodel.dev/* identity labels are removed and
+	// AnnotationOrphanedAt is added, so the resources keep running but no
+	// longer belong to the instance. The caller then removes the CR as for a
+	// normal delete. Wait and PropagationPolicy do not apply. Mutually
+	// exclusive with KeepInventory.
+	Orphan bool
+
+	// MaxRetries is how many times each resource's delete (or orphan patch)
+	// is retried after a conflict, server timeout, or throttling response.
+	// Zero means DefaultMaxRetries; negative disables retries.
+	MaxRetries int
 }
 
 // DeleteResult contains the outcome of a delete operation.
@@ -47,11 +123,76 @@ type DeleteResult struct {
 	// Deleted is the number of resources successfully deleted.
 	Deleted int
 
+	// Orphaned is the number of resources untracked by an Orphan delete.
+	Orphaned int
+
 	// Resources lists all discovered resources (for dry-run display).
 	Resources []*unstructured.Unstructured
 
 	// Errors contains per-resource errors (non-fatal).
 	Errors []resourceError
+
+	// States is the final state of each resource, in deletion order. Empty on
+	// dry-run.
+	States []ResourceDeleteState
+
+	// Retries is the total number of retries made after transient API
+	// errors, across all resources.
+	Retries int
+
+	// Planned lists, in deletion order, every resource a dry run would
+	// delete. Delete fills in the workloads; the caller appends the
+	// ModuleInstance CR, which it deletes last. Empty unless DryRun is set
+	// without Orphan.
+	Planned []PlannedDelete
+}
+
+// Where the resources of a delete were found, as reported in PlannedDelete.
+const (
+	// SourceInventory: listed in the ModuleInstance CR's inventory, or the
+	// CR itself.
+	SourceInventory = "inventory"
+
+	// SourceLabelScan: found by the instance's identity labels, because the
+	// inventory was bypassed (--no-inventory) or could not be read.
+	SourceLabelScan = "label scan"
+)
+
+// PlannedDelete is one resource a dry-run delete would remove.
+type PlannedDelete struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+}
+
+// FormatPlannedDelete renders one dry-run preview line: the resource, the
+// deleted status it would reach, and where it was found.
+func FormatPlannedDelete(p PlannedDelete) string {
+	return output.FormatResourceLine(p.Kind, p.Namespace, p.Name, output.StatusDeleted) + "  " + output.Dim("("+p.Source+")")
+}
+
+// ResourceDeleteState is the final state of one resource after Delete.
+type ResourceDeleteState struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// State is one of the DeleteState* constants.
+	State string
+	// Finalizers are the finalizers still on a terminating resource.
+	Finalizers []string
+}
+
+// Remaining returns the resources a waiting Delete saw still present when its
+// timeout elapsed.
+func (r *DeleteResult) Remaining() []ResourceDeleteState {
+	var out []ResourceDeleteState
+	for _, s := range r.States {
+		if s.State == DeleteStateTerminating {
+			out = append(out, s)
+		}
+	}
+	return out
 }
 
 // Delete removes all resources belonging to an instance deployment.
@@ -59,6 +200,9 @@ type DeleteResult struct {
 // the caller. Resources are deleted in reverse weight order. The ModuleInstance
 // CR itself is deleted last by the caller, after Delete returns.
 func Delete(ctx context.Context, client *Client, opts DeleteOptions) (*DeleteResult, error) {
+	if opts.KeepInventory && opts.Orphan {
+		return nil, errors.New("keeping the inventory and orphaning resources are mutually exclusive")
+	}
 	result := &DeleteResult{}
 
 	// Use instance name for logging if available, otherwise use InstanceID
@@ -69,6 +213,10 @@ func Delete(ctx context.Context, client *Client, opts DeleteOptions) (*DeleteRes
 	instanceLog := output.InstanceLogger(logName)
 
 	resources := opts.InventoryLive
+	source := opts.Source
+	if source == "" {
+		source = SourceInventory
+	}
 
 	instanceLog.Debug("deleting instance resources from inventory",
 		"instance", logName,
@@ -98,25 +246,49 @@ func Delete(ctx context.Context, client *Client, opts DeleteOptions) (*DeleteRes
 		name := res.GetName()
 		ns := res.GetNamespace()
 
+		if opts.Orphan {
+			orphanOne(ctx, client, res, opts, instanceLog, result)
+			continue
+		}
+
 		if opts.DryRun {
-			instanceLog.Info(output.FormatResourceLine(kind, ns, name, output.StatusUnchanged))
+			planned := PlannedDelete{Kind: kind, Namespace: ns, Name: name, Source: source}
+			instanceLog.Info(FormatPlannedDelete(planned))
+			result.Planned = append(result.Planned, planned)
 			result.Deleted++
 			continue
 		}
 
-		if err := deleteResource(ctx, client, res); err != nil {
+		retries, err := withRetry(ctx, resolveMaxRetries(opts.MaxRetries), kind+"/"+name, func() error {
+			return deleteResource(ctx, client, res, opts.PropagationPolicy)
+		})
+		result.Retries += retries
+		if err != nil {
 			instanceLog.Warn(fmt.Sprintf("deleting %s/%s: %v", kind, name, err))
+			output.EmitResource(opts.InstanceName, kind, ns, name, "", err)
 			result.Errors = append(result.Errors, resourceError{
 				Kind:      kind,
 				Name:      name,
 				Namespace: ns,
 				Err:       err,
 			})
+			result.States = append(result.States, ResourceDeleteState{Kind: kind, Name: name, Namespace: ns, State: DeleteStateFailed})
 			continue
 		}
 
 		instanceLog.Info(output.FormatResourceLine(kind, ns, name, output.StatusDeleted))
+		output.EmitResource(opts.InstanceName, kind, ns, name, output.StatusDeleted, nil)
 		result.Deleted++
+		result.States = append(result.States, ResourceDeleteState{Kind: kind, Name: name, Namespace: ns, State: DeleteStateDeleted})
+	}
+
+	if opts.Wait && !opts.DryRun && !opts.Orphan {
+		timeout := opts.Timeout
+		if timeout <= 0 {
+			timeout = DefaultDeleteWaitTimeout
+		}
+		instanceLog.Info(fmt.Sprintf("waiting up to %s for deleted resources to be removed", timeout))
+		waitForDeletion(ctx, client, resources, result.States, timeout, deleteWaitPollInterval)
 	}
 
 	// The ModuleInstance CR is deleted last by the caller (after this returns),
@@ -124,11 +296,132 @@ func Delete(ctx context.Context, client *Client, opts DeleteOptions) (*DeleteRes
 	return result, nil
 }
 
-// deleteResource deletes a single resource with foreground propagation.
-func deleteResource(ctx context.Context, client *Client, obj *unstructured.Unstructured) error {
-	gvr := GVRFromUnstructured(obj)
+// orphanOne untracks a single resource for an Orphan delete, recording the
+// outcome in result.
+func orphanOne(ctx context.Context, client *Client, res *unstructured.Unstructured, opts DeleteOptions, instanceLog *log.Logger, result *DeleteResult) {
+	kind, name, ns := res.GetKind(), res.GetName(), res.GetNamespace()
+	if opts.DryRun {
+		instanceLog.Info(output.FormatResourceLine(kind, ns, name, output.StatusOrphaned))
+		result.Orphaned++
+		return
+	}
+	retries, err := withRetry(ctx, resolveMaxRetries(opts.MaxRetries), kind+"/"+name, func() error {
+		return OrphanResource(ctx, client, res, time.Now())
+	})
+	result.Retries += retries
+	if err != nil {
+		instanceLog.Warn(fmt.Sprintf("orphaning %s/%s: %v", kind, name, err))
+		output.EmitResource(opts.InstanceName, kind, ns, name, "", err)
+		result.Errors = append(result.Errors, resourceError{Kind: kind, Name: name, Namespace: ns, Err: fmt.Errorf("orphaning: %w", err)})
+		result.States = append(result.States, ResourceDeleteState{Kind: kind, Name: name, Namespace: ns, State: DeleteStateFailed})
+		return
+	}
+	instanceLog.Info(output.FormatResourceLine(kind, ns, name, output.StatusOrphaned))
+	output.EmitResource(opts.InstanceName, kind, ns, name, output.StatusOrphaned, nil)
+	result.Orphaned++
+	result.States = append(result.States, ResourceDeleteState{Kind: kind, Name: name, Namespace: ns, State: DeleteStateOrphaned})
+}
+
+// OrphanResource merge-patches away obj's instance identity labels and an OPM
+// managed-by label, and stamps AnnotationOrphanedAt. Dropping managed-by keeps
+// the resource from still claiming an OPM manager it no longer has, so label
+// scans and a later adoption treat it as unmanaged. Like adoption's label
+// stamp, a merge patch touches only metadata, leaving the spec and its field
+// ownership as they are.
+func OrphanResource(ctx context.Context, client *Client, obj *unstructured.Unstructured, now time.Time) error {
+	labels := map[string]any{}
+	for k, v := range obj.GetLabels() {
+		if strings.HasPrefix(k, instanceLabelPrefix) || (k == pkgcore.LabelManagedBy && pkgcore.IsOPMManagedBy(v)) {
+			labels[k] = nil
+		}
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{
+		"labels":      labels,
+		"annotations": map[string]string{AnnotationOrphanedAt: now.UTC().Format(time.RFC3339)},
+	}})
+	if err != nil {
+		return err
+	}
+	_, err = client.ResourceClientFor(obj).
+		Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// waitForDeletion polls each deleted resource until it is absent or timeout
+// elapses, updating states (parallel to resources) in place: absent resources
+// become gone, those still present become terminating with their finalizers.
+// A resource that could never be read is reported terminating at the deadline.
+func waitForDeletion(ctx context.Context, client *Client, resources []*unstructured.Unstructured, states []ResourceDeleteState, timeout, pollInterval time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending := 0
+		for i := range states {
+			st := &states[i]
+			if st.State != DeleteStateDeleted && st.State != DeleteStateTerminating {
+				continue
+			}
+			live, err := client.ResourceClient(client.GVRFor(resources[i]), st.Namespace).Get(ctx, st.Name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				st.State = DeleteStateGone
+				st.Finalizers = nil
+			case err != nil:
+				output.Debug("delete wait: could not read resource", "resource", st.Kind+"/"+st.Name, "error", err)
+				pending++
+			default:
+				st.State = DeleteStateTerminating
+				st.Finalizers = live.GetFinalizers()
+				pending++
+			}
+		}
+		if pending == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			// Never confirmed gone — report it as still present.
+			for i := range states {
+				if states[i].State == DeleteStateDeleted {
+					states[i].State = DeleteStateTerminating
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// FormatRemaining renders resources a waiting Delete left behind, one per
+// line with the finalizers holding them.
+func FormatRemaining(remaining []ResourceDeleteState) string {
+	lines := make([]string, 0, len(remaining))
+	for _, r := range remaining {
+		line := r.Kind + "/" + r.Name
+		if r.Namespace != "" {
+			line += " in " + r.Namespace
+		}
+		if len(r.Finalizers) > 0 {
+			line += " (finalizers: " + strings.Join(r.Finalizers, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// deleteResource deletes a single resource with the given propagation policy
+// (foreground when empty).
+func deleteResource(ctx context.Context, client *Client, obj *unstructured.Unstructured, propagation metav1.DeletionPropagation) error {
+	gvr := client.GVRFor(obj)
 	ns := obj.GetNamespace()
-	propagation := metav1.DeletePropagationForeground
+	if propagation == "" {
+		propagation = metav1.DeletePropagationForeground
+	}
 
 	deleteOpts := metav1.DeleteOptions{
 		PropagationPolicy: &propagation,
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestSortByWeightDescending(t *testing.T) {
@@ -67,6 +69,130 @@ func TestDelete_DeletesOnlyTrackedInventoryResources(t *testing.T) {
 	assert.Equal(t, untracked.GetName(), remaining.GetName())
 }
 
+func TestDelete_WaitReportsFinalState(t *testing.T) {
+	ctx := context.Background()
+	gone := makeUnstructured("v1", "ConfigMap", "gone", "default")
+	held := makeUnstructured("apps/v1", "StatefulSet", "db", "default")
+	held.SetFinalizers([]string{"foregroundDeletion"})
+
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), gone.DeepCopy(), held.DeepCopy())
+	var propagation metav1.DeletionPropagation
+	dyn.PrependReactor("delete", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		del := action.(k8stesting.DeleteActionImpl)
+		propagation = *del.DeleteOptions.PropagationPolicy
+		// The StatefulSet waits on its pods under foreground deletion: the
+		// delete is accepted but the object stays.
+		return del.GetName() == "db", nil, nil
+	})
+	client := &Client{Dynamic: dyn}
+
+	result, err := Delete(ctx, client, DeleteOptions{
+		InstanceName:          "demo",
+		Namespace:             "default",
+		InventoryLive:         []*unstructured.Unstructured{gone.DeepCopy(), held.DeepCopy()},
+		InventoryRecordExists: true,
+		Wait:                  true,
+		Timeout:               50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, metav1.DeletePropagationForeground, propagation, "foreground is the default policy")
+	assert.Equal(t, []ResourceDeleteState{
+		{Kind: "StatefulSet", Name: "db", Namespace: "default", State: DeleteStateTerminating, Finalizers: []string{"foregroundDeletion"}},
+		{Kind: "ConfigMap", Name: "gone", Namespace: "default", State: DeleteStateGone},
+	}, result.States)
+	require.Len(t, result.Remaining(), 1)
+	assert.Equal(t, "StatefulSet/db in default (finalizers: foregroundDeletion)", FormatRemaining(result.Remaining()))
+}
+
+func TestDelete_NoWaitReportsDeleted(t *testing.T) {
+	cm := makeUnstructured("v1", "ConfigMap", "cm", "default")
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), cm.DeepCopy())
+	var propagation metav1.DeletionPropagation
+	dyn.PrependReactor("delete", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		propagation = *action.(k8stesting.DeleteActionImpl).DeleteOptions.PropagationPolicy
+		return false, nil, nil
+	})
+
+	result, err := Delete(context.Background(), &Client{Dynamic: dyn}, DeleteOptions{
+		InstanceName:      "demo",
+		Namespace:         "default",
+		InventoryLive:     []*unstructured.Unstructured{cm.DeepCopy()},
+		PropagationPolicy: metav1.DeletePropagationOrphan,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, metav1.DeletePropagationOrphan, propagation)
+	require.Len(t, result.States, 1)
+	assert.Equal(t, DeleteStateDeleted, result.States[0].State)
+	assert.Empty(t, result.Remaining())
+}
+
+func TestDelete_OrphanUntracksWithoutDeleting(t *testing.T) {
+	ctx := context.Background()
+	cm := makeUnstructured("v1", "ConfigMap", "cm", "default")
+	cm.SetLabels(map[string]string{
+		"app.kubernetes.io/managed-by":          "opm-cli",
+		"module-instance.opmodel.dev/name":      "demo",
+		"module-instance.opmodel.dev/namespace": "default",
+		"component.opmodel.dev/name":            "web",
+	})
+	client := &Client{Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), cm.DeepCopy())}
+
+	result, err := Delete(ctx, client, DeleteOptions{
+		InstanceName:          "demo",
+		Namespace:             "default",
+		InventoryLive:         []*unstructured.Unstructured{cm.DeepCopy()},
+		InventoryRecordExists: true,
+		Orphan:                true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Orphaned)
+	assert.Zero(t, result.Deleted)
+	require.Len(t, result.States, 1)
+	assert.Equal(t, DeleteStateOrphaned, result.States[0].State)
+
+	live, err := client.ResourceClient(GVRFromUnstructured(cm), "default").Get(ctx, "cm", metav1.GetOptions{})
+	require.NoError(t, err, "an orphaned resource keeps running")
+	assert.Equal(t, map[string]string{
+		"component.opmodel.dev/name": "web",
+	}, live.GetLabels(), "instance labels and the OPM managed-by label are removed")
+	_, err = time.Parse(time.RFC3339, live.GetAnnotations()[AnnotationOrphanedAt])
+	assert.NoError(t, err)
+}
+
+func TestDelete_DryRunListsPlannedDeletionsInOrder(t *testing.T) {
+	ctx := context.Background()
+	cm := makeUnstructured("v1", "ConfigMap", "config", "default")
+	deploy := makeUnstructured("apps/v1", "Deployment", "web", "default")
+	client := &Client{Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), cm.DeepCopy(), deploy.DeepCopy())}
+
+	result, err := Delete(ctx, client, DeleteOptions{
+		InstanceName:          "demo",
+		Namespace:             "default",
+		InventoryLive:         []*unstructured.Unstructured{cm.DeepCopy(), deploy.DeepCopy()},
+		InventoryRecordExists: true,
+		Source:                SourceLabelScan,
+		DryRun:                true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []PlannedDelete{
+		{Kind: "Deployment", Namespace: "default", Name: "web", Source: SourceLabelScan},
+		{Kind: "ConfigMap", Namespace: "default", Name: "config", Source: SourceLabelScan},
+	}, result.Planned)
+	assert.Empty(t, result.States)
+
+	_, err = client.ResourceClient(GVRFromUnstructured(deploy), "default").Get(ctx, "web", metav1.GetOptions{})
+	assert.NoError(t, err, "a dry run deletes nothing")
+}
+
+func TestDelete_KeepInventoryAndOrphanAreExclusive(t *testing.T) {
+	_, err := Delete(context.Background(), &Client{}, DeleteOptions{
+		InstanceName:  "demo",
+		KeepInventory: true,
+		Orphan:        true,
+	})
+	assert.ErrorContains(t, err, "mutually exclusive")
+}
+
 func makeUnstructured(apiVersion, kind, name, namespace string) *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{}
 	obj.SetAPIVersion(apiVersion)
@@ -3,7 +3,12 @@ package kubernetes
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gonvenience/ytbx"
@@ -15,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 )
 
 // ResourceState represents the state of a resource in a diff comparison.
@@ -29,46 +35,132 @@ const (
 	ResourceOrphaned ResourceState = "orphaned"
 	// ResourceUnchanged means the resource exists both locally and on the cluster with no differences.
 	ResourceUnchanged ResourceState = "unchanged"
+	// ResourceRecreate means the resource is modified in an immutable field,
+	// so the change cannot be applied in place: the resource must be deleted
+	// and recreated.
+	ResourceRecreate ResourceState = "recreate"
+	// ResourceRemoved means, in a live-to-live diff, the resource exists in
+	// the base instance but has no counterpart in the other.
+	ResourceRemoved ResourceState = "removed"
 )
 
+// FieldChange is a single field-level difference within a modified resource.
+type FieldChange struct {
+	// Path is the dot-style path of the changed field (e.g. "spec.replicas").
+	Path string `json:"path" yaml:"path"`
+	// Type is the kind of change: added, removed, modified, or reordered.
+	Type string `json:"type" yaml:"type"`
+	// From is the live value (absent for additions).
+	From any `json:"from,omitempty" yaml:"from,omitempty"`
+	// To is the rendered value (absent for removals).
+	To any `json:"to,omitempty" yaml:"to,omitempty"`
+
+	// pattern is Path with each named-list selector (the "app" of
+	// containers.app.image) replaced by "*", so GroupIdenticalChanges can
+	// match the same change to differently named containers. Empty when Path
+	// has no selector.
+	pattern string
+}
+
 // resourceDiff contains the diff details for a single resource.
 type resourceDiff struct {
 	// Kind is the Kubernetes resource kind.
-	Kind string
+	Kind string `json:"kind" yaml:"kind"`
 	// Name is the resource name.
-	Name string
+	Name string `json:"name" yaml:"name"`
 	// Namespace is the resource namespace.
-	Namespace string
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Component is the OPM component the resource belongs to, when labelled.
+	Component string `json:"component,omitempty" yaml:"component,omitempty"`
 	// State indicates whether the resource is modified, added, or orphaned.
-	State ResourceState
+	State ResourceState `json:"category" yaml:"category"`
 	// Diff is the human-readable diff output (only for modified resources).
-	Diff string
+	Diff string `json:"-" yaml:"-"`
+	// Changes lists the field-level changes (only for modified resources, and
+	// only when the comparer can report them).
+	Changes []FieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+	// Immutable lists the immutable fields the changes touch (only for
+	// resources to recreate).
+	Immutable []string `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+	// AgainstName and AgainstNamespace identify the resource in the other
+	// instance of a live-to-live diff (see DiffLive); empty when it has no
+	// counterpart there, and for render-to-cluster diffs.
+	AgainstName      string `json:"againstName,omitempty" yaml:"againstName,omitempty"`
+	AgainstNamespace string `json:"againstNamespace,omitempty" yaml:"againstNamespace,omitempty"`
+	// LastApplied compares the rendered resource with the kubectl
+	// last-applied-configuration annotation on the live one. Set only with
+	// DiffOptions.CompareLastApplied, on resources that carry the annotation.
+	LastApplied *LastAppliedDiff `json:"lastApplied,omitempty" yaml:"lastApplied,omitempty"`
+}
+
+// AnnotationLastApplied is the annotation kubectl apply records its intent in.
+const AnnotationLastApplied = "kubectl.kubernetes.io/last-applied-configuration"
+
+// LastAppliedDiff is a rendered resource compared with what kubectl last
+// applied: the change OPM makes relative to kubectl's intent, as opposed to
+// relative to the live object, which also holds defaults and other writers'
+// fields.
+type LastAppliedDiff struct {
+	// Diff is the human-readable diff; empty when the render matches.
+	Diff string `json:"-" yaml:"-"`
+	// Changes lists the field-level changes.
+	Changes []FieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
 }
 
 // DiffResult contains the full diff output.
 type DiffResult struct {
 	// Resources is the list of resource diffs.
-	Resources []resourceDiff
+	Resources []resourceDiff `json:"resources" yaml:"resources"`
 	// Modified is the count of modified resources.
-	Modified int
+	Modified int `json:"modified" yaml:"modified"`
 	// Added is the count of added resources.
-	Added int
+	Added int `json:"added" yaml:"added"`
 	// Orphaned is the count of orphaned resources.
-	Orphaned int
+	Orphaned int `json:"orphaned" yaml:"orphaned"`
 	// Unchanged is the count of unchanged resources.
-	Unchanged int
+	Unchanged int `json:"unchanged" yaml:"unchanged"`
+	// Recreate is the count of resources whose changes touch an immutable
+	// field. They are not counted in Modified.
+	Recreate int `json:"recreate,omitempty" yaml:"recreate,omitempty"`
+	// Removed is the count of removed resources (live-to-live diffs only).
+	Removed int `json:"removed,omitempty" yaml:"removed,omitempty"`
+	// ForeignOwned lists inventory resources whose labels no longer tie them
+	// to the instance, as passed in DiffOptions. They are not counted as
+	// differences.
+	ForeignOwned []ForeignOwnedResource `json:"foreignOwned,omitempty" yaml:"foreignOwned,omitempty"`
 	// Warnings contains non-fatal warnings (e.g., from partial render).
-	Warnings []string
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	// Scope is the part of the comparison that ran, when not all of it did
+	// (DiffOptions.NoOrphans or OrphansOnly).
+	Scope DiffScope `json:"scope,omitempty" yaml:"scope,omitempty"`
 }
 
+// DiffScope names the part of a diff that ran when it was restricted.
+type DiffScope string
+
+const (
+	// DiffScopeFields: rendered resources were compared with the cluster, and
+	// orphans were not looked for.
+	DiffScopeFields DiffScope = "fields"
+	// DiffScopeOrphans: only orphans were looked for; rendered resources were
+	// not compared.
+	DiffScopeOrphans DiffScope = "orphans"
+)
+
 // IsEmpty returns true if there are no differences.
 func (r *DiffResult) IsEmpty() bool {
-	return r.Modified == 0 && r.Added == 0 && r.Orphaned == 0
+	return r.Modified == 0 && r.Recreate == 0 && r.Added == 0 && r.Orphaned == 0 && r.Removed == 0
 }
 
 // SummaryLine returns a human-readable summary of the diff.
 func (r *DiffResult) SummaryLine() string {
 	if r.IsEmpty() {
+		switch r.Scope {
+		case DiffScopeFields:
+			return "No differences found (orphans not checked)"
+		case DiffScopeOrphans:
+			return "No orphaned resources found"
+		}
 		return "No differences found"
 	}
 
@@ -76,53 +168,96 @@ func (r *DiffResult) SummaryLine() string {
 	if r.Modified > 0 {
 		parts = append(parts, fmt.Sprintf("%d modified", r.Modified))
 	}
+	if r.Recreate > 0 {
+		parts = append(parts, fmt.Sprintf("%d to recreate", r.Recreate))
+	}
 	if r.Added > 0 {
 		parts = append(parts, fmt.Sprintf("%d added", r.Added))
 	}
 	if r.Orphaned > 0 {
 		parts = append(parts, fmt.Sprintf("%d orphaned", r.Orphaned))
 	}
-	return strings.Join(parts, ", ")
+	if r.Removed > 0 {
+		parts = append(parts, fmt.Sprintf("%d removed", r.Removed))
+	}
+	summary := strings.Join(parts, ", ")
+	if r.Scope == DiffScopeFields {
+		summary += " (orphans not checked)"
+	}
+	return summary
 }
 
-// comparer wraps the diff comparison logic. It uses dyff by default but
-// can be replaced with a different implementation.
-type comparer interface {
+// Key returns the resource's display key: Kind/namespace/name, or Kind/name
+// for cluster-scoped resources.
+func (d resourceDiff) Key() string {
+	if d.Namespace != "" {
+		return d.Kind + "/" + d.Namespace + "/" + d.Name
+	}
+	return d.Kind + "/" + d.Name
+}
+
+// Comparer produces the human-readable diff stored for each modified
+// resource. Whether a resource is modified, and how (see FieldChange), is the
+// same whichever Comparer presents it; NewComparerFor picks one by DiffTool.
+type Comparer interface {
 	// Compare compares two YAML documents and returns a human-readable diff.
 	// Returns empty string if there are no differences.
 	Compare(rendered, live *unstructured.Unstructured) (string, error)
 }
 
+// fieldComparer is implemented by comparers that can also report the
+// structured field changes behind their human-readable diff.
+type fieldComparer interface {
+	CompareFields(rendered, live *unstructured.Unstructured) (string, []FieldChange, error)
+}
+
+// compareResource runs the comparer, collecting field changes when the
+// comparer supports them.
+func compareResource(c Comparer, rendered, live *unstructured.Unstructured) (string, []FieldChange, error) {
+	if fc, ok := c.(fieldComparer); ok {
+		return fc.CompareFields(rendered, live)
+	}
+	out, err := c.Compare(rendered, live)
+	return out, nil, err
+}
+
 // dyffComparer is the default comparer implementation using homeport/dyff.
 type dyffComparer struct{}
 
 // NewComparer creates a new comparer using dyff.
-func NewComparer() comparer {
+func NewComparer() Comparer {
 	return &dyffComparer{}
 }
 
 // Compare compares two unstructured resources using dyff for semantic YAML comparison.
 func (c *dyffComparer) Compare(rendered, live *unstructured.Unstructured) (string, error) {
+	out, _, err := c.CompareFields(rendered, live)
+	return out, err
+}
+
+// CompareFields is Compare that also returns the field-level changes from the
+// dyff report.
+func (c *dyffComparer) CompareFields(rendered, live *unstructured.Unstructured) (string, []FieldChange, error) {
 	// Marshal both to YAML for dyff comparison
 	renderedYAML, err := yaml.Marshal(rendered.Object)
 	if err != nil {
-		return "", fmt.Errorf("marshaling rendered resource: %w", err)
+		return "", nil, fmt.Errorf("marshaling rendered resource: %w", err)
 	}
 
 	liveYAML, err := yaml.Marshal(live.Object)
 	if err != nil {
-		return "", fmt.Errorf("marshaling live resource: %w", err)
+		return "", nil, fmt.Errorf("marshaling live resource: %w", err)
 	}
 
 	// Parse YAML documents for dyff
 	renderedDocs, err := ytbx.LoadDocuments(renderedYAML)
 	if err != nil {
-		return "", fmt.Errorf("parsing rendered YAML: %w", err)
+		return "", nil, fmt.Errorf("parsing rendered YAML: %w", err)
 	}
 
 	liveDocs, err := ytbx.LoadDocuments(liveYAML)
 	if err != nil {
-		return "", fmt.Errorf("parsing live YAML: %w", err)
+		return "", nil, fmt.Errorf("parsing live YAML: %w", err)
 	}
 
 	renderedInput := ytbx.InputFile{
@@ -138,12 +273,12 @@ func (c *dyffComparer) Compare(rendered, live *unstructured.Unstructured) (strin
 	// Compare using dyff (live as "from", rendered as "to")
 	report, err := dyff.CompareInputFiles(liveInput, renderedInput)
 	if err != nil {
-		return "", fmt.Errorf("comparing resources: %w", err)
+		return "", nil, fmt.Errorf("comparing resources: %w", err)
 	}
 
 	// No differences
 	if len(report.Diffs) == 0 {
-		return "", nil
+		return "", nil, nil
 	}
 
 	// Format the report
@@ -153,10 +288,68 @@ func (c *dyffComparer) Compare(rendered, live *unstructured.Unstructured) (strin
 		OmitHeader: true,
 	}
 	if err := writer.WriteReport(&buf); err != nil {
-		return "", fmt.Errorf("formatting diff report: %w", err)
+		return "", nil, fmt.Errorf("formatting diff report: %w", err)
 	}
 
-	return buf.String(), nil
+	return buf.String(), fieldChangesFromReport(report), nil
+}
+
+// fieldChangesFromReport flattens a dyff report into field changes. Values
+// that cannot be decoded are left empty rather than failing the diff.
+func fieldChangesFromReport(report dyff.Report) []FieldChange {
+	var changes []FieldChange
+	for _, d := range report.Diffs {
+		fieldPath, pattern := "", ""
+		if d.Path != nil {
+			fieldPath = d.Path.ToDotStyle()
+			if p := selectorPattern(d.Path.PathElements); p != fieldPath {
+				pattern = p
+			}
+		}
+		for _, detail := range d.Details {
+			fc := FieldChange{Path: fieldPath, Type: changeType(detail.Kind), pattern: pattern}
+			if detail.From != nil {
+				_ = detail.From.Decode(&fc.From) //nolint:errcheck // best-effort value decode
+			}
+			if detail.To != nil {
+				_ = detail.To.Decode(&fc.To) //nolint:errcheck // best-effort value decode
+			}
+			changes = append(changes, fc)
+		}
+	}
+	return changes
+}
+
+// selectorPattern renders a path in dot style like ToDotStyle, with the name
+// of each named-list entry (an element with a Key, such as a container's
+// name) replaced by "*".
+func selectorPattern(elements []ytbx.PathElement) string {
+	sections := make([]string, 0, len(elements))
+	for _, e := range elements {
+		switch {
+		case e.Key != "":
+			sections = append(sections, "*")
+		case e.Name != "":
+			sections = append(sections, e.Name)
+		case e.Idx >= 0:
+			sections = append(sections, strconv.Itoa(e.Idx))
+		}
+	}
+	return strings.Join(sections, ".")
+}
+
+// changeType maps a dyff detail kind to its field-change type name.
+func changeType(kind rune) string {
+	switch kind {
+	case dyff.ADDITION:
+		return "added"
+	case dyff.REMOVAL:
+		return "removed"
+	case dyff.ORDERCHANGE:
+		return "reordered"
+	default:
+		return "modified"
+	}
 }
 
 // stripServerManagedFields removes well-known server-only fields from a
@@ -304,7 +497,7 @@ func buildNameIndex(live []interface{}) map[string]map[string]interface{} {
 
 // FetchLiveState fetches a single resource from the cluster.
 func fetchLiveState(ctx context.Context, client *Client, resource *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	gvr := GVRFromUnstructured(resource)
+	gvr := client.GVRFor(resource)
 	ns := resource.GetNamespace()
 	name := resource.GetName()
 
@@ -316,23 +509,124 @@ func resourceKey(gvk schema.GroupVersionKind, namespace, name string) string {
 	return fmt.Sprintf("%s/%s/%s/%s/%s", gvk.Group, gvk.Version, gvk.Kind, namespace, name)
 }
 
+// DiffFilter restricts a diff to resources of the given kinds and names.
+// An empty field matches everything.
+type DiffFilter struct {
+	// Kinds are resource kinds to include, matched case-insensitively.
+	Kinds []string
+	// Names are glob patterns (path.Match syntax) for resource names to include.
+	Names []string
+}
+
+// Validate reports the first malformed name pattern.
+func (f DiffFilter) Validate() error {
+	for _, p := range f.Names {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the resource passes both the kind and name filters.
+func (f DiffFilter) Matches(obj *unstructured.Unstructured) bool {
+	if len(f.Kinds) > 0 && !slices.ContainsFunc(f.Kinds, func(k string) bool {
+		return strings.EqualFold(k, obj.GetKind())
+	}) {
+		return false
+	}
+	if len(f.Names) > 0 && !slices.ContainsFunc(f.Names, func(p string) bool {
+		ok, _ := path.Match(p, obj.GetName()) //nolint:errcheck // patterns are checked by Validate
+		return ok
+	}) {
+		return false
+	}
+	return true
+}
+
+// apply returns the resources that match the filter.
+func (f DiffFilter) apply(resources []*unstructured.Unstructured) []*unstructured.Unstructured {
+	if len(f.Kinds) == 0 && len(f.Names) == 0 {
+		return resources
+	}
+	var kept []*unstructured.Unstructured
+	for _, r := range resources {
+		if f.Matches(r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
 // DiffOptions configures a Diff operation.
 type DiffOptions struct {
 	// InventoryLive is the list of live resources pre-fetched from the inventory
 	// Secret by the caller. Orphan detection uses set-difference against this list.
 	// When nil, no live resources are known and no orphans are reported.
 	InventoryLive []*unstructured.Unstructured
+
+	// Filter restricts both the rendered and the inventory sets before
+	// comparison, so filtered-out resources are never reported as orphans.
+	Filter DiffFilter
+
+	// ForeignOwned is carried into the result as given (see
+	// inventory.VerifyOwnership).
+	ForeignOwned []ForeignOwnedResource
+
+	// CompareLastApplied also compares each rendered resource with the
+	// kubectl last-applied-configuration annotation on its live counterpart,
+	// for reviewing a migration from kubectl to OPM. The result is reported
+	// per resource and does not change its state or the counts.
+	CompareLastApplied bool
+
+	// IgnorePaths are fields left out of every comparison (--ignore-path and
+	// the module's AnnotationDiffIgnorePaths); see WithIgnorePaths.
+	IgnorePaths []IgnorePath
+
+	// NoSmartIgnore compares spec.replicas of autoscaled Deployments and
+	// StatefulSets too. By default it is left out when an HPA, rendered or
+	// in the inventory, targets the workload or the live replicas were set
+	// through the scale subresource: that count is the autoscaler's, not drift.
+	NoSmartIgnore bool
+
+	// NoOrphans skips orphan detection: only the rendered resources are
+	// compared, for when the inventory is known to be stale. Mutually
+	// exclusive with OrphansOnly.
+	NoOrphans bool
+
+	// OrphansOnly skips comparing the rendered resources with the cluster and
+	// reports only the orphans, for auditing leftovers before a cleanup.
+	// Mutually exclusive with NoOrphans.
+	OrphansOnly bool
 }
 
 // Diff compares rendered resources against the live cluster state and returns categorized results.
 // instanceName is unused but kept for caller context (logging reserved for future use).
-func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstructured, instanceName string, comparer comparer, opts ...DiffOptions) (*DiffResult, error) {
-	var diffOpts DiffOptions
-	if len(opts) > 0 {
-		diffOpts = opts[0]
+func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstructured, instanceName string, comparer Comparer, opts DiffOptions) (*DiffResult, error) {
+	if opts.NoOrphans && opts.OrphansOnly {
+		return nil, errors.New("skipping orphans and reporting only orphans are mutually exclusive")
+	}
+	result := &DiffResult{ForeignOwned: opts.ForeignOwned}
+	switch {
+	case opts.NoOrphans:
+		result.Scope = DiffScopeFields
+	case opts.OrphansOnly:
+		result.Scope = DiffScopeOrphans
 	}
+	comparer = WithIgnorePaths(comparer, opts.IgnorePaths)
 
-	result := &DiffResult{}
+	// HPA targets come from the unfiltered sets: --kind Deployment still
+	// sees the HPA that scales it.
+	var targets map[string]bool
+	if !opts.NoSmartIgnore {
+		targets = hpaTargets(resources, opts.InventoryLive)
+	}
+
+	// Filter both sides the same way: a live resource of a filtered kind that
+	// is missing from the render is still an orphan; anything filtered out on
+	// one side is filtered out on the other.
+	resources = opts.Filter.apply(resources)
+	inventoryLive := opts.Filter.apply(opts.InventoryLive)
 
 	// Build a set of rendered resource keys for orphan detection
 	renderedKeys := make(map[string]bool)
@@ -341,11 +635,17 @@ func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstruc
 		renderedKeys[key] = true
 	}
 
-	// Compare each rendered resource against live state
-	for _, res := range resources {
+	// Compare each rendered resource against live state. Under OrphansOnly
+	// the render only feeds orphan detection.
+	compared := resources
+	if opts.OrphansOnly {
+		compared = nil
+	}
+	for _, res := range compared {
 		kind := res.GetKind()
 		name := res.GetName()
 		ns := res.GetNamespace()
+		component := res.GetLabels()[pkgcore.LabelComponentName]
 
 		live, err := fetchLiveState(ctx, client, res)
 		if err != nil {
@@ -355,6 +655,7 @@ func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstruc
 					Kind:      kind,
 					Name:      name,
 					Namespace: ns,
+					Component: component,
 					State:     ResourceAdded,
 				})
 				result.Added++
@@ -365,13 +666,27 @@ func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstruc
 			continue
 		}
 
+		var lastApplied *LastAppliedDiff
+		if opts.CompareLastApplied {
+			lastApplied, err = compareLastApplied(comparer, res, live)
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("comparing %s/%s with its last-applied configuration: %v", kind, name, err))
+			}
+		}
+
+		// An autoscaled workload's replica count is not the render's to
+		// compare; with it gone from res, projection drops it from live too.
+		if !opts.NoSmartIgnore && autoscaled(res, live, targets) {
+			res = withoutReplicas(res)
+		}
+
 		// Filter live object to only contain fields present in rendered output.
 		// Two-layer filtering: strip server metadata, then project to rendered paths.
 		stripServerManagedFields(live.Object)
 		live.Object = projectLiveToRendered(res.Object, live.Object)
 
 		// Resource exists on both sides — compare
-		diffOutput, err := comparer.Compare(res, live)
+		diffOutput, changes, err := compareResource(comparer, res, live)
 		if err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("comparing %s/%s: %v", kind, name, err))
 			continue
@@ -380,31 +695,53 @@ func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstruc
 		if diffOutput == "" {
 			// No differences
 			result.Resources = append(result.Resources, resourceDiff{
-				Kind:      kind,
-				Name:      name,
-				Namespace: ns,
-				State:     ResourceUnchanged,
+				Kind:        kind,
+				Name:        name,
+				Namespace:   ns,
+				Component:   component,
+				LastApplied: lastApplied,
+				State:       ResourceUnchanged,
 			})
 			result.Unchanged++
+		} else if immutable := ImmutableChanges(res.GroupVersionKind().GroupKind(), changes); len(immutable) > 0 {
+			result.Resources = append(result.Resources, resourceDiff{
+				Kind:        kind,
+				Name:        name,
+				Namespace:   ns,
+				Component:   component,
+				LastApplied: lastApplied,
+				State:       ResourceRecreate,
+				Diff:        diffOutput,
+				Changes:     changes,
+				Immutable:   immutable,
+			})
+			result.Recreate++
 		} else {
 			result.Resources = append(result.Resources, resourceDiff{
-				Kind:      kind,
-				Name:      name,
-				Namespace: ns,
-				State:     ResourceModified,
-				Diff:      diffOutput,
+				Kind:        kind,
+				Name:        name,
+				Namespace:   ns,
+				Component:   component,
+				LastApplied: lastApplied,
+				State:       ResourceModified,
+				Diff:        diffOutput,
+				Changes:     changes,
 			})
 			result.Modified++
 		}
 	}
 
 	// Detect orphaned resources (on cluster but not in local render)
-	orphans := findOrphans(renderedKeys, diffOpts.InventoryLive)
+	if opts.NoOrphans {
+		return result, nil
+	}
+	orphans := findOrphans(renderedKeys, inventoryLive)
 	for _, orphan := range orphans {
 		result.Resources = append(result.Resources, resourceDiff{
 			Kind:      orphan.GetKind(),
 			Name:      orphan.GetName(),
 			Namespace: orphan.GetNamespace(),
+			Component: orphan.GetLabels()[pkgcore.LabelComponentName],
 			State:     ResourceOrphaned,
 		})
 		result.Orphaned++
@@ -413,6 +750,30 @@ func Diff(ctx context.Context, client *Client, resources []*unstructured.Unstruc
 	return result, nil
 }
 
+// compareLastApplied compares rendered with the last-applied-configuration
+// annotation on live. It returns nil when live has none.
+func compareLastApplied(c Comparer, rendered, live *unstructured.Unstructured) (*LastAppliedDiff, error) {
+	raw, ok := live.GetAnnotations()[AnnotationLastApplied]
+	if !ok {
+		return nil, nil
+	}
+	intent := &unstructured.Unstructured{}
+	if err := intent.UnmarshalJSON([]byte(raw)); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", AnnotationLastApplied, err)
+	}
+	// kubectl records the annotation inside the configuration it applied
+	// only when the input carried it; either way it is not intent.
+	unstructured.RemoveNestedField(intent.Object, "metadata", "annotations", AnnotationLastApplied)
+	if len(intent.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(intent.Object, "metadata", "annotations")
+	}
+	diffOutput, changes, err := compareResource(c, rendered, intent)
+	if err != nil {
+		return nil, err
+	}
+	return &LastAppliedDiff{Diff: diffOutput, Changes: changes}, nil
+}
+
 // findOrphans returns resources that exist in inventoryLive but are not present
 // in the rendered resource set. When inventoryLive is nil the set is empty and
 // no orphans are reported (first-time diff where no instance has been deployed yet).
@@ -429,3 +790,48 @@ func findOrphans(renderedKeys map[string]bool, inventoryLive []*unstructured.Uns
 
 	return orphans
 }
+
+// DiffOutputFormat selects how a DiffResult is rendered.
+type DiffOutputFormat string
+
+const (
+	// DiffOutputText is the human-readable dyff output (default).
+	DiffOutputText DiffOutputFormat = "text"
+	// DiffOutputJSON is a structured JSON document with per-resource entries.
+	DiffOutputJSON DiffOutputFormat = "json"
+	// DiffOutputName lists only the keys of changed resources, one per line.
+	DiffOutputName DiffOutputFormat = "name"
+)
+
+// ParseDiffOutputFormat parses a --output value for diff.
+func ParseDiffOutputFormat(s string) (DiffOutputFormat, bool) {
+	switch f := DiffOutputFormat(strings.ToLower(s)); f {
+	case DiffOutputText, DiffOutputJSON, DiffOutputName:
+		return f, true
+	default:
+		return "", false
+	}
+}
+
+// FormatDiffJSON renders the diff result as indented JSON, including
+// unchanged resources so consumers can filter on category.
+func FormatDiffJSON(result *DiffResult) (string, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling diff to JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatDiffNames returns the keys of modified, added, and orphaned resources,
+// one per line. Unchanged resources are omitted.
+func FormatDiffNames(result *DiffResult) string {
+	var lines []string
+	for _, rd := range result.Resources {
+		if rd.State == ResourceUnchanged {
+			continue
+		}
+		lines = append(lines, rd.Key())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// minDiffGroupSize is the fewest identically changed resources that are
+// collapsed into a group; a single resource is always shown in full.
+const minDiffGroupSize = 2
+
+// DiffGroup is a set of modified resources of one component, kind, and
+// namespace whose field changes are identical — an image bump across every
+// Deployment of a component, say — shown once instead of once per resource.
+type DiffGroup struct {
+	Component string
+	Kind      string
+	Namespace string
+	// Names are the member resources, in diff order.
+	Names []string
+	// Keys are the members' display keys (resourceDiff.Key), in diff order.
+	Keys []string
+	// Changes is the change set every member shares. When the members name a
+	// list entry differently (containers.app.image, containers.api.image),
+	// the paths show "*" in its place.
+	Changes []FieldChange
+
+	// fingerprint is the exact change set of the first member, to tell
+	// whether the others differ only in list-entry names.
+	fingerprint string
+}
+
+// GroupIdenticalChanges finds the modified resources of result that share a
+// component, a kind, a namespace, and the same field changes, and returns one
+// DiffGroup per set of at least minDiffGroupSize, in order of first
+// appearance. Changes that differ only in the name of a list entry — the same
+// image bump to containers named differently — count as the same. Resources
+// without structured changes are never grouped.
+func GroupIdenticalChanges(result *DiffResult) []DiffGroup {
+	index := make(map[string]int)
+	var groups []DiffGroup
+	for _, rd := range result.Resources {
+		if rd.State != ResourceModified || len(rd.Changes) == 0 {
+			continue
+		}
+		exact, err := changesFingerprint(rd.Changes, false)
+		if err != nil {
+			continue
+		}
+		normalized, err := changesFingerprint(rd.Changes, true)
+		if err != nil {
+			continue
+		}
+		key := rd.Component + "\x00" + rd.Kind + "\x00" + rd.Namespace + "\x00" + normalized
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, DiffGroup{
+				Component: rd.Component, Kind: rd.Kind, Namespace: rd.Namespace,
+				Changes: rd.Changes, fingerprint: exact,
+			})
+		} else if exact != groups[i].fingerprint {
+			groups[i].Changes = patternChanges(rd.Changes)
+		}
+		groups[i].Names = append(groups[i].Names, rd.Name)
+		groups[i].Keys = append(groups[i].Keys, rd.Key())
+	}
+
+	kept := groups[:0]
+	for _, g := range groups {
+		if len(g.Names) >= minDiffGroupSize {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+// changesFingerprint identifies a change set independent of the order the
+// comparer reported it in. normalized compares paths with named-list
+// selectors replaced (FieldChange.pattern).
+func changesFingerprint(changes []FieldChange, normalized bool) (string, error) {
+	if normalized {
+		changes = patternChanges(changes)
+	}
+	sorted := make([]FieldChange, len(changes))
+	copy(sorted, changes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	b, err := json.Marshal(sorted)
+	return string(b), err
+}
+
+// patternChanges returns a copy of changes with each Path replaced by its
+// selector pattern, where one is known.
+func patternChanges(changes []FieldChange) []FieldChange {
+	out := make([]FieldChange, len(changes))
+	for i, c := range changes {
+		if c.pattern != "" {
+			c.Path = c.pattern
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// Header renders the group's summary line, e.g.
+// "5 × Deployment (media): web-a, web-b, web-c, web-d, web-e".
+func (g DiffGroup) Header() string {
+	var scope []string
+	if g.Namespace != "" {
+		scope = append(scope, g.Namespace)
+	}
+	if g.Component != "" {
+		scope = append(scope, "component "+g.Component)
+	}
+	where := ""
+	if len(scope) > 0 {
+		where = " (" + strings.Join(scope, ", ") + ")"
+	}
+	return fmt.Sprintf("%d × %s%s: %s", len(g.Names), g.Kind, where, strings.Join(g.Names, ", "))
+}
+
+// FormatFieldChange renders one change on a line: "path: from → to" for a
+// modification, "path: + to" and "path: - from" for additions and removals.
+func FormatFieldChange(c FieldChange) string {
+	switch c.Type {
+	case "added":
+		return fmt.Sprintf("%s: + %s", c.Path, formatChangeValue(c.To))
+	case "removed":
+		return fmt.Sprintf("%s: - %s", c.Path, formatChangeValue(c.From))
+	case "reordered":
+		return c.Path + ": reordered"
+	default:
+		return fmt.Sprintf("%s: %s → %s", c.Path, formatChangeValue(c.From), formatChangeValue(c.To))
+	}
+}
+
+// formatChangeValue renders a change value compactly: scalars as-is, maps and
+// lists as single-line JSON.
+func formatChangeValue(v any) string {
+	switch v.(type) {
+	case map[string]any, []any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGroupIdenticalChanges(t *testing.T) {
+	bump := []FieldChange{{Path: "spec.template.spec.containers.app.image", Type: "modified", From: "app:1", To: "app:2"}}
+	modified := func(kind, name string, changes []FieldChange) resourceDiff {
+		return resourceDiff{Kind: kind, Name: name, Namespace: "media", State: ResourceModified, Changes: changes}
+	}
+	result := &DiffResult{Resources: []resourceDiff{
+		modified("Deployment", "web", bump),
+		modified("Deployment", "api", []FieldChange{{Path: "spec.replicas", Type: "modified", From: 1, To: 2}}),
+		modified("Deployment", "worker", bump),
+		modified("StatefulSet", "db", bump),
+		{Kind: "Deployment", Name: "new", Namespace: "media", State: ResourceAdded},
+		modified("Deployment", "cron", bump),
+		modified("Deployment", "legacy", nil),
+	}}
+
+	groups := GroupIdenticalChanges(result)
+	require.Len(t, groups, 1, "a different kind, a different change set, and no structured changes never group")
+	assert.Equal(t, []string{"web", "worker", "cron"}, groups[0].Names)
+	assert.Equal(t, []string{"Deployment/media/web", "Deployment/media/worker", "Deployment/media/cron"}, groups[0].Keys)
+	assert.Equal(t, "3 × Deployment (media): web, worker, cron", groups[0].Header())
+}
+
+func TestGroupIdenticalChanges_OrderIndependent(t *testing.T) {
+	a := FieldChange{Path: "spec.replicas", Type: "modified", From: 1, To: 2}
+	b := FieldChange{Path: "metadata.labels.tier", Type: "added", To: "web"}
+	result := &DiffResult{Resources: []resourceDiff{
+		{Kind: "Deployment", Name: "x", State: ResourceModified, Changes: []FieldChange{a, b}},
+		{Kind: "Deployment", Name: "y", State: ResourceModified, Changes: []FieldChange{b, a}},
+	}}
+
+	groups := GroupIdenticalChanges(result)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "2 × Deployment: x, y", groups[0].Header())
+}
+
+func TestFormatFieldChange(t *testing.T) {
+	tests := []struct {
+		change FieldChange
+		want   string
+	}{
+		{FieldChange{Path: "spec.replicas", Type: "modified", From: 1, To: 3}, "spec.replicas: 1 → 3"},
+		{FieldChange{Path: "metadata.labels", Type: "added", To: map[string]any{"tier": "web"}}, `metadata.labels: + {"tier":"web"}`},
+		{FieldChange{Path: "spec.ports", Type: "removed", From: []any{80}}, "spec.ports: - [80]"},
+		{FieldChange{Path: "spec.args", Type: "reordered"}, "spec.args: reordered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatFieldChange(tt.change))
+		})
+	}
+}
+
+func TestGroupIdenticalChanges_NamedListSelectors(t *testing.T) {
+	bump := func(container string) []FieldChange {
+		return []FieldChange{{
+			Path:    "spec.template.spec.containers." + container + ".image",
+			pattern: "spec.template.spec.containers.*.image",
+			Type:    "modified", From: "app:1", To: "app:2",
+		}}
+	}
+	result := &DiffResult{Resources: []resourceDiff{
+		{Kind: "Deployment", Name: "web", Component: "frontend", State: ResourceModified, Changes: bump("web")},
+		{Kind: "Deployment", Name: "api", Component: "frontend", State: ResourceModified, Changes: bump("api")},
+		{Kind: "Deployment", Name: "worker", Component: "backend", State: ResourceModified, Changes: bump("worker")},
+	}}
+
+	groups := GroupIdenticalChanges(result)
+	require.Len(t, groups, 1, "the backend Deployment is another component and stays on its own")
+	assert.Equal(t, []string{"web", "api"}, groups[0].Names)
+	assert.Equal(t, "2 × Deployment (component frontend): web, api", groups[0].Header())
+	require.Len(t, groups[0].Changes, 1)
+	assert.Equal(t, "spec.template.spec.containers.*.image", groups[0].Changes[0].Path,
+		"members naming the container differently share the selector pattern")
+}
+
+func TestFieldChangesFromReport_SelectorPattern(t *testing.T) {
+	live := makeUnstructured("apps/v1", "Deployment", "web", "media")
+	rendered := live.DeepCopy()
+	_ = unstructured.SetNestedSlice(live.Object, []any{map[string]any{"name": "web", "image": "app:1"}}, "spec", "template", "spec", "containers")
+	_ = unstructured.SetNestedSlice(rendered.Object, []any{map[string]any{"name": "web", "image": "app:2"}}, "spec", "template", "spec", "containers")
+
+	_, changes, err := compareResource(NewComparer(), rendered, live)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "spec.template.spec.containers.web.image", changes[0].Path)
+	assert.Equal(t, "spec.template.spec.containers.*.image", changes[0].pattern)
+}
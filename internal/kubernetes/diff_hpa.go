@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// autoscaledKinds are the workloads whose spec.replicas a
+// HorizontalPodAutoscaler takes over.
+var autoscaledKinds = map[string]bool{
+	"apps/Deployment":  true,
+	"apps/StatefulSet": true,
+}
+
+// hpaTargets returns the workloads the HorizontalPodAutoscalers among objs
+// scale, keyed by hpaTargetKey.
+func hpaTargets(objs ...[]*unstructured.Unstructured) map[string]bool {
+	targets := make(map[string]bool)
+	for _, set := range objs {
+		for _, obj := range set {
+			if obj.GetKind() != "HorizontalPodAutoscaler" || !strings.HasPrefix(obj.GetAPIVersion(), "autoscaling/") {
+				continue
+			}
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "kind")
+			name, _, _ := unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "name")
+			if kind != "" && name != "" {
+				targets[hpaTargetKey(kind, obj.GetNamespace(), name)] = true
+			}
+		}
+	}
+	return targets
+}
+
+func hpaTargetKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// autoscaled reports whether an autoscaler owns rendered's spec.replicas:
+// an HPA among targets scales it, or the live object's replicas were last
+// written through the scale subresource, as the HPA controller does.
+func autoscaled(rendered, live *unstructured.Unstructured, targets map[string]bool) bool {
+	gvk := rendered.GroupVersionKind()
+	if !autoscaledKinds[gvk.Group+"/"+gvk.Kind] {
+		return false
+	}
+	if targets[hpaTargetKey(gvk.Kind, rendered.GetNamespace(), rendered.GetName())] {
+		return true
+	}
+	for _, entry := range live.GetManagedFields() {
+		if entry.Subresource != "scale" || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Spec map[string]any `json:"f:spec"`
+		}
+		if json.Unmarshal(entry.FieldsV1.Raw, &fields) != nil {
+			continue
+		}
+		if _, ok := fields.Spec["f:replicas"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutReplicas returns a copy of obj without spec.replicas.
+func withoutReplicas(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+	unstructured.RemoveNestedField(out.Object, "spec", "replicas")
+	return out
+}
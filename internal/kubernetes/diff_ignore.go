@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AnnotationDiffIgnorePaths, in a module's metadata.annotations, lists
+// comma-separated ignore paths (see ParseIgnorePath) every diff of the
+// module's instances applies, on top of --ignore-path.
+const AnnotationDiffIgnorePaths = "opmodel.dev/diff-ignore-paths"
+
+// IgnorePath is a field a diff leaves out of the comparison: on resources of
+// Kind, or on every resource when Kind is empty.
+type IgnorePath struct {
+	Kind string
+	// Segments are the path's map keys from the object root; "*" matches
+	// every key of a map and every element of a list.
+	Segments []string
+}
+
+func (p IgnorePath) String() string {
+	var b strings.Builder
+	if p.Kind != "" {
+		b.WriteString(p.Kind + ":")
+	}
+	for i, s := range p.Segments {
+		if strings.ContainsAny(s, `.[]"`) {
+			fmt.Fprintf(&b, "[%q]", s)
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// ParseIgnorePath parses "[Kind:]path". The path is dot-separated keys, with
+// a key that holds dots or slashes quoted in brackets and * for any key or
+// list element:
+//
+//	spec.replicas
+//	Deployment:spec.template.spec.containers.*.image
+//	metadata.annotations["deployment.kubernetes.io/revision"]
+func ParseIgnorePath(s string) (IgnorePath, error) {
+	var p IgnorePath
+	rest := strings.TrimSpace(s)
+	if kind, path, ok := strings.Cut(rest, ":"); ok && kind != "" && !strings.ContainsAny(kind, `.["`) {
+		p.Kind, rest = kind, path
+	}
+	rest = strings.TrimPrefix(strings.TrimPrefix(rest, "$"), ".")
+
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return IgnorePath{}, fmt.Errorf("ignore path %q: unterminated [", s)
+			}
+			key := rest[1:end]
+			if len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+				key = key[1 : len(key)-1]
+			}
+			if key == "" {
+				return IgnorePath{}, fmt.Errorf("ignore path %q: empty []", s)
+			}
+			p.Segments = append(p.Segments, key)
+			rest = strings.TrimPrefix(rest[end+1:], ".")
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return IgnorePath{}, fmt.Errorf("ignore path %q: empty key", s)
+			}
+			p.Segments = append(p.Segments, rest[:end])
+			rest = strings.TrimPrefix(rest[end:], ".")
+		}
+	}
+	if len(p.Segments) == 0 {
+		return IgnorePath{}, fmt.Errorf("ignore path %q: no field path", s)
+	}
+	return p, nil
+}
+
+// ParseIgnorePaths parses each of paths with ParseIgnorePath.
+func ParseIgnorePaths(paths []string) ([]IgnorePath, error) {
+	out := make([]IgnorePath, 0, len(paths))
+	for _, s := range paths {
+		p, err := ParseIgnorePath(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// ModuleIgnorePaths returns the ignore paths a module's
+// AnnotationDiffIgnorePaths lists.
+func ModuleIgnorePaths(annotations map[string]string) ([]IgnorePath, error) {
+	raw := strings.TrimSpace(annotations[AnnotationDiffIgnorePaths])
+	if raw == "" {
+		return nil, nil
+	}
+	var paths []string
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			paths = append(paths, s)
+		}
+	}
+	out, err := ParseIgnorePaths(paths)
+	if err != nil {
+		return nil, fmt.Errorf("module annotation %s: %w", AnnotationDiffIgnorePaths, err)
+	}
+	return out, nil
+}
+
+// WithIgnorePaths wraps c so both sides of each comparison have paths removed
+// first: a difference only in an ignored field is no difference at all.
+func WithIgnorePaths(c Comparer, paths []IgnorePath) Comparer {
+	if len(paths) == 0 {
+		return c
+	}
+	return &ignoringComparer{inner: c, paths: paths}
+}
+
+// ignoringComparer is a comparer that drops ignored fields before comparing.
+type ignoringComparer struct {
+	inner Comparer
+	paths []IgnorePath
+}
+
+func (c *ignoringComparer) Compare(rendered, live *unstructured.Unstructured) (string, error) {
+	return c.inner.Compare(c.strip(rendered), c.strip(live))
+}
+
+func (c *ignoringComparer) CompareFields(rendered, live *unstructured.Unstructured) (string, []FieldChange, error) {
+	return compareResource(c.inner, c.strip(rendered), c.strip(live))
+}
+
+// strip returns a copy of obj without the ignored fields for its kind.
+func (c *ignoringComparer) strip(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+	for _, p := range c.paths {
+		if p.Kind == "" || strings.EqualFold(p.Kind, obj.GetKind()) {
+			removeIgnoredPath(out.Object, p.Segments)
+		}
+	}
+	return out
+}
+
+// removeIgnoredPath deletes the field at segs below v, following every key or
+// element a "*" segment matches.
+func removeIgnoredPath(v any, segs []string) {
+	switch node := v.(type) {
+	case map[string]any:
+		if segs[0] == "*" {
+			for k := range node {
+				if len(segs) == 1 {
+					delete(node, k)
+				} else {
+					removeIgnoredPath(node[k], segs[1:])
+				}
+			}
+			return
+		}
+		if len(segs) == 1 {
+			delete(node, segs[0])
+			return
+		}
+		if child, ok := node[segs[0]]; ok {
+			removeIgnoredPath(child, segs[1:])
+		}
+	case []any:
+		if segs[0] != "*" || len(segs) == 1 {
+			return
+		}
+		for _, elem := range node {
+			removeIgnoredPath(elem, segs[1:])
+		}
+	}
+}
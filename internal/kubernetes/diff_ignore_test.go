@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseIgnorePath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want IgnorePath
+	}{
+		{"spec.replicas", IgnorePath{Segments: []string{"spec", "replicas"}}},
+		{"$.spec.replicas", IgnorePath{Segments: []string{"spec", "replicas"}}},
+		{"Deployment:spec.template.spec.containers.*.image",
+			IgnorePath{Kind: "Deployment", Segments: []string{"spec", "template", "spec", "containers", "*", "image"}}},
+		{`metadata.annotations["deployment.kubernetes.io/revision"]`,
+			IgnorePath{Segments: []string{"metadata", "annotations", "deployment.kubernetes.io/revision"}}},
+		{`metadata.annotations['a.io/b'].x`, IgnorePath{Segments: []string{"metadata", "annotations", "a.io/b", "x"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseIgnorePath(tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			again, err := ParseIgnorePath(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, again, "String round-trips")
+		})
+	}
+
+	for _, bad := range []string{"", "Deployment:", "spec..replicas", `metadata.annotations["x`, "spec[]"} {
+		_, err := ParseIgnorePath(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestModuleIgnorePaths(t *testing.T) {
+	paths, err := ModuleIgnorePaths(map[string]string{AnnotationDiffIgnorePaths: "Deployment:spec.replicas, metadata.labels.rev"})
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+	assert.Equal(t, "Deployment", paths[0].Kind)
+
+	paths, err = ModuleIgnorePaths(nil)
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	_, err = ModuleIgnorePaths(map[string]string{AnnotationDiffIgnorePaths: "spec..x"})
+	assert.ErrorContains(t, err, AnnotationDiffIgnorePaths)
+}
+
+func TestWithIgnorePaths(t *testing.T) {
+	deployment := func(replicas int64, image, restarted string) *unstructured.Unstructured {
+		obj := makeUnstructured("apps/v1", "Deployment", "web", "media")
+		obj.SetAnnotations(map[string]string{"example.com/restarted-at": restarted})
+		_ = unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas")
+		_ = unstructured.SetNestedSlice(obj.Object, []any{map[string]any{"name": "app", "image": image}},
+			"spec", "template", "spec", "containers")
+		return obj
+	}
+	rendered := deployment(1, "web:2", "t1")
+	live := deployment(5, "web:2", "t2")
+
+	paths, err := ParseIgnorePaths([]string{"Deployment:spec.replicas", `metadata.annotations["example.com/restarted-at"]`})
+	require.NoError(t, err)
+	out, changes, err := compareResource(WithIgnorePaths(NewComparer(), paths), rendered, live)
+	require.NoError(t, err)
+	assert.Empty(t, out, "only ignored fields differ")
+	assert.Empty(t, changes)
+	assert.Equal(t, "t1", rendered.GetAnnotations()["example.com/restarted-at"], "inputs are left untouched")
+
+	paths, err = ParseIgnorePaths([]string{"StatefulSet:spec.replicas"})
+	require.NoError(t, err)
+	out, _, err = compareResource(WithIgnorePaths(NewComparer(), paths), rendered, live)
+	require.NoError(t, err)
+	assert.Contains(t, out, "replicas", "a path scoped to another kind does not apply")
+
+	live = deployment(1, "web:3", "t1")
+	paths, err = ParseIgnorePaths([]string{"spec.template.spec.containers.*.image"})
+	require.NoError(t, err)
+	out, _, err = compareResource(WithIgnorePaths(NewComparer(), paths), rendered, live)
+	require.NoError(t, err)
+	assert.Empty(t, out, "* matches every list element")
+}
+
+func TestWithIgnorePaths_NotCountedAsModified(t *testing.T) {
+	rendered := []*unstructured.Unstructured{liveResource("jf", "media", "web", "Deployment", "web", 1)}
+	baseline := []*unstructured.Unstructured{liveResource("jf", "media", "web", "Deployment", "web", 4)}
+
+	assert.Equal(t, 1, DiffRendered(rendered, baseline, NewComparer(), DiffFilter{}).Modified)
+
+	paths, err := ParseIgnorePaths([]string{"Deployment:spec.replicas"})
+	require.NoError(t, err)
+	result := DiffRendered(rendered, baseline, WithIgnorePaths(NewComparer(), paths), DiffFilter{})
+	assert.Zero(t, result.Modified)
+	assert.Equal(t, 1, result.Unchanged)
+}
@@ -48,7 +48,7 @@ func TestDiffIntegration_ShowsModifications(t *testing.T) {
 	modifiedResources := []*unstructured.Unstructured{modifiedCM}
 
 	// Diff should show modifications
-	diffResult, err := Diff(ctx, client, modifiedResources, instanceName, comparer)
+	diffResult, err := Diff(ctx, client, modifiedResources, instanceName, comparer, DiffOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, 1, diffResult.Modified, "should detect 1 modified resource")
 	assert.Equal(t, 0, diffResult.Added)
@@ -92,7 +92,7 @@ func TestDiffIntegration_ApplyThenDiffShowsNoDifferences(t *testing.T) {
 	assert.Equal(t, 1, applyResult.Applied)
 
 	// Diff immediately — with field projection, should show "No differences found"
-	diffResult, err := Diff(ctx, client, resources, instanceName, comparer)
+	diffResult, err := Diff(ctx, client, resources, instanceName, comparer, DiffOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, 0, diffResult.Modified, "apply-then-diff with no changes should report 0 modified")
 	assert.Equal(t, 0, diffResult.Added, "apply-then-diff with no changes should report 0 added")
@@ -177,7 +177,7 @@ func TestDiffIntegration_AllAdditions(t *testing.T) {
 	resources := []*unstructured.Unstructured{cm}
 
 	// Diff without prior deployment — all should be additions
-	diffResult, err := Diff(ctx, client, resources, instanceName, comparer)
+	diffResult, err := Diff(ctx, client, resources, instanceName, comparer, DiffOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, 0, diffResult.Modified)
 	assert.Equal(t, 1, diffResult.Added, "resource should show as added")
@@ -0,0 +1,208 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// LiveInstance is one side of a live-to-live diff: an instance name and the
+// resources discovered from its inventory.
+type LiveInstance struct {
+	Name      string
+	Resources []*unstructured.Unstructured
+}
+
+// DiffLive compares the live resources of two instances — typically the same
+// module deployed to two environments. Resources are paired by logical
+// identity (component, group, and kind) rather than by name and namespace,
+// which differ between environments by design. When a component has several
+// resources of one kind, the resource name with the instance-name prefix
+// trimmed disambiguates them.
+//
+// The result reads from base to against: Modified pairs are configured
+// differently, Added resources exist only in against, Removed resources exist
+// only in base. Per-instance identity (name, namespace, OPM instance labels)
+// and server-managed fields are excluded from the comparison.
+func DiffLive(base, against LiveInstance, comparer Comparer, filter DiffFilter) *DiffResult {
+	// A kind is disambiguated by name when EITHER side has several of it in a
+	// component, so both sides use the same keys even when their counts differ.
+	ambiguous := make(map[string]bool)
+	for _, inst := range []LiveInstance{base, against} {
+		counts := make(map[string]int, len(inst.Resources))
+		for _, obj := range inst.Resources {
+			counts[logicalKind(obj)]++
+		}
+		for k, n := range counts {
+			if n > 1 {
+				ambiguous[k] = true
+			}
+		}
+	}
+	baseByKey, baseWarnings := indexByLogicalIdentity(base, ambiguous)
+	againstByKey, againstWarnings := indexByLogicalIdentity(against, ambiguous)
+
+	result := &DiffResult{Warnings: append(baseWarnings, againstWarnings...)}
+	keys := make([]string, 0, len(baseByKey)+len(againstByKey))
+	for k := range baseByKey {
+		keys = append(keys, k)
+	}
+	for k := range againstByKey {
+		if _, ok := baseByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		b, a := baseByKey[key], againstByKey[key]
+		switch {
+		case a == nil:
+			if !filter.Matches(b) {
+				continue
+			}
+			result.Resources = append(result.Resources, resourceDiff{
+				Kind: b.GetKind(), Name: b.GetName(), Namespace: b.GetNamespace(), State: ResourceRemoved,
+				Component: b.GetLabels()[pkgcore.LabelComponentName],
+			})
+			result.Removed++
+		case b == nil:
+			if !filter.Matches(a) {
+				continue
+			}
+			result.Resources = append(result.Resources, resourceDiff{
+				Kind: a.GetKind(), Name: a.GetName(), Namespace: a.GetNamespace(), State: ResourceAdded,
+				Component:   a.GetLabels()[pkgcore.LabelComponentName],
+				AgainstName: a.GetName(), AgainstNamespace: a.GetNamespace(),
+			})
+			result.Added++
+		default:
+			if !filter.Matches(b) && !filter.Matches(a) {
+				continue
+			}
+			rd := resourceDiff{
+				Kind: b.GetKind(), Name: b.GetName(), Namespace: b.GetNamespace(),
+				Component:   b.GetLabels()[pkgcore.LabelComponentName],
+				AgainstName: a.GetName(), AgainstNamespace: a.GetNamespace(),
+			}
+			diffOutput, changes, err := compareResource(comparer,
+				comparableLive(a, against.Name), comparableLive(b, base.Name))
+			switch {
+			case err != nil:
+				result.Warnings = append(result.Warnings, fmt.Sprintf("comparing %s: %v", rd.Key(), err))
+				continue
+			case diffOutput == "":
+				rd.State = ResourceUnchanged
+				result.Unchanged++
+			default:
+				rd.State = ResourceModified
+				rd.Diff = diffOutput
+				rd.Changes = changes
+				result.Modified++
+			}
+			result.Resources = append(result.Resources, rd)
+		}
+	}
+	return result
+}
+
+// logicalKind is a resource's pairing key before disambiguation: component,
+// group, and kind.
+func logicalKind(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	return obj.GetLabels()[pkgcore.LabelComponentName] + "/" + gvk.Group + "/" + gvk.Kind
+}
+
+// indexByLogicalIdentity keys an instance's resources by component, group,
+// and kind, extending the key with the prefix-trimmed name for the ambiguous
+// kinds. Two resources that still share a key — say "web" and "jf-prod-web"
+// in instance jf-prod — are reported in a warning and keyed by their full
+// names instead, so neither is silently dropped.
+func indexByLogicalIdentity(inst LiveInstance, ambiguous map[string]bool) (map[string]*unstructured.Unstructured, []string) {
+	keyOf := func(obj *unstructured.Unstructured) string {
+		key := logicalKind(obj)
+		if ambiguous[key] {
+			key += "/" + strings.TrimPrefix(obj.GetName(), inst.Name+"-")
+		}
+		return key
+	}
+	byKey := make(map[string][]*unstructured.Unstructured, len(inst.Resources))
+	for _, obj := range inst.Resources {
+		byKey[keyOf(obj)] = append(byKey[keyOf(obj)], obj)
+	}
+
+	out := make(map[string]*unstructured.Unstructured, len(inst.Resources))
+	var warnings []string
+	for key, objs := range byKey {
+		if len(objs) == 1 {
+			out[key] = objs[0]
+			continue
+		}
+		names := make([]string, len(objs))
+		for i, obj := range objs {
+			names[i] = obj.GetNamespace() + "/" + obj.GetName()
+			out[key+"/="+obj.GetNamespace()+"/"+obj.GetName()] = obj
+		}
+		sort.Strings(names)
+		warnings = append(warnings, fmt.Sprintf("instance %s: %s %s pair under the same identity; they are compared by full name",
+			inst.Name, objs[0].GetKind(), strings.Join(names, " and ")))
+	}
+	sort.Strings(warnings)
+	return out, warnings
+}
+
+// comparableLive returns a copy of a live resource with everything that is
+// expected to differ between instances removed: server-managed and
+// cluster-assigned fields, the name and namespace, and the OPM instance
+// identity labels.
+func comparableLive(obj *unstructured.Unstructured, instanceName string) *unstructured.Unstructured {
+	c := obj.DeepCopy()
+	stripServerManagedFields(c.Object)
+	unstructured.RemoveNestedField(c.Object, "metadata", "name")
+	unstructured.RemoveNestedField(c.Object, "metadata", "namespace")
+	unstructured.RemoveNestedField(c.Object, "metadata", "ownerReferences")
+
+	// Fields the cluster assigns per object, which always differ between
+	// environments: allocated Service IPs, the Deployment controller's
+	// revision counter, and kubectl's client-side apply record.
+	if c.GetKind() == "Service" && c.GroupVersionKind().Group == "" {
+		unstructured.RemoveNestedField(c.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(c.Object, "spec", "clusterIPs")
+	}
+	annotations := c.GetAnnotations()
+	for _, k := range []string{
+		"deployment.kubernetes.io/revision",
+		"kubectl.kubernetes.io/last-applied-configuration",
+	} {
+		delete(annotations, k)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(c.Object, "metadata", "annotations")
+	} else {
+		c.SetAnnotations(annotations)
+	}
+
+	labels := c.GetLabels()
+	for _, k := range []string{
+		pkgcore.LabelModuleInstanceName,
+		pkgcore.LabelModuleInstanceNamespace,
+		pkgcore.LabelModuleInstanceUUID,
+	} {
+		delete(labels, k)
+	}
+	for k, v := range labels {
+		if v == instanceName {
+			delete(labels, k) // e.g. app.kubernetes.io/instance
+		}
+	}
+	if len(labels) == 0 {
+		unstructured.RemoveNestedField(c.Object, "metadata", "labels")
+	} else {
+		c.SetLabels(labels)
+	}
+	return c
+}
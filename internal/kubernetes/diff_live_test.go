@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// liveResource builds a live resource as an instance would own it: named with
+// the instance prefix, carrying the instance and component labels.
+func liveResource(instance, namespace, component, kind, suffix string, replicas int64) *unstructured.Unstructured {
+	obj := makeUnstructured("apps/v1", kind, instance+"-"+suffix, namespace)
+	obj.SetLabels(map[string]string{
+		pkgcore.LabelComponentName:           component,
+		pkgcore.LabelModuleInstanceName:      instance,
+		pkgcore.LabelModuleInstanceNamespace: namespace,
+		"app.kubernetes.io/instance":         instance,
+	})
+	obj.SetResourceVersion("12345")
+	if replicas > 0 {
+		_ = unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas")
+	}
+	return obj
+}
+
+func TestDiffLive(t *testing.T) {
+	staging := LiveInstance{Name: "jf-staging", Resources: []*unstructured.Unstructured{
+		liveResource("jf-staging", "staging", "web", "Deployment", "web", 1),
+		liveResource("jf-staging", "staging", "web", "ConfigMap", "settings", 0),
+		liveResource("jf-staging", "staging", "web", "ConfigMap", "env", 0),
+		liveResource("jf-staging", "staging", "debug", "Deployment", "debug", 1),
+	}}
+	prod := LiveInstance{Name: "jf-prod", Resources: []*unstructured.Unstructured{
+		liveResource("jf-prod", "prod", "web", "Deployment", "web", 3),
+		liveResource("jf-prod", "prod", "web", "ConfigMap", "settings", 0),
+		liveResource("jf-prod", "prod", "web", "ConfigMap", "env", 0),
+		liveResource("jf-prod", "prod", "cache", "StatefulSet", "cache", 1),
+	}}
+
+	result := DiffLive(staging, prod, NewComparer(), DiffFilter{})
+	require.Empty(t, result.Warnings)
+
+	assert.Equal(t, 1, result.Modified, "only the replica count differs")
+	assert.Equal(t, 2, result.Unchanged, "the ConfigMaps pair by prefix-trimmed name and match")
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Removed)
+
+	byState := map[ResourceState][]resourceDiff{}
+	for _, rd := range result.Resources {
+		byState[rd.State] = append(byState[rd.State], rd)
+	}
+	modified := byState[ResourceModified][0]
+	assert.Equal(t, "jf-staging-web", modified.Name)
+	assert.Equal(t, "jf-prod-web", modified.AgainstName)
+	assert.Equal(t, "prod", modified.AgainstNamespace)
+	require.Len(t, modified.Changes, 1)
+	assert.Equal(t, FieldChange{Path: "spec.replicas", Type: "modified", From: 1, To: 3}, modified.Changes[0])
+
+	assert.Equal(t, "jf-prod-cache", byState[ResourceAdded][0].AgainstName)
+	assert.Equal(t, "jf-staging-debug", byState[ResourceRemoved][0].Name)
+	assert.Equal(t, "1 modified, 1 added, 1 removed", result.SummaryLine())
+}
+
+func TestDiffLive_Filter(t *testing.T) {
+	a := LiveInstance{Name: "a", Resources: []*unstructured.Unstructured{
+		liveResource("a", "ns", "web", "Deployment", "web", 1),
+		liveResource("a", "ns", "web", "ConfigMap", "settings", 0),
+	}}
+	b := LiveInstance{Name: "b", Resources: []*unstructured.Unstructured{
+		liveResource("b", "ns", "web", "Deployment", "web", 2),
+	}}
+
+	result := DiffLive(a, b, NewComparer(), DiffFilter{Kinds: []string{"ConfigMap"}})
+	assert.Equal(t, 0, result.Modified, "Deployment is filtered out")
+	assert.Equal(t, 1, result.Removed)
+}
+
+func TestDiffLive_UnevenCountsStillPair(t *testing.T) {
+	staging := LiveInstance{Name: "s", Resources: []*unstructured.Unstructured{
+		liveResource("s", "staging", "web", "ConfigMap", "settings", 0),
+	}}
+	prod := LiveInstance{Name: "p", Resources: []*unstructured.Unstructured{
+		liveResource("p", "prod", "web", "ConfigMap", "settings", 0),
+		liveResource("p", "prod", "web", "ConfigMap", "extra", 0),
+	}}
+
+	result := DiffLive(staging, prod, NewComparer(), DiffFilter{})
+	assert.Equal(t, 1, result.Unchanged, "settings pairs with settings although prod has two ConfigMaps")
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 0, result.Removed)
+}
+
+func TestDiffLive_KeyCollisionIsReported(t *testing.T) {
+	a := LiveInstance{Name: "a", Resources: []*unstructured.Unstructured{
+		liveResource("a", "ns", "web", "ConfigMap", "web", 0),
+		liveResource("a", "ns", "web", "ConfigMap", "other", 0),
+	}}
+	// "a-web" and "web" both trim to "web".
+	clash := liveResource("a", "ns", "web", "ConfigMap", "x", 0)
+	clash.SetName("web")
+	a.Resources = append(a.Resources, clash)
+	b := LiveInstance{Name: "b", Resources: []*unstructured.Unstructured{
+		liveResource("b", "ns", "web", "ConfigMap", "other", 0),
+	}}
+
+	result := DiffLive(a, b, NewComparer(), DiffFilter{})
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "ns/a-web and ns/web")
+	assert.Equal(t, 2, result.Removed, "both colliding resources are still reported")
+	assert.Equal(t, 1, result.Unchanged)
+}
+
+func TestDiffLive_IgnoresClusterAssignedFields(t *testing.T) {
+	svc := func(instance, ip string) *unstructured.Unstructured {
+		obj := makeUnstructured("v1", "Service", instance+"-web", "ns")
+		obj.SetLabels(map[string]string{pkgcore.LabelComponentName: "web"})
+		obj.SetAnnotations(map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": "{" + ip + "}",
+			"deployment.kubernetes.io/revision":                ip,
+		})
+		_ = unstructured.SetNestedField(obj.Object, ip, "spec", "clusterIP")
+		_ = unstructured.SetNestedStringSlice(obj.Object, []string{ip}, "spec", "clusterIPs")
+		return obj
+	}
+	a := LiveInstance{Name: "a", Resources: []*unstructured.Unstructured{svc("a", "10.0.0.1")}}
+	b := LiveInstance{Name: "b", Resources: []*unstructured.Unstructured{svc("b", "10.0.0.2")}}
+
+	result := DiffLive(a, b, NewComparer(), DiffFilter{})
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 0, result.Modified)
+}
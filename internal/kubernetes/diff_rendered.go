@@ -0,0 +1,72 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// DiffRendered compares a render with a baseline render of the same instance
+// — typically the one its last apply produced — without reading the cluster:
+// what changed in the source since then, regardless of drift. Resources pair
+// by group, kind, namespace, and name. Added resources are new in rendered;
+// Orphaned ones are only in baseline, and the next apply prunes them.
+func DiffRendered(rendered, baseline []*unstructured.Unstructured, comparer Comparer, filter DiffFilter) *DiffResult {
+	rendered = filter.apply(rendered)
+	baseline = filter.apply(baseline)
+
+	baseByKey := make(map[string]*unstructured.Unstructured, len(baseline))
+	for _, obj := range baseline {
+		baseByKey[resourceKey(obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())] = obj
+	}
+	renderedKeys := make(map[string]bool, len(rendered))
+
+	result := &DiffResult{}
+	for _, res := range rendered {
+		key := resourceKey(res.GroupVersionKind(), res.GetNamespace(), res.GetName())
+		renderedKeys[key] = true
+		rd := resourceDiff{
+			Kind: res.GetKind(), Name: res.GetName(), Namespace: res.GetNamespace(),
+			Component: res.GetLabels()[pkgcore.LabelComponentName],
+		}
+		base, ok := baseByKey[key]
+		if !ok {
+			rd.State = ResourceAdded
+			result.Resources = append(result.Resources, rd)
+			result.Added++
+			continue
+		}
+
+		diffOutput, changes, err := compareResource(comparer, res, base)
+		switch {
+		case err != nil:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("comparing %s: %v", rd.Key(), err))
+			continue
+		case diffOutput == "":
+			rd.State = ResourceUnchanged
+			result.Unchanged++
+		default:
+			rd.Diff, rd.Changes = diffOutput, changes
+			if immutable := ImmutableChanges(res.GroupVersionKind().GroupKind(), changes); len(immutable) > 0 {
+				rd.State, rd.Immutable = ResourceRecreate, immutable
+				result.Recreate++
+			} else {
+				rd.State = ResourceModified
+				result.Modified++
+			}
+		}
+		result.Resources = append(result.Resources, rd)
+	}
+
+	for _, obj := range findOrphans(renderedKeys, baseline) {
+		result.Resources = append(result.Resources, resourceDiff{
+			Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace(),
+			Component: obj.GetLabels()[pkgcore.LabelComponentName],
+			State:     ResourceOrphaned,
+		})
+		result.Orphaned++
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiffRendered(t *testing.T) {
+	rendered := []*unstructured.Unstructured{
+		liveResource("jf", "media", "web", "Deployment", "web", 3),
+		liveResource("jf", "media", "web", "ConfigMap", "settings", 0),
+		liveResource("jf", "media", "cache", "StatefulSet", "cache", 1),
+	}
+	baseline := []*unstructured.Unstructured{
+		liveResource("jf", "media", "web", "Deployment", "web", 1),
+		liveResource("jf", "media", "web", "ConfigMap", "settings", 0),
+		liveResource("jf", "media", "debug", "Deployment", "debug", 1),
+	}
+
+	result := DiffRendered(rendered, baseline, NewComparer(), DiffFilter{})
+	require.Empty(t, result.Warnings)
+	assert.Equal(t, 1, result.Modified)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Orphaned, "a resource only the last apply rendered is pruned next")
+
+	states := map[string]ResourceState{}
+	for _, rd := range result.Resources {
+		states[rd.Name] = rd.State
+	}
+	assert.Equal(t, map[string]ResourceState{
+		"jf-web": ResourceModified, "jf-settings": ResourceUnchanged,
+		"jf-cache": ResourceAdded, "jf-debug": ResourceOrphaned,
+	}, states)
+}
+
+func TestDiffRendered_Filter(t *testing.T) {
+	rendered := []*unstructured.Unstructured{liveResource("jf", "media", "web", "Deployment", "web", 3)}
+	baseline := []*unstructured.Unstructured{
+		liveResource("jf", "media", "web", "Deployment", "web", 1),
+		liveResource("jf", "media", "web", "ConfigMap", "settings", 0),
+	}
+
+	result := DiffRendered(rendered, baseline, NewComparer(), DiffFilter{Kinds: []string{"ConfigMap"}})
+	assert.Zero(t, result.Modified, "Deployments are filtered out")
+	assert.Equal(t, 1, result.Orphaned)
+}
@@ -1,12 +1,17 @@
 package kubernetes
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 )
 
 // --- 7.1: Tests for CompareResource (dyff comparer) ---
@@ -357,6 +362,26 @@ func TestDiffResult_SummaryLine(t *testing.T) {
 			result:   DiffResult{Modified: 5, Added: 3},
 			expected: "5 modified, 3 added",
 		},
+		{
+			name:     "modified and to recreate",
+			result:   DiffResult{Modified: 1, Recreate: 2},
+			expected: "1 modified, 2 to recreate",
+		},
+		{
+			name:     "orphans skipped",
+			result:   DiffResult{Modified: 1, Scope: DiffScopeFields},
+			expected: "1 modified (orphans not checked)",
+		},
+		{
+			name:     "no differences, orphans skipped",
+			result:   DiffResult{Scope: DiffScopeFields},
+			expected: "No differences found (orphans not checked)",
+		},
+		{
+			name:     "no orphans, orphans only",
+			result:   DiffResult{Scope: DiffScopeOrphans},
+			expected: "No orphaned resources found",
+		},
 	}
 
 	for _, tc := range tests {
@@ -773,3 +798,330 @@ func TestDiffResult_IsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareResource_FieldChanges(t *testing.T) {
+	rendered := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "test-cm", "namespace": "default"},
+			"data":       map[string]interface{}{"key1": "new", "key3": "added"},
+		},
+	}
+	live := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "test-cm", "namespace": "default"},
+			"data":       map[string]interface{}{"key1": "old"},
+		},
+	}
+
+	diff, changes, err := compareResource(NewComparer(), rendered, live)
+	require.NoError(t, err)
+	assert.NotEmpty(t, diff)
+
+	byPath := make(map[string]FieldChange, len(changes))
+	for _, c := range changes {
+		byPath[c.Path] = c
+	}
+	require.Contains(t, byPath, "data.key1")
+	assert.Equal(t, "modified", byPath["data.key1"].Type)
+	assert.Equal(t, "old", byPath["data.key1"].From)
+	assert.Equal(t, "new", byPath["data.key1"].To)
+
+	require.Contains(t, byPath, "data")
+	assert.Equal(t, "added", byPath["data"].Type)
+}
+
+func TestParseDiffOutputFormat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want DiffOutputFormat
+		ok   bool
+	}{
+		{"text", DiffOutputText, true},
+		{"JSON", DiffOutputJSON, true},
+		{"name", DiffOutputName, true},
+		{"yaml", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseDiffOutputFormat(tt.in)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	result := &DiffResult{
+		Resources: []resourceDiff{
+			{Kind: "Deployment", Name: "web", Namespace: "apps", State: ResourceModified, Diff: "human",
+				Changes: []FieldChange{{Path: "spec.replicas", Type: "modified", From: 1, To: 3}}},
+			{Kind: "Service", Name: "web", Namespace: "apps", State: ResourceUnchanged},
+			{Kind: "ClusterRole", Name: "reader", State: ResourceAdded},
+			{Kind: "ConfigMap", Name: "old", Namespace: "apps", State: ResourceOrphaned},
+		},
+		Modified: 1, Added: 1, Orphaned: 1, Unchanged: 1,
+	}
+
+	t.Run("name", func(t *testing.T) {
+		assert.Equal(t, "Deployment/apps/web\nClusterRole/reader\nConfigMap/apps/old", FormatDiffNames(result))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := FormatDiffJSON(result)
+		require.NoError(t, err)
+
+		var decoded struct {
+			Modified  int `json:"modified"`
+			Unchanged int `json:"unchanged"`
+			Resources []struct {
+				Kind     string        `json:"kind"`
+				Category string        `json:"category"`
+				Changes  []FieldChange `json:"changes"`
+			} `json:"resources"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+		assert.Equal(t, 1, decoded.Modified)
+		assert.Equal(t, 1, decoded.Unchanged)
+		require.Len(t, decoded.Resources, 4)
+		assert.Equal(t, "modified", decoded.Resources[0].Category)
+		require.Len(t, decoded.Resources[0].Changes, 1)
+		assert.Equal(t, "spec.replicas", decoded.Resources[0].Changes[0].Path)
+		assert.Equal(t, "unchanged", decoded.Resources[1].Category)
+		assert.NotContains(t, out, "human", "human-readable diff is not part of the JSON schema")
+	})
+}
+
+func TestDiffFilter_Matches(t *testing.T) {
+	svc := makeUnstructured("v1", "Service", "web-frontend", "default")
+	cm := makeUnstructured("v1", "ConfigMap", "api-config", "default")
+
+	tests := []struct {
+		name   string
+		filter DiffFilter
+		svc    bool
+		cm     bool
+	}{
+		{"empty matches all", DiffFilter{}, true, true},
+		{"kind case-insensitive", DiffFilter{Kinds: []string{"service"}}, true, false},
+		{"name glob", DiffFilter{Names: []string{"api-*"}}, false, true},
+		{"kind and name both apply", DiffFilter{Kinds: []string{"Service"}, Names: []string{"api-*"}}, false, false},
+		{"repeated values are ORed", DiffFilter{Names: []string{"web-*", "api-*"}}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.svc, tt.filter.Matches(svc))
+			assert.Equal(t, tt.cm, tt.filter.Matches(cm))
+		})
+	}
+}
+
+func TestDiffFilter_ValidateRejectsBadPattern(t *testing.T) {
+	require.Error(t, DiffFilter{Names: []string{"web-["}}.Validate())
+	require.NoError(t, DiffFilter{Names: []string{"web-*"}}.Validate())
+}
+
+func TestDiff_FilterAppliesToOrphans(t *testing.T) {
+	ctx := context.Background()
+
+	renderedSvc := makeUnstructured("v1", "Service", "web", "default")
+	liveSvc := makeUnstructured("v1", "Service", "web", "default")
+	orphanSvc := makeUnstructured("v1", "Service", "legacy", "default")
+	orphanCM := makeUnstructured("v1", "ConfigMap", "legacy", "default")
+
+	client := &Client{
+		Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+			liveSvc.DeepCopy(), orphanSvc.DeepCopy(), orphanCM.DeepCopy()),
+	}
+
+	result, err := Diff(ctx, client, []*unstructured.Unstructured{renderedSvc}, "demo", NewComparer(), DiffOptions{
+		InventoryLive: []*unstructured.Unstructured{liveSvc, orphanSvc, orphanCM},
+		Filter:        DiffFilter{Kinds: []string{"Service"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Orphaned, "filtered-out ConfigMap must not be reported as orphaned")
+	assert.Equal(t, "Service/default/legacy", result.Resources[len(result.Resources)-1].Key())
+}
+
+func TestDiff_OrphanScopes(t *testing.T) {
+	ctx := context.Background()
+
+	rendered := makeUnstructured("v1", "ConfigMap", "web", "default")
+	rendered.Object["data"] = map[string]interface{}{"key": "new"}
+	added := makeUnstructured("v1", "ConfigMap", "added", "default")
+	live := makeUnstructured("v1", "ConfigMap", "web", "default")
+	live.Object["data"] = map[string]interface{}{"key": "old"}
+	orphan := makeUnstructured("v1", "ConfigMap", "legacy", "default")
+
+	client := &Client{
+		Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live.DeepCopy(), orphan.DeepCopy()),
+	}
+	diff := func(opts DiffOptions) *DiffResult {
+		t.Helper()
+		opts.InventoryLive = []*unstructured.Unstructured{live.DeepCopy(), orphan.DeepCopy()}
+		result, err := Diff(ctx, client, []*unstructured.Unstructured{rendered, added}, "demo", NewComparer(), opts)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("no orphans", func(t *testing.T) {
+		result := diff(DiffOptions{NoOrphans: true})
+		assert.Equal(t, DiffScopeFields, result.Scope)
+		assert.Equal(t, 1, result.Modified)
+		assert.Equal(t, 1, result.Added)
+		assert.Zero(t, result.Orphaned)
+		assert.Equal(t, "1 modified, 1 added (orphans not checked)", result.SummaryLine())
+	})
+
+	t.Run("orphans only", func(t *testing.T) {
+		result := diff(DiffOptions{OrphansOnly: true})
+		assert.Equal(t, DiffScopeOrphans, result.Scope)
+		assert.Zero(t, result.Modified+result.Added+result.Unchanged, "rendered resources are not compared")
+		assert.Equal(t, 1, result.Orphaned, "the rendered ConfigMap is still not an orphan")
+		require.Len(t, result.Resources, 1)
+		assert.Equal(t, "ConfigMap/default/legacy", result.Resources[0].Key())
+		assert.Equal(t, "1 orphaned", result.SummaryLine())
+	})
+
+	t.Run("exclusive", func(t *testing.T) {
+		_, err := Diff(ctx, client, nil, "demo", NewComparer(), DiffOptions{NoOrphans: true, OrphansOnly: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestDiff_ImmutableChangeIsRecreate(t *testing.T) {
+	ctx := context.Background()
+
+	deployment := func(app string, replicas int64) *unstructured.Unstructured {
+		obj := makeUnstructured("apps/v1", "Deployment", "web", "default")
+		obj.Object["spec"] = map[string]interface{}{
+			"replicas": replicas,
+			"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": app}},
+		}
+		return obj
+	}
+
+	client := &Client{
+		Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment("web", 1)),
+	}
+
+	t.Run("selector change", func(t *testing.T) {
+		result, err := Diff(ctx, client, []*unstructured.Unstructured{deployment("frontend", 1)}, "demo", NewComparer(), DiffOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Recreate)
+		assert.Equal(t, 0, result.Modified)
+		require.Len(t, result.Resources, 1)
+		assert.Equal(t, ResourceRecreate, result.Resources[0].State)
+		assert.Equal(t, []string{"spec.selector"}, result.Resources[0].Immutable)
+		assert.NotEmpty(t, result.Resources[0].Diff)
+	})
+
+	t.Run("mutable change", func(t *testing.T) {
+		result, err := Diff(ctx, client, []*unstructured.Unstructured{deployment("web", 3)}, "demo", NewComparer(), DiffOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Recreate)
+		assert.Equal(t, 1, result.Modified)
+	})
+}
+
+func TestDiff_CompareLastApplied(t *testing.T) {
+	ctx := context.Background()
+
+	configMap := func(name, value string) *unstructured.Unstructured {
+		obj := makeUnstructured("v1", "ConfigMap", name, "default")
+		obj.Object["data"] = map[string]interface{}{"mode": value}
+		return obj
+	}
+	// kubectl applied mode=debug; someone has since edited it to fast.
+	live := configMap("kubectl-managed", "fast")
+	live.SetAnnotations(map[string]string{
+		AnnotationLastApplied: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"kubectl-managed","namespace":"default"},"data":{"mode":"debug"}}`,
+	})
+	client := &Client{
+		Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live, configMap("plain", "fast")),
+	}
+	rendered := []*unstructured.Unstructured{configMap("kubectl-managed", "fast"), configMap("plain", "fast")}
+
+	result, err := Diff(ctx, client, rendered, "demo", NewComparer(), DiffOptions{CompareLastApplied: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Unchanged, "the last-applied comparison does not change the live diff")
+	require.Len(t, result.Resources, 2)
+
+	require.NotNil(t, result.Resources[0].LastApplied)
+	assert.NotEmpty(t, result.Resources[0].LastApplied.Diff, "render sets mode=fast where kubectl applied debug")
+	assert.Nil(t, result.Resources[1].LastApplied, "no annotation, nothing to compare")
+
+	result, err = Diff(ctx, client, rendered, "demo", NewComparer(), DiffOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, result.Resources[0].LastApplied, "off unless requested")
+}
+
+func TestDiff_AutoscaledReplicasAreNotDrift(t *testing.T) {
+	ctx := context.Background()
+
+	deployment := func(name string, replicas int64) *unstructured.Unstructured {
+		obj := makeUnstructured("apps/v1", "Deployment", name, "default")
+		obj.Object["spec"] = map[string]interface{}{
+			"replicas": replicas,
+			"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": name}},
+			"paused":   false,
+		}
+		return obj
+	}
+	hpa := makeUnstructured("autoscaling/v2", "HorizontalPodAutoscaler", "web", "default")
+	hpa.Object["spec"] = map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"},
+		"minReplicas":    int64(1),
+		"maxReplicas":    int64(10),
+	}
+
+	// The HPA has scaled web from 1 to 5. api has no HPA of its own, but
+	// its replicas were last written through the scale subresource.
+	api := deployment("api", 4)
+	api.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:     "kube-controller-manager",
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		Subresource: "scale",
+		FieldsType:  "FieldsV1",
+		FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+	}})
+	client := &Client{
+		Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment("web", 5), api, hpa.DeepCopy()),
+	}
+	rendered := []*unstructured.Unstructured{deployment("web", 1), deployment("api", 2), hpa}
+
+	t.Run("smart ignore", func(t *testing.T) {
+		result, err := Diff(ctx, client, rendered, "demo", NewComparer(), DiffOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Unchanged)
+		assert.Equal(t, 0, result.Modified)
+		assert.Equal(t, int64(1), rendered[0].Object["spec"].(map[string]interface{})["replicas"], "the render is not modified")
+	})
+
+	t.Run("filtered to the Deployment", func(t *testing.T) {
+		result, err := Diff(ctx, client, rendered, "demo", NewComparer(), DiffOptions{Filter: DiffFilter{Kinds: []string{"Deployment"}}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Unchanged, "the filtered-out HPA still marks web as autoscaled")
+	})
+
+	t.Run("no smart ignore", func(t *testing.T) {
+		result, err := Diff(ctx, client, rendered, "demo", NewComparer(), DiffOptions{NoSmartIgnore: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Modified)
+		assert.Equal(t, 1, result.Unchanged)
+	})
+
+	t.Run("other changes still show", func(t *testing.T) {
+		changed := deployment("web", 1)
+		changed.Object["spec"].(map[string]interface{})["paused"] = true
+		result, err := Diff(ctx, client, []*unstructured.Unstructured{changed, hpa}, "demo", NewComparer(), DiffOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Modified)
+		assert.NotContains(t, result.Resources[0].Diff, "replicas")
+	})
+}
@@ -0,0 +1,116 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DiffTool selects the Comparer that presents each modified resource.
+type DiffTool string
+
+const (
+	// DiffToolDyff presents changes as a dyff report, field by field (default).
+	DiffToolDyff DiffTool = "dyff"
+	// DiffToolUnified presents changes as a unified diff of the two
+	// resources' YAML, live as the old side.
+	DiffToolUnified DiffTool = "unified"
+	// DiffToolJSONPatch presents changes as the JSON merge patch (RFC 7386)
+	// that turns the live resource into the rendered one.
+	DiffToolJSONPatch DiffTool = "jsonpatch"
+)
+
+// ParseDiffTool parses a --diff-tool value.
+func ParseDiffTool(s string) (DiffTool, bool) {
+	switch t := DiffTool(strings.ToLower(s)); t {
+	case DiffToolDyff, DiffToolUnified, DiffToolJSONPatch:
+		return t, true
+	default:
+		return "", false
+	}
+}
+
+// NewComparerFor returns the Comparer for tool; an unknown tool gets dyff.
+// Every tool detects changes with dyff, so the field changes, and with them
+// which resources are modified or must be recreated, do not depend on tool.
+func NewComparerFor(tool DiffTool) Comparer {
+	switch tool {
+	case DiffToolUnified:
+		return &presentingComparer{present: unifiedDiff}
+	case DiffToolJSONPatch:
+		return &presentingComparer{present: mergePatchDiff}
+	default:
+		return NewComparer()
+	}
+}
+
+// presentingComparer detects changes with dyff and presents the resources
+// that have any with present.
+type presentingComparer struct {
+	present func(rendered, live *unstructured.Unstructured) (string, error)
+}
+
+func (c *presentingComparer) Compare(rendered, live *unstructured.Unstructured) (string, error) {
+	out, _, err := c.CompareFields(rendered, live)
+	return out, err
+}
+
+func (c *presentingComparer) CompareFields(rendered, live *unstructured.Unstructured) (string, []FieldChange, error) {
+	out, changes, err := (&dyffComparer{}).CompareFields(rendered, live)
+	if err != nil || out == "" {
+		return out, changes, err
+	}
+	out, err = c.present(rendered, live)
+	if err != nil {
+		return "", nil, err
+	}
+	return out, changes, nil
+}
+
+// unifiedDiff renders a unified diff of the YAML of live and rendered.
+func unifiedDiff(rendered, live *unstructured.Unstructured) (string, error) {
+	liveYAML, err := yaml.Marshal(live.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshaling live resource: %w", err)
+	}
+	renderedYAML, err := yaml.Marshal(rendered.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshaling rendered resource: %w", err)
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(liveYAML)),
+		B:        difflib.SplitLines(string(renderedYAML)),
+		FromFile: "cluster",
+		ToFile:   "rendered",
+		Context:  3,
+	})
+}
+
+// mergePatchDiff renders, as indented JSON, the merge patch that takes live
+// to rendered. A field the render drops shows as null.
+func mergePatchDiff(rendered, live *unstructured.Unstructured) (string, error) {
+	liveJSON, err := json.Marshal(live.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshaling live resource: %w", err)
+	}
+	renderedJSON, err := json.Marshal(rendered.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshaling rendered resource: %w", err)
+	}
+	patch, err := jsonpatch.CreateMergePatch(liveJSON, renderedJSON)
+	if err != nil {
+		return "", fmt.Errorf("computing merge patch: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, patch, "", "  "); err != nil {
+		return "", fmt.Errorf("formatting merge patch: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.String(), nil
+}
@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseDiffTool(t *testing.T) {
+	for _, s := range []string{"dyff", "unified", "JSONPatch"} {
+		_, ok := ParseDiffTool(s)
+		assert.True(t, ok, s)
+	}
+	_, ok := ParseDiffTool("vimdiff")
+	assert.False(t, ok)
+}
+
+func TestNewComparerFor(t *testing.T) {
+	configMap := func(data map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "web", "namespace": "media"},
+			"data":       data,
+		}}
+	}
+	live := configMap(map[string]any{"mode": "slow", "stale": "yes"})
+	rendered := configMap(map[string]any{"mode": "fast"})
+
+	_, dyffChanges, err := compareResource(NewComparer(), rendered, live)
+	require.NoError(t, err)
+	require.NotEmpty(t, dyffChanges)
+
+	t.Run("unified", func(t *testing.T) {
+		out, changes, err := compareResource(NewComparerFor(DiffToolUnified), rendered, live)
+		require.NoError(t, err)
+		assert.Contains(t, out, "--- cluster")
+		assert.Contains(t, out, "+++ rendered")
+		assert.Contains(t, out, "-    mode: slow")
+		assert.Contains(t, out, "+    mode: fast")
+		assert.Equal(t, dyffChanges, changes, "detection does not depend on the tool")
+	})
+
+	t.Run("jsonpatch", func(t *testing.T) {
+		out, changes, err := compareResource(NewComparerFor(DiffToolJSONPatch), rendered, live)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data": {"mode": "fast", "stale": null}}`, out)
+		assert.Equal(t, dyffChanges, changes)
+	})
+
+	t.Run("no differences", func(t *testing.T) {
+		for _, tool := range []DiffTool{DiffToolDyff, DiffToolUnified, DiffToolJSONPatch} {
+			out, err := NewComparerFor(tool).Compare(live, live)
+			require.NoError(t, err)
+			assert.Empty(t, out, tool)
+		}
+	})
+}
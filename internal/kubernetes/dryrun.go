@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// Dry-run modes (--dry-run). A client dry run sends no writes at all and
+// compares the render with the live objects; a server dry run sends every
+// apply with dryRun=All, so admission webhooks, defaulting, and quotas run
+// without anything being persisted.
+const (
+	DryRunNone   = "none"
+	DryRunClient = "client"
+	DryRunServer = "server"
+)
+
+// ParseDryRun validates a --dry-run value. "true" and "false", which the flag
+// took when it was a boolean, mean server and none.
+func ParseDryRun(s string) (string, error) {
+	switch s {
+	case DryRunNone, DryRunClient, DryRunServer:
+		return s, nil
+	case "true":
+		return DryRunServer, nil
+	case "false", "":
+		return DryRunNone, nil
+	}
+	return "", fmt.Errorf("invalid --dry-run %q (valid: %s, %s, %s)", s, DryRunNone, DryRunClient, DryRunServer)
+}
+
+// DryRunView pairs a rendered resource with the object the API server
+// returned for it from a server dry run.
+type DryRunView struct {
+	Rendered *unstructured.Unstructured
+	Server   *unstructured.Unstructured
+}
+
+// clientDryRunOnce reports what applying obj would do without sending it: a
+// resource missing from the cluster would be created; one whose live fields
+// differ from the render would be configured.
+func clientDryRunOnce(ctx context.Context, client *Client, obj *unstructured.Unstructured) (applyOutcome, error) {
+	var out applyOutcome
+	live, err := client.ResourceClientFor(obj).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		out.status = output.StatusCreated
+		return out, nil
+	}
+	if err != nil {
+		return out, err
+	}
+	stripServerManagedFields(live.Object)
+	live.Object = projectLiveToRendered(obj.Object, live.Object)
+	diff, err := NewComparer().Compare(obj, live)
+	if err != nil {
+		return out, fmt.Errorf("comparing with the live object: %w", err)
+	}
+	out.status = output.StatusUnchanged
+	if diff != "" {
+		out.status = output.StatusConfigured
+	}
+	return out, nil
+}
+
+// FormatServerDryRunDiff shows, for each resource whose server dry-run view
+// differs from the render, how: the fields defaulting, mutating admission, or
+// the live object added or changed. Server-managed metadata and status are
+// left out. An empty string means the server kept every render as is.
+func FormatServerDryRunDiff(views []DryRunView) (string, error) {
+	var b strings.Builder
+	for _, v := range views {
+		if v.Server == nil {
+			continue
+		}
+		server := v.Server.DeepCopy()
+		stripServerManagedFields(server.Object)
+		// Compare diffs from its second argument to its first: from the
+		// render to the server's view, so defaulted fields read as added.
+		diff, err := NewComparer().Compare(server, v.Rendered)
+		if err != nil {
+			return "", fmt.Errorf("comparing %s/%s with its server view: %w", v.Rendered.GetKind(), v.Rendered.GetName(), err)
+		}
+		if diff == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s\n%s\n", output.FormatResourceLine(v.Rendered.GetKind(), v.Rendered.GetNamespace(), v.Rendered.GetName(), "server view"), strings.TrimRight(diff, "\n"))
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
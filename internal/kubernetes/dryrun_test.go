@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+func TestParseDryRun(t *testing.T) {
+	for in, want := range map[string]string{
+		"": DryRunNone, "false": DryRunNone, "none": DryRunNone,
+		"true": DryRunServer, "server": DryRunServer, "client": DryRunClient,
+	} {
+		got, err := ParseDryRun(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseDryRun("all")
+	require.Error(t, err)
+}
+
+func TestApply_ClientDryRunSendsNoWrites(t *testing.T) {
+	live := componentObject("v1", "ConfigMap", "same", "web")
+	live.Object["data"] = map[string]any{"k": "v"}
+	live.SetResourceVersion("7")
+	changed := componentObject("v1", "ConfigMap", "changed", "web")
+	changed.Object["data"] = map[string]any{"k": "old"}
+
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live.DeepCopy(), changed.DeepCopy())
+	dyn.PrependReactor("patch", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("client dry run must not patch")
+	})
+
+	rendered := []*unstructured.Unstructured{
+		componentObject("v1", "ConfigMap", "same", "web"),
+		componentObject("v1", "ConfigMap", "changed", "web"),
+		componentObject("v1", "ConfigMap", "new", "web"),
+	}
+	rendered[0].Object["data"] = map[string]any{"k": "v"}
+	rendered[1].Object["data"] = map[string]any{"k": "new"}
+
+	result, err := Apply(context.Background(), &Client{Dynamic: dyn}, rendered, "demo", ApplyOptions{ClientDryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Configured)
+	assert.Equal(t, 1, result.Created)
+	assert.Empty(t, result.DryRunViews, "only a server dry run has a server view")
+}
+
+func TestApply_ServerDryRunKeepsServerViews(t *testing.T) {
+	rec := &applyRecorder{}
+	resources := []*unstructured.Unstructured{componentObject("apps/v1", "Deployment", "web", "web")}
+
+	result, err := Apply(context.Background(), rec.client(), resources, "demo", ApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, result.DryRunViews, 1)
+	assert.Same(t, resources[0], result.DryRunViews[0].Rendered)
+	assert.Equal(t, "web", result.DryRunViews[0].Server.GetName())
+}
+
+func TestFormatServerDryRunDiff(t *testing.T) {
+	rendered := componentObject("apps/v1", "Deployment", "web", "web")
+	rendered.Object["spec"] = map[string]any{"replicas": int64(2)}
+
+	server := rendered.DeepCopy()
+	server.SetResourceVersion("12")
+	server.SetUID("abc")
+	server.Object["status"] = map[string]any{"replicas": int64(0)}
+	require.NoError(t, unstructured.SetNestedField(server.Object, "RollingUpdate", "spec", "strategy", "type"))
+
+	out, err := FormatServerDryRunDiff([]DryRunView{{Rendered: rendered, Server: server}})
+	require.NoError(t, err)
+	assert.Contains(t, out, output.FormatResourceLine("Deployment", "media", "web", "server view"))
+	assert.Contains(t, out, "RollingUpdate", "defaulted fields are shown")
+	assert.NotContains(t, out, "resourceVersion", "server-managed metadata is not")
+
+	same, err := FormatServerDryRunDiff([]DryRunView{{Rendered: rendered, Server: rendered.DeepCopy()}})
+	require.NoError(t, err)
+	assert.Empty(t, same)
+}
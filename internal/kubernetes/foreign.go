@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ForeignOwnedResource is a resource an instance's inventory tracks whose
+// live labels no longer tie it to the instance — typically because another
+// tool (Helm, kubectl) adopted it and relabelled it.
+type ForeignOwnedResource struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Reason says which labels changed (e.g. `managed by "Helm"`).
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// FormatForeignOwned renders foreign-owned resources as a block for text
+// output, or "" when there are none.
+func FormatForeignOwned(foreign []ForeignOwnedResource) string {
+	if len(foreign) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\nTracked resources no longer labelled as this instance's (another tool may own them):\n")
+	for _, f := range foreign {
+		fmt.Fprintf(&sb, "    %s/%s: %s\n", f.Kind, f.Name, f.Reason)
+	}
+	return sb.String()
+}
@@ -0,0 +1,19 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatForeignOwned(t *testing.T) {
+	assert.Empty(t, FormatForeignOwned(nil))
+
+	out := FormatForeignOwned([]ForeignOwnedResource{
+		{Kind: "Service", Name: "svc", Namespace: "apps", Reason: `managed by "Helm"`},
+		{Kind: "ConfigMap", Name: "cfg", Namespace: "apps", Reason: "has no labels"},
+	})
+	assert.Equal(t, "\nTracked resources no longer labelled as this instance's (another tool may own them):\n"+
+		"    Service/svc: managed by \"Helm\"\n"+
+		"    ConfigMap/cfg: has no labels\n", out)
+}
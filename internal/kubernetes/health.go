@@ -21,6 +21,9 @@ const (
 	HealthMissing HealthStatus = "Missing"
 	// HealthBound means a PersistentVolumeClaim is bound to a PersistentVolume.
 	HealthBound HealthStatus = "Bound"
+	// HealthTerminating means the resource has a deletionTimestamp but still
+	// exists on the cluster, typically held by finalizers.
+	HealthTerminating HealthStatus = "Terminating"
 )
 
 // conditionStatusTrue is the Kubernetes condition status value representing "true".
@@ -30,6 +33,9 @@ const conditionStatusTrue = "True"
 // Distinct from HealthStatus "Ready" — this is the .conditions[].type field value.
 const conditionTypeReady = "Ready"
 
+// conditionTypeAvailable is the Kubernetes condition type for availability.
+const conditionTypeAvailable = "Available"
+
 // workloadKinds are resources that use the Available/Ready condition for health.
 // Note: StatefulSet is intentionally excluded — it does not emit conditions
 // and must be evaluated via readyReplicas instead.
@@ -62,11 +68,23 @@ var passiveKinds = map[string]bool{
 	"LimitRange":          true,
 	"StorageClass":        true,
 	"PriorityClass":       true,
+
+	"Endpoints":                      true,
+	"EndpointSlice":                  true,
+	"IngressClass":                   true,
+	"HorizontalPodAutoscaler":        true,
+	"MutatingWebhookConfiguration":   true,
+	"ValidatingWebhookConfiguration": true,
 }
 
 // EvaluateHealth determines the health status of a Kubernetes resource
 // based on its kind and status conditions.
 func EvaluateHealth(resource *unstructured.Unstructured) HealthStatus {
+	// A resource marked for deletion is terminating regardless of its kind.
+	if resource.GetDeletionTimestamp() != nil {
+		return HealthTerminating
+	}
+
 	kind := resource.GetKind()
 
 	// Workloads: Deployment, DaemonSet — check Available/Ready condition
@@ -99,7 +117,7 @@ func EvaluateHealth(resource *unstructured.Unstructured) HealthStatus {
 		return HealthReady
 	}
 
-	// Custom resources: check for Ready condition, fallback to passive
+	// Custom resources: check the kind's readiness conditions
 	return evaluateCustomHealth(resource)
 }
 
@@ -147,7 +165,7 @@ func evaluatePVCHealth(resource *unstructured.Unstructured) HealthStatus {
 func evaluateWorkloadHealth(resource *unstructured.Unstructured) HealthStatus {
 	conditions := getConditions(resource)
 	for _, c := range conditions {
-		if c.Type == "Available" || c.Type == conditionTypeReady {
+		if c.Type == conditionTypeAvailable || c.Type == conditionTypeReady {
 			if c.Status == conditionStatusTrue {
 				return HealthReady
 			}
@@ -193,19 +211,71 @@ func evaluateJobHealth(resource *unstructured.Unstructured) HealthStatus {
 	return HealthNotReady
 }
 
-// evaluateCustomHealth checks for a Ready condition on custom resources.
-// If no Ready condition exists, treats the resource as passive (healthy).
+// defaultReadyConditionTypes are the condition types that report readiness
+// for a kind with no entry in readyConditionTypes or the overrides.
+var defaultReadyConditionTypes = []string{conditionTypeReady, conditionTypeAvailable}
+
+// readyConditionTypes is the built-in table of kinds whose readiness is
+// reported by a condition other than Ready or Available.
+var readyConditionTypes = map[string][]string{
+	"CustomResourceDefinition": {"Established"},
+}
+
+// readyConditionOverrides holds the user's per-kind condition types
+// (kubernetes.readyConditions in config.cue), set by SetReadyConditionTypes.
+var readyConditionOverrides map[string][]string
+
+// SetReadyConditionTypes installs per-kind overrides of the condition types
+// that report readiness for custom resources. Keys are a kind ("Cluster") or
+// a kind qualified by its API group ("Cluster.postgresql.cnpg.io"); the
+// qualified key wins. Overrides replace the built-in table entry for a kind.
+func SetReadyConditionTypes(overrides map[string][]string) {
+	readyConditionOverrides = overrides
+}
+
+// conditionTypesFor returns the condition types, in priority order, that
+// report readiness for a resource. configured is false when the kind has no
+// override or built-in entry and the defaults apply.
+func conditionTypesFor(resource *unstructured.Unstructured) (types []string, configured bool) {
+	kind := resource.GetKind()
+	if group := resource.GroupVersionKind().Group; group != "" {
+		if types, ok := readyConditionOverrides[kind+"."+group]; ok {
+			return types, true
+		}
+	}
+	if types, ok := readyConditionOverrides[kind]; ok {
+		return types, true
+	}
+	if types, ok := readyConditionTypes[kind]; ok {
+		return types, true
+	}
+	return defaultReadyConditionTypes, false
+}
+
+// evaluateCustomHealth evaluates a kind the CLI has no dedicated rule for from
+// its status conditions: the first of the kind's readiness condition types
+// that is present decides, Ready when its status is "True" and NotReady
+// otherwise. A kind with configured condition types that reports none of them
+// yet is Unknown. Any other kind without a Ready or Available condition — a
+// plain configuration CR — is passive and counts as Ready, so it does not
+// hold an instance NotReady forever.
 func evaluateCustomHealth(resource *unstructured.Unstructured) HealthStatus {
 	conditions := getConditions(resource)
-	for _, c := range conditions {
-		if c.Type == conditionTypeReady {
+	types, configured := conditionTypesFor(resource)
+	for _, condType := range types {
+		for _, c := range conditions {
+			if c.Type != condType {
+				continue
+			}
 			if c.Status == conditionStatusTrue {
 				return HealthReady
 			}
 			return HealthNotReady
 		}
 	}
-	// No Ready condition — treat as passive
+	if configured {
+		return HealthUnknown
+	}
 	return HealthReady
 }
 
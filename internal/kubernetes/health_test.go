@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -270,12 +271,27 @@ func TestEvaluateHealth_Custom(t *testing.T) {
 			expected: HealthNotReady,
 		},
 		{
-			name:       "Custom without Ready condition (passive fallback)",
+			name: "Custom with Available=True",
+			conditions: []map[string]interface{}{
+				{"type": "Available", "status": "True"},
+			},
+			expected: HealthReady,
+		},
+		{
+			name: "Custom with Ready=False wins over Available=True",
+			conditions: []map[string]interface{}{
+				{"type": "Available", "status": "True"},
+				{"type": "Ready", "status": "False"},
+			},
+			expected: HealthNotReady,
+		},
+		{
+			name:       "Custom without conditions is passive",
 			conditions: nil,
 			expected:   HealthReady,
 		},
 		{
-			name: "Custom with other conditions but no Ready",
+			name: "Custom with other conditions but no Ready is passive",
 			conditions: []map[string]interface{}{
 				{"type": "Synced", "status": "True"},
 			},
@@ -290,3 +306,45 @@ func TestEvaluateHealth_Custom(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateHealth_CustomConditionTypes(t *testing.T) {
+	crd := makeResource("CustomResourceDefinition", []map[string]interface{}{
+		{"type": "Established", "status": "True"},
+	})
+	assert.Equal(t, HealthReady, EvaluateHealth(crd), "built-in table: CRDs report Established")
+
+	cluster := makeResource("Cluster", []map[string]interface{}{
+		{"type": "Ready", "status": "True"},
+		{"type": "ContinuousArchiving", "status": "False"},
+	})
+	cluster.SetAPIVersion("postgresql.cnpg.io/v1")
+	assert.Equal(t, HealthReady, EvaluateHealth(cluster))
+
+	t.Cleanup(func() { SetReadyConditionTypes(nil) })
+	SetReadyConditionTypes(map[string][]string{
+		"Cluster":                    {"Ready"},
+		"Cluster.postgresql.cnpg.io": {"ContinuousArchiving"},
+	})
+	assert.Equal(t, HealthNotReady, EvaluateHealth(cluster), "a group-qualified override wins")
+
+	SetReadyConditionTypes(map[string][]string{"CustomResourceDefinition": {"NamesAccepted"}})
+	assert.Equal(t, HealthUnknown, EvaluateHealth(crd), "an override replaces the built-in entry")
+}
+
+func TestEvaluateHealth_Terminating(t *testing.T) {
+	for _, kind := range []string{"ConfigMap", "Deployment", "MyCustomResource"} {
+		t.Run(kind, func(t *testing.T) {
+			res := makeResource(kind, []map[string]interface{}{
+				{"type": "Ready", "status": "True"},
+				{"type": "Available", "status": "True"},
+			})
+			now := metav1.Now()
+			res.SetDeletionTimestamp(&now)
+			res.SetFinalizers([]string{"example.com/cleanup"})
+
+			status := EvaluateHealth(res)
+			assert.Equal(t, HealthTerminating, status)
+			assert.False(t, IsHealthy(status))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// immutableFields lists, per kind, the dot-style paths the API server refuses
+// to change once a resource exists. A change at or below one of them cannot be
+// applied in place: the resource has to be deleted and recreated. Only
+// unconditionally immutable fields are listed; fields that become immutable
+// through the object's own state (a ConfigMap with immutable: true) are not.
+var immutableFields = map[schema.GroupKind][]string{
+	{Group: "apps", Kind: "Deployment"}: {"spec.selector"},
+	{Group: "apps", Kind: "DaemonSet"}:  {"spec.selector"},
+	{Group: "apps", Kind: "ReplicaSet"}: {"spec.selector"},
+	{Group: "apps", Kind: "StatefulSet"}: {
+		"spec.selector", "spec.serviceName", "spec.volumeClaimTemplates", "spec.podManagementPolicy",
+	},
+	{Group: "batch", Kind: "Job"}: {"spec.selector", "spec.template"},
+	{Kind: "Service"}:             {"spec.clusterIP", "spec.clusterIPs"},
+	{Kind: "Secret"}:              {"type"},
+	{Kind: "PersistentVolumeClaim"}: {
+		"spec.accessModes", "spec.storageClassName", "spec.volumeMode", "spec.volumeName",
+		"spec.selector", "spec.dataSource", "spec.dataSourceRef",
+	},
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}:        {"roleRef"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}: {"roleRef"},
+	{Group: "storage.k8s.io", Kind: "StorageClass"}: {
+		"provisioner", "parameters", "reclaimPolicy", "volumeBindingMode",
+	},
+}
+
+// ImmutableChanges returns the immutable fields of kind gk that changes touch,
+// each once. A non-empty result means the changes cannot be applied in place.
+func ImmutableChanges(gk schema.GroupKind, changes []FieldChange) []string {
+	fields := immutableFields[gk]
+	if len(fields) == 0 {
+		return nil
+	}
+	var touched []string
+	for _, field := range fields {
+		for _, c := range changes {
+			if c.Path == field || strings.HasPrefix(c.Path, field+".") {
+				touched = append(touched, field)
+				break
+			}
+		}
+	}
+	return touched
+}
@@ -0,0 +1,24 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestImmutableChanges(t *testing.T) {
+	statefulSet := schema.GroupKind{Group: "apps", Kind: "StatefulSet"}
+	changes := []FieldChange{
+		{Path: "spec.replicas"},
+		{Path: "spec.volumeClaimTemplates.data.spec.resources.requests.storage"},
+		{Path: "spec.serviceName"},
+		{Path: "spec.serviceNameSuffix"},
+	}
+
+	assert.Equal(t, []string{"spec.serviceName", "spec.volumeClaimTemplates"}, ImmutableChanges(statefulSet, changes))
+	assert.Empty(t, ImmutableChanges(statefulSet, []FieldChange{{Path: "spec.template.spec.containers.app.image"}}))
+	assert.Empty(t, ImmutableChanges(schema.GroupKind{Kind: "ConfigMap"}, changes), "kinds without immutable fields")
+	assert.Equal(t, []string{"roleRef"},
+		ImmutableChanges(schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}, []FieldChange{{Path: "roleRef.name"}}))
+}
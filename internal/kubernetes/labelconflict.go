@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// Label conflict policies (--label-conflict-policy). They decide which value
+// a label or annotation takes when the render and the live resource disagree
+// on it. Keys OPM itself defines — anything in the opmodel.dev domain and
+// app.kubernetes.io/managed-by — always take the render's value: apply,
+// inventory, and status depend on them.
+const (
+	// LabelConflictOPMWins applies the render's value (the default).
+	LabelConflictOPMWins = "opm-wins"
+
+	// LabelConflictKeepExisting keeps the live value of every conflicting key.
+	LabelConflictKeepExisting = "keep-existing"
+
+	// LabelConflictMerge keeps the live value of conflicting keys another
+	// field manager set, and applies the render's value to keys opm-cli set
+	// on an earlier apply, so a module's own label changes still roll out.
+	LabelConflictMerge = "merge"
+)
+
+// ValidateLabelConflictPolicy rejects an unknown --label-conflict-policy.
+// The empty string is accepted as LabelConflictOPMWins.
+func ValidateLabelConflictPolicy(policy string) error {
+	switch policy {
+	case "", LabelConflictOPMWins, LabelConflictKeepExisting, LabelConflictMerge:
+		return nil
+	}
+	return fmt.Errorf("invalid label conflict policy %q (valid: %s, %s, %s)",
+		policy, LabelConflictOPMWins, LabelConflictKeepExisting, LabelConflictMerge)
+}
+
+// isOPMKey reports whether a label or annotation key is one OPM defines.
+func isOPMKey(key string) bool {
+	if key == pkgcore.LabelManagedBy {
+		return true
+	}
+	prefix, _, ok := strings.Cut(key, "/")
+	return ok && (prefix == "opmodel.dev" || strings.HasSuffix(prefix, ".opmodel.dev"))
+}
+
+// resolveMetadataConflicts returns obj with each conflicting label and
+// annotation resolved against live under policy, and the keys that kept their
+// live value ("label foo", "annotation bar"), sorted. obj is returned as is
+// when nothing is conceded.
+func resolveMetadataConflicts(obj, live *unstructured.Unstructured, policy string) (*unstructured.Unstructured, []string) {
+	if live == nil || policy == "" || policy == LabelConflictOPMWins {
+		return obj, nil
+	}
+	var owned map[string]bool
+	if policy == LabelConflictMerge {
+		owned = map[string]bool{}
+		for _, p := range opmOwnedFields(live) {
+			owned[fieldsKey(p)] = true
+		}
+	}
+
+	var conceded []string
+	resolve := func(field string, desired, current map[string]string) map[string]string {
+		var out map[string]string
+		for key, value := range desired {
+			liveValue, ok := current[key]
+			if !ok || liveValue == value || isOPMKey(key) {
+				continue
+			}
+			if owned[fieldsKey([]string{"f:metadata", "f:" + field, "f:" + key})] {
+				continue
+			}
+			if out == nil {
+				out = make(map[string]string, len(desired))
+				for k, v := range desired {
+					out[k] = v
+				}
+			}
+			out[key] = liveValue
+			conceded = append(conceded, strings.TrimSuffix(field, "s")+" "+key)
+		}
+		return out
+	}
+
+	labels := resolve("labels", obj.GetLabels(), live.GetLabels())
+	annotations := resolve("annotations", obj.GetAnnotations(), live.GetAnnotations())
+	if len(conceded) == 0 {
+		return obj, nil
+	}
+	resolved := obj.DeepCopy()
+	if labels != nil {
+		resolved.SetLabels(labels)
+	}
+	if annotations != nil {
+		resolved.SetAnnotations(annotations)
+	}
+	sort.Strings(conceded)
+	return resolved, conceded
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func labelledConfigMap(labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "web", "namespace": "demo"},
+	}}
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestResolveMetadataConflicts(t *testing.T) {
+	desired := labelledConfigMap(map[string]string{
+		"team":                 "platform",
+		"tier":                 "web",
+		pkgcore.LabelManagedBy: pkgcore.LabelManagedByValue,
+	})
+	newLive := func() *unstructured.Unstructured {
+		return labelledConfigMap(map[string]string{
+			"team":                 "payments", // set by another tool
+			"tier":                 "frontend", // set by opm-cli on an earlier apply
+			pkgcore.LabelManagedBy: "helm",
+		})
+	}
+
+	t.Run("opm-wins applies the render", func(t *testing.T) {
+		got, conceded := resolveMetadataConflicts(desired, newLive(), LabelConflictOPMWins)
+		assert.Same(t, desired, got)
+		assert.Empty(t, conceded)
+	})
+
+	t.Run("keep-existing keeps every conflicting live value but OPM's own", func(t *testing.T) {
+		got, conceded := resolveMetadataConflicts(desired, newLive(), LabelConflictKeepExisting)
+		assert.Equal(t, []string{"label team", "label tier"}, conceded)
+		assert.Equal(t, "payments", got.GetLabels()["team"])
+		assert.Equal(t, "frontend", got.GetLabels()["tier"])
+		assert.Equal(t, pkgcore.LabelManagedByValue, got.GetLabels()[pkgcore.LabelManagedBy])
+		assert.Equal(t, "platform", desired.GetLabels()["team"], "the rendered object is not modified")
+	})
+
+	t.Run("merge keeps only values another manager set", func(t *testing.T) {
+		live := ownedBy(newLive(), `{"f:metadata":{"f:labels":{"f:tier":{}}}}`)
+		got, conceded := resolveMetadataConflicts(desired, live, LabelConflictMerge)
+		assert.Equal(t, []string{"label team"}, conceded)
+		assert.Equal(t, "payments", got.GetLabels()["team"])
+		assert.Equal(t, "web", got.GetLabels()["tier"])
+	})
+}
+
+func TestValidateLabelConflictPolicy(t *testing.T) {
+	require.NoError(t, ValidateLabelConflictPolicy(""))
+	require.NoError(t, ValidateLabelConflictPolicy(LabelConflictMerge))
+	require.Error(t, ValidateLabelConflictPolicy("theirs"))
+}
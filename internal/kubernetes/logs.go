@@ -0,0 +1,229 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// logRestartDelay is how long a followed stream waits before reopening after
+// its container stops, giving a restarting container time to come back.
+const logRestartDelay = 2 * time.Second
+
+// logWorkloadKinds are the workloads whose pods Logs streams: those that
+// select their pods by .spec.selector.matchLabels.
+var logWorkloadKinds = map[string]bool{
+	kindDeployment:  true,
+	kindStatefulSet: true,
+	kindDaemonSet:   true,
+	kindJob:         true,
+}
+
+// LogsOptions configures Logs.
+type LogsOptions struct {
+	// InventoryLive is the list of live OPM-managed resources from inventory
+	// resolution. Pods are found through its workloads' selectors.
+	InventoryLive []*unstructured.Unstructured
+
+	// Container selects the container to stream. Empty streams a pod's only
+	// container, or its first one with a warning when it has several.
+	Container string
+
+	// Follow keeps streaming until ctx is done, reopening a stream whose
+	// container restarts.
+	Follow bool
+
+	// Since limits the logs to those newer than this duration; zero is all.
+	Since time.Duration
+
+	// Tail is the number of most recent lines per container; negative is all.
+	Tail int64
+
+	// Warn reports a pod or container that is skipped or defaulted.
+	Warn func(msg string, keyvals ...any)
+}
+
+// logStream is one container's log stream and the prefix its lines carry.
+type logStream struct {
+	namespace, pod, container string
+	prefix                    string
+}
+
+// Logs streams the logs of the pods an instance's workloads select to w, each
+// line prefixed with [component/pod]. Streams run concurrently; lines from
+// different pods interleave but never split. It returns the first error a
+// stream fails with, after every stream has ended.
+func Logs(ctx context.Context, client *Client, opts LogsOptions, w io.Writer) error {
+	streams, err := logStreams(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+	if len(streams) == 0 {
+		return nil
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	for _, s := range streams {
+		wg.Add(1)
+		go func(s logStream) {
+			defer wg.Done()
+			if err := streamContainerLogs(ctx, client, s, opts, w, &mu); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("streaming logs of %s/%s: %w", s.pod, s.container, err)
+				}
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// logStreams resolves the pods of the workloads in opts.InventoryLive and the
+// container to stream from each, sorted by prefix.
+func logStreams(ctx context.Context, client *Client, opts LogsOptions) ([]logStream, error) {
+	warn := opts.Warn
+	if warn == nil {
+		warn = func(string, ...any) {}
+	}
+
+	seen := make(map[string]bool)
+	var streams []logStream
+	for _, workload := range opts.InventoryLive {
+		if !logWorkloadKinds[workload.GetKind()] {
+			continue
+		}
+		pods, err := selectedPods(ctx, client, workload)
+		if err != nil {
+			return nil, fmt.Errorf("listing pods of %s/%s: %w", workload.GetKind(), workload.GetName(), err)
+		}
+		component := workload.GetLabels()[pkgcore.LabelComponentName]
+		if component == "" {
+			component = workload.GetName()
+		}
+		for i := range pods {
+			pod := &pods[i]
+			if seen[pod.Namespace+"/"+pod.Name] {
+				continue
+			}
+			seen[pod.Namespace+"/"+pod.Name] = true
+			container, ok := logContainer(pod, opts.Container, warn)
+			if !ok {
+				continue
+			}
+			streams = append(streams, logStream{
+				namespace: pod.Namespace,
+				pod:       pod.Name,
+				container: container,
+				prefix:    fmt.Sprintf("[%s/%s] ", component, pod.Name),
+			})
+		}
+	}
+	sort.Slice(streams, func(i, j int) bool { return streams[i].prefix < streams[j].prefix })
+	return streams, nil
+}
+
+// logContainer picks the container of pod to stream: want when set, which the
+// pod must have, or else the first container, with a warning when there are
+// others.
+func logContainer(pod *corev1.Pod, want string, warn func(string, ...any)) (string, bool) {
+	if len(pod.Spec.Containers) == 0 {
+		return "", false
+	}
+	if want != "" {
+		for _, c := range pod.Spec.Containers {
+			if c.Name == want {
+				return want, true
+			}
+		}
+		warn("pod has no such container; skipping", "pod", pod.Name, "container", want)
+		return "", false
+	}
+	first := pod.Spec.Containers[0].Name
+	if len(pod.Spec.Containers) > 1 {
+		warn("pod has several containers; showing the first (use --container to choose)",
+			"pod", pod.Name, "container", first, "containers", len(pod.Spec.Containers))
+	}
+	return first, true
+}
+
+// streamContainerLogs copies one container's log to w, line by line under mu.
+// When following, a stream that ends because the container stopped is
+// reopened from where it left off, until the pod is gone or ctx is done.
+func streamContainerLogs(ctx context.Context, client *Client, s logStream, opts LogsOptions, w io.Writer, mu *sync.Mutex) error {
+	podLogOpts := &corev1.PodLogOptions{Container: s.container, Follow: opts.Follow}
+	if opts.Since > 0 {
+		seconds := int64(opts.Since.Seconds())
+		podLogOpts.SinceSeconds = &seconds
+	}
+	if opts.Tail >= 0 {
+		tail := opts.Tail
+		podLogOpts.TailLines = &tail
+	}
+
+	for {
+		err := copyLogStream(ctx, client, s, podLogOpts, w, mu)
+		if !opts.Follow || ctx.Err() != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		// The container stopped or restarted: pick up after the last line.
+		resumed := metav1.Now()
+		podLogOpts.SinceSeconds, podLogOpts.TailLines, podLogOpts.SinceTime = nil, nil, &resumed
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logRestartDelay):
+		}
+		if _, err := client.Clientset.CoreV1().Pods(s.namespace).Get(ctx, s.pod, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			return nil
+		}
+	}
+}
+
+// copyLogStream opens one log stream and writes its lines to w until it ends.
+func copyLogStream(ctx context.Context, client *Client, s logStream, podLogOpts *corev1.PodLogOptions, w io.Writer, mu *sync.Mutex) error {
+	rc, err := client.Clientset.CoreV1().Pods(s.namespace).GetLogs(s.pod, podLogOpts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		mu.Lock()
+		_, err := fmt.Fprintf(w, "%s%s\n", s.prefix, scanner.Text())
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
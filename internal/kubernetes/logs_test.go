@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func logsPod(name string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}}}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+	return pod
+}
+
+func logsWorkload(kind, name string) *unstructured.Unstructured {
+	obj := makeUnstructured("apps/v1", kind, name, "default")
+	obj.SetLabels(map[string]string{pkgcore.LabelComponentName: "frontend"})
+	obj.Object["spec"] = map[string]any{
+		"selector": map[string]any{"matchLabels": map[string]any{"app": "web"}},
+	}
+	return obj
+}
+
+func TestLogs(t *testing.T) {
+	client := &Client{Clientset: fake.NewSimpleClientset(logsPod("web-b", "app"), logsPod("web-a", "app", "sidecar"))}
+	var warnings []string
+	opts := LogsOptions{
+		InventoryLive: []*unstructured.Unstructured{
+			logsWorkload("Deployment", "web"),
+			makeUnstructured("v1", "ConfigMap", "web", "default"),
+		},
+		Tail: -1,
+		Warn: func(msg string, keyvals ...any) {
+			warnings = append(warnings, fmt.Sprint(append([]any{msg}, keyvals...)...))
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Logs(context.Background(), client, opts, &buf))
+	// The fake clientset serves "fake logs" for every container.
+	assert.ElementsMatch(t, []string{"[frontend/web-a] fake logs", "[frontend/web-b] fake logs"},
+		strings.Split(strings.TrimSpace(buf.String()), "\n"))
+	require.Len(t, warnings, 1, "only the multi-container pod warns")
+	assert.Contains(t, warnings[0], "web-a")
+}
+
+func TestLogStreams_Container(t *testing.T) {
+	client := &Client{Clientset: fake.NewSimpleClientset(logsPod("web-a", "app", "sidecar"), logsPod("web-b", "app"))}
+	var warned int
+	opts := LogsOptions{
+		InventoryLive: []*unstructured.Unstructured{logsWorkload("StatefulSet", "web")},
+		Container:     "sidecar",
+		Warn:          func(string, ...any) { warned++ },
+	}
+
+	streams, err := logStreams(context.Background(), client, opts)
+	require.NoError(t, err)
+	require.Len(t, streams, 1, "web-b has no sidecar and is skipped")
+	assert.Equal(t, "web-a", streams[0].pod)
+	assert.Equal(t, "sidecar", streams[0].container)
+	assert.Equal(t, 1, warned)
+}
+
+func TestLogStreams_PodSelectedTwiceStreamsOnce(t *testing.T) {
+	client := &Client{Clientset: fake.NewSimpleClientset(logsPod("web-a", "app"))}
+	opts := LogsOptions{InventoryLive: []*unstructured.Unstructured{
+		logsWorkload("Deployment", "web"),
+		logsWorkload("DaemonSet", "agent"),
+		logsWorkload("CronJob", "nightly"),
+	}}
+
+	streams, err := logStreams(context.Background(), client, opts)
+	require.NoError(t, err)
+	assert.Len(t, streams, 1)
+}
@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// restMapper returns the client's REST mapper, running discovery when there is
+// none yet or refresh is set, or nil for a client without a Clientset (such as
+// a test fake). The mapper is kept in memory for the life of the client. A
+// failed discovery keeps the previous mapper, or an empty one, so callers fall
+// back to pluralization rather than rediscovering on every lookup.
+func (c *Client) restMapper(refresh bool) meta.RESTMapper {
+	if c.Clientset == nil {
+		return nil
+	}
+	c.mapperMu.Lock()
+	defer c.mapperMu.Unlock()
+	if c.mapper != nil && !refresh {
+		return c.mapper
+	}
+	groups, err := restmapper.GetAPIGroupResources(c.Clientset.Discovery())
+	switch {
+	case err == nil:
+		c.mapper = restmapper.NewDiscoveryRESTMapper(groups)
+	case c.mapper == nil:
+		output.Debug("discovering API resources failed; resource names fall back to pluralization", "error", err)
+		c.mapper = meta.MultiRESTMapper{}
+	}
+	return c.mapper
+}
+
+// GVRFor returns the GroupVersionResource obj is served at. The mapping comes
+// from cached discovery; a kind the cache does not know triggers one
+// rediscovery per kind, in case it was registered after the cache was filled.
+// When discovery cannot map the kind, the resource name falls back to
+// GVRFromUnstructured's pluralization.
+func (c *Client) GVRFor(obj *unstructured.Unstructured) schema.GroupVersionResource {
+	gvk := obj.GroupVersionKind()
+	mapper := c.restMapper(false)
+	if mapper == nil || gvk.Kind == "" {
+		return GVRFromUnstructured(obj)
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) && c.markRediscovered(gvk.GroupKind()) {
+		mapping, err = c.restMapper(true).RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return GVRFromUnstructured(obj)
+	}
+	return mapping.Resource
+}
+
+// ResourceClientFor returns the dynamic resource client for obj, resolving
+// its resource through GVRFor.
+func (c *Client) ResourceClientFor(obj *unstructured.Unstructured) dynamic.ResourceInterface {
+	return c.ResourceClient(c.GVRFor(obj), obj.GetNamespace())
+}
+
+// ResourceForKind resolves a kind or resource name, optionally restricted to
+// an API group, as kubectl resolves a resource argument: "Deployment",
+// and "deployments" both name apps/v1 deployments. It reports whether
+// the resource is namespaced. Without discovery it cannot tell, and fails.
+func (c *Client) ResourceForKind(kind, group string) (schema.GroupVersionResource, bool, error) {
+	mapper := c.restMapper(false)
+	if mapper == nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("resolving %s: API discovery is unavailable", kind)
+	}
+	partial := schema.GroupVersionResource{Group: group, Resource: strings.ToLower(kind)}
+	gvk, err := mapper.KindFor(partial)
+	if meta.IsNoMatchError(err) && c.markRediscovered(schema.GroupKind{Group: group, Kind: kind}) {
+		mapper = c.restMapper(true)
+		gvk, err = mapper.KindFor(partial)
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("resolving %s: %w", kind, err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("resolving %s: %w", kind, err)
+	}
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// InvalidateMappings drops the cached discovery results, so the next lookup
+// rediscovers. Call it once a CRD applied in this run is established: its
+// kind is now served but absent from the cache.
+func (c *Client) InvalidateMappings() {
+	c.mapperMu.Lock()
+	defer c.mapperMu.Unlock()
+	c.mapper = nil
+	c.rediscovered = nil
+}
+
+// markRediscovered records that gk has triggered a rediscovery and reports
+// whether it is the first time: a kind the cluster does not serve must not
+// rediscover on every lookup.
+func (c *Client) markRediscovered(gk schema.GroupKind) bool {
+	c.mapperMu.Lock()
+	defer c.mapperMu.Unlock()
+	if c.rediscovered[gk] {
+		return false
+	}
+	if c.rediscovered == nil {
+		c.rediscovered = make(map[schema.GroupKind]bool)
+	}
+	c.rediscovered[gk] = true
+	return true
+}
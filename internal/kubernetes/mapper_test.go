@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// discoveryClient returns a client whose discovery serves the given
+// example.com/v1 resources, keyed by kind, and the fake discovery to change
+// them through.
+func discoveryClient(t *testing.T, resources map[string]string) (*Client, *fakediscovery.FakeDiscovery) {
+	t.Helper()
+	clientset := fake.NewClientset()
+	disc, ok := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	require.True(t, ok)
+	serveExampleResources(disc, resources)
+	return &Client{Clientset: clientset}, disc
+}
+
+func serveExampleResources(disc *fakediscovery.FakeDiscovery, resources map[string]string) {
+	list := &metav1.APIResourceList{GroupVersion: "example.com/v1"}
+	for kind, name := range resources {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: name, Kind: kind, Namespaced: true})
+	}
+	disc.Resources = []*metav1.APIResourceList{list}
+}
+
+func TestGVRFor(t *testing.T) {
+	widget := componentObject("example.com/v1", "Widget", "w", "a")
+	gadget := componentObject("example.com/v1", "Gadget", "g", "a")
+
+	t.Run("maps through discovery, not pluralization", func(t *testing.T) {
+		client, _ := discoveryClient(t, map[string]string{"Widget": "widgetz"})
+		assert.Equal(t, schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgetz"}, client.GVRFor(widget))
+	})
+
+	t.Run("caches until invalidated", func(t *testing.T) {
+		client, disc := discoveryClient(t, map[string]string{"Widget": "widgetz"})
+		require.Equal(t, "widgetz", client.GVRFor(widget).Resource)
+
+		serveExampleResources(disc, map[string]string{"Widget": "widgetry"})
+		assert.Equal(t, "widgetz", client.GVRFor(widget).Resource, "served from the cache")
+
+		client.InvalidateMappings()
+		assert.Equal(t, "widgetry", client.GVRFor(widget).Resource)
+	})
+
+	t.Run("rediscovers a kind registered after the cache filled", func(t *testing.T) {
+		client, disc := discoveryClient(t, map[string]string{"Widget": "widgetz"})
+		require.Equal(t, "widgetz", client.GVRFor(widget).Resource)
+
+		serveExampleResources(disc, map[string]string{"Widget": "widgetz", "Gadget": "gadgetry"})
+		assert.Equal(t, "gadgetry", client.GVRFor(gadget).Resource)
+	})
+
+	t.Run("unknown kinds fall back to pluralization", func(t *testing.T) {
+		client, _ := discoveryClient(t, nil)
+		assert.Equal(t, "gadgets", client.GVRFor(gadget).Resource)
+		assert.Equal(t, "gadgets", (&Client{}).GVRFor(gadget).Resource, "no clientset")
+	})
+}
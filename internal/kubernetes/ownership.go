@@ -0,0 +1,209 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// ResourceOwnership is the field ownership of one applied resource, parsed
+// from the metadata.managedFields the server returned for the apply
+// (--show-managed-fields).
+type ResourceOwnership struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Managers lists each field manager with the fields it owns: opm-cli
+	// first, then the others by name.
+	Managers []ManagerFields
+}
+
+// ManagerFields is one managedFields entry: a field manager, how it wrote,
+// and the leaf field paths it owns (e.g. ".spec.replicas",
+// ".spec.template.spec.containers[name=web].image").
+type ManagerFields struct {
+	Manager     string
+	Operation   string
+	Subresource string
+	Fields      []string
+}
+
+// IsOPM reports whether the entry is the CLI's own server-side-apply manager.
+func (m ManagerFields) IsOPM() bool {
+	return m.Manager == fieldManagerName
+}
+
+// SharedFields returns the fields that opm-cli and at least one other manager
+// both own. A shared field is co-managed: another actor writing a different
+// value there is what makes a field "keep reverting" between applies.
+func (r ResourceOwnership) SharedFields() []string {
+	opm := make(map[string]bool)
+	for _, m := range r.Managers {
+		if m.IsOPM() {
+			for _, f := range m.Fields {
+				opm[f] = true
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	var shared []string
+	for _, m := range r.Managers {
+		if m.IsOPM() {
+			continue
+		}
+		for _, f := range m.Fields {
+			if opm[f] && !seen[f] {
+				seen[f] = true
+				shared = append(shared, f)
+			}
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// ownershipFromObject parses obj's managedFields. Entries whose fieldsV1
+// cannot be decoded are kept with no fields rather than failing the report.
+func ownershipFromObject(obj *unstructured.Unstructured) ResourceOwnership {
+	own := ResourceOwnership{Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	for _, entry := range obj.GetManagedFields() {
+		mf := ManagerFields{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			Subresource: entry.Subresource,
+		}
+		if entry.FieldsV1 != nil {
+			var tree map[string]any
+			if err := json.Unmarshal(entry.FieldsV1.Raw, &tree); err != nil {
+				output.Debug("decoding managedFields", "resource", own.Kind+"/"+own.Name, "manager", entry.Manager, "error", err)
+			} else {
+				flattenFieldsV1("", tree, &mf.Fields)
+				sort.Strings(mf.Fields)
+			}
+		}
+		own.Managers = append(own.Managers, mf)
+	}
+	sort.SliceStable(own.Managers, func(i, j int) bool {
+		if own.Managers[i].IsOPM() != own.Managers[j].IsOPM() {
+			return own.Managers[i].IsOPM()
+		}
+		return own.Managers[i].Manager < own.Managers[j].Manager
+	})
+	return own
+}
+
+// flattenFieldsV1 collects the owned paths of a fieldsV1 tree, rendered with
+// fieldsV1Segment.
+func flattenFieldsV1(prefix string, tree map[string]any, out *[]string) {
+	walkFieldsV1(nil, tree, func(keys []string) {
+		path := prefix
+		for _, k := range keys {
+			path += fieldsV1Segment(k)
+		}
+		*out = append(*out, path)
+	})
+}
+
+// walkFieldsV1 calls fn with the raw key path of every owned node of a
+// fieldsV1 tree. A node with no children is a leaf field; a "." key marks a
+// list item or map owned as a whole in addition to its children.
+func walkFieldsV1(prefix []string, tree map[string]any, fn func(keys []string)) {
+	for key, child := range tree {
+		if key == "." {
+			if len(prefix) > 0 {
+				fn(slices.Clone(prefix))
+			}
+			continue
+		}
+		path := append(slices.Clone(prefix), key)
+		sub, _ := child.(map[string]any)
+		if len(sub) == 0 {
+			fn(path)
+			continue
+		}
+		walkFieldsV1(path, sub, fn)
+	}
+}
+
+// fieldsV1Segment renders one fieldsV1 key as a path segment: "f:name" is a
+// field, "k:{...}" a list item by key, "v:..." a set item by value, and
+// "i:N" a list item by index.
+func fieldsV1Segment(key string) string {
+	kind, rest, _ := strings.Cut(key, ":")
+	switch kind {
+	case "f":
+		return "." + rest
+	case "k":
+		var keys map[string]any
+		if err := json.Unmarshal([]byte(rest), &keys); err != nil {
+			return "[" + rest + "]"
+		}
+		parts := make([]string, 0, len(keys))
+		for k, v := range keys {
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(parts)
+		return "[" + strings.Join(parts, ",") + "]"
+	case "v":
+		return "[=" + strings.Trim(rest, `"`) + "]"
+	case "i":
+		return "[" + rest + "]"
+	default:
+		return "." + key
+	}
+}
+
+// FormatOwnershipReport renders the field ownership of applied resources:
+// per resource, how many fields opm-cli owns, and each other manager with the
+// fields it owns. Fields opm-cli also owns are marked shared.
+func FormatOwnershipReport(reports []ResourceOwnership) string {
+	var sb strings.Builder
+	sb.WriteString("Field ownership:\n")
+	for _, r := range reports {
+		id := r.Kind + "/" + r.Name
+		if r.Namespace != "" {
+			id += " (" + r.Namespace + ")"
+		}
+		fmt.Fprintf(&sb, "  %s\n", output.StyleNoun(id))
+
+		shared := make(map[string]bool)
+		for _, f := range r.SharedFields() {
+			shared[f] = true
+		}
+		if len(r.Managers) == 0 {
+			sb.WriteString("    " + output.Dim("<no managedFields returned>") + "\n")
+			continue
+		}
+		for _, m := range r.Managers {
+			how := m.Operation
+			if m.Subresource != "" {
+				how += ", " + m.Subresource
+			}
+			fmt.Fprintf(&sb, "    %s (%s): %s\n", m.Manager, how, pluralFields(len(m.Fields)))
+			if m.IsOPM() {
+				continue
+			}
+			for _, f := range m.Fields {
+				if shared[f] {
+					fmt.Fprintf(&sb, "      %s  %s\n", f, output.Dim("shared with "+fieldManagerName))
+					continue
+				}
+				fmt.Fprintf(&sb, "      %s\n", f)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func pluralFields(n int) string {
+	if n == 1 {
+		return "1 field"
+	}
+	return fmt.Sprintf("%d fields", n)
+}
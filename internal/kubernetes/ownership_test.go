@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func withManagedFields(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:   "kube-controller-manager",
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:replicas":{}}}`)},
+		},
+		{
+			Manager:   fieldManagerName,
+			Operation: metav1.ManagedFieldsOperationApply,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{` +
+				`"k:{\"name\":\"web\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`)},
+		},
+		{
+			Manager:   "kubectl-edit",
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}},"f:metadata":{"f:finalizers":{"v:\"example.com/hold\"":{}}}}`)},
+		},
+	})
+	return obj
+}
+
+func TestOwnershipFromObject(t *testing.T) {
+	own := ownershipFromObject(withManagedFields(componentObject("apps/v1", "Deployment", "web", "web")))
+
+	require.Len(t, own.Managers, 3)
+	assert.Equal(t, fieldManagerName, own.Managers[0].Manager, "opm-cli is listed first")
+	assert.Equal(t, []string{
+		".spec.replicas",
+		".spec.template.spec.containers[name=web]",
+		".spec.template.spec.containers[name=web].image",
+		".spec.template.spec.containers[name=web].name",
+	}, own.Managers[0].Fields)
+	assert.Equal(t, "kube-controller-manager", own.Managers[1].Manager)
+	assert.Equal(t, []string{".metadata.finalizers[=example.com/hold]", ".spec.replicas"}, own.Managers[2].Fields)
+	assert.Equal(t, []string{".spec.replicas"}, own.SharedFields())
+
+	report := FormatOwnershipReport([]ResourceOwnership{own})
+	for _, want := range []string{"Deployment/web (media)", "opm-cli (Apply): 4 fields", "kubectl-edit (Update): 2 fields", ".status.replicas", "shared with opm-cli"} {
+		assert.Contains(t, report, want)
+	}
+}
+
+func TestApply_ReportOwnership(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := withManagedFields(componentObject("apps/v1", "Deployment", action.(k8stesting.PatchAction).GetName(), "web"))
+		obj.SetResourceVersion("1")
+		return true, obj, nil
+	})
+	resources := []*unstructured.Unstructured{componentObject("apps/v1", "Deployment", "web", "web")}
+
+	result, err := Apply(context.Background(), &Client{Dynamic: dyn}, resources, "demo", ApplyOptions{ReportOwnership: true})
+	require.NoError(t, err)
+	require.Len(t, result.Ownership, 1)
+	assert.Equal(t, "web", result.Ownership[0].Name)
+
+	result, err = Apply(context.Background(), &Client{Dynamic: dyn}, resources, "demo", ApplyOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Ownership, "ownership is collected only on request")
+}
@@ -21,7 +21,29 @@ func listWorkloadPods(ctx context.Context, client *Client, resource *unstructure
 	if !workloadKinds[kind] {
 		return nil, nil
 	}
+	return listSelectedPods(ctx, client, resource)
+}
+
+// listSelectedPods lists the pods matched by a resource's
+// .spec.selector.matchLabels — the pods a Deployment, StatefulSet, DaemonSet,
+// or Job manages. Returns nil for resources without a selector.
+func listSelectedPods(ctx context.Context, client *Client, resource *unstructured.Unstructured) ([]podInfo, error) {
+	pods, err := selectedPods(ctx, client, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]podInfo, 0, len(pods))
+	for i := range pods {
+		result = append(result, extractPodInfoFromPod(&pods[i]))
+	}
 
+	return result, nil
+}
+
+// selectedPods returns the pods a resource's .spec.selector.matchLabels
+// selects, in its namespace. Returns nil for resources without a selector.
+func selectedPods(ctx context.Context, client *Client, resource *unstructured.Unstructured) ([]corev1.Pod, error) {
 	// Extract .spec.selector.matchLabels from the workload
 	matchLabels, found, err := unstructured.NestedStringMap(resource.Object, "spec", "selector", "matchLabels")
 	if err != nil {
@@ -40,13 +62,7 @@ func listWorkloadPods(ctx context.Context, client *Client, resource *unstructure
 	if err != nil {
 		return nil, err
 	}
-
-	result := make([]podInfo, 0, len(podList.Items))
-	for i := range podList.Items {
-		result = append(result, extractPodInfoFromPod(&podList.Items[i]))
-	}
-
-	return result, nil
+	return podList.Items, nil
 }
 
 // extractPodInfoFromPod extracts a podInfo from a corev1.Pod.
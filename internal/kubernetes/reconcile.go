@@ -0,0 +1,252 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// Reconcile mode (--reconcile) closes the one gap a forced server-side apply
+// leaves. Force already takes back every field the render sets, so edited
+// values revert. But when a later render drops a field, server-side apply
+// removes it only if opm-cli was its sole owner: a field that someone also
+// claimed (kubectl edit, kubectl apply) keeps its value, and the resource
+// never converges to the render. Reconcile compares what opm-cli owned before
+// the apply with what it owns after, and removes each field it gave up that is
+// still present.
+
+// opmOwnedFields returns the raw fieldsV1 key paths opm-cli owns on obj
+// through server-side apply of the main resource (status and other
+// subresources excluded).
+func opmOwnedFields(obj *unstructured.Unstructured) [][]string {
+	var paths [][]string
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManagerName || entry.Operation != metav1.ManagedFieldsOperationApply ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		var tree map[string]any
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &tree); err != nil {
+			output.Debug("decoding managedFields", "resource", obj.GetKind()+"/"+obj.GetName(), "error", err)
+			continue
+		}
+		walkFieldsV1(nil, tree, func(keys []string) { paths = append(paths, keys) })
+	}
+	return paths
+}
+
+// releasedFields returns the fields opm-cli owned before an apply and no
+// longer owns after it, dropping any whose subtree opm-cli still owns part of:
+// removing those would take rendered fields with them.
+func releasedFields(before, after [][]string) [][]string {
+	owned := make(map[string]bool, len(after))
+	for _, p := range after {
+		owned[fieldsKey(p)] = true
+	}
+	var released [][]string
+	for _, p := range before {
+		key := fieldsKey(p)
+		if owned[key] {
+			continue
+		}
+		stillUsed := false
+		for _, q := range after {
+			if len(q) > len(p) && strings.HasPrefix(fieldsKey(q), key+"\x00") {
+				stillUsed = true
+				break
+			}
+		}
+		if !stillUsed {
+			released = append(released, p)
+		}
+	}
+	return released
+}
+
+func fieldsKey(keys []string) string {
+	return strings.Join(keys, "\x00")
+}
+
+// fieldPointer resolves a raw fieldsV1 key path against obj, returning the
+// JSON pointer segments of the node it names, or false when the node is not
+// present.
+func fieldPointer(obj map[string]any, keys []string) ([]string, bool) {
+	var cur any = obj
+	pointer := make([]string, 0, len(keys))
+	for _, key := range keys {
+		kind, rest, _ := strings.Cut(key, ":")
+		switch kind {
+		case "f":
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if cur, ok = m[rest]; !ok {
+				return nil, false
+			}
+			pointer = append(pointer, rest)
+		case "k", "v", "i":
+			list, ok := cur.([]any)
+			if !ok {
+				return nil, false
+			}
+			i := listIndex(list, kind, rest)
+			if i < 0 {
+				return nil, false
+			}
+			cur = list[i]
+			pointer = append(pointer, strconv.Itoa(i))
+		default:
+			return nil, false
+		}
+	}
+	return pointer, true
+}
+
+// listIndex finds the element of list a fieldsV1 list key names: by key
+// fields ("k"), by value ("v"), or by position ("i"). -1 when absent.
+func listIndex(list []any, kind, rest string) int {
+	switch kind {
+	case "i":
+		i, err := strconv.Atoi(rest)
+		if err != nil || i < 0 || i >= len(list) {
+			return -1
+		}
+		return i
+	case "v":
+		var want any
+		if err := json.Unmarshal([]byte(rest), &want); err != nil {
+			return -1
+		}
+		for i, elem := range list {
+			if jsonEqual(elem, want) {
+				return i
+			}
+		}
+	case "k":
+		var keys map[string]any
+		if err := json.Unmarshal([]byte(rest), &keys); err != nil {
+			return -1
+		}
+		for i, elem := range list {
+			m, ok := elem.(map[string]any)
+			if !ok {
+				continue
+			}
+			match := true
+			for k, v := range keys {
+				if !jsonEqual(m[k], v) {
+					match = false
+					break
+				}
+			}
+			if match {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// jsonEqual compares two decoded JSON values, treating numbers by value
+// (unstructured objects hold int64, json.Unmarshal float64).
+func jsonEqual(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(ja) == string(jb)
+}
+
+// removalPatch builds a JSON patch removing each pointer from obj. Pointers
+// under another removed pointer are dropped, and list elements are removed
+// from the highest index down so earlier removals do not shift later ones.
+func removalPatch(pointers [][]string) ([]byte, int, error) {
+	sort.Slice(pointers, func(i, j int) bool { return len(pointers[i]) < len(pointers[j]) })
+	var kept [][]string
+	for _, p := range pointers {
+		covered := false
+		for _, k := range kept {
+			if len(k) <= len(p) && slices.Equal(k, p[:len(k)]) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			kept = append(kept, p)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return pointerAfter(kept[i], kept[j]) })
+
+	ops := make([]map[string]string, 0, len(kept))
+	for _, p := range kept {
+		escaped := make([]string, len(p))
+		for i, seg := range p {
+			escaped[i] = strings.ReplaceAll(strings.ReplaceAll(seg, "~", "~0"), "/", "~1")
+		}
+		ops = append(ops, map[string]string{"op": "remove", "path": "/" + strings.Join(escaped, "/")})
+	}
+	patch, err := json.Marshal(ops)
+	return patch, len(ops), err
+}
+
+// pointerAfter orders pointers so that, among siblings, higher list indices
+// come first.
+func pointerAfter(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		ai, errA := strconv.Atoi(a[i])
+		bi, errB := strconv.Atoi(b[i])
+		if errA == nil && errB == nil {
+			return ai > bi
+		}
+		return a[i] > b[i]
+	}
+	return len(a) > len(b)
+}
+
+// removeReleasedFields deletes from the live object every field opm-cli
+// owned before the apply (before) and released in it, that applied still
+// has. It returns the number of fields removed and the object after removal.
+func removeReleasedFields(ctx context.Context, client *Client, applied *unstructured.Unstructured, before [][]string, dryRun bool) (int, *unstructured.Unstructured, error) {
+	released := releasedFields(before, opmOwnedFields(applied))
+	var pointers [][]string
+	for _, keys := range released {
+		if p, ok := fieldPointer(applied.Object, keys); ok {
+			pointers = append(pointers, p)
+		}
+	}
+	if len(pointers) == 0 {
+		return 0, applied, nil
+	}
+
+	patch, n, err := removalPatch(pointers)
+	if err != nil {
+		return 0, applied, err
+	}
+	output.Debug("reconcile: removing released fields", "resource", applied.GetKind()+"/"+applied.GetName(), "patch", string(patch))
+
+	opts := metav1.PatchOptions{FieldManager: fieldManagerName}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	result, err := client.ResourceClientFor(applied).
+		Patch(ctx, applied.GetName(), types.JSONPatchType, patch, opts)
+	if err != nil {
+		return 0, applied, err
+	}
+	return n, result, nil
+}
@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestReleasedFields(t *testing.T) {
+	before := [][]string{
+		{"f:spec", "f:replicas"},
+		{"f:spec", "f:paused"},
+		{"f:metadata", "f:labels", "f:tier"},
+		{"f:spec", "f:template", "f:spec", "f:containers", `k:{"name":"web"}`},
+	}
+	after := [][]string{
+		{"f:spec", "f:replicas"},
+		{"f:spec", "f:template", "f:spec", "f:containers", `k:{"name":"web"}`, "f:image"},
+	}
+	assert.Equal(t, [][]string{
+		{"f:spec", "f:paused"},
+		{"f:metadata", "f:labels", "f:tier"},
+	}, releasedFields(before, after), "the container is still partly owned, so it is not released")
+}
+
+func TestFieldPointer(t *testing.T) {
+	obj := map[string]any{
+		"metadata": map[string]any{
+			"finalizers": []any{"example.com/a", "example.com/hold"},
+		},
+		"spec": map[string]any{
+			"paused": true,
+			"ports":  []any{map[string]any{"containerPort": int64(80), "protocol": "TCP"}, map[string]any{"containerPort": int64(9090), "protocol": "TCP"}},
+			"args":   []any{"--a", "--b"},
+		},
+	}
+	tests := []struct {
+		name string
+		keys []string
+		want []string
+		ok   bool
+	}{
+		{"field", []string{"f:spec", "f:paused"}, []string{"spec", "paused"}, true},
+		{"list by key", []string{"f:spec", "f:ports", `k:{"containerPort":9090,"protocol":"TCP"}`}, []string{"spec", "ports", "1"}, true},
+		{"set by value", []string{"f:metadata", "f:finalizers", `v:"example.com/hold"`}, []string{"metadata", "finalizers", "1"}, true},
+		{"list by index", []string{"f:spec", "f:args", "i:0"}, []string{"spec", "args", "0"}, true},
+		{"absent field", []string{"f:spec", "f:replicas"}, nil, false},
+		{"absent key", []string{"f:spec", "f:ports", `k:{"containerPort":443,"protocol":"TCP"}`}, nil, false},
+		{"index out of range", []string{"f:spec", "f:args", "i:5"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := fieldPointer(obj, tt.keys)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRemovalPatch(t *testing.T) {
+	patch, n, err := removalPatch([][]string{
+		{"spec", "ports", "0"},
+		{"metadata", "labels", "tier"},
+		{"spec", "ports", "2"},
+		{"metadata", "labels"},
+		{"metadata", "annotations", "a/b~c"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, n, "metadata.labels.tier is covered by metadata.labels")
+	assert.JSONEq(t, `[
+		{"op":"remove","path":"/spec/ports/2"},
+		{"op":"remove","path":"/spec/ports/0"},
+		{"op":"remove","path":"/metadata/labels"},
+		{"op":"remove","path":"/metadata/annotations/a~1b~0c"}
+	]`, string(patch))
+}
+
+// ownedBy sets a single opm-cli Apply managedFields entry on obj.
+func ownedBy(obj *unstructured.Unstructured, fields string) *unstructured.Unstructured {
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:   fieldManagerName,
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(fields)},
+	}})
+	return obj
+}
+
+func TestApply_ReconcileRemovesReleasedFields(t *testing.T) {
+	live := ownedBy(componentObject("apps/v1", "Deployment", "web", "web"), `{"f:spec":{"f:replicas":{},"f:paused":{}}}`)
+	_ = unstructured.SetNestedField(live.Object, true, "spec", "paused")
+	_ = unstructured.SetNestedField(live.Object, int64(2), "spec", "replicas")
+	live.SetResourceVersion("1")
+
+	var jsonPatches []string
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("get", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, live.DeepCopy(), nil
+	})
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pa := action.(k8stesting.PatchAction)
+		if pa.GetPatchType() == types.JSONPatchType {
+			jsonPatches = append(jsonPatches, string(pa.GetPatch()))
+			obj := live.DeepCopy()
+			unstructured.RemoveNestedField(obj.Object, "spec", "paused")
+			obj.SetResourceVersion("3")
+			return true, obj, nil
+		}
+		// A kubectl edit co-owns spec.paused, so the server keeps it after
+		// opm-cli stops applying it.
+		obj := ownedBy(live.DeepCopy(), `{"f:spec":{"f:replicas":{}}}`)
+		return true, obj, nil
+	})
+	resources := []*unstructured.Unstructured{componentObject("apps/v1", "Deployment", "web", "web")}
+
+	result, err := Apply(context.Background(), &Client{Dynamic: dyn}, resources, "demo", ApplyOptions{Reconcile: true})
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	require.Len(t, jsonPatches, 1)
+	assert.JSONEq(t, `[{"op":"remove","path":"/spec/paused"}]`, jsonPatches[0])
+	assert.Equal(t, 1, result.Reconciled)
+	assert.Equal(t, 1, result.Configured, "removing a field changes an otherwise unchanged resource")
+
+	jsonPatches = nil
+	result, err = Apply(context.Background(), &Client{Dynamic: dyn}, resources, "demo", ApplyOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, jsonPatches, "released fields are left alone without --reconcile")
+	assert.Zero(t, result.Reconciled)
+}
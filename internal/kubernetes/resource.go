@@ -1,10 +1,12 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 )
 
@@ -97,3 +99,65 @@ func (c *Client) ResourceClient(gvr schema.GroupVersionResource, ns string) dyna
 	}
 	return c.Dynamic.Resource(gvr)
 }
+
+// ResolvedKind is a resource type resolved against the cluster's discovery API.
+type ResolvedKind struct {
+	GVR        schema.GroupVersionResource
+	GVK        schema.GroupVersionKind
+	Namespaced bool
+}
+
+// ResolveKind resolves a user-supplied type name to its preferred cluster
+// version. The name may be a Kind ("Deployment") or plural resource
+// ("deployments"), optionally qualified by group ("Deployment.apps"), matched
+// case-insensitively. An unqualified name served by several groups is an
+// error naming the candidates.
+func ResolveKind(client *Client, name string) (*ResolvedKind, error) {
+	kind, group, qualified := strings.Cut(name, ".")
+
+	lists, err := discovery.ServerPreferredResources(client.Clientset.Discovery())
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("discovering API resources: %w", err)
+	}
+
+	var matches []ResolvedKind
+	for _, list := range lists {
+		gv, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			continue
+		}
+		if qualified && !strings.EqualFold(gv.Group, group) {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") { // subresource
+				continue
+			}
+			if !strings.EqualFold(r.Kind, kind) && !strings.EqualFold(r.Name, kind) {
+				continue
+			}
+			matches = append(matches, ResolvedKind{
+				GVR:        gv.WithResource(r.Name),
+				GVK:        gv.WithKind(r.Kind),
+				Namespaced: r.Namespaced,
+			})
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("resource type %q not found on the cluster", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		candidates := make([]string, len(matches))
+		for i, m := range matches {
+			candidates[i] = m.GVK.Kind
+			if m.GVK.Group != "" {
+				candidates[i] += "." + m.GVK.Group
+			}
+		}
+		return nil, fmt.Errorf("resource type %q is ambiguous; qualify it with a group: %s",
+			name, strings.Join(candidates, ", "))
+	}
+}
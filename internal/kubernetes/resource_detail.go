@@ -0,0 +1,233 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// recentEventLimit caps the events shown for a single resource.
+const recentEventLimit = 10
+
+// ResourceDetail is the drill-down status of one tracked resource
+// (opm instance status --resource).
+type ResourceDetail struct {
+	// InstanceName is the instance the resource belongs to.
+	InstanceName string `json:"instanceName" yaml:"instanceName"`
+	Kind         string `json:"kind" yaml:"kind"`
+	Name         string `json:"name" yaml:"name"`
+	Namespace    string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Component is the source component name (from inventory).
+	Component string       `json:"component,omitempty" yaml:"component,omitempty"`
+	Status    HealthStatus `json:"status" yaml:"status"`
+	Age       string       `json:"age" yaml:"age"`
+	// Finalizers lists the resource's finalizers.
+	Finalizers []string `json:"finalizers,omitempty" yaml:"finalizers,omitempty"`
+	// Conditions are the resource's status conditions.
+	Conditions []conditionDetail `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	// Pods are the pods the resource manages through its selector.
+	Pods []podInfo `json:"pods,omitempty" yaml:"pods,omitempty"`
+	// Events are the most recent events for the resource and the objects
+	// it owns (ReplicaSets, Pods), oldest first.
+	Events []EventEntry `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// conditionDetail is one status condition with its explanation.
+type conditionDetail struct {
+	Type               string `json:"type" yaml:"type"`
+	Status             string `json:"status" yaml:"status"`
+	Reason             string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message            string `json:"message,omitempty" yaml:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty" yaml:"lastTransitionTime,omitempty"`
+}
+
+// GetResourceDetail evaluates one live resource in depth: health (the same
+// evaluation as the status table), conditions, the pods it manages, and its
+// recent events. Pod and event lookups are best-effort; a failure leaves the
+// section empty rather than failing the command.
+func GetResourceDetail(ctx context.Context, client *Client, res *unstructured.Unstructured, opts StatusOptions) *ResourceDetail {
+	rh, _ := buildResourceHealth(ctx, client, res, StatusOptions{ComponentMap: opts.ComponentMap})
+
+	detail := &ResourceDetail{
+		InstanceName: opts.InstanceName,
+		Kind:         rh.Kind,
+		Name:         rh.Name,
+		Namespace:    rh.Namespace,
+		Component:    rh.Component,
+		Status:       rh.Status,
+		Age:          rh.Age,
+		Finalizers:   res.GetFinalizers(),
+		Conditions:   getConditionDetails(res),
+	}
+
+	if pods, err := listSelectedPods(ctx, client, res); err != nil {
+		output.Debug("listing pods", "resource", res.GetKind()+"/"+res.GetName(), "error", err)
+	} else {
+		detail.Pods = pods
+	}
+
+	namespace := res.GetNamespace()
+	if namespace == "" {
+		namespace = opts.Namespace
+	}
+	events, err := GetModuleEvents(ctx, client, EventsOptions{
+		Namespace:     namespace,
+		InstanceName:  opts.InstanceName,
+		InventoryLive: []*unstructured.Unstructured{res},
+	})
+	if err != nil {
+		output.Debug("listing events", "resource", res.GetKind()+"/"+res.GetName(), "error", err)
+	} else {
+		detail.Events = events.Events
+		if n := len(detail.Events); n > recentEventLimit {
+			detail.Events = detail.Events[n-recentEventLimit:]
+		}
+	}
+
+	return detail
+}
+
+// MissingResourceDetail is the detail of a tracked resource that no longer
+// exists on the cluster.
+func MissingResourceDetail(m MissingResource, opts StatusOptions) *ResourceDetail {
+	return &ResourceDetail{
+		InstanceName: opts.InstanceName,
+		Kind:         m.Kind,
+		Name:         m.Name,
+		Namespace:    m.Namespace,
+		Component:    opts.ComponentMap[m.Kind+"/"+m.Namespace+"/"+m.Name],
+		Status:       HealthMissing,
+		Age:          "<unknown>",
+	}
+}
+
+// IsHealthy reports whether the resource counts as ready in the status table.
+func (d *ResourceDetail) IsHealthy() bool {
+	return d.Status == HealthReady || d.Status == HealthComplete || d.Status == HealthBound
+}
+
+// getConditionDetails extracts status conditions with reason and message.
+func getConditionDetails(resource *unstructured.Unstructured) []conditionDetail {
+	rawConditions, found, err := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	if err != nil || !found {
+		return nil
+	}
+
+	var conditions []conditionDetail
+	for _, raw := range rawConditions {
+		c, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cd := conditionDetail{}
+		cd.Type, _, _ = unstructured.NestedString(c, "type")                             //nolint:errcheck // best-effort condition parsing
+		cd.Status, _, _ = unstructured.NestedString(c, "status")                         //nolint:errcheck // best-effort condition parsing
+		cd.Reason, _, _ = unstructured.NestedString(c, "reason")                         //nolint:errcheck // best-effort condition parsing
+		cd.Message, _, _ = unstructured.NestedString(c, "message")                       //nolint:errcheck // best-effort condition parsing
+		cd.LastTransitionTime, _, _ = unstructured.NestedString(c, "lastTransitionTime") //nolint:errcheck // best-effort condition parsing
+		if cd.Type != "" {
+			conditions = append(conditions, cd)
+		}
+	}
+	return conditions
+}
+
+// FormatResourceDetail formats a resource detail based on the output format.
+// Table and wide render a kubectl-describe-style block.
+func FormatResourceDetail(detail *ResourceDetail, format output.Format) (string, error) {
+	switch format {
+	case output.FormatJSON:
+		data, err := json.MarshalIndent(detail, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshaling resource detail to JSON: %w", err)
+		}
+		return string(data), nil
+	case output.FormatYAML:
+		data, err := yaml.Marshal(detail)
+		if err != nil {
+			return "", fmt.Errorf("marshaling resource detail to YAML: %w", err)
+		}
+		return string(data), nil
+	default:
+		return formatResourceDetailText(detail), nil
+	}
+}
+
+func formatResourceDetailText(d *ResourceDetail) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Resource:   %s/%s\n", d.Kind, output.StyleNoun(d.Name))
+	fmt.Fprintf(&sb, "Instance:   %s\n", output.StyleNoun(d.InstanceName))
+	if d.Namespace != "" {
+		fmt.Fprintf(&sb, "Namespace:  %s\n", output.StyleNoun(d.Namespace))
+	}
+	if d.Component != "" {
+		fmt.Fprintf(&sb, "Component:  %s\n", output.FormatComponent(d.Component))
+	}
+	fmt.Fprintf(&sb, "Status:     %s\n", output.FormatHealthStatus(string(d.Status)))
+	fmt.Fprintf(&sb, "Age:        %s\n", d.Age)
+	if len(d.Finalizers) > 0 {
+		fmt.Fprintf(&sb, "Finalizers: %s\n", strings.Join(d.Finalizers, ", "))
+	}
+
+	if d.Status == HealthMissing {
+		sb.WriteString("\nThe resource is tracked in the inventory but does not exist on the cluster.\n" +
+			"Re-apply the instance to recreate it.\n")
+		return sb.String()
+	}
+
+	if len(d.Conditions) > 0 {
+		sb.WriteString("\nConditions:\n")
+		tbl := output.NewTable("TYPE", "STATUS", "REASON", "MESSAGE")
+		for _, c := range d.Conditions {
+			tbl.Row(c.Type, c.Status, c.Reason, c.Message)
+		}
+		sb.WriteString(indentBlock(tbl.String()))
+	}
+
+	if len(d.Pods) > 0 {
+		sb.WriteString("\nPods:\n")
+		tbl := output.NewTable("NAME", "PHASE", "READY", "RESTARTS", "REASON")
+		for _, p := range d.Pods {
+			ready := "false"
+			if p.Ready {
+				ready = "true"
+			}
+			tbl.Row(p.Name, output.FormatPodPhase(p.Phase, p.Ready), ready, fmt.Sprintf("%d", p.Restarts), p.Reason)
+		}
+		sb.WriteString(indentBlock(tbl.String()))
+	}
+
+	sb.WriteString("\nEvents:\n")
+	if len(d.Events) == 0 {
+		sb.WriteString("    " + output.Dim("<none>") + "\n")
+		return sb.String()
+	}
+	tbl := output.NewTable("LAST SEEN", "TYPE", "RESOURCE", "REASON", "MESSAGE")
+	for _, ev := range d.Events {
+		lastSeen := ev.LastSeen
+		if t, err := time.Parse(time.RFC3339, ev.LastSeen); err == nil {
+			lastSeen = FormatDuration(time.Since(t))
+		}
+		tbl.Row(lastSeen, output.FormatEventType(ev.Type), output.FormatEventResource(ev.Kind, ev.Name), ev.Reason, ev.Message)
+	}
+	sb.WriteString(indentBlock(tbl.String()))
+	return sb.String()
+}
+
+// indentBlock indents every non-empty line of s by four spaces.
+func indentBlock(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "    " + l
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,93 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+func TestGetResourceDetail(t *testing.T) {
+	deploy := makeResource("Deployment", []map[string]interface{}{
+		{"type": "Available", "status": "False", "reason": "MinimumReplicasUnavailable", "message": "Deployment does not have minimum availability."},
+	})
+	deploy.SetName("web")
+	deploy.SetUID("uid-web")
+	require.NoError(t, unstructured.SetNestedStringMap(deploy.Object, map[string]string{"app": "web"}, "spec", "selector", "matchLabels"))
+
+	now := time.Now()
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					RestartCount: 4,
+					State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{"app": "other"}}},
+	)
+	for i := range 12 {
+		_, err := clientset.CoreV1().Events("default").Create(context.Background(), &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("ev%d", i), Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{UID: "uid-web", Kind: "Deployment", Name: "web"},
+			Type:           "Warning",
+			Reason:         fmt.Sprintf("Reason%d", i),
+			LastTimestamp:  metav1.NewTime(now.Add(time.Duration(i-12) * time.Minute)),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	detail := GetResourceDetail(context.Background(), &Client{Clientset: clientset}, deploy, StatusOptions{
+		InstanceName: "my-app",
+		Namespace:    "default",
+		ComponentMap: map[string]string{"Deployment/default/web": "frontend"},
+	})
+
+	assert.Equal(t, "frontend", detail.Component)
+	assert.Equal(t, HealthNotReady, detail.Status)
+	assert.False(t, detail.IsHealthy())
+	require.Len(t, detail.Conditions, 1)
+	assert.Equal(t, "MinimumReplicasUnavailable", detail.Conditions[0].Reason)
+	require.Len(t, detail.Pods, 1, "only pods matching the selector")
+	assert.Equal(t, "web-abc", detail.Pods[0].Name)
+	assert.Equal(t, 4, detail.Pods[0].Restarts)
+	require.Len(t, detail.Events, recentEventLimit, "only the most recent events")
+	assert.Equal(t, "Reason2", detail.Events[0].Reason)
+	assert.Equal(t, "Reason11", detail.Events[recentEventLimit-1].Reason)
+
+	out, err := FormatResourceDetail(detail, output.FormatTable)
+	require.NoError(t, err)
+	for _, want := range []string{"Resource:   Deployment/web", "Conditions:", "MinimumReplicasUnavailable", "Pods:", "web-abc", "Events:", "Reason11"} {
+		assert.Contains(t, out, want)
+	}
+
+	js, err := FormatResourceDetail(detail, output.FormatJSON)
+	require.NoError(t, err)
+	assert.Contains(t, js, `"reason": "MinimumReplicasUnavailable"`)
+}
+
+func TestMissingResourceDetail(t *testing.T) {
+	detail := MissingResourceDetail(MissingResource{Kind: "ConfigMap", Namespace: "default", Name: "cfg"}, StatusOptions{
+		InstanceName: "my-app",
+		ComponentMap: map[string]string{"ConfigMap/default/cfg": "config"},
+	})
+	assert.Equal(t, HealthMissing, detail.Status)
+	assert.Equal(t, "config", detail.Component)
+
+	out, err := FormatResourceDetail(detail, output.FormatTable)
+	require.NoError(t, err)
+	assert.Contains(t, out, "does not exist on the cluster")
+	assert.NotContains(t, out, "Events:")
+}
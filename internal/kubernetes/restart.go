@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// AnnotationRestartedAt is the pod template annotation kubectl rollout restart
+// sets; changing it rolls the workload's pods.
+const AnnotationRestartedAt = "kubectl.kubernetes.io/restartedAt"
+
+// restartKinds are the workloads a restart rolls.
+var restartKinds = map[string]bool{
+	kindDeployment:  true,
+	kindStatefulSet: true,
+	kindDaemonSet:   true,
+}
+
+// IsRestartable reports whether obj is a workload Restart rolls: a
+// Deployment, StatefulSet, or DaemonSet.
+func IsRestartable(obj *unstructured.Unstructured) bool {
+	return restartKinds[obj.GetKind()]
+}
+
+// Restart triggers a rolling restart of each workload, as kubectl rollout
+// restart does: it sets AnnotationRestartedAt on the pod template to at. The
+// annotation is not part of the render, so a later apply leaves it alone. It
+// returns the patched workloads and stops at the first failure.
+func Restart(ctx context.Context, client *Client, workloads []*unstructured.Unstructured, at time.Time) ([]*unstructured.Unstructured, error) {
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{AnnotationRestartedAt: at.UTC().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restarted := make([]*unstructured.Unstructured, 0, len(workloads))
+	for _, obj := range workloads {
+		if !IsRestartable(obj) {
+			continue
+		}
+		patched, err := client.ResourceClientFor(obj).Patch(ctx, obj.GetName(), types.MergePatchType, patch,
+			metav1.PatchOptions{FieldManager: fieldManagerName})
+		if err != nil {
+			return restarted, fmt.Errorf("restarting %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		output.Debug("restarted workload", "kind", obj.GetKind(), "name", obj.GetName())
+		restarted = append(restarted, patched)
+	}
+	return restarted, nil
+}
+
+// RolloutComplete reports whether a workload runs its latest pod template on
+// every replica, as kubectl rollout status decides. Kinds other than
+// Deployment, StatefulSet, and DaemonSet are always complete.
+func RolloutComplete(obj *unstructured.Unstructured) bool {
+	status := func(field string) int64 {
+		v, _, _ := unstructured.NestedInt64(obj.Object, "status", field) //nolint:errcheck // absent counts as 0
+		return v
+	}
+	if status("observedGeneration") < obj.GetGeneration() {
+		return false
+	}
+	desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas") //nolint:errcheck // best-effort replica count
+	if !found {
+		desired = 1
+	}
+
+	switch obj.GetKind() {
+	case kindDeployment:
+		updated := status("updatedReplicas")
+		return updated >= desired && status("replicas") == updated && status("availableReplicas") >= updated
+	case kindStatefulSet:
+		current, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision") //nolint:errcheck // best-effort revision
+		update, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")   //nolint:errcheck // best-effort revision
+		return status("updatedReplicas") >= desired && status("readyReplicas") >= desired && current == update
+	case kindDaemonSet:
+		scheduled := status("desiredNumberScheduled")
+		return status("updatedNumberScheduled") >= scheduled && status("numberAvailable") >= scheduled
+	}
+	return true
+}
+
+// WaitForRollouts polls workloads every interval until each has completed its
+// rollout (see RolloutComplete) or timeout passes. On timeout the error names
+// the workloads still rolling out.
+func WaitForRollouts(ctx context.Context, client *Client, workloads []*unstructured.Unstructured, timeout, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	timedOut := func(pending []*unstructured.Unstructured) error {
+		names := make([]string, 0, len(pending))
+		for _, obj := range pending {
+			names = append(names, obj.GetKind()+"/"+obj.GetName())
+		}
+		sort.Strings(names)
+		return fmt.Errorf("timed out after %s waiting for rollouts: %s", timeout, strings.Join(names, ", "))
+	}
+
+	pending := workloads
+	for {
+		still, err := pendingRollouts(ctx, client, pending)
+		switch {
+		case ctx.Err() != nil:
+			return timedOut(pending)
+		case err != nil:
+			return err
+		case len(still) == 0:
+			return nil
+		}
+		pending = still
+		output.Debug("waiting for rollouts", "pending", len(pending))
+
+		select {
+		case <-ctx.Done():
+			return timedOut(pending)
+		case <-ticker.C:
+		}
+	}
+}
+
+// pendingRollouts re-reads workloads and returns those still rolling out.
+func pendingRollouts(ctx context.Context, client *Client, workloads []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	var pending []*unstructured.Unstructured
+	for _, obj := range workloads {
+		live, err := client.ResourceClientFor(obj).Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if !RolloutComplete(live) {
+			pending = append(pending, live)
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestRestart(t *testing.T) {
+	deploy := makeUnstructured("apps/v1", "Deployment", "web", "default")
+	deploy.Object["spec"] = map[string]any{"template": map[string]any{"metadata": map[string]any{}}}
+	cm := makeUnstructured("v1", "ConfigMap", "web", "default")
+	client := &Client{Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deploy, cm)}
+
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	restarted, err := Restart(context.Background(), client, []*unstructured.Unstructured{deploy, cm}, at)
+	require.NoError(t, err)
+	require.Len(t, restarted, 1, "only workloads are restarted")
+
+	live, err := client.ResourceClientFor(deploy).Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	got, _, _ := unstructured.NestedString(live.Object, "spec", "template", "metadata", "annotations", AnnotationRestartedAt)
+	assert.Equal(t, "2026-10-16T12:00:00Z", got)
+}
+
+func TestRolloutComplete(t *testing.T) {
+	workload := func(kind string, generation int64, spec, status map[string]any) *unstructured.Unstructured {
+		obj := makeUnstructured("apps/v1", kind, "web", "default")
+		obj.SetGeneration(generation)
+		obj.Object["spec"] = spec
+		obj.Object["status"] = status
+		return obj
+	}
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want bool
+	}{
+		{"deployment rolled out", workload("Deployment", 2, map[string]any{"replicas": int64(2)},
+			map[string]any{"observedGeneration": int64(2), "replicas": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)}), true},
+		{"deployment not observed", workload("Deployment", 3, map[string]any{"replicas": int64(2)},
+			map[string]any{"observedGeneration": int64(2), "replicas": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)}), false},
+		{"deployment old pods remain", workload("Deployment", 2, map[string]any{"replicas": int64(2)},
+			map[string]any{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(2), "availableReplicas": int64(2)}), false},
+		{"statefulset revision pending", workload("StatefulSet", 1, map[string]any{"replicas": int64(1)},
+			map[string]any{"observedGeneration": int64(1), "updatedReplicas": int64(1), "readyReplicas": int64(1), "currentRevision": "a", "updateRevision": "b"}), false},
+		{"statefulset rolled out", workload("StatefulSet", 1, map[string]any{},
+			map[string]any{"observedGeneration": int64(1), "updatedReplicas": int64(1), "readyReplicas": int64(1), "currentRevision": "b", "updateRevision": "b"}), true},
+		{"daemonset rolling", workload("DaemonSet", 1, map[string]any{},
+			map[string]any{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(2), "numberAvailable": int64(3)}), false},
+		{"daemonset rolled out", workload("DaemonSet", 1, map[string]any{},
+			map[string]any{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(3), "numberAvailable": int64(3)}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RolloutComplete(tt.obj))
+		})
+	}
+}
+
+func TestWaitForRollouts_TimeoutNamesPending(t *testing.T) {
+	deploy := makeUnstructured("apps/v1", "Deployment", "web", "default")
+	deploy.SetGeneration(2)
+	client := &Client{Dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deploy)}
+
+	err := WaitForRollouts(context.Background(), client, []*unstructured.Unstructured{deploy}, 50*time.Millisecond, 10*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Deployment/web")
+}
@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// DefaultMaxRetries is the number of times a single resource operation is
+// retried after a transient API error when ApplyOptions.MaxRetries or
+// DeleteOptions.MaxRetries is zero.
+const DefaultMaxRetries = 3
+
+// maxRetryDelay caps the backoff between retries, whether computed or
+// requested by the server.
+const maxRetryDelay = 30 * time.Second
+
+// retryBaseDelay is the wait before the first retry; each later retry doubles
+// it. A variable so tests can retry without sleeping.
+var retryBaseDelay = 500 * time.Millisecond
+
+// resolveMaxRetries maps an options MaxRetries to a retry budget: zero means
+// DefaultMaxRetries, negative disables retries.
+func resolveMaxRetries(n int) int {
+	switch {
+	case n == 0:
+		return DefaultMaxRetries
+	case n < 0:
+		return 0
+	default:
+		return n
+	}
+}
+
+// isRetryable reports whether err is a transient API error worth retrying: a
+// conflict (the object changed under the request), a server timeout, or
+// throttling (429). Everything else — validation, not-found, forbidden — is
+// terminal.
+func isRetryable(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err)
+}
+
+// retryDelay is the wait before retry number attempt (0-based): the server's
+// Retry-After when it sent one, else exponential backoff from
+// retryBaseDelay, capped at maxRetryDelay either way.
+func retryDelay(err error, attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// withRetry runs op, retrying it after transient errors up to maxRetries
+// times, and returns the number of retries made alongside op's final error.
+// A cancelled context ends the retries with the last error.
+func withRetry(ctx context.Context, maxRetries int, describe string, op func() error) (int, error) {
+	return withRetryIf(ctx, maxRetries, describe, isRetryable, op)
+}
+
+// withRetryIf is withRetry with the caller deciding which errors are worth
+// retrying.
+func withRetryIf(ctx context.Context, maxRetries int, describe string, retryable func(error) bool, op func() error) (int, error) {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || !retryable(err) || attempt >= maxRetries {
+			return attempt, err
+		}
+		delay := retryDelay(err, attempt)
+		output.Debug("retrying after transient API error", "resource", describe, "attempt", attempt+1, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+	}
+}
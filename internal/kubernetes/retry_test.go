@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testGR = schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+// noRetryDelay makes retries immediate for the duration of a test.
+func noRetryDelay(t *testing.T) {
+	t.Helper()
+	prev := retryBaseDelay
+	retryBaseDelay = time.Nanosecond
+	t.Cleanup(func() { retryBaseDelay = prev })
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"conflict", apierrors.NewConflict(testGR, "web", errors.New("resourceVersion changed")), true},
+		{"server timeout", apierrors.NewServerTimeout(testGR, "patch", 1), true},
+		{"throttled", apierrors.NewTooManyRequests("slow down", 1), true},
+		{"invalid", apierrors.NewBadRequest("spec.replicas: must be >= 0"), false},
+		{"not found", apierrors.NewNotFound(testGR, "web"), false},
+		{"forbidden", apierrors.NewForbidden(testGR, "web", errors.New("rbac")), false},
+		{"plain", errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	conflict := apierrors.NewConflict(testGR, "web", errors.New("changed"))
+	assert.Equal(t, retryBaseDelay, retryDelay(conflict, 0))
+	assert.Equal(t, 4*retryBaseDelay, retryDelay(conflict, 2))
+	assert.Equal(t, maxRetryDelay, retryDelay(conflict, 20), "backoff is capped")
+
+	assert.Equal(t, 7*time.Second, retryDelay(apierrors.NewTooManyRequests("slow down", 7), 0), "Retry-After wins")
+	assert.Equal(t, maxRetryDelay, retryDelay(apierrors.NewTooManyRequests("slow down", 600), 0))
+}
+
+func TestWithRetry(t *testing.T) {
+	noRetryDelay(t)
+	throttled := apierrors.NewTooManyRequests("slow down", 0)
+
+	calls := 0
+	retries, err := withRetry(context.Background(), 3, "Deployment/web", func() error {
+		calls++
+		if calls < 3 {
+			return throttled
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, retries)
+
+	calls = 0
+	retries, err = withRetry(context.Background(), 2, "Deployment/web", func() error {
+		calls++
+		return throttled
+	})
+	assert.Equal(t, throttled, err)
+	assert.Equal(t, 2, retries)
+	assert.Equal(t, 3, calls, "one attempt plus MaxRetries retries")
+
+	calls = 0
+	_, err = withRetry(context.Background(), 3, "Deployment/web", func() error {
+		calls++
+		return apierrors.NewBadRequest("invalid")
+	})
+	assert.True(t, apierrors.IsBadRequest(err))
+	assert.Equal(t, 1, calls, "non-retryable errors fail immediately")
+}
+
+func TestResolveMaxRetries(t *testing.T) {
+	assert.Equal(t, DefaultMaxRetries, resolveMaxRetries(0))
+	assert.Equal(t, 0, resolveMaxRetries(-1))
+	assert.Equal(t, 5, resolveMaxRetries(5))
+}
+
+// failFirst returns a reactor that fails the first n matching calls with err
+// and lets the rest through to the fake's object tracker.
+func failFirst(n int, err error) k8stesting.ReactionFunc {
+	return func(k8stesting.Action) (bool, runtime.Object, error) {
+		if n > 0 {
+			n--
+			return true, nil, err
+		}
+		return false, nil, nil
+	}
+}
+
+func TestApply_RetriesTransientErrors(t *testing.T) {
+	noRetryDelay(t)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := componentObject("apps/v1", "Deployment", action.(k8stesting.PatchAction).GetName(), "web")
+		obj.SetResourceVersion("1")
+		return true, obj, nil
+	})
+	dyn.PrependReactor("patch", "*", failFirst(2, apierrors.NewConflict(testGR, "web", errors.New("changed"))))
+	resources := []*unstructured.Unstructured{componentObject("apps/v1", "Deployment", "web", "web")}
+
+	result, err := Apply(context.Background(), &Client{Dynamic: dyn}, resources, "demo", ApplyOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Applied)
+	assert.Equal(t, 2, result.Retries)
+}
+
+func TestDelete_RetriesTransientErrors(t *testing.T) {
+	noRetryDelay(t)
+	cm := makeUnstructured("v1", "ConfigMap", "cm", "default")
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), cm.DeepCopy())
+	dyn.PrependReactor("delete", "*", failFirst(1, apierrors.NewTooManyRequests("slow down", 0)))
+
+	result, err := Delete(context.Background(), &Client{Dynamic: dyn}, DeleteOptions{
+		InstanceName:  "demo",
+		Namespace:     "default",
+		InventoryLive: []*unstructured.Unstructured{cm.DeepCopy()},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 1, result.Retries)
+
+	dyn = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), cm.DeepCopy())
+	dyn.PrependReactor("delete", "*", failFirst(5, apierrors.NewTooManyRequests("slow down", 0)))
+	result, err = Delete(context.Background(), &Client{Dynamic: dyn}, DeleteOptions{
+		InstanceName:  "demo",
+		Namespace:     "default",
+		InventoryLive: []*unstructured.Unstructured{cm.DeepCopy()},
+		MaxRetries:    -1,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1, "retries disabled")
+	assert.Zero(t, result.Retries)
+}
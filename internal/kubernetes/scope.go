@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// knownClusterScopedKinds are well-known kinds that never take a namespace.
+// They decide scope when there is no discovery to ask: at render time, and for
+// a client without a Clientset.
+var knownClusterScopedKinds = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}:        true,
+	{Kind: "Node"}:             true,
+	{Kind: "PersistentVolume"}: true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:               true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                           true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                 true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                    true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                             true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                              true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                    true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: true,
+	{Group: "gateway.networking.k8s.io", Kind: "GatewayClass"}:                      true,
+	{Group: "cert-manager.io", Kind: "ClusterIssuer"}:                               true,
+}
+
+// IsKnownClusterScoped reports whether gk is a well-known cluster-scoped kind.
+// Kinds it does not know may still be cluster-scoped; ask Client.ClusterScoped
+// when a cluster is at hand.
+func IsKnownClusterScoped(gk schema.GroupKind) bool {
+	return knownClusterScopedKinds[gk]
+}
+
+// ClusterScoped reports whether gvk is cluster-scoped, from the scope
+// discovery maps it to. A kind discovery cannot map, or a client without
+// discovery, falls back to IsKnownClusterScoped.
+func (c *Client) ClusterScoped(gvk schema.GroupVersionKind) bool {
+	mapper := c.restMapper(false)
+	if mapper == nil {
+		return IsKnownClusterScoped(gvk.GroupKind())
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) && c.markRediscovered(gvk.GroupKind()) {
+		mapping, err = c.restMapper(true).RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return IsKnownClusterScoped(gvk.GroupKind())
+	}
+	return mapping.Scope.Name() == meta.RESTScopeNameRoot
+}
+
+// ClusterScopedNamespaceError fails a render or apply under
+// --strict-namespace: cluster-scoped resources were given a namespace.
+type ClusterScopedNamespaceError struct {
+	// Resources are the offending resources, as Kind/namespace/name.
+	Resources []string
+}
+
+func (e *ClusterScopedNamespaceError) Error() string {
+	return fmt.Sprintf("strict namespace: %d cluster-scoped resource(s) rendered with a namespace: %s",
+		len(e.Resources), strings.Join(e.Resources, ", "))
+}
+
+// StripClusterScopedNamespaces clears metadata.namespace on each resource
+// whose kind is cluster-scoped (see Client.ClusterScoped), which the API
+// server would otherwise reject with a confusing error. It returns the
+// resources it changed. With strict set it changes nothing and returns a
+// *ClusterScopedNamespaceError instead.
+func StripClusterScopedNamespaces(client *Client, resources []*unstructured.Unstructured, strict bool) ([]*unstructured.Unstructured, error) {
+	var stray []*unstructured.Unstructured
+	for _, obj := range resources {
+		if obj.GetNamespace() != "" && client.ClusterScoped(obj.GroupVersionKind()) {
+			stray = append(stray, obj)
+		}
+	}
+	if len(stray) == 0 {
+		return nil, nil
+	}
+	if strict {
+		refs := make([]string, 0, len(stray))
+		for _, obj := range stray {
+			refs = append(refs, obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName())
+		}
+		return nil, &ClusterScopedNamespaceError{Resources: refs}
+	}
+	for _, obj := range stray {
+		obj.SetNamespace("")
+	}
+	return stray, nil
+}
@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestStripClusterScopedNamespaces(t *testing.T) {
+	clusterRole := func() *unstructured.Unstructured {
+		return componentObject("rbac.authorization.k8s.io/v1", "ClusterRole", "web", "web")
+	}
+	widgetClass := func() *unstructured.Unstructured {
+		return componentObject("example.com/v1", "WidgetClass", "fast", "web")
+	}
+	configMap := func() *unstructured.Unstructured {
+		return componentObject("v1", "ConfigMap", "web", "web")
+	}
+
+	t.Run("without discovery, well-known kinds", func(t *testing.T) {
+		resources := []*unstructured.Unstructured{clusterRole(), widgetClass(), configMap()}
+		stripped, err := StripClusterScopedNamespaces(&Client{}, resources, false)
+		require.NoError(t, err)
+		require.Len(t, stripped, 1)
+		assert.Empty(t, resources[0].GetNamespace(), "the ClusterRole loses its stray namespace")
+		assert.Equal(t, "media", resources[1].GetNamespace(), "an unknown kind is left alone")
+		assert.Equal(t, "media", resources[2].GetNamespace())
+	})
+
+	t.Run("discovery scope", func(t *testing.T) {
+		client, disc := discoveryClient(t, nil)
+		disc.Resources = []*metav1.APIResourceList{{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{{Name: "widgetclasses", Kind: "WidgetClass", Namespaced: false}},
+		}}
+		resources := []*unstructured.Unstructured{widgetClass(), configMap()}
+		stripped, err := StripClusterScopedNamespaces(client, resources, false)
+		require.NoError(t, err)
+		require.Len(t, stripped, 1)
+		assert.Empty(t, resources[0].GetNamespace(), "the CRD-served cluster-scoped kind loses its namespace")
+		assert.Equal(t, "media", resources[1].GetNamespace())
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		resources := []*unstructured.Unstructured{clusterRole()}
+		_, err := StripClusterScopedNamespaces(&Client{}, resources, true)
+		var stray *ClusterScopedNamespaceError
+		require.ErrorAs(t, err, &stray)
+		assert.Equal(t, []string{"ClusterRole/media/web"}, stray.Resources)
+		assert.Equal(t, "media", resources[0].GetNamespace(), "strict mode changes nothing")
+	})
+}
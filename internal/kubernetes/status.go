@@ -17,6 +17,7 @@ import (
 // no longer exists on the cluster. Passed from the command layer (which fetches
 // the inventory) into GetModuleStatus so that "Missing" entries appear in the output.
 type MissingResource struct {
+	Group     string
 	Kind      string
 	Namespace string
 	Name      string
@@ -39,6 +40,13 @@ type StatusOptions struct {
 	// Empty for local modules.
 	Version string
 
+	// ModuleDigest is the verified module digest recorded by the last
+	// digest-pinned apply. Empty when that apply was not pinned.
+	ModuleDigest string
+
+	// LastOperation is the operation ID of the last apply, from the CR.
+	LastOperation string
+
 	// Owner is the effective owner of the instance from inventory provenance.
 	Owner string
 
@@ -57,6 +65,11 @@ type StatusOptions struct {
 	// no longer exist on the cluster. These are shown with "Missing" status.
 	MissingResources []MissingResource
 
+	// ForeignOwned lists the live resources whose labels no longer tie them to
+	// the instance (see inventory.VerifyOwnership). They are reported as
+	// given; GetInstanceStatus does not check labels itself.
+	ForeignOwned []ForeignOwnedResource
+
 	// Wide enables extraction of workload-specific wide info (replicas, image).
 	Wide bool
 
@@ -78,6 +91,17 @@ type resourceHealth struct {
 	Status HealthStatus `json:"status" yaml:"status"`
 	// Age is the human-readable age of the resource.
 	Age string `json:"age" yaml:"age"`
+	// Message explains a status other than healthy in a sentence: the
+	// message of the first unsatisfied condition, a replica count, or why
+	// the resource is Missing or Terminating. Empty when healthy.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Reasons are the status conditions of a resource that is not healthy,
+	// each as "Type=Status: Reason" (e.g. "Available=False:
+	// MinimumReplicasUnavailable"), in the resource's condition order.
+	Reasons []string `json:"reasons,omitempty" yaml:"reasons,omitempty"`
+	// Finalizers lists the finalizers blocking deletion, populated only for
+	// Terminating resources.
+	Finalizers []string `json:"finalizers,omitempty" yaml:"finalizers,omitempty"`
 	// Wide holds extra workload-specific info (replicas, image), populated when Wide mode is on.
 	Wide *wideInfo `json:"wide,omitempty" yaml:"wide,omitempty"`
 	// Verbose holds pod-level diagnostics, populated when Verbose mode is on.
@@ -111,12 +135,20 @@ type statusSummary struct {
 	NotReady int `json:"notReady" yaml:"notReady"`
 }
 
-// StatusResult contains the full status output.
+// StatusResult contains the full status output. It is what status -o json
+// and -o yaml print, so its field names are a stable interface: fields may be
+// added, but existing ones are not renamed or removed. A Missing resource
+// (tracked in the inventory, absent from the cluster) has the same shape as
+// a live one, with Status "Missing" and Age "<unknown>".
 type StatusResult struct {
 	// InstanceName is the human-readable instance name.
 	InstanceName string `json:"instanceName" yaml:"instanceName"`
 	// Version is the module version (empty for local modules).
 	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// ModuleDigest is the module's verified OCI manifest digest, if pinned.
+	ModuleDigest string `json:"moduleDigest,omitempty" yaml:"moduleDigest,omitempty"`
+	// LastOperation is the operation ID of the last apply.
+	LastOperation string `json:"lastOperation,omitempty" yaml:"lastOperation,omitempty"`
 	// Owner is the effective owner of the instance.
 	Owner string `json:"owner" yaml:"owner"`
 	// Namespace is the Kubernetes namespace.
@@ -127,6 +159,9 @@ type StatusResult struct {
 	AggregateStatus HealthStatus `json:"aggregateStatus" yaml:"aggregateStatus"`
 	// Summary contains aggregate resource counts.
 	Summary statusSummary `json:"summary" yaml:"summary"`
+	// ForeignOwned lists tracked resources another tool appears to have taken
+	// over: their instance UUID or managed-by labels no longer match.
+	ForeignOwned []ForeignOwnedResource `json:"foreignOwned,omitempty" yaml:"foreignOwned,omitempty"`
 }
 
 // GetInstanceStatus evaluates health for all resources tracked in opts.InventoryLive.
@@ -154,10 +189,13 @@ func GetInstanceStatus(ctx context.Context, client *Client, opts StatusOptions)
 	}
 
 	result := &StatusResult{
-		InstanceName: opts.InstanceName,
-		Version:      opts.Version,
-		Owner:        opts.Owner,
-		Namespace:    opts.Namespace,
+		InstanceName:  opts.InstanceName,
+		Version:       opts.Version,
+		ModuleDigest:  opts.ModuleDigest,
+		LastOperation: opts.LastOperation,
+		Owner:         opts.Owner,
+		Namespace:     opts.Namespace,
+		ForeignOwned:  opts.ForeignOwned,
 	}
 	allReady := true
 
@@ -175,8 +213,10 @@ func GetInstanceStatus(ctx context.Context, client *Client, opts StatusOptions)
 			Kind:      m.Kind,
 			Name:      m.Name,
 			Namespace: m.Namespace,
+			Component: opts.ComponentMap[m.Kind+"/"+m.Namespace+"/"+m.Name],
 			Status:    HealthMissing,
 			Age:       "<unknown>",
+			Message:   missingMessage,
 		})
 		allReady = false
 	}
@@ -204,6 +244,23 @@ func GetInstanceStatus(ctx context.Context, client *Client, opts StatusOptions)
 	return result, nil
 }
 
+// NotReadyResources describes each resource in the result that is not
+// healthy, as "Kind namespace/name (Status)", in result order.
+func (r *StatusResult) NotReadyResources() []string {
+	var out []string
+	for _, res := range r.Resources {
+		if IsHealthy(res.Status) {
+			continue
+		}
+		ref := res.Name
+		if res.Namespace != "" {
+			ref = res.Namespace + "/" + res.Name
+		}
+		out = append(out, fmt.Sprintf("%s %s (%s)", res.Kind, ref, res.Status))
+	}
+	return out
+}
+
 // buildResourceHealth constructs a resourceHealth for a single live resource.
 // Returns the populated struct and whether the resource is healthy.
 func buildResourceHealth(ctx context.Context, client *Client, res *unstructured.Unstructured, opts StatusOptions) (resourceHealth, bool) {
@@ -221,6 +278,11 @@ func buildResourceHealth(ctx context.Context, client *Client, res *unstructured.
 		Age:       age,
 	}
 
+	if health == HealthTerminating {
+		rh.Finalizers = res.GetFinalizers()
+	}
+	rh.Message, rh.Reasons = explainHealth(res, health)
+
 	if opts.Wide {
 		rh.Wide = extractWideInfo(res)
 	}
@@ -239,6 +301,49 @@ func buildResourceHealth(ctx context.Context, client *Client, res *unstructured.
 	return rh, healthy
 }
 
+// missingMessage is the Message of a Missing resource.
+const missingMessage = "tracked in the inventory but not found on the cluster"
+
+// explainHealth returns the Message and Reasons of a live resource that is
+// not healthy, from its conditions, replica counts, phase, or finalizers.
+func explainHealth(res *unstructured.Unstructured, health HealthStatus) (string, []string) {
+	if IsHealthy(health) {
+		return "", nil
+	}
+	if health == HealthTerminating {
+		if fins := res.GetFinalizers(); len(fins) > 0 {
+			return "deletion is waiting on finalizers: " + strings.Join(fins, ", "), nil
+		}
+		return "deletion in progress", nil
+	}
+
+	var message string
+	var reasons []string
+	for _, c := range getConditionDetails(res) {
+		if c.Reason != "" {
+			reasons = append(reasons, c.Type+"="+c.Status+": "+c.Reason)
+		}
+		if message == "" && c.Message != "" && (c.Status != conditionStatusTrue || c.Type == "Failed") {
+			message = c.Message
+		}
+	}
+	if message != "" {
+		return message, reasons
+	}
+
+	if desired, found, _ := unstructured.NestedInt64(res.Object, "spec", "replicas"); found { //nolint:errcheck // best-effort replica count
+		ready, _, _ := unstructured.NestedInt64(res.Object, "status", "readyReplicas") //nolint:errcheck // best-effort ready count
+		return fmt.Sprintf("%d/%d replicas ready", ready, desired), reasons
+	}
+	if phase, _, _ := unstructured.NestedString(res.Object, "status", "phase"); phase != "" { //nolint:errcheck // best-effort phase
+		return "phase " + phase, reasons
+	}
+	if health == HealthUnknown {
+		return "no readiness condition reported", reasons
+	}
+	return "", reasons
+}
+
 // FormatStatusTable renders the status result as a formatted table (default format).
 func FormatStatusTable(result *StatusResult) string {
 	var sb strings.Builder
@@ -257,6 +362,9 @@ func FormatStatusTable(result *StatusResult) string {
 	}
 	sb.WriteString(tbl.String())
 
+	sb.WriteString(formatTerminatingBlocks(result))
+	sb.WriteString(FormatForeignOwned(result.ForeignOwned))
+
 	// Render verbose pod details below the table
 	sb.WriteString(formatVerboseBlocks(result))
 
@@ -290,6 +398,8 @@ func formatStatusWide(result *StatusResult) string {
 	}
 	sb.WriteString(tbl.String())
 
+	sb.WriteString(formatTerminatingBlocks(result))
+	sb.WriteString(FormatForeignOwned(result.ForeignOwned))
 	sb.WriteString(formatVerboseBlocks(result))
 
 	return sb.String()
@@ -302,6 +412,12 @@ func formatStatusHeader(result *StatusResult) string {
 	if result.Version != "" {
 		fmt.Fprintf(&sb, "Version:    %s\n", result.Version)
 	}
+	if result.ModuleDigest != "" {
+		fmt.Fprintf(&sb, "Digest:     %s\n", result.ModuleDigest)
+	}
+	if result.LastOperation != "" {
+		fmt.Fprintf(&sb, "Operation:  %s\n", result.LastOperation)
+	}
 	if result.Owner != "" {
 		fmt.Fprintf(&sb, "Owner:      %s\n", result.Owner)
 	}
@@ -319,6 +435,26 @@ func formatStatusHeader(result *StatusResult) string {
 	return sb.String()
 }
 
+// formatTerminatingBlocks lists the finalizers holding each Terminating
+// resource, so a stuck delete or prune can be traced to its controller.
+func formatTerminatingBlocks(result *StatusResult) string {
+	var sb strings.Builder
+	for _, r := range result.Resources {
+		if r.Status != HealthTerminating {
+			continue
+		}
+		if len(r.Finalizers) == 0 {
+			fmt.Fprintf(&sb, "\n%s/%s is terminating %s\n", r.Kind, r.Name, output.Dim("(no finalizers)"))
+			continue
+		}
+		fmt.Fprintf(&sb, "\n%s/%s is terminating, blocked by finalizers:\n", r.Kind, r.Name)
+		for _, f := range r.Finalizers {
+			fmt.Fprintf(&sb, "    %s\n", f)
+		}
+	}
+	return sb.String()
+}
+
 // formatVerboseBlocks renders pod detail blocks for resources with verbose data.
 // Column widths are computed dynamically per block from actual pod name and phase
 // lengths, producing compact kubectl-style output with no excess whitespace.
@@ -448,6 +584,8 @@ func FormatStatus(result *StatusResult, format output.Format) (string, error) {
 		return formatStatusYAML(result)
 	case output.FormatWide:
 		return formatStatusWide(result), nil
+	case output.FormatPrometheus:
+		return formatStatusPrometheus(result), nil
 	case output.FormatTable:
 		return FormatStatusTable(result), nil
 	case output.FormatDir:
@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// statusMetric is one gauge of the Prometheus status output.
+type statusMetric struct {
+	name  string
+	help  string
+	value func(*StatusResult) int
+}
+
+// statusMetrics are the gauges status -o prometheus emits, in output order.
+// Their names and labels are a monitoring interface: like the JSON fields of
+// StatusResult, they may be added to but not renamed.
+var statusMetrics = []statusMetric{
+	{"opm_instance_resources_total", "Resources the instance's inventory tracks.",
+		func(r *StatusResult) int { return r.Summary.Total }},
+	{"opm_instance_resources_ready", "Tracked resources that are Ready, Complete, or Bound.",
+		func(r *StatusResult) int { return r.Summary.Ready }},
+	{"opm_instance_resources_missing", "Tracked resources absent from the cluster.",
+		func(r *StatusResult) int { return r.countStatus(HealthMissing) }},
+	{"opm_instance_resources_foreign_owned", "Tracked resources whose ownership labels another tool has changed.",
+		func(r *StatusResult) int { return len(r.ForeignOwned) }},
+	{"opm_instance_ready", "1 when every tracked resource is healthy, else 0.",
+		func(r *StatusResult) int { return boolGauge(r.AggregateStatus == HealthReady) }},
+	{"opm_instance_drifted", "1 when the cluster no longer holds what the inventory records: a tracked resource is missing or foreign-owned.",
+		func(r *StatusResult) int {
+			return boolGauge(r.countStatus(HealthMissing) > 0 || len(r.ForeignOwned) > 0)
+		}},
+}
+
+func (r *StatusResult) countStatus(status HealthStatus) int {
+	n := 0
+	for _, res := range r.Resources {
+		if res.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func boolGauge(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// formatStatusPrometheus renders the status result as gauges in the
+// Prometheus text exposition format, for a node-exporter textfile collector
+// or a scraping wrapper. Each gauge is labeled module_instance and namespace;
+// the label is not "instance", which Prometheus reserves for the scrape
+// target and would rename on ingestion.
+func formatStatusPrometheus(result *StatusResult) string {
+	labels := fmt.Sprintf(`{module_instance="%s",namespace="%s"}`,
+		escapePrometheusLabel(result.InstanceName), escapePrometheusLabel(result.Namespace))
+	var sb strings.Builder
+	for _, m := range statusMetrics {
+		fmt.Fprintf(&sb, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&sb, "# TYPE %s gauge\n", m.name)
+		fmt.Fprintf(&sb, "%s%s %d\n", m.name, labels, m.value(result))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// prometheusLabelEscaper applies the only escapes a Prometheus label value
+// allows: backslash, double quote, and newline.
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapePrometheusLabel(s string) string {
+	return prometheusLabelEscaper.Replace(s)
+}
@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+func TestFormatStatus_Prometheus(t *testing.T) {
+	result := &StatusResult{
+		InstanceName:    "my-app",
+		Namespace:       "default",
+		AggregateStatus: HealthNotReady,
+		Summary:         statusSummary{Total: 3, Ready: 1, NotReady: 2},
+		Resources: []resourceHealth{
+			{Kind: "Deployment", Name: "web", Namespace: "default", Status: HealthReady},
+			{Kind: "Service", Name: "web", Namespace: "default", Status: HealthNotReady},
+			{Kind: "ConfigMap", Name: "config", Namespace: "default", Status: HealthMissing},
+		},
+	}
+
+	formatted, err := FormatStatus(result, output.FormatPrometheus)
+	require.NoError(t, err)
+	labels := `{module_instance="my-app",namespace="default"}`
+	for _, line := range []string{
+		"# TYPE opm_instance_resources_total gauge",
+		"opm_instance_resources_total" + labels + " 3",
+		"opm_instance_resources_ready" + labels + " 1",
+		"opm_instance_resources_missing" + labels + " 1",
+		"opm_instance_resources_foreign_owned" + labels + " 0",
+		"opm_instance_ready" + labels + " 0",
+		"opm_instance_drifted" + labels + " 1",
+	} {
+		assert.Contains(t, formatted+"\n", line+"\n")
+	}
+}
+
+func TestFormatStatus_PrometheusHealthy(t *testing.T) {
+	result := &StatusResult{
+		InstanceName:    "my-app",
+		Namespace:       "default",
+		AggregateStatus: HealthReady,
+		Summary:         statusSummary{Total: 1, Ready: 1},
+		Resources:       []resourceHealth{{Kind: "Deployment", Name: "web", Namespace: "default", Status: HealthReady}},
+	}
+
+	formatted, err := FormatStatus(result, output.FormatPrometheus)
+	require.NoError(t, err)
+	assert.Contains(t, formatted, `opm_instance_ready{module_instance="my-app",namespace="default"} 1`)
+	assert.Contains(t, formatted, `opm_instance_drifted{module_instance="my-app",namespace="default"} 0`)
+}
+
+func TestEscapePrometheusLabel(t *testing.T) {
+	assert.Equal(t, `a\\b\"c\nd`, escapePrometheusLabel("a\\b\"c\nd"))
+}
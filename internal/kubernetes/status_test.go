@@ -1,11 +1,15 @@
 package kubernetes
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // --- 7.6: Tests for output format selection ---
@@ -213,3 +217,146 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatStatusTable_TerminatingFinalizers(t *testing.T) {
+	result := &StatusResult{
+		InstanceName:    "my-app",
+		Owner:           "cli",
+		Namespace:       "production",
+		AggregateStatus: HealthNotReady,
+		Summary:         statusSummary{Total: 2, Ready: 1, NotReady: 1},
+		Resources: []resourceHealth{
+			{
+				Kind: "PersistentVolumeClaim", Name: "data", Namespace: "production",
+				Status: HealthTerminating, Age: "2d",
+				Finalizers: []string{"kubernetes.io/pvc-protection"},
+			},
+			{Kind: "Service", Name: "svc", Namespace: "production", Status: HealthReady, Age: "2d"},
+		},
+	}
+
+	out := FormatStatusTable(result)
+	assert.Contains(t, out, "Terminating")
+	assert.Contains(t, out, "PersistentVolumeClaim/data is terminating, blocked by finalizers:")
+	assert.Contains(t, out, "kubernetes.io/pvc-protection")
+	assert.NotContains(t, out, "Service/svc is terminating")
+}
+
+func TestFormatStatus_ForeignOwned(t *testing.T) {
+	result := &StatusResult{
+		InstanceName:    "my-app",
+		Namespace:       "production",
+		AggregateStatus: HealthReady,
+		Summary:         statusSummary{Total: 1, Ready: 1},
+		Resources:       []resourceHealth{{Kind: "Service", Name: "svc", Namespace: "production", Status: HealthReady, Age: "2d"}},
+		ForeignOwned:    []ForeignOwnedResource{{Kind: "Service", Name: "svc", Namespace: "production", Reason: `managed by "Helm"`}},
+	}
+
+	out := FormatStatusTable(result)
+	assert.Contains(t, out, "no longer labelled as this instance's")
+	assert.Contains(t, out, `Service/svc: managed by "Helm"`)
+
+	js, err := FormatStatus(result, "json")
+	require.NoError(t, err)
+	assert.Contains(t, js, `"foreignOwned"`)
+
+	result.ForeignOwned = nil
+	assert.NotContains(t, FormatStatusTable(result), "no longer labelled")
+}
+
+func TestGetInstanceStatus_TerminatingIsNotReady(t *testing.T) {
+	cm := makeResource("ConfigMap", nil)
+	now := metav1.Now()
+	cm.SetDeletionTimestamp(&now)
+	cm.SetFinalizers([]string{"example.com/hold"})
+
+	result, err := GetInstanceStatus(context.Background(), nil, StatusOptions{
+		InstanceName:  "my-app",
+		Namespace:     "default",
+		InventoryLive: []*unstructured.Unstructured{cm},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, HealthNotReady, result.AggregateStatus)
+	assert.Equal(t, 1, result.Summary.NotReady)
+	require.Len(t, result.Resources, 1)
+	assert.Equal(t, HealthTerminating, result.Resources[0].Status)
+	assert.Equal(t, []string{"example.com/hold"}, result.Resources[0].Finalizers)
+}
+
+func TestGetInstanceStatus_ConditionlessCustomResourceIsReady(t *testing.T) {
+	live := []*unstructured.Unstructured{
+		makeResource("ConfigMap", nil),
+		makeResource("MyCustomResource", nil),
+	}
+
+	result, err := GetInstanceStatus(context.Background(), nil, StatusOptions{
+		InstanceName:  "my-app",
+		Namespace:     "default",
+		InventoryLive: live,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, HealthReady, result.AggregateStatus)
+	assert.Equal(t, 2, result.Summary.Ready)
+
+	status, ready, total := QuickInstanceHealth(live, 0)
+	assert.Equal(t, HealthReady, status)
+	assert.Equal(t, 2, ready)
+	assert.Equal(t, 2, total)
+}
+
+func TestStatusResult_NotReadyResources(t *testing.T) {
+	result := &StatusResult{
+		Resources: []resourceHealth{
+			{Kind: "Deployment", Name: "web", Namespace: "apps", Status: HealthNotReady},
+			{Kind: "PersistentVolumeClaim", Name: "data", Namespace: "apps", Status: HealthBound},
+			{Kind: "ConfigMap", Name: "cfg", Namespace: "apps", Status: HealthMissing},
+			{Kind: "ClusterRole", Name: "reader", Status: HealthReady},
+		},
+	}
+	assert.Equal(t, []string{
+		"Deployment apps/web (NotReady)",
+		"ConfigMap apps/cfg (Missing)",
+	}, result.NotReadyResources())
+}
+
+func TestGetInstanceStatus_JSONExplainsNotReady(t *testing.T) {
+	deploy := makeResource("Deployment", []map[string]interface{}{
+		{"type": "Available", "status": "False", "reason": "MinimumReplicasUnavailable", "message": "Deployment does not have minimum availability."},
+		{"type": "Progressing", "status": "True", "reason": "ReplicaSetUpdated"},
+	})
+	deploy.SetName("web")
+	cm := makeResource("ConfigMap", nil)
+
+	result, err := GetInstanceStatus(context.Background(), nil, StatusOptions{
+		InstanceName:     "my-app",
+		Namespace:        "default",
+		InventoryLive:    []*unstructured.Unstructured{deploy, cm},
+		MissingResources: []MissingResource{{Kind: "Secret", Namespace: "default", Name: "creds"}},
+		ComponentMap:     map[string]string{"Deployment/default/web": "web", "Secret/default/creds": "web"},
+	})
+	require.NoError(t, err)
+
+	formatted, err := FormatStatus(result, "json")
+	require.NoError(t, err)
+	var decoded struct {
+		AggregateStatus string           `json:"aggregateStatus"`
+		Resources       []map[string]any `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(formatted), &decoded))
+	assert.Equal(t, "NotReady", decoded.AggregateStatus)
+	require.Len(t, decoded.Resources, 3)
+
+	web := decoded.Resources[0]
+	assert.Equal(t, "NotReady", web["status"])
+	assert.Equal(t, "Deployment does not have minimum availability.", web["message"])
+	assert.Equal(t, []any{"Available=False: MinimumReplicasUnavailable", "Progressing=True: ReplicaSetUpdated"}, web["reasons"])
+
+	assert.NotContains(t, decoded.Resources[1], "message", "healthy resources carry no message")
+
+	// A Missing resource has the same shape as a live one.
+	missing := decoded.Resources[2]
+	assert.Equal(t, map[string]any{
+		"kind": "Secret", "name": "creds", "namespace": "default", "component": "web",
+		"status": "Missing", "age": "<unknown>", "message": missingMessage,
+	}, missing)
+}
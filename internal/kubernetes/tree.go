@@ -467,10 +467,9 @@ type treeRow struct {
 }
 
 // FormatTree formats a TreeResult according to the requested output format.
-// Color stripping for non-TTY environments is handled automatically: lipgloss
-// uses termenv.ColorProfile() to detect the terminal capability and falls back
-// to termenv.Ascii (no color) when stdout is a pipe or CI environment. No
-// explicit TTY check is required here.
+// Color stripping is handled by the global --color policy (output.SetColorMode):
+// lipgloss renders plain text when stdout is a pipe, NO_COLOR is set, or
+// --color=never is given. No explicit TTY check is required here.
 func FormatTree(result *TreeResult, format output.Format) (string, error) {
 	switch format {
 	case output.FormatJSON:
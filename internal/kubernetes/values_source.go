@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds of ValuesRef.
+const (
+	ValuesKindConfigMap = "configmap"
+	ValuesKindSecret    = "secret"
+)
+
+// ValuesRef names a ConfigMap or Secret key holding module values, as given
+// to --values-from-configmap and --values-from-secret: namespace/name, with
+// an optional :key.
+type ValuesRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Key selects one data key. Empty means the object must hold exactly one.
+	Key string
+}
+
+// String renders the reference as it is written on the command line, kind
+// first: "configmap demo/app-values:values.yaml".
+func (r ValuesRef) String() string {
+	s := r.Kind + " " + r.Namespace + "/" + r.Name
+	if r.Key != "" {
+		s += ":" + r.Key
+	}
+	return s
+}
+
+// ParseValuesRef parses a namespace/name[:key] reference of the given kind.
+func ParseValuesRef(kind, ref string) (ValuesRef, error) {
+	objRef, key, _ := strings.Cut(ref, ":")
+	namespace, name, ok := strings.Cut(objRef, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return ValuesRef{}, fmt.Errorf("invalid %s reference %q (want namespace/name[:key])", kind, ref)
+	}
+	return ValuesRef{Kind: kind, Namespace: namespace, Name: name, Key: key}, nil
+}
+
+// FetchValues reads the data a ValuesRef names. Without a key, the ConfigMap
+// or Secret must hold exactly one data key; otherwise the keys it does hold
+// are listed so the reference can be fixed. A missing object is returned as
+// the API server's NotFound error.
+func FetchValues(ctx context.Context, client *Client, ref ValuesRef) ([]byte, error) {
+	var data map[string][]byte
+	switch ref.Kind {
+	case ValuesKindConfigMap:
+		cm, err := client.Clientset.CoreV1().ConfigMaps(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", ref, err)
+		}
+		data = make(map[string][]byte, len(cm.Data))
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+	case ValuesKindSecret:
+		secret, err := client.Clientset.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", ref, err)
+		}
+		data = secret.Data
+	default:
+		return nil, fmt.Errorf("unknown values source kind %q", ref.Kind)
+	}
+
+	if ref.Key != "" {
+		v, ok := data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("%s: key %q not found (keys: %s)", ref, ref.Key, strings.Join(sortedKeys(data), ", "))
+		}
+		return v, nil
+	}
+	if len(data) != 1 {
+		return nil, fmt.Errorf("%s holds %d keys (%s); name one with :key", ref, len(data), strings.Join(sortedKeys(data), ", "))
+	}
+	for _, v := range data {
+		return v, nil
+	}
+	return nil, nil
+}
+
+func sortedKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
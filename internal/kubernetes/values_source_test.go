@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseValuesRef(t *testing.T) {
+	ref, err := ParseValuesRef(ValuesKindConfigMap, "demo/app-values:values.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, ValuesRef{Kind: ValuesKindConfigMap, Namespace: "demo", Name: "app-values", Key: "values.yaml"}, ref)
+	assert.Equal(t, "configmap demo/app-values:values.yaml", ref.String())
+
+	ref, err = ParseValuesRef(ValuesKindSecret, "demo/app-values")
+	require.NoError(t, err)
+	assert.Empty(t, ref.Key)
+
+	for _, bad := range []string{"app-values", "/app-values", "demo/", "a/b/c"} {
+		_, err := ParseValuesRef(ValuesKindConfigMap, bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFetchValues(t *testing.T) {
+	client := &Client{Clientset: fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "demo"},
+			Data:       map[string]string{"values.cue": "replicas: 3"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "demo"},
+			Data:       map[string]string{"a.yaml": "a: 1", "b.yaml": "b: 2"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "demo"},
+			Data:       map[string][]byte{"values.yaml": []byte("password: s3cret")},
+		},
+	)}
+	ctx := context.Background()
+
+	data, err := FetchValues(ctx, client, ValuesRef{Kind: ValuesKindConfigMap, Namespace: "demo", Name: "single"})
+	require.NoError(t, err)
+	assert.Equal(t, "replicas: 3", string(data))
+
+	data, err = FetchValues(ctx, client, ValuesRef{Kind: ValuesKindConfigMap, Namespace: "demo", Name: "multi", Key: "b.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, "b: 2", string(data))
+
+	data, err = FetchValues(ctx, client, ValuesRef{Kind: ValuesKindSecret, Namespace: "demo", Name: "creds"})
+	require.NoError(t, err)
+	assert.Equal(t, "password: s3cret", string(data))
+
+	_, err = FetchValues(ctx, client, ValuesRef{Kind: ValuesKindConfigMap, Namespace: "demo", Name: "multi"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "holds 2 keys (a.yaml, b.yaml)")
+
+	_, err = FetchValues(ctx, client, ValuesRef{Kind: ValuesKindConfigMap, Namespace: "demo", Name: "multi", Key: "c.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `key "c.yaml" not found`)
+
+	_, err = FetchValues(ctx, client, ValuesRef{Kind: ValuesKindSecret, Namespace: "demo", Name: "missing"})
+	require.True(t, apierrors.IsNotFound(err))
+}
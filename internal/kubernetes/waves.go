@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/pkg/resourceorder"
+)
+
+// AnnotationApplyWave moves a resource into another apply wave. Its value is
+// an integer on the resourceorder weight scale: CRDs are -100, namespaces 0,
+// Secrets and ConfigMaps 15, workloads 100, and unlisted kinds 1000, so
+// "120" applies a resource after the workloads and before ingresses.
+const AnnotationApplyWave = "opmodel.dev/apply-wave"
+
+// WaveResult is the outcome of one apply wave.
+type WaveResult struct {
+	// Wave is the wave's ordinal; lower waves apply first.
+	Wave int
+
+	// Resources is the number of resources in the wave.
+	Resources int
+
+	// Failed is the number of them that failed to apply.
+	Failed int
+
+	// Duration is how long the wave took, from its first apply starting to
+	// its last finishing.
+	Duration time.Duration
+}
+
+// ResourceWave returns the apply wave of obj: its AnnotationApplyWave value
+// when set, else the resourceorder weight of its kind. A value that is not an
+// integer is an error.
+func ResourceWave(obj *unstructured.Unstructured) (int, error) {
+	if v, ok := obj.GetAnnotations()[AnnotationApplyWave]; ok {
+		wave, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s/%s: %s annotation %q is not an integer", obj.GetKind(), obj.GetName(), AnnotationApplyWave, v)
+		}
+		return wave, nil
+	}
+	return resourceorder.GetWeight(obj.GroupVersionKind()), nil
+}
+
+// applyWave is the resources of one wave, in render order.
+type applyWave struct {
+	wave      int
+	resources []*unstructured.Unstructured
+}
+
+// applyWaves groups resources into waves, lowest first, keeping render order
+// within each wave. Every annotation is checked before anything is grouped,
+// so an invalid one fails the apply before any resource is written.
+func applyWaves(resources []*unstructured.Unstructured) ([]applyWave, error) {
+	byWave := make(map[int][]*unstructured.Unstructured)
+	for _, res := range resources {
+		wave, err := ResourceWave(res)
+		if err != nil {
+			return nil, err
+		}
+		byWave[wave] = append(byWave[wave], res)
+	}
+	waves := make([]applyWave, 0, len(byWave))
+	for wave, res := range byWave {
+		waves = append(waves, applyWave{wave: wave, resources: res})
+	}
+	sort.Slice(waves, func(i, j int) bool { return waves[i].wave < waves[j].wave })
+	return waves, nil
+}
@@ -0,0 +1,196 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/pkg/resourceorder"
+)
+
+// withWave sets the apply-wave annotation on obj.
+func withWave(obj *unstructured.Unstructured, wave string) *unstructured.Unstructured {
+	obj.SetAnnotations(map[string]string{AnnotationApplyWave: wave})
+	return obj
+}
+
+func TestResourceWave(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     *unstructured.Unstructured
+		want    int
+		wantErr string
+	}{
+		{name: "CRD by kind", obj: componentObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "crd", "a"), want: resourceorder.WeightCRD},
+		{name: "ConfigMap by kind", obj: componentObject("v1", "ConfigMap", "cm", "a"), want: resourceorder.WeightConfigMap},
+		{name: "Deployment by kind", obj: componentObject("apps/v1", "Deployment", "web", "a"), want: resourceorder.WeightDeployment},
+		{name: "unlisted kind", obj: componentObject("example.com/v1", "Widget", "w", "a"), want: resourceorder.WeightDefault},
+		{name: "annotation overrides kind", obj: withWave(componentObject("v1", "ConfigMap", "cm", "a"), "120"), want: 120},
+		{name: "negative annotation", obj: withWave(componentObject("apps/v1", "Deployment", "web", "a"), "-5"), want: -5},
+		{name: "zero annotation", obj: withWave(componentObject("apps/v1", "Deployment", "web", "a"), "0"), want: 0},
+		{name: "word", obj: withWave(componentObject("v1", "ConfigMap", "cm", "a"), "early"), wantErr: `ConfigMap/cm: opmodel.dev/apply-wave annotation "early" is not an integer`},
+		{name: "fraction", obj: withWave(componentObject("v1", "ConfigMap", "cm", "a"), "1.5"), wantErr: "is not an integer"},
+		{name: "empty", obj: withWave(componentObject("v1", "ConfigMap", "cm", "a"), ""), wantErr: "is not an integer"},
+		{name: "padded", obj: withWave(componentObject("v1", "ConfigMap", "cm", "a"), " 3"), wantErr: "is not an integer"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wave, err := ResourceWave(tc.obj)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, wave)
+		})
+	}
+}
+
+func TestApplyWaves(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*unstructured.Unstructured
+		want      [][]string
+		wantWaves []int
+		wantErr   string
+	}{
+		{name: "no resources"},
+		{
+			name: "one kind is one wave in render order",
+			resources: []*unstructured.Unstructured{
+				componentObject("apps/v1", "Deployment", "web", "a"),
+				componentObject("apps/v1", "Deployment", "api", "b"),
+			},
+			want:      [][]string{{"web", "api"}},
+			wantWaves: []int{resourceorder.WeightDeployment},
+		},
+		{
+			name: "kinds of equal weight share a wave",
+			resources: []*unstructured.Unstructured{
+				componentObject("apps/v1", "Deployment", "web", "a"),
+				componentObject("v1", "Secret", "secret-b", "b"),
+				componentObject("v1", "ConfigMap", "cm-a", "a"),
+			},
+			want:      [][]string{{"secret-b", "cm-a"}, {"web"}},
+			wantWaves: []int{resourceorder.WeightSecret, resourceorder.WeightDeployment},
+		},
+		{
+			name: "annotation moves a resource into another wave",
+			resources: []*unstructured.Unstructured{
+				componentObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "crd", "a"),
+				componentObject("v1", "ConfigMap", "cm-a", "a"),
+				withWave(componentObject("v1", "ConfigMap", "cm-late", "b"), "120"),
+				componentObject("v1", "Secret", "secret-b", "b"),
+				componentObject("apps/v1", "Deployment", "web", "a"),
+				componentObject("example.com/v1", "Widget", "w", "b"),
+			},
+			want:      [][]string{{"crd"}, {"cm-a", "secret-b"}, {"web"}, {"cm-late"}, {"w"}},
+			wantWaves: []int{resourceorder.WeightCRD, resourceorder.WeightConfigMap, resourceorder.WeightDeployment, 120, resourceorder.WeightDefault},
+		},
+		{
+			name: "annotation joins a kind's wave",
+			resources: []*unstructured.Unstructured{
+				componentObject("apps/v1", "Deployment", "web", "a"),
+				withWave(componentObject("v1", "ConfigMap", "cm", "a"), "100"),
+			},
+			want:      [][]string{{"web", "cm"}},
+			wantWaves: []int{resourceorder.WeightDeployment},
+		},
+		{
+			name: "invalid annotation fails the grouping",
+			resources: []*unstructured.Unstructured{
+				componentObject("v1", "ConfigMap", "cm-a", "a"),
+				withWave(componentObject("v1", "ConfigMap", "cm-b", "a"), "last"),
+			},
+			wantErr: "is not an integer",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			waves, err := applyWaves(tc.resources)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var got [][]string
+			var gotWaves []int
+			for _, wave := range waves {
+				var names []string
+				for _, r := range wave.resources {
+					names = append(names, r.GetName())
+				}
+				got = append(got, names)
+				gotWaves = append(gotWaves, wave.wave)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantWaves, gotWaves)
+		})
+	}
+}
+
+func TestApply_InvalidWaveAppliesNothing(t *testing.T) {
+	resources := []*unstructured.Unstructured{
+		componentObject("v1", "Secret", "creds", "a"),
+		withWave(componentObject("v1", "ConfigMap", "cm", "a"), "early"),
+	}
+
+	rec := &applyRecorder{}
+	_, err := Apply(context.Background(), rec.client(), resources, "demo", ApplyOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not an integer")
+	assert.Empty(t, rec.events, "nothing is applied, not even the valid resources")
+}
+
+// Three waves — a Secret, a Deployment, and an Ingress — with one resource
+// failing. Under fail-fast the waves after the failing one never start; the
+// failing wave itself always finishes.
+func TestApply_FailFastStopsLaterWaves(t *testing.T) {
+	resources := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			componentObject("v1", "Secret", "creds", "web"),
+			componentObject("apps/v1", "Deployment", "web", "web"),
+			componentObject("apps/v1", "Deployment", "worker", "worker"),
+			componentObject("networking.k8s.io/v1", "Ingress", "web-ingress", "web"),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		fail        string
+		failFast    bool
+		wantApplied int
+		wantSkipped int
+		wantWaves   int
+		notStarted  []string
+	}{
+		{name: "first wave fails", fail: "creds", failFast: true, wantSkipped: 3, wantWaves: 1, notStarted: []string{"web", "worker", "web-ingress"}},
+		{name: "middle wave fails", fail: "worker", failFast: true, wantApplied: 2, wantSkipped: 1, wantWaves: 2, notStarted: []string{"web-ingress"}},
+		{name: "last wave fails", fail: "web-ingress", failFast: true, wantApplied: 3, wantWaves: 3},
+		{name: "no failure", failFast: true, wantApplied: 4, wantWaves: 3},
+		{name: "without fail-fast every wave applies", fail: "creds", wantApplied: 3, wantWaves: 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := &applyRecorder{fail: map[string]bool{tc.fail: true}}
+
+			result, err := Apply(context.Background(), rec.client(), resources(), "demo", ApplyOptions{FailFast: tc.failFast})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantApplied, result.Applied)
+			assert.Equal(t, tc.wantSkipped, result.Skipped)
+			assert.Len(t, result.Waves, tc.wantWaves)
+			if tc.fail != "" {
+				assert.Len(t, result.Errors, 1)
+			}
+			for _, name := range tc.notStarted {
+				assert.Equal(t, -1, rec.position("start "+name), "%s is in a later wave", name)
+			}
+		})
+	}
+}
@@ -15,11 +15,7 @@ import (
 // waitPollInterval is how often Wait re-checks target readiness.
 const waitPollInterval = 2 * time.Second
 
-const (
-	kindDeployment       = "Deployment"
-	conditionStatusTrue  = "True"
-	conditionEstablished = "Established"
-)
+const kindDeployment = "Deployment"
 
 // ReadyPredicate reports whether a live object (as currently observed on the
 // cluster) is ready.
@@ -43,22 +39,7 @@ func DefaultPredicate(obj *unstructured.Unstructured) bool {
 // CRDEstablishedPredicate reports whether a CustomResourceDefinition has
 // reached the Established=True condition.
 func CRDEstablishedPredicate(obj *unstructured.Unstructured) bool {
-	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
-	if err != nil || !found {
-		return false
-	}
-	for _, raw := range conditions {
-		c, ok := raw.(map[string]any)
-		if !ok {
-			continue
-		}
-		condType, _, _ := unstructured.NestedString(c, "type")     //nolint:errcheck // best-effort condition parsing
-		condStatus, _, _ := unstructured.NestedString(c, "status") //nolint:errcheck // best-effort condition parsing
-		if condType == conditionEstablished && condStatus == conditionStatusTrue {
-			return true
-		}
-	}
-	return false
+	return kubernetes.CRDEstablished(obj)
 }
 
 // WorkloadReadyPredicate reports whether a workload resource (e.g. a
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gonvenience/bunt"
+	"github.com/muesli/termenv"
+)
+
+// Values of the global --color flag.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+var (
+	colorMu sync.Mutex
+	// colorProfile is the profile SetColorMode chose; colorSet is false until
+	// it runs, leaving each renderer to its own detection.
+	colorProfile = termenv.ColorProfile()
+	colorSet     bool
+)
+
+// SetColorMode applies the color policy to every styled output the CLI
+// writes: lipgloss styles, log lines, and dyff diffs. auto colors only when
+// stdout is a terminal and NO_COLOR is unset; always and never force it on or
+// off. An empty mode selects auto.
+func SetColorMode(mode string) error {
+	var enabled bool
+	switch mode {
+	case "", ColorAuto:
+		enabled = autoColor(os.Getenv("NO_COLOR"), StdoutIsTerminal())
+	case ColorAlways:
+		enabled = true
+	case ColorNever:
+	default:
+		return fmt.Errorf("invalid --color %q (valid: %s, %s, %s)", mode, ColorAuto, ColorAlways, ColorNever)
+	}
+
+	profile := termenv.Ascii
+	if enabled {
+		profile = termenv.ColorProfile()
+		if profile == termenv.Ascii {
+			// Forced color on a non-terminal: the palette is ANSI 256.
+			profile = termenv.ANSI256
+		}
+	}
+
+	colorMu.Lock()
+	defer colorMu.Unlock()
+	colorProfile, colorSet = profile, true
+	lipgloss.SetColorProfile(profile)
+	logger.SetColorProfile(profile)
+	if enabled {
+		bunt.SetColorSettings(bunt.ON, bunt.AUTO)
+	} else {
+		bunt.SetColorSettings(bunt.OFF, bunt.OFF)
+	}
+	return nil
+}
+
+// ColorEnabled reports whether styled output carries ANSI color.
+func ColorEnabled() bool {
+	colorMu.Lock()
+	defer colorMu.Unlock()
+	return colorProfile != termenv.Ascii
+}
+
+// autoColor is the auto policy: color on a terminal, unless NO_COLOR is set
+// to any non-empty value (https://no-color.org).
+func autoColor(noColor string, tty bool) bool {
+	return noColor == "" && tty
+}
+
+// applyLoggerColor carries the color policy over to a newly built logger.
+func applyLoggerColor() {
+	colorMu.Lock()
+	defer colorMu.Unlock()
+	if colorSet {
+		logger.SetColorProfile(colorProfile)
+	}
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoColor(t *testing.T) {
+	assert.True(t, autoColor("", true))
+	assert.False(t, autoColor("", false), "a pipe gets plain text")
+	assert.False(t, autoColor("1", true), "NO_COLOR wins on a terminal")
+}
+
+func TestSetColorMode(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetColorMode(ColorNever)) })
+
+	require.NoError(t, SetColorMode(ColorAlways))
+	assert.True(t, ColorEnabled())
+	assert.Contains(t, StyleNoun("web"), "\x1b[", "always colors off a terminal")
+
+	require.NoError(t, SetColorMode(ColorNever))
+	assert.False(t, ColorEnabled())
+	assert.Equal(t, "web", StyleNoun("web"))
+
+	t.Setenv("NO_COLOR", "1")
+	require.NoError(t, SetColorMode(""))
+	assert.False(t, ColorEnabled(), "empty selects auto")
+
+	err := SetColorMode("rainbow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --color "rainbow"`)
+}
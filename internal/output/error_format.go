@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	opmerrors "github.com/open-platform-model/cli/pkg/errors"
+)
+
+// Values of the global --error-format flag.
+const (
+	ErrorFormatPretty = "pretty"
+	ErrorFormatJSON   = "json"
+)
+
+var (
+	errorFormatMu sync.Mutex
+	errorFormat   = ErrorFormatPretty
+)
+
+// SetErrorFormat selects how validation errors are reported: pretty, the
+// styled grouped text, or json, a JSON array of {path, message, file, line,
+// column} records on stderr. An empty format selects pretty.
+func SetErrorFormat(format string) error {
+	switch format {
+	case "":
+		format = ErrorFormatPretty
+	case ErrorFormatPretty, ErrorFormatJSON:
+	default:
+		return fmt.Errorf("invalid --error-format %q (valid: %s, %s)", format, ErrorFormatPretty, ErrorFormatJSON)
+	}
+	errorFormatMu.Lock()
+	defer errorFormatMu.Unlock()
+	errorFormat = format
+	return nil
+}
+
+// JSONErrors reports whether errors are reported as JSON.
+func JSONErrors() bool {
+	errorFormatMu.Lock()
+	defer errorFormatMu.Unlock()
+	return errorFormat == ErrorFormatJSON
+}
+
+// FormatErrorJSON renders err as a JSON array of FieldError records, one per
+// CUE error position; an error without positions is a single record with
+// just its message.
+func FormatErrorJSON(err error) string {
+	records := opmerrors.FieldErrorsFromError(err)
+	if records == nil {
+		records = []opmerrors.FieldError{}
+	}
+	data, marshalErr := json.Marshal(records)
+	if marshalErr != nil {
+		data, _ = json.Marshal([]opmerrors.FieldError{{Message: err.Error()}}) //nolint:errcheck // a plain string always marshals
+	}
+	return string(data)
+}
+
+// PrintErrorJSON writes err to stderr as FormatErrorJSON renders it, on one
+// line, and mirrors it to the event stream.
+func PrintErrorJSON(err error) {
+	EmitError("", err)
+	fmt.Fprintln(os.Stderr, FormatErrorJSON(err))
+}
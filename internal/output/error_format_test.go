@@ -0,0 +1,27 @@
+package output
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetErrorFormat(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetErrorFormat(ErrorFormatPretty)) })
+
+	require.NoError(t, SetErrorFormat(ErrorFormatJSON))
+	assert.True(t, JSONErrors())
+	require.NoError(t, SetErrorFormat(""))
+	assert.False(t, JSONErrors(), "empty selects pretty")
+
+	err := SetErrorFormat("xml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --error-format "xml"`)
+}
+
+func TestFormatErrorJSON_PlainError(t *testing.T) {
+	assert.JSONEq(t, `[{"message":"connecting to cluster: refused"}]`,
+		FormatErrorJSON(errors.New("connecting to cluster: refused")))
+}
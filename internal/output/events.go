@@ -0,0 +1,212 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventsFormatJSONL selects the JSON lines event stream (--output-events jsonl).
+const EventsFormatJSONL = "jsonl"
+
+// EventType classifies an entry in the event stream.
+type EventType string
+
+const (
+	// EventPhase marks the start or end of an operation phase (apply, prune, delete).
+	EventPhase EventType = "phase"
+	// EventResource reports the outcome for a single resource.
+	EventResource EventType = "resource"
+	// EventWarning carries a non-fatal warning.
+	EventWarning EventType = "warning"
+	// EventError carries an error, including the command's final error.
+	EventError EventType = "error"
+)
+
+// Event is one line of the --output-events stream. The schema is stable:
+// fields are only ever added, and every field except time and type is
+// omitted when empty.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Type      EventType `json:"type"`
+	Message   string    `json:"message,omitempty"`
+	Instance  string    `json:"instance,omitempty"`
+	Phase     string    `json:"phase,omitempty"`
+	Kind      string    `json:"kind,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	// Status is the resource outcome: created, configured, unchanged,
+	// deleted, pruned, skipped (protected), or failed.
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Operation is the ID of the apply run the event belongs to (see
+	// SetOperation).
+	Operation string `json:"operation,omitempty"`
+}
+
+// StatusPruned is the resource event status for a pruned stale resource.
+const StatusPruned = "pruned"
+
+var (
+	eventsMu      sync.Mutex
+	eventsWriter  io.Writer // nil when the stream is disabled
+	eventsFile    *os.File  // the --output-events-file, closed by CloseEvents
+	emittedErrors []error   // errors already carried by an error event
+	operationID   string    // stamped on every event; see SetOperation
+)
+
+// SetupEvents enables the event stream for the given format. An empty format
+// disables it; "jsonl" writes one JSON object per line to path, or to stderr
+// alongside the human log when path is empty. Normal output keeps stdout
+// either way.
+func SetupEvents(format, path string) error {
+	switch format {
+	case "":
+		SetEventWriter(nil)
+		return nil
+	case EventsFormatJSONL:
+	default:
+		return fmt.Errorf("invalid --output-events format %q (valid: %s)", format, EventsFormatJSONL)
+	}
+
+	if path == "" {
+		SetEventWriter(os.Stderr)
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("opening --output-events-file: %w", err)
+	}
+	SetEventWriter(f)
+	eventsMu.Lock()
+	eventsFile = f
+	eventsMu.Unlock()
+	return nil
+}
+
+// CloseEvents flushes and closes the --output-events-file, if one is open,
+// and disables the stream. Call it before the process exits.
+func CloseEvents() error {
+	eventsMu.Lock()
+	f := eventsFile
+	eventsMu.Unlock()
+	SetEventWriter(nil)
+	if f == nil {
+		return nil
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close() //nolint:errcheck // the sync error is the one to report
+		return fmt.Errorf("flushing --output-events-file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing --output-events-file: %w", err)
+	}
+	return nil
+}
+
+// SetEventWriter redirects the event stream to w; nil disables it.
+// Intended for testing.
+func SetEventWriter(w io.Writer) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	eventsWriter = w
+	eventsFile = nil
+	emittedErrors = nil
+}
+
+// SetOperation stamps id on every later event that does not carry its own,
+// so all events of one apply run can be correlated. An empty id stops the
+// stamping.
+func SetOperation(id string) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	operationID = id
+}
+
+// EmitEvent writes e to the event stream, stamping the time and the current
+// operation when unset. It is a no-op when the stream is disabled.
+func EmitEvent(e Event) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if eventsWriter == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Operation == "" {
+		e.Operation = operationID
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	_, _ = eventsWriter.Write(append(data, '\n')) //nolint:errcheck // best-effort stream
+}
+
+// EmitPhase emits a phase event for an instance.
+func EmitPhase(instance, phase, msg string) {
+	EmitEvent(Event{Type: EventPhase, Instance: instance, Phase: phase, Message: msg})
+}
+
+// EmitResource emits a resource outcome event. A non-nil err sets the status
+// to failed.
+func EmitResource(instance, kind, namespace, name, status string, err error) {
+	e := Event{Type: EventResource, Instance: instance, Kind: kind, Namespace: namespace, Name: name, Status: status}
+	if err != nil {
+		e.Status = StatusFailed
+		e.Error = err.Error()
+	}
+	EmitEvent(e)
+}
+
+// EmitWarning emits a warning event for an instance.
+func EmitWarning(instance, msg string) {
+	EmitEvent(Event{Type: EventWarning, Instance: instance, Message: msg})
+}
+
+// EmitError emits an error event, unless err (or an error it wraps) was
+// already carried by an earlier error event — the command's final error is
+// usually one that Error logged on the way out.
+func EmitError(instance string, err error) {
+	if err == nil {
+		return
+	}
+	eventsMu.Lock()
+	for _, seen := range emittedErrors {
+		if errors.Is(err, seen) {
+			eventsMu.Unlock()
+			return
+		}
+	}
+	eventsMu.Unlock()
+	EmitEvent(Event{Type: EventError, Instance: instance, Error: err.Error()})
+}
+
+// recordEmittedError remembers an error carried by an error event so
+// EmitError does not report it twice.
+func recordEmittedError(err error) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if eventsWriter != nil {
+		emittedErrors = append(emittedErrors, err)
+	}
+}
+
+// eventKeyvals folds an "error"/"err" key-value into an event; the rest of
+// the key-values stay in the human log only.
+func eventKeyvals(e Event, keyvals []interface{}) Event {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && (k == "error" || k == "err") {
+			e.Error = fmt.Sprint(keyvals[i+1])
+			if err, isErr := keyvals[i+1].(error); isErr && e.Type == EventError {
+				recordEmittedError(err)
+			}
+		}
+	}
+	return e
+}
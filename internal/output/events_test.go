@@ -0,0 +1,138 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureEvents(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	SetEventWriter(&buf)
+	t.Cleanup(func() { SetEventWriter(nil) })
+	return &buf
+}
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []Event {
+	t.Helper()
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(line), &e), "line %q", line)
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestSetupEvents(t *testing.T) {
+	t.Cleanup(func() { SetEventWriter(nil) })
+	require.NoError(t, SetupEvents("", ""))
+	assert.Nil(t, eventsWriter)
+	require.NoError(t, SetupEvents("jsonl", ""))
+	assert.Equal(t, os.Stderr, eventsWriter, "without a file the stream takes stderr, leaving stdout to normal output")
+	err := SetupEvents("xml", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jsonl")
+}
+
+func TestSetupEvents_File(t *testing.T) {
+	t.Cleanup(func() { SetEventWriter(nil) })
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, SetupEvents("jsonl", path))
+
+	EmitPhase("demo", "apply", "start")
+	require.NoError(t, CloseEvents())
+	require.NoError(t, CloseEvents(), "closing twice is a no-op")
+	EmitPhase("demo", "apply", "done")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"message":"start"`)
+	assert.NotContains(t, string(data), `"message":"done"`, "the stream stops once the file is closed")
+}
+
+func TestEmitError_SkipsErrorAlreadyLogged(t *testing.T) {
+	buf := captureEvents(t)
+	var logBuf bytes.Buffer
+	SetLogWriter(&logBuf)
+
+	cause := errors.New("connection refused")
+	Error("connecting to cluster", "error", cause)
+	EmitError("", fmt.Errorf("apply failed: %w", cause))
+	EmitError("", errors.New("unrelated"))
+
+	events := decodeEvents(t, buf)
+	require.Len(t, events, 2, "the wrapped final error is not emitted a second time")
+	assert.Equal(t, "connection refused", events[0].Error)
+	assert.Equal(t, "unrelated", events[1].Error)
+}
+
+func TestEmitEvent_DisabledIsNoOp(t *testing.T) {
+	SetEventWriter(nil)
+	assert.NotPanics(t, func() { EmitPhase("demo", "apply", "start") })
+}
+
+func TestEmitEvent_JSONLines(t *testing.T) {
+	buf := captureEvents(t)
+
+	EmitPhase("demo", "apply", "applying 2 resource(s)")
+	EmitResource("demo", "Deployment", "apps", "web", StatusCreated, nil)
+	EmitResource("demo", "Service", "apps", "web", "", errors.New("forbidden"))
+	EmitError("demo", errors.New("boom"))
+
+	events := decodeEvents(t, buf)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, EventPhase, events[0].Type)
+	assert.Equal(t, "apply", events[0].Phase)
+	assert.False(t, events[0].Time.IsZero())
+
+	assert.Equal(t, EventResource, events[1].Type)
+	assert.Equal(t, "created", events[1].Status)
+	assert.Equal(t, "Deployment", events[1].Kind)
+
+	assert.Equal(t, StatusFailed, events[2].Status)
+	assert.Equal(t, "forbidden", events[2].Error)
+
+	assert.Equal(t, EventError, events[3].Type)
+	assert.Equal(t, "boom", events[3].Error)
+}
+
+func TestEmitEvent_StampsOperation(t *testing.T) {
+	buf := captureEvents(t)
+	SetOperation("op-1")
+	t.Cleanup(func() { SetOperation("") })
+
+	EmitPhase("demo", "apply", "start")
+	SetOperation("")
+	EmitPhase("demo", "apply", "done")
+
+	events := decodeEvents(t, buf)
+	require.Len(t, events, 2)
+	assert.Equal(t, "op-1", events[0].Operation)
+	assert.Empty(t, events[1].Operation)
+}
+
+func TestWarn_MirrorsToEventStream(t *testing.T) {
+	buf := captureEvents(t)
+	var logBuf bytes.Buffer
+	SetLogWriter(&logBuf)
+
+	Warn("pruning stale resources failed", "error", errors.New("timeout"), "count", 3)
+
+	events := decodeEvents(t, buf)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventWarning, events[0].Type)
+	assert.Equal(t, "pruning stale resources failed", events[0].Message)
+	assert.Equal(t, "timeout", events[0].Error)
+	assert.Contains(t, logBuf.String(), "pruning stale resources failed")
+}
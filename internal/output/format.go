@@ -21,6 +21,10 @@ const (
 
 	// FormatWide outputs as a wide table with additional columns (kubectl-style).
 	FormatWide Format = "wide"
+
+	// FormatPrometheus outputs gauges in the Prometheus text exposition
+	// format. Only instance status accepts it, so it is not part of Valid.
+	FormatPrometheus Format = "prometheus"
 )
 
 // Valid returns true if the format is valid.
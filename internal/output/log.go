@@ -14,6 +14,10 @@ type LogConfig struct {
 	// Verbose enables debug-level logging, timestamps, and caller info.
 	Verbose bool
 
+	// Quiet limits logging to errors: progress and warnings are dropped.
+	// Verbose wins when both are set.
+	Quiet bool
+
 	// Timestamps controls timestamp display. Nil means use default (true).
 	// When Verbose is true, timestamps are forced on regardless.
 	Timestamps *bool
@@ -21,7 +25,7 @@ type LogConfig struct {
 
 // Logger is the global logger instance.
 // Initialized with default options; call SetupLogging to configure.
-var logger = log.NewWithOptions(os.Stderr, log.Options{
+var logger = log.NewWithOptions(stderrWriter{}, log.Options{
 	ReportTimestamp: true,
 	ReportCaller:    false,
 	TimeFormat:      "15:04:05",
@@ -30,8 +34,11 @@ var logger = log.NewWithOptions(os.Stderr, log.Options{
 // SetupLogging configures the global logger based on the provided config.
 func SetupLogging(cfg LogConfig) {
 	level := log.InfoLevel
-	if cfg.Verbose {
+	switch {
+	case cfg.Verbose:
 		level = log.DebugLevel
+	case cfg.Quiet:
+		level = log.ErrorLevel
 	}
 
 	// Resolve timestamps: verbose forces on, otherwise flag/config/default(true).
@@ -43,12 +50,13 @@ func SetupLogging(cfg LogConfig) {
 		showTimestamps = true
 	}
 
-	logger = log.NewWithOptions(os.Stderr, log.Options{
+	logger = log.NewWithOptions(stderrWriter{}, log.Options{
 		Level:           level,
 		ReportTimestamp: showTimestamps,
 		ReportCaller:    cfg.Verbose,
 		TimeFormat:      "15:04:05",
 	})
+	applyLoggerColor()
 }
 
 // InstanceLogger returns a child logger scoped to a instance name.
@@ -74,14 +82,16 @@ func Info(msg string, keyvals ...interface{}) {
 	logger.Info(msg, keyvals...)
 }
 
-// Warn logs a warning message.
+// Warn logs a warning message and mirrors it to the event stream.
 func Warn(msg string, keyvals ...interface{}) {
 	logger.Warn(msg, keyvals...)
+	EmitEvent(eventKeyvals(Event{Type: EventWarning, Message: msg}, keyvals))
 }
 
-// Error logs an error message.
+// Error logs an error message and mirrors it to the event stream.
 func Error(msg string, keyvals ...interface{}) {
 	logger.Error(msg, keyvals...)
+	EmitEvent(eventKeyvals(Event{Type: EventError, Message: msg}, keyvals))
 }
 
 // Print prints a message to stdout without any formatting.
@@ -94,12 +104,34 @@ func Println(msg string) {
 	os.Stdout.WriteString(msg + "\n")
 }
 
+// Quiet reports whether the logger drops progress messages (--quiet, or a
+// machine output format implying it).
+func Quiet() bool {
+	return logger.GetLevel() > log.InfoLevel
+}
+
+// StdoutIsTerminal reports whether Print and Println write to a terminal,
+// for output that redraws in place.
+func StdoutIsTerminal() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
 // Details prints supplementary multi-line content to stderr.
 // Use for structured error details (e.g. CUE validation output)
 // that don't fit the key-value log format.
 func Details(msg string) {
-	fmt.Fprintln(os.Stderr)
-	fmt.Fprintln(os.Stderr, msg)
+	fmt.Fprintf(stderrWriter{}, "\n%s\n", msg)
+}
+
+// Report prints a one-line machine-readable report to stderr, such as a JSON
+// summary beside JSON manifests on stdout. Quiet drops it with the progress
+// messages.
+func Report(msg string) {
+	if Quiet() {
+		return
+	}
+	fmt.Fprintln(stderrWriter{}, msg)
 }
 
 // Prompt prints an interactive prompt to stderr (no newline).
@@ -122,4 +154,5 @@ func SetLogWriter(w io.Writer) {
 		ReportCaller:    false,
 		TimeFormat:      "15:04:05",
 	})
+	applyLoggerColor()
 }
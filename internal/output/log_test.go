@@ -2,11 +2,14 @@ package output
 
 import (
 	"bytes"
+	"io"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/charmbracelet/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // captureLog sets up the logger to write to a buffer and returns the buffer.
@@ -67,6 +70,22 @@ func TestSetupLogging_DefaultInfoLevel(t *testing.T) {
 	assert.Equal(t, log.InfoLevel, logger.GetLevel(), "default should be info level")
 }
 
+func TestSetupLogging_QuietLogsErrorsOnly(t *testing.T) {
+	buf := captureLog(LogConfig{Quiet: true})
+	Info("progress-msg")
+	Warn("warn-msg")
+	Error("error-msg")
+	out := buf.String()
+	assert.NotContains(t, out, "progress-msg")
+	assert.NotContains(t, out, "warn-msg")
+	assert.Contains(t, out, "error-msg")
+}
+
+func TestSetupLogging_VerboseWinsOverQuiet(t *testing.T) {
+	SetupLogging(LogConfig{Verbose: true, Quiet: true})
+	assert.Equal(t, log.DebugLevel, logger.GetLevel())
+}
+
 func TestInstanceLogger_HasPrefix(t *testing.T) {
 	SetupLogging(LogConfig{})
 	instanceLog := InstanceLogger("my-app")
@@ -82,6 +101,28 @@ func TestInstanceLogger_InheritsLevel(t *testing.T) {
 	assert.Equal(t, log.DebugLevel, instanceLog.GetLevel(), "instance logger should inherit debug level")
 }
 
+func TestReport_QuietDropsIt(t *testing.T) {
+	for _, quiet := range []bool{false, true} {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		stderr := os.Stderr
+		os.Stderr = w
+		SetupLogging(LogConfig{Quiet: quiet})
+		Report(`{"total":3}`)
+		os.Stderr = stderr
+		require.NoError(t, w.Close())
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		if quiet {
+			assert.Empty(t, string(out))
+		} else {
+			assert.Equal(t, "{\"total\":3}\n", string(out))
+		}
+	}
+	SetupLogging(LogConfig{})
+}
+
 func TestBoolPtr(t *testing.T) {
 	trueVal := BoolPtr(true)
 	falseVal := BoolPtr(false)
@@ -24,6 +24,9 @@ func WriteManifests(resources []*unstructured.Unstructured, opts ManifestOptions
 
 	switch opts.Format {
 	case FormatJSON:
+		if opts.List {
+			return writeJSONList(resources, opts.Writer)
+		}
 		return writeJSON(resources, opts.Writer)
 	case FormatYAML:
 		return writeYAML(resources, opts.Writer)
@@ -39,6 +42,9 @@ type ManifestOptions struct {
 	Format Format
 	// Writer is the output destination
 	Writer io.Writer
+	// List wraps JSON output in a v1 List object instead of a bare array,
+	// so the output can be piped straight to kubectl. Ignored for YAML.
+	List bool
 }
 
 // sortResources sorts resources by weight, then by namespace, then by name.
@@ -87,19 +93,45 @@ func writeYAML(resources []*unstructured.Unstructured, w io.Writer) error {
 
 // writeJSON writes resources as a JSON array.
 func writeJSON(resources []*unstructured.Unstructured, w io.Writer) error {
+	objects, err := rawObjects(resources)
+	if err != nil {
+		return err
+	}
+	return encodeIndentedJSON(objects, w)
+}
+
+// writeJSONList writes resources as the items of a v1 List.
+func writeJSONList(resources []*unstructured.Unstructured, w io.Writer) error {
+	objects, err := rawObjects(resources)
+	if err != nil {
+		return err
+	}
+	list := struct {
+		APIVersion string            `json:"apiVersion"`
+		Kind       string            `json:"kind"`
+		Items      []json.RawMessage `json:"items"`
+	}{APIVersion: "v1", Kind: "List", Items: objects}
+	return encodeIndentedJSON(list, w)
+}
+
+// rawObjects marshals each resource to JSON, preserving order.
+func rawObjects(resources []*unstructured.Unstructured) ([]json.RawMessage, error) {
 	objects := make([]json.RawMessage, len(resources))
 	for i, res := range resources {
 		j, err := json.Marshal(res.Object)
 		if err != nil {
-			return fmt.Errorf("encoding resource %s/%s: %w", res.GetKind(), res.GetName(), err)
+			return nil, fmt.Errorf("encoding resource %s/%s: %w", res.GetKind(), res.GetName(), err)
 		}
 		objects[i] = j
 	}
+	return objects, nil
+}
 
+func encodeIndentedJSON(v any, w io.Writer) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(objects); err != nil {
+	if err := encoder.Encode(v); err != nil {
 		return fmt.Errorf("encoding JSON: %w", err)
 	}
 
@@ -111,8 +143,8 @@ func marshalYAML(res *unstructured.Unstructured) ([]byte, error) {
 	return yaml.Marshal(res.Object)
 }
 
-// writeResource writes a single resource to the writer.
-func writeResource(res *unstructured.Unstructured, format Format, w io.Writer) error {
+// WriteResource writes a single resource to w as YAML or JSON.
+func WriteResource(res *unstructured.Unstructured, format Format, w io.Writer) error {
 	switch format {
 	case FormatJSON:
 		j, err := json.Marshal(res.Object)
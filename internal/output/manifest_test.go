@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestWriteManifests_JSON(t *testing.T) {
+	resources := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			dirResource("Service", "media", "web", ""),
+			dirResource("Namespace", "", "media", ""),
+		}
+	}
+
+	var array bytes.Buffer
+	require.NoError(t, WriteManifests(resources(), ManifestOptions{Format: FormatJSON, Writer: &array}))
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(array.Bytes(), &items))
+	require.Len(t, items, 2)
+	assert.Equal(t, "Namespace", items[0]["kind"], "weight ordering applies to JSON too")
+
+	var list bytes.Buffer
+	require.NoError(t, WriteManifests(resources(), ManifestOptions{Format: FormatJSON, Writer: &list, List: true}))
+	var wrapped struct {
+		APIVersion string           `json:"apiVersion"`
+		Kind       string           `json:"kind"`
+		Items      []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(list.Bytes(), &wrapped))
+	assert.Equal(t, "v1", wrapped.APIVersion)
+	assert.Equal(t, "List", wrapped.Kind)
+	assert.Equal(t, items, wrapped.Items, "the List wraps the same items in the same order")
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// progressBarWidth is the number of cells in a progress bar.
+const progressBarWidth = 24
+
+var (
+	// progressMu serializes stderr writes with the active progress bar, so a
+	// log line never lands in the middle of the bar.
+	progressMu     sync.Mutex
+	activeProgress *ProgressBar
+)
+
+// ProgressBar reports a count of completed steps on stderr. On a terminal it
+// is one line redrawn in place, which log output scrolls above; elsewhere each
+// step is a plain line.
+type ProgressBar struct {
+	verb string
+	tty  bool
+	line string
+}
+
+// NewProgressBar starts a progress bar whose steps read "<verb> N/total".
+// Finish must be called once the work is done.
+func NewProgressBar(verb string) *ProgressBar {
+	fi, err := os.Stderr.Stat()
+	p := &ProgressBar{verb: verb, tty: err == nil && fi.Mode()&os.ModeCharDevice != 0}
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	activeProgress = p
+	return p
+}
+
+// Update reports done of total steps complete, the latest being item.
+func (p *ProgressBar) Update(done, total int, item string) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if !p.tty {
+		fmt.Fprintf(os.Stderr, "%s %d/%d (%s)\n", p.verb, done, total, item)
+		return
+	}
+	p.clear()
+	p.line = ""
+	if done >= total {
+		// Complete: the bar goes, and the results logged next take its place.
+		return
+	}
+	p.line = renderProgress(p.verb, done, total, item)
+	os.Stderr.WriteString(p.line)
+}
+
+// Finish clears the bar from the terminal.
+func (p *ProgressBar) Finish() {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p.clear()
+	p.line = ""
+	if activeProgress == p {
+		activeProgress = nil
+	}
+}
+
+// clear erases the drawn bar, leaving the cursor at the start of its line.
+func (p *ProgressBar) clear() {
+	if p.tty && p.line != "" {
+		os.Stderr.WriteString("\r\x1b[K")
+	}
+}
+
+// renderProgress draws one progress line: the bar, the count, and the item.
+func renderProgress(verb string, done, total int, item string) string {
+	filled := 0
+	if total > 0 {
+		filled = min(progressBarWidth*done/total, progressBarWidth)
+	}
+	bar := lipgloss.NewStyle().Foreground(colorGreenCheck).Render(strings.Repeat("█", filled)) +
+		styleDim.Render(strings.Repeat("░", progressBarWidth-filled))
+	return fmt.Sprintf("%s %s %d/%d %s", bar, verb, done, total, item)
+}
+
+// stderrWriter writes to stderr around the active progress bar: it clears
+// the bar, writes, and redraws it below the new output.
+type stderrWriter struct{}
+
+func (stderrWriter) Write(b []byte) (int, error) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p := activeProgress
+	if p != nil {
+		p.clear()
+	}
+	n, err := os.Stderr.Write(b)
+	if p != nil && p.tty && p.line != "" {
+		os.Stderr.WriteString(p.line)
+	}
+	return n, err
+}
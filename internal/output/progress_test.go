@@ -0,0 +1,17 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderProgress(t *testing.T) {
+	require.NoError(t, SetColorMode(ColorNever))
+
+	line := renderProgress("applied", 6, 24, "Deployment/web")
+	assert.Equal(t, "██████"+"░░░░░░░░░░░░░░░░░░"+" applied 6/24 Deployment/web", line)
+
+	assert.Contains(t, renderProgress("applied", 0, 0, "x"), "░░░░", "an empty apply draws an empty bar")
+}
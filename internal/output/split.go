@@ -1,12 +1,17 @@
 package output
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
 )
 
 // SplitOptions controls split file output.
@@ -95,5 +100,168 @@ func writeResourceFile(res *unstructured.Unstructured, destPath string, format F
 	}
 	defer f.Close()
 
-	return writeResource(res, format, f)
+	return WriteResource(res, format, f)
+}
+
+// DirOptions controls per-component directory output (--split-layout component).
+type DirOptions struct {
+	// Dir is the root output directory.
+	Dir string
+	// Format specifies output format: "yaml" or "json".
+	Format Format
+	// Force allows writing into a directory that holds files this build
+	// would not produce.
+	Force bool
+}
+
+// WriteManifestDir writes each resource to its own file under opts.Dir, in a
+// subdirectory per component. Files are named <lowercase-kind>-<name>.<ext>.
+// When two resources in the same component share kind and name, the file
+// names of both add what tells them apart: the namespace
+// (<kind>-<namespace>-<name>), and when that is not enough the API group
+// (<kind>.<group>-...). Resources that still map to the same file are an
+// error rather than one overwriting the other. Resources without a component
+// label are written to the root of the directory.
+//
+// Pre-existing files the build would not produce are an error unless
+// opts.Force is set, so a stale file from a removed resource cannot linger
+// unnoticed in a committed render. Files the build does produce are
+// overwritten. It returns the written paths, relative to opts.Dir.
+func WriteManifestDir(resources []*unstructured.Unstructured, opts DirOptions) ([]string, error) {
+	paths, err := dirLayout(resources, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.Force {
+		unexpected, err := unexpectedFiles(opts.Dir, paths)
+		if err != nil {
+			return nil, err
+		}
+		if len(unexpected) > 0 {
+			return nil, fmt.Errorf("output directory %s contains files this build would not write: %s (use --force to write anyway)",
+				opts.Dir, strings.Join(unexpected, ", "))
+		}
+	}
+
+	written := make([]string, 0, len(resources))
+	for i, res := range resources {
+		dest := filepath.Join(opts.Dir, paths[i])
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return nil, fmt.Errorf("creating output directory: %w", err)
+		}
+		if err := writeResourceFile(res, dest, opts.Format); err != nil {
+			return nil, fmt.Errorf("writing %s: %w", dest, err)
+		}
+		Debug("wrote resource file",
+			"kind", res.GetKind(),
+			"name", res.GetName(),
+			"file", dest,
+		)
+		written = append(written, paths[i])
+	}
+	return written, nil
+}
+
+// dirLayout computes the relative path for each resource, index-aligned with
+// resources. Two resources that would share a path are an error.
+func dirLayout(resources []*unstructured.Unstructured, format Format) ([]string, error) {
+	ext := ".yaml"
+	if format == FormatJSON {
+		ext = ".json"
+	}
+
+	// Count per component first, so every member of a collision gets the
+	// disambiguating part — not just the second one seen. Kind+name across
+	// several namespaces adds the namespace; kind+namespace+name seen more
+	// than once (different groups) adds the group.
+	component := func(res *unstructured.Unstructured) string {
+		return res.GetLabels()[pkgcore.LabelComponentName]
+	}
+	byName := func(res *unstructured.Unstructured) string {
+		return component(res) + "/" + strings.ToLower(res.GetKind()) + "/" + res.GetName()
+	}
+	byNamespace := func(res *unstructured.Unstructured) string {
+		return byName(res) + "/" + res.GetNamespace()
+	}
+	namespaces := make(map[string]map[string]bool, len(resources))
+	namespaceCounts := make(map[string]int, len(resources))
+	for _, res := range resources {
+		if namespaces[byName(res)] == nil {
+			namespaces[byName(res)] = make(map[string]bool)
+		}
+		namespaces[byName(res)][res.GetNamespace()] = true
+		namespaceCounts[byNamespace(res)]++
+	}
+
+	paths := make([]string, len(resources))
+	owner := make(map[string]*unstructured.Unstructured, len(resources))
+	for i, res := range resources {
+		base := strings.ToLower(res.GetKind())
+		if group := res.GroupVersionKind().Group; namespaceCounts[byNamespace(res)] > 1 && group != "" {
+			base += "." + sanitizeName(group)
+		}
+		if len(namespaces[byName(res)]) > 1 && res.GetNamespace() != "" {
+			base += "-" + sanitizeName(res.GetNamespace())
+		}
+		base += "-" + sanitizeName(res.GetName())
+
+		if c := component(res); c != "" {
+			paths[i] = filepath.Join(sanitizeName(c), base+ext)
+		} else {
+			paths[i] = base + ext
+		}
+
+		if prev, ok := owner[paths[i]]; ok {
+			return nil, fmt.Errorf("%s and %s would both be written to %s",
+				describeResource(prev), describeResource(res), paths[i])
+		}
+		owner[paths[i]] = res
+	}
+	return paths, nil
+}
+
+// describeResource names a resource for error messages: apiVersion, kind,
+// and namespace/name.
+func describeResource(res *unstructured.Unstructured) string {
+	name := res.GetName()
+	if ns := res.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+	return res.GetAPIVersion() + " " + res.GetKind() + " " + name
+}
+
+// unexpectedFiles lists files under dir (relative, sorted) that are not in
+// want. A missing dir has none.
+func unexpectedFiles(dir string, want []string) ([]string, error) {
+	expected := make(map[string]bool, len(want))
+	for _, p := range want {
+		expected[p] = true
+	}
+
+	var unexpected []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return relErr
+		}
+		if !expected[rel] {
+			unexpected = append(unexpected, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading output directory: %w", err)
+	}
+	sort.Strings(unexpected)
+	return unexpected, nil
 }
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func dirResource(kind, namespace, name, component string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name},
+	}}
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	if component != "" {
+		obj.SetLabels(map[string]string{pkgcore.LabelComponentName: component})
+	}
+	return obj
+}
+
+func TestWriteManifestDir_Layout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "rendered")
+	resources := []*unstructured.Unstructured{
+		dirResource("Deployment", "media", "web", "web"),
+		dirResource("Service", "media", "web", "web"),
+		dirResource("ConfigMap", "media", "settings", "web"),
+		dirResource("ConfigMap", "other", "settings", "web"),
+		dirResource("Namespace", "", "media", ""),
+	}
+
+	written, err := WriteManifestDir(resources, DirOptions{Dir: dir, Format: FormatYAML})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("web", "deployment-web.yaml"),
+		filepath.Join("web", "service-web.yaml"),
+		filepath.Join("web", "configmap-media-settings.yaml"),
+		filepath.Join("web", "configmap-other-settings.yaml"),
+		"namespace-media.yaml",
+	}, written)
+
+	data, err := os.ReadFile(filepath.Join(dir, "web", "deployment-web.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "kind: Deployment")
+}
+
+func TestWriteManifestDir_JSONExtension(t *testing.T) {
+	dir := t.TempDir()
+	written, err := WriteManifestDir([]*unstructured.Unstructured{dirResource("Service", "media", "web", "")},
+		DirOptions{Dir: dir, Format: FormatJSON})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-web.json"}, written)
+}
+
+func TestWriteManifestDir_PreExistingFiles(t *testing.T) {
+	resources := []*unstructured.Unstructured{dirResource("Service", "media", "web", "web")}
+
+	tests := []struct {
+		name    string
+		present string
+		force   bool
+		wantErr string
+	}{
+		{name: "overwrites a file it produces", present: filepath.Join("web", "service-web.yaml")},
+		{name: "rejects a stale file", present: filepath.Join("web", "deployment-old.yaml"), wantErr: "deployment-old.yaml"},
+		{name: "force writes past a stale file", present: "notes.txt", force: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(tt.present)), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, tt.present), []byte("old"), 0o644))
+
+			_, err := WriteManifestDir(resources, DirOptions{Dir: dir, Format: FormatYAML, Force: tt.force})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Contains(t, err.Error(), "--force")
+				assert.NoFileExists(t, filepath.Join(dir, "web", "service-web.yaml"), "nothing is written on refusal")
+				return
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(dir, "web", "service-web.yaml"))
+		})
+	}
+}
+
+func TestWriteManifestDir_GroupCollision(t *testing.T) {
+	certManager := dirResource("Certificate", "media", "web", "web")
+	certManager.SetAPIVersion("cert-manager.io/v1")
+	other := dirResource("Certificate", "media", "web", "web")
+	other.SetAPIVersion("certs.example.com/v1")
+
+	written, err := WriteManifestDir([]*unstructured.Unstructured{certManager, other},
+		DirOptions{Dir: t.TempDir(), Format: FormatYAML})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("web", "certificate.cert-manager.io-web.yaml"),
+		filepath.Join("web", "certificate.certs.example.com-web.yaml"),
+	}, written)
+}
+
+func TestWriteManifestDir_UnresolvableCollision(t *testing.T) {
+	// Names sanitize to the same file: "a/b" and "a-b".
+	dir := t.TempDir()
+	_, err := WriteManifestDir([]*unstructured.Unstructured{
+		dirResource("ConfigMap", "media", "a/b", "web"),
+		dirResource("ConfigMap", "media", "a-b", "web"),
+	}, DirOptions{Dir: dir, Format: FormatYAML})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "v1 ConfigMap media/a/b and v1 ConfigMap media/a-b")
+	assert.NoFileExists(t, filepath.Join(dir, "web", "configmap-a-b.yaml"))
+}
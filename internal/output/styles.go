@@ -54,25 +54,38 @@ const (
 	StatusConfigured = "configured"
 	StatusUnchanged  = "unchanged"
 	StatusDeleted    = "deleted"
+	StatusAdopted    = "adopted"
 	StatusValid      = "valid"
-	statusFailed     = "failed"
+	StatusFailed     = "failed"
+
+	// StatusProtected marks a stale resource left in place because it carries
+	// a protective finalizer.
+	StatusProtected = "skipped (protected)"
+
+	// StatusNotPruned marks a stale resource left in place because pruning
+	// is turned off for the apply.
+	StatusNotPruned = "skipped (pruning off)"
+
+	// StatusOrphaned marks a resource released from its instance by an
+	// orphaning delete and left running.
+	StatusOrphaned = "orphaned"
 )
 
 // StatusStyle returns the lipgloss style for a given resource status string.
 // Unknown statuses return an unstyled default.
 func statusStyle(status string) lipgloss.Style {
 	switch status {
-	case StatusCreated:
+	case StatusCreated, StatusAdopted:
 		return lipgloss.NewStyle().Foreground(colorGreen)
 	case StatusValid:
 		return lipgloss.NewStyle().Foreground(colorGreen)
-	case StatusConfigured:
+	case StatusConfigured, StatusProtected, StatusNotPruned, StatusOrphaned:
 		return lipgloss.NewStyle().Foreground(ColorYellow)
 	case StatusUnchanged:
 		return lipgloss.NewStyle().Faint(true)
 	case StatusDeleted:
 		return lipgloss.NewStyle().Foreground(colorRed)
-	case statusFailed:
+	case StatusFailed:
 		return lipgloss.NewStyle().Bold(true).Foreground(colorBoldRed)
 	default:
 		return lipgloss.NewStyle()
@@ -87,7 +100,7 @@ func statusIcon(status string) string {
 	switch status {
 	case StatusValid:
 		return "✓"
-	case StatusCreated:
+	case StatusCreated, StatusAdopted:
 		return "+"
 	case StatusConfigured:
 		return "~"
@@ -95,7 +108,7 @@ func statusIcon(status string) string {
 		return "="
 	case StatusDeleted:
 		return "-"
-	case statusFailed:
+	case StatusFailed:
 		return "!"
 	default:
 		return " "
@@ -257,14 +270,14 @@ func StyleNoun(s string) string {
 }
 
 // FormatHealthStatus renders a health status string with the appropriate color.
-// Ready/Complete/Bound → green, NotReady/Missing → red, Unknown/Pending/Lost → yellow, others → unstyled.
+// Ready/Complete/Bound → green, NotReady/Missing → red, Unknown/Pending/Lost/Terminating → yellow, others → unstyled.
 func FormatHealthStatus(status string) string {
 	switch status {
 	case "Ready", "Complete", "Bound":
 		return lipgloss.NewStyle().Foreground(colorGreen).Render(status)
 	case "NotReady", "Missing":
 		return lipgloss.NewStyle().Foreground(colorRed).Render(status)
-	case "Unknown", "Pending", "Lost":
+	case "Unknown", "Pending", "Lost", "Terminating":
 		return lipgloss.NewStyle().Foreground(ColorYellow).Render(status)
 	default:
 		return status
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// templateFormatPrefix introduces a template in an --output value:
+// "template=<file>".
+const templateFormatPrefix = "template="
+
+// ParseTemplateFormat reports whether an --output value selects template
+// output, and returns the template file path. "template=" with no path is an
+// error.
+func ParseTemplateFormat(value string) (path string, ok bool, err error) {
+	if !strings.HasPrefix(value, templateFormatPrefix) {
+		return "", false, nil
+	}
+	path = strings.TrimPrefix(value, templateFormatPrefix)
+	if path == "" {
+		return "", true, fmt.Errorf("--output template= needs a template file, e.g. -o template=report.tmpl")
+	}
+	return path, true, nil
+}
+
+// templateFuncs are the functions available to output templates beyond the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"toYaml": func(v any) (string, error) {
+		b, err := yaml.Marshal(v)
+		return strings.TrimSuffix(string(b), "\n"), err
+	},
+	"toJson": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"indent": func(n int, s string) string {
+		pad := strings.Repeat(" ", n)
+		return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+}
+
+// LoadManifestTemplate parses a Go text/template file for manifest output.
+func LoadManifestTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// WriteTemplate renders resources through tmpl, in the same order as
+// WriteManifests. By default the template runs once over the whole set, as a
+// v1 List (.items holds the resources, like kubectl -o go-template); with
+// perResource it runs once per resource, with the resource as its data.
+func WriteTemplate(resources []*unstructured.Unstructured, tmpl *template.Template, perResource bool, w io.Writer) error {
+	sortResources(resources)
+
+	if perResource {
+		for _, res := range resources {
+			if err := tmpl.Execute(w, res.Object); err != nil {
+				return fmt.Errorf("executing template for %s/%s: %w", res.GetKind(), res.GetName(), err)
+			}
+		}
+		return nil
+	}
+
+	items := make([]any, len(resources))
+	for i, res := range resources {
+		items[i] = res.Object
+	}
+	list := map[string]any{"apiVersion": "v1", "kind": "List", "items": items}
+	if err := tmpl.Execute(w, list); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	return nil
+}
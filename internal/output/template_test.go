@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseTemplateFormat(t *testing.T) {
+	path, ok, err := ParseTemplateFormat("template=report.tmpl")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "report.tmpl", path)
+
+	_, ok, err = ParseTemplateFormat("yaml")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = ParseTemplateFormat("template=")
+	require.Error(t, err)
+}
+
+func writeTemplateFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "out.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestWriteTemplate(t *testing.T) {
+	resources := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			dirResource("Service", "media", "web", ""),
+			dirResource("Namespace", "", "media", ""),
+		}
+	}
+
+	set, err := LoadManifestTemplate(writeTemplateFile(t,
+		`{{ .kind }}:{{ range .items }} {{ .kind }}/{{ .metadata.name }}{{ end }}`+"\n"))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WriteTemplate(resources(), set, false, &buf))
+	assert.Equal(t, "List: Namespace/media Service/web\n", buf.String())
+
+	each, err := LoadManifestTemplate(writeTemplateFile(t,
+		`{{ .metadata.name }} {{ toJson .metadata }}`+"\n"))
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteTemplate(resources(), each, true, &buf))
+	assert.Equal(t, "media {\"name\":\"media\"}\nweb {\"name\":\"web\",\"namespace\":\"media\"}\n", buf.String())
+}
+
+func TestLoadManifestTemplate_ParseError(t *testing.T) {
+	_, err := LoadManifestTemplate(writeTemplateFile(t, "{{ .kind "))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing template")
+}
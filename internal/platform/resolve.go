@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/open-platform-model/library/opm/helper/synth"
 
@@ -58,8 +59,9 @@ type ClusterSpecGetter func(ctx context.Context) (spec map[string]any, name stri
 
 // ResolveOptions selects the platform sources for one command invocation.
 type ResolveOptions struct {
-	// PlatformFlag is the --platform flag value (highest precedence).
-	PlatformFlag string
+	// PlatformFiles are the --platform flag values (highest precedence): a
+	// base platform file, then overlays; see DecodeFiles.
+	PlatformFiles []string
 	// ConfigPath is the resolved config file path; the local default
 	// platform file is its sibling platform.cue.
 	ConfigPath string
@@ -73,12 +75,12 @@ type ResolveOptions struct {
 // I/O stays a separate, caller-driven step — see Materialize).
 func Resolve(ctx context.Context, opts ResolveOptions) (synth.PlatformInput, Resolution, error) {
 	// 1. Explicit local override.
-	if opts.PlatformFlag != "" {
-		in, err := DecodeFile(opts.PlatformFlag)
+	if len(opts.PlatformFiles) > 0 {
+		in, err := DecodeFiles(opts.PlatformFiles)
 		if err != nil {
 			return synth.PlatformInput{}, Resolution{}, err
 		}
-		return in, Resolution{Source: SourceFlagFile, Location: opts.PlatformFlag}, nil
+		return in, Resolution{Source: SourceFlagFile, Location: strings.Join(opts.PlatformFiles, " + ")}, nil
 	}
 
 	// 2. Cluster Platform CR (cluster-facing commands only).
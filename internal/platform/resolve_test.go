@@ -47,9 +47,9 @@ type: "kubernetes"
 	}
 
 	in, res, err := Resolve(context.Background(), ResolveOptions{
-		PlatformFlag: flagFile,
-		ConfigPath:   configPath,
-		Cluster:      getter,
+		PlatformFiles: []string{flagFile},
+		ConfigPath:    configPath,
+		Cluster:       getter,
 	})
 	require.NoError(t, err)
 	assert.Equal(t, "override", in.Name)
@@ -80,6 +80,41 @@ func DecodeFile(path string) (synth.PlatformInput, error) {
 	return w.toInput(), nil
 }
 
+// DecodeFiles decodes a base platform file followed by overlays, in order,
+// into one platform input. The base names the platform; every file must
+// declare the same type. Catalog subscriptions are merged: an overlay adds
+// catalogs, and where it subscribes a catalog an earlier file already does,
+// the overlay's subscription replaces it. Later files therefore take
+// precedence, so a base Kubernetes platform plus an organization overlay
+// renders with both catalogs' transformers.
+func DecodeFiles(paths []string) (synth.PlatformInput, error) {
+	if len(paths) == 0 {
+		return synth.PlatformInput{}, fmt.Errorf("no platform file given")
+	}
+	merged, err := DecodeFile(paths[0])
+	if err != nil {
+		return synth.PlatformInput{}, err
+	}
+	for _, path := range paths[1:] {
+		overlay, err := DecodeFile(path)
+		if err != nil {
+			return synth.PlatformInput{}, err
+		}
+		if overlay.Type != merged.Type {
+			return synth.PlatformInput{}, fmt.Errorf(
+				"platform overlay %s has type %q, but %s has type %q: overlays must target the same platform type",
+				path, overlay.Type, paths[0], merged.Type)
+		}
+		if len(overlay.Subscriptions) > 0 && merged.Subscriptions == nil {
+			merged.Subscriptions = make(map[string]synth.SubscriptionSpec, len(overlay.Subscriptions))
+		}
+		for catalog, sub := range overlay.Subscriptions {
+			merged.Subscriptions[catalog] = sub
+		}
+	}
+	return merged, nil
+}
+
 // DecodeCRSpec decodes a cluster Platform CR's spec (as an unstructured map)
 // into a synth.PlatformInput. name is the CR's metadata.name.
 //
@@ -76,6 +76,39 @@ func TestDecodeFile_InvalidFileRejected(t *testing.T) {
 	require.Error(t, err, "missing required name must fail schema validation")
 }
 
+func TestDecodeFiles_OverlayMergesSubscriptions(t *testing.T) {
+	base := writePlatformFile(t, config.DefaultPlatformTemplate)
+	overlay := writePlatformFile(t, `name: "acme"
+type: "kubernetes"
+registry: {
+	"acme.example.com/catalogs/acme": {}
+	"opmodel.dev/catalogs/kubernetes": filter: range: ">=1.4.0 <2.0.0"
+}
+`)
+
+	in, err := DecodeFiles([]string{base, overlay})
+	require.NoError(t, err)
+
+	assert.Equal(t, "cluster", in.Name, "the base names the platform")
+	require.Len(t, in.Subscriptions, 3)
+	assert.Contains(t, in.Subscriptions, "opmodel.dev/catalogs/opm")
+	assert.Contains(t, in.Subscriptions, "acme.example.com/catalogs/acme")
+	assert.Equal(t, ">=1.4.0 <2.0.0", in.Subscriptions["opmodel.dev/catalogs/kubernetes"].Filter.Range,
+		"the later file's subscription wins")
+}
+
+func TestDecodeFiles_RejectsTypeMismatch(t *testing.T) {
+	base := writePlatformFile(t, config.DefaultPlatformTemplate)
+	overlay := writePlatformFile(t, `name: "edge"
+type: "nomad"
+`)
+
+	_, err := DecodeFiles([]string{base, overlay})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), overlay)
+	assert.Contains(t, err.Error(), `"nomad"`)
+}
+
 func TestDecodeCRSpec_RoundTripsWireShape(t *testing.T) {
 	// The CR spec is the same wire shape the file uses.
 	spec := map[string]any{
@@ -0,0 +1,245 @@
+// Package adopt implements `opm instance adopt`: bringing pre-existing
+// (brownfield) resources under an instance's management without re-creating
+// them. Matching resources are labeled with the instance identity and recorded
+// in the ModuleInstance inventory; nothing is rendered or applied.
+package adopt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+	pkginventory "github.com/open-platform-model/cli/pkg/inventory"
+)
+
+// Request is one adopt invocation.
+type Request struct {
+	// Name and Namespace identify the ModuleInstance adopting the resources.
+	Name      string
+	Namespace string
+
+	// Selector is the label selector resources must match. Required: an empty
+	// selector would sweep up every resource of the listed kinds.
+	Selector string
+
+	// Kinds are the resource types to search (Kind, plural, or Kind.group).
+	Kinds []string
+
+	// DryRun lists what would be adopted without labeling or recording it.
+	DryRun bool
+
+	K8sClient *kubernetes.Client
+	Log       *log.Logger
+}
+
+// Result summarizes an adopt run.
+type Result struct {
+	// Adopted are the entries newly recorded in the inventory (or, on a dry
+	// run, that would be).
+	Adopted []inventory.InventoryEntry
+	// Skipped counts matches left alone: already tracked, or owned by another
+	// instance.
+	Skipped int
+}
+
+// Execute lists the matching resources, labels them with the instance
+// identity, and appends them to the instance's inventory.
+func Execute(ctx context.Context, req Request) (*Result, error) {
+	if req.Selector == "" {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("--selector is required")}
+	}
+	selector, err := labels.Parse(req.Selector)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("invalid --selector: %w", err)}
+	}
+	if len(req.Kinds) == 0 {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("at least one --kind is required")}
+	}
+
+	rec, err := inventory.GetRecord(ctx, req.K8sClient, req.Name, req.Namespace)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("reading ModuleInstance %q: %w", req.Name, err)}
+	}
+	if rec == nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: fmt.Errorf(
+			"instance %q not found in namespace %q — apply it before adopting resources", req.Name, req.Namespace)}
+	}
+	if inventory.ResolveOwnership(rec) == inventory.ModeOperatorOwned {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf(
+			"instance %q is operator-managed; the operator owns its inventory", req.Name)}
+	}
+
+	candidates, err := listCandidates(ctx, req, selector.String())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var toAdopt []candidate
+	for _, c := range candidates {
+		obj := c.obj
+		entry := inventory.NewEntryFromResource(obj)
+		if tracked(rec.Inventory.Entries, entry) {
+			result.Skipped++
+			continue
+		}
+		if owner, owned := ownedByOtherInstance(obj, req.Name, req.Namespace); owned {
+			req.Log.Warn(fmt.Sprintf("skipping %s/%s: already managed by instance %q", entry.Kind, entry.Name, owner))
+			result.Skipped++
+			continue
+		}
+		toAdopt = append(toAdopt, c)
+		result.Adopted = append(result.Adopted, entry)
+	}
+
+	if req.DryRun {
+		for _, e := range result.Adopted {
+			req.Log.Info(output.FormatResourceLine(e.Kind, e.Namespace, e.Name, output.StatusAdopted) + " (dry run)")
+		}
+		return result, nil
+	}
+	if len(toAdopt) == 0 {
+		return result, nil
+	}
+
+	for _, c := range toAdopt {
+		obj := c.obj
+		if err := stampLabels(ctx, req.K8sClient, c.gvr, obj, req.Name, req.Namespace, rec.InstanceUUID); err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf(
+				"labeling %s/%s: %w", obj.GetKind(), obj.GetName(), err)}
+		}
+		req.Log.Info(output.FormatResourceLine(obj.GetKind(), obj.GetNamespace(), obj.GetName(), output.StatusAdopted))
+	}
+
+	if err := recordAdopted(ctx, req.K8sClient, rec, result.Adopted); err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	return result, nil
+}
+
+// candidate is a listed resource together with the resolved GVR it was
+// listed under, so the label patch does not have to guess the plural.
+type candidate struct {
+	obj *unstructured.Unstructured
+	gvr schema.GroupVersionResource
+}
+
+// listCandidates lists every resource of the requested kinds that matches the
+// selector: namespaced kinds in the instance namespace, cluster-scoped kinds
+// cluster-wide.
+func listCandidates(ctx context.Context, req Request, selector string) ([]candidate, error) {
+	var out []candidate
+	for _, k := range req.Kinds {
+		rk, err := kubernetes.ResolveKind(req.K8sClient, k)
+		if err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
+		ns := ""
+		if rk.Namespaced {
+			ns = req.Namespace
+		}
+		list, err := req.K8sClient.ResourceClient(rk.GVR, ns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("listing %s: %w", rk.GVR.Resource, err)}
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			// Lists of core-group kinds come back without a per-item GVK on
+			// some clients; the inventory entry needs it.
+			obj.SetGroupVersionKind(rk.GVK)
+			out = append(out, candidate{obj: obj, gvr: rk.GVR})
+		}
+	}
+	return out, nil
+}
+
+// tracked reports whether the entry is already in the inventory.
+func tracked(entries []inventory.InventoryEntry, e inventory.InventoryEntry) bool {
+	for _, existing := range entries {
+		if inventory.K8sIdentityEqual(existing, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedByOtherInstance reports whether the resource carries another OPM
+// instance's identity labels.
+func ownedByOtherInstance(obj *unstructured.Unstructured, name, namespace string) (string, bool) {
+	l := obj.GetLabels()
+	owner := l[pkgcore.LabelModuleInstanceName]
+	if owner == "" || !pkgcore.IsOPMManagedBy(l[pkgcore.LabelManagedBy]) {
+		return "", false
+	}
+	if owner == name && l[pkgcore.LabelModuleInstanceNamespace] == namespace {
+		return "", false
+	}
+	return owner, true
+}
+
+// stampLabels merge-patches the instance identity labels onto a resource. A
+// merge patch touches only metadata.labels, so adoption does not take SSA
+// ownership of the resource's spec — the next apply does that.
+func stampLabels(ctx context.Context, client *kubernetes.Client, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, name, namespace, uuid string) error {
+	l := map[string]string{
+		pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+		pkgcore.LabelModuleInstanceName:      name,
+		pkgcore.LabelModuleInstanceNamespace: namespace,
+	}
+	if uuid != "" {
+		l[pkgcore.LabelModuleInstanceUUID] = uuid
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"labels": l}})
+	if err != nil {
+		return err
+	}
+	_, err = client.ResourceClient(gvr, obj.GetNamespace()).
+		Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// recordAdopted appends the adopted entries to the inventory as a new
+// revision. The CLI-owned status subset is restated in full: omitting a field
+// from the server-side apply would delete it.
+func recordAdopted(ctx context.Context, client *kubernetes.Client, rec *inventory.Record, adopted []inventory.InventoryEntry) error {
+	entries := make([]inventory.InventoryEntry, 0, len(rec.Inventory.Entries)+len(adopted))
+	entries = append(entries, rec.Inventory.Entries...)
+	entries = append(entries, adopted...)
+
+	return inventory.ApplyStatus(ctx, client, inventory.StatusInput{
+		Name:      rec.Name,
+		Namespace: rec.Namespace,
+		Inventory: pkginventory.Inventory{
+			Revision: rec.Inventory.Revision + 1,
+			Digest:   inventory.ComputeDigest(entries),
+			Count:    len(entries),
+			Entries:  entries,
+		},
+		InstanceUUID:            rec.InstanceUUID,
+		LastAppliedRenderDigest: rec.LastAppliedRenderDigest,
+		LastAppliedSourceDigest: rec.LastAppliedSourceDigest,
+		LastAppliedConfigDigest: rec.LastAppliedConfigDigest,
+		LastAppliedAt:           orNow(rec.LastAppliedAt),
+	})
+}
+
+// orNow returns ts, or the current time when ts is empty.
+func orNow(ts string) string {
+	if ts != "" {
+		return ts
+	}
+	return time.Now().UTC().Format(time.RFC3339)
+}
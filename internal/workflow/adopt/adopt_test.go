@@ -0,0 +1,208 @@
+package adopt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func moduleInstance(owner string, entries ...map[string]any) *unstructured.Unstructured {
+	invEntries := make([]any, len(entries))
+	for i, e := range entries {
+		invEntries[i] = e
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": inventory.APIVersionModuleInstance,
+		"kind":       inventory.KindModuleInstance,
+		"metadata":   map[string]any{"name": "jellyfin", "namespace": "media"},
+		"spec":       map[string]any{"owner": owner},
+		"status": map[string]any{
+			"instanceUUID": "uuid-1",
+			"inventory": map[string]any{
+				"revision": int64(2),
+				"count":    int64(len(entries)),
+				"entries":  invEntries,
+			},
+		},
+	}}
+}
+
+func configMap(name string, labels map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": name, "namespace": "media", "labels": labels},
+	}}
+}
+
+// testClient builds a client whose discovery serves ConfigMaps and whose
+// dynamic client holds objs. Status-subresource applies on the ModuleInstance
+// are captured into statusPatch, since the fake tracker cannot apply them.
+func testClient(statusPatch *map[string]any, objs ...*unstructured.Unstructured) *kubernetes.Client {
+	runtimeObjs := make([]runtime.Object, len(objs))
+	for i, o := range objs {
+		runtimeObjs[i] = o
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			inventory.ModuleInstanceGVR: "ModuleInstanceList",
+			configMapGVR:                "ConfigMapList",
+		}, runtimeObjs...)
+	dyn.PrependReactor("patch", inventory.ResourceModuleInstances, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(k8stesting.PatchAction)
+		if !ok || patch.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+		if err := json.Unmarshal(patch.GetPatch(), statusPatch); err != nil {
+			return true, nil, err
+		}
+		return true, &unstructured.Unstructured{Object: *statusPatch}, nil
+	})
+
+	cs := k8sfake.NewClientset()
+	cs.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+	}}
+	return &kubernetes.Client{Dynamic: dyn, Clientset: cs}
+}
+
+func request(client *kubernetes.Client) Request {
+	return Request{
+		Name:      "jellyfin",
+		Namespace: "media",
+		Selector:  "app=jellyfin",
+		Kinds:     []string{"ConfigMap"},
+		K8sClient: client,
+		Log:       output.InstanceLogger("jellyfin"),
+	}
+}
+
+func exitCode(t *testing.T, err error) int {
+	t.Helper()
+	var exitErr *opmexit.ExitError
+	require.True(t, errors.As(err, &exitErr), "expected ExitError, got %T", err)
+	return exitErr.Code
+}
+
+func TestExecute_ValidatesInput(t *testing.T) {
+	client := testClient(new(map[string]any), moduleInstance(inventory.OwnerCLI))
+
+	tests := []struct {
+		name    string
+		mutate  func(*Request)
+		wantMsg string
+	}{
+		{"missing selector", func(r *Request) { r.Selector = "" }, "--selector is required"},
+		{"invalid selector", func(r *Request) { r.Selector = "app in (" }, "invalid --selector"},
+		{"missing kinds", func(r *Request) { r.Kinds = nil }, "--kind"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request(client)
+			tt.mutate(&req)
+			_, err := Execute(context.Background(), req)
+			require.Error(t, err)
+			assert.Equal(t, opmexit.ExitValidationError, exitCode(t, err))
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestExecute_InstanceNotFound(t *testing.T) {
+	_, err := Execute(context.Background(), request(testClient(new(map[string]any))))
+	require.Error(t, err)
+	assert.Equal(t, opmexit.ExitNotFound, exitCode(t, err))
+}
+
+func TestExecute_RefusesOperatorOwnedInstance(t *testing.T) {
+	client := testClient(new(map[string]any), moduleInstance(inventory.OwnerOperator))
+
+	_, err := Execute(context.Background(), request(client))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operator-managed")
+}
+
+func TestExecute_UnknownKind(t *testing.T) {
+	client := testClient(new(map[string]any), moduleInstance(inventory.OwnerCLI))
+	req := request(client)
+	req.Kinds = []string{"Widget"}
+
+	_, err := Execute(context.Background(), req)
+	require.Error(t, err)
+	assert.Equal(t, opmexit.ExitValidationError, exitCode(t, err))
+	assert.Contains(t, err.Error(), `"Widget" not found`)
+}
+
+func TestExecute_DryRunChangesNothing(t *testing.T) {
+	var statusPatch map[string]any
+	client := testClient(&statusPatch, moduleInstance(inventory.OwnerCLI),
+		configMap("legacy", map[string]any{"app": "jellyfin"}))
+	req := request(client)
+	req.DryRun = true
+
+	result, err := Execute(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, result.Adopted, 1)
+	assert.Equal(t, "legacy", result.Adopted[0].Name)
+	assert.Nil(t, statusPatch, "dry run must not write the inventory")
+
+	cm, err := client.ResourceClient(configMapGVR, "media").Get(context.Background(), "legacy", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, cm.GetLabels(), pkgcore.LabelManagedBy)
+}
+
+func TestExecute_LabelsAndRecordsMatches(t *testing.T) {
+	var statusPatch map[string]any
+	tracked := map[string]any{"group": "", "version": "v1", "kind": "ConfigMap", "namespace": "media", "name": "tracked", "component": "web"}
+	client := testClient(&statusPatch, moduleInstance(inventory.OwnerCLI, tracked),
+		configMap("legacy", map[string]any{"app": "jellyfin"}),
+		configMap("tracked", map[string]any{"app": "jellyfin"}),
+		configMap("other-owner", map[string]any{
+			"app":                                "jellyfin",
+			pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+			pkgcore.LabelModuleInstanceName:      "radarr",
+			pkgcore.LabelModuleInstanceNamespace: "media",
+		}),
+		configMap("unrelated", map[string]any{"app": "sonarr"}),
+	)
+
+	result, err := Execute(context.Background(), request(client))
+	require.NoError(t, err)
+	require.Len(t, result.Adopted, 1)
+	assert.Equal(t, "legacy", result.Adopted[0].Name)
+	assert.Equal(t, 2, result.Skipped, "tracked and other-owner are skipped")
+
+	cm, err := client.ResourceClient(configMapGVR, "media").Get(context.Background(), "legacy", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, pkgcore.LabelManagedByValue, cm.GetLabels()[pkgcore.LabelManagedBy])
+	assert.Equal(t, "jellyfin", cm.GetLabels()[pkgcore.LabelModuleInstanceName])
+	assert.Equal(t, "uuid-1", cm.GetLabels()[pkgcore.LabelModuleInstanceUUID])
+	assert.Equal(t, "jellyfin", cm.GetLabels()["app"], "existing labels are kept")
+
+	require.NotNil(t, statusPatch)
+	status := statusPatch["status"].(map[string]any)
+	assert.Equal(t, "uuid-1", status["instanceUUID"], "identity must be restated on the status apply")
+	inv := status["inventory"].(map[string]any)
+	assert.EqualValues(t, 3, inv["revision"])
+	assert.EqualValues(t, 2, inv["count"])
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,12 +22,27 @@ import (
 	pkginventory "github.com/open-platform-model/cli/pkg/inventory"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/uuid"
 )
 
 type Options struct {
-	DryRun                 bool
-	CreateNS               bool
-	NoPrune                bool
+	DryRun bool
+	// ClientDryRun, with DryRun, makes the dry run client-side
+	// (--dry-run=client): nothing is sent to the API server, not even
+	// dry-run requests. Without it a dry run is server-side.
+	ClientDryRun bool
+	// ShowServerDiff, with a server-side dry run, prints how the API
+	// server's view of each resource differs from the render (--server-diff).
+	ShowServerDiff bool
+	CreateNS       bool
+	// NoPrune leaves stale resources in place (--prune=false). They are
+	// reported and stay in the inventory, so the next apply with pruning on
+	// removes them.
+	NoPrune bool
+	// Adopt lets the apply take over rendered resources that already exist
+	// without OPM's labels (--adopt); without it the apply refuses them. See
+	// RunPreApplyExistenceCheck.
+	Adopt                  bool
 	Force                  bool
 	SuccessUpToDateMessage string
 	SuccessAppliedMessage  string
@@ -34,6 +51,71 @@ type Options struct {
 	// in CLI-executor mode, which does its own applying. Zero uses
 	// inventory.DefaultReconcileTimeout.
 	Timeout time.Duration
+
+	// PruneOrder lists kinds to prune first, in order; the rest follow in
+	// reverse apply-weight order. Empty means reverse apply-weight order only.
+	PruneOrder []string
+
+	// PruneSafetyRatio is the largest share of the previously tracked
+	// resources one apply may delete; above it pruning is refused with an
+	// inventory.PruneSafetyError (--prune-safety-ratio). Zero means
+	// inventory.DefaultPruneSafetyRatio.
+	PruneSafetyRatio float64
+
+	// ForcePrune prunes regardless of PruneSafetyRatio (--force-prune).
+	ForcePrune bool
+
+	// DetachStale relabels stale resources out of OPM management instead of
+	// deleting them (--prune-mode detach). Protective finalizers do not apply:
+	// nothing is deleted.
+	DetachStale bool
+
+	// ProtectedFinalizers skips pruning stale resources carrying any of these
+	// finalizers; they stay tracked and are reported as skipped (protected).
+	ProtectedFinalizers []string
+
+	// NoInventory applies without reading or writing the ModuleInstance CR;
+	// previously applied resources are found by label scan (--no-inventory).
+	NoInventory bool
+
+	// Concurrency is the number of components applied in parallel within an
+	// apply wave (--apply-concurrency); see kubernetes.Apply.
+	Concurrency int
+
+	// FailFast stops the apply at the first wave with a failed resource
+	// (--fail-fast); see kubernetes.ApplyOptions.FailFast.
+	FailFast bool
+
+	// ShowManagedFields prints, after the apply, which fields opm-cli and
+	// every other field manager own on each applied resource
+	// (--show-managed-fields).
+	ShowManagedFields bool
+
+	// Reconcile also removes fields a previous apply set that this render
+	// drops but another field manager still holds (--reconcile); see
+	// kubernetes.ApplyOptions.Reconcile.
+	Reconcile bool
+
+	// LabelConflictPolicy decides which value a label or annotation takes
+	// when the render and a live resource disagree (--label-conflict-policy);
+	// see kubernetes.ApplyOptions.LabelConflictPolicy. CLI-executor mode only:
+	// an operator-owned instance is applied by the operator.
+	LabelConflictPolicy string
+
+	// StrictNamespace fails the apply on cluster-scoped resources the render
+	// gave a namespace, instead of clearing it (--strict-namespace); see
+	// kubernetes.StripClusterScopedNamespaces.
+	StrictNamespace bool
+
+	// InventoryWriteAttempts bounds the conditional inventory write when a
+	// concurrent apply of the same instance keeps changing the
+	// ModuleInstance; see inventory.UpdateStatus. Zero means
+	// inventory.DefaultStatusWriteAttempts.
+	InventoryWriteAttempts int
+
+	// Progress, when set, is called as each resource's apply completes;
+	// see kubernetes.ApplyOptions.Progress. CLI-executor mode only.
+	Progress func(kubernetes.ApplyProgress)
 }
 
 type Request struct {
@@ -41,6 +123,11 @@ type Request struct {
 	K8sClient *kubernetes.Client
 	Log       *log.Logger
 	Options   Options
+
+	// OperationID identifies this apply run. Execute generates one when it
+	// is empty, logs it, stamps it on every event, and records it on the
+	// ModuleInstance CR, so the run's logs, events, and record correlate.
+	OperationID string
 }
 
 func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orchestration for apply flow spans gates, apply, prune, and CR spec+status writes
@@ -51,7 +138,15 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 	instanceID := result.Instance.UUID
 	dryRun := req.Options.DryRun
 
-	if err := EnsureNamespaceIfRequested(ctx, req.K8sClient, namespace, req.Options.CreateNS, dryRun, instanceLog); err != nil {
+	if req.OperationID == "" {
+		req.OperationID = string(uuid.NewUUID())
+	}
+	output.SetOperation(req.OperationID)
+	defer output.SetOperation("")
+	instanceLog.Info("apply operation", "id", req.OperationID)
+
+	nsCreated, err := EnsureNamespaceIfRequested(ctx, req.K8sClient, namespace, req.Options.CreateNS, dryRun, instanceLog)
+	if err != nil {
 		return err
 	}
 
@@ -60,6 +155,21 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 	manifestDigest := result.RenderDigest
 	output.Debug("render digest computed", "digest", manifestDigest)
 
+	// The render already cleared the namespace of well-known cluster-scoped
+	// kinds; the cluster's discovery also knows the scope of CRD-served ones.
+	stripped, err := kubernetes.StripClusterScopedNamespaces(req.K8sClient, result.Resources, req.Options.StrictNamespace)
+	if err != nil {
+		instanceLog.Error("namespace check failed", "error", err)
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+	}
+	for _, obj := range stripped {
+		instanceLog.Warn("removed namespace from cluster-scoped resource", "resource", obj.GetKind()+"/"+obj.GetName())
+	}
+
+	if req.Options.NoInventory {
+		return executeStateless(ctx, req)
+	}
+
 	// Pre-apply gates 1-3 (cluster probes). Skipped entirely on dry-run — they
 	// exist to protect writes, and a dry-run writes nothing (enhancement 0006 D5).
 	if !dryRun {
@@ -70,29 +180,21 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 		}
 	}
 
-	// Ownership has to be resolved on dry-run too. LoadPreviousInventory
-	// deliberately reads nothing here — its record feeds the prune and migration
-	// paths, which a dry-run never exercises — but with no record the resolver
-	// below would see an absent CR and preview the CLI-executor path, promising
-	// resource applies that an operator-owned instance would never receive.
-	// --dry-run is server-side, so the client is connected and this read is safe.
-	if dryRun && instanceID != "" {
-		rec, err := inventory.GetRecord(ctx, req.K8sClient, name, namespace)
-		if err != nil {
-			instanceLog.Warn("could not read inventory CR, previewing as CLI-managed", "error", err)
-		} else if inventory.ResolveOwnership(rec) == inventory.ModeOperatorOwned {
-			return previewThinEditor(req, rec)
-		}
-	}
-
 	// Load the previous inventory from the CR; when absent, look for a legacy
-	// Secret to migrate. Both are read-only.
+	// Secret to migrate. Both are read-only, so a dry run loads them too: the
+	// record decides ownership, and its entries are what a dry run reports as
+	// stale. Either kind of dry run reads the cluster, so the client is
+	// connected.
 	prevRecord, legacy := LoadPreviousInventory(ctx, req.K8sClient, name, namespace, instanceID, dryRun, instanceLog)
 
 	// Gate 4: ownership — the single branch point (0006 D18). An operator-owned
 	// instance takes the thin-editor path and returns; everything below this
-	// point is CLI-executor mode.
+	// point is CLI-executor mode. A dry run previews the spec edit instead of
+	// a render-and-apply the CLI would never perform.
 	if inventory.ResolveOwnership(prevRecord) == inventory.ModeOperatorOwned {
+		if dryRun {
+			return previewThinEditor(req, prevRecord)
+		}
 		return executeThinEditor(ctx, req, prevRecord)
 	}
 
@@ -107,7 +209,8 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 
 	prevEntries := previousEntries(prevRecord, legacy)
 	currentEntries := CurrentInventoryEntries(result.Resources)
-	staleSet := ComputeStaleInventorySet(prevEntries, currentEntries)
+	trackedEntries := TrackCreatedNamespace(currentEntries, prevEntries, namespace, nsCreated)
+	staleSet := ComputeStaleInventorySet(prevEntries, trackedEntries)
 
 	if err := GuardEmptyRender(len(result.Resources), prevEntries, req.Options.Force, instanceLog); err != nil {
 		return err
@@ -116,11 +219,13 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 		return nil
 	}
 
-	// Gate 6: existence check, first-ever apply only (no previous inventory).
-	hasPrevInventory := prevRecord != nil || legacy != nil
-	if err := RunPreApplyExistenceCheck(ctx, req.K8sClient, hasPrevInventory, dryRun, currentEntries); err != nil {
+	// Gate 6: existence check on the resources joining the inventory.
+	if err := RunPreApplyExistenceCheck(ctx, req.K8sClient, prevEntries, currentEntries, dryRun, req.Options.Adopt, instanceLog); err != nil {
 		return err
 	}
+	// The namespace joins the inventory only after the existence check: it
+	// exists because this run created it.
+	currentEntries = trackedEntries
 
 	if dryRun {
 		instanceLog.Info("dry run - no changes will be made")
@@ -132,7 +237,18 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 	var applyResult *kubernetes.ApplyResult
 	if len(result.Resources) > 0 {
 		var err error
-		applyResult, err = kubernetes.Apply(ctx, req.K8sClient, result.Resources, name, kubernetes.ApplyOptions{DryRun: dryRun})
+		output.EmitPhase(name, "apply", fmt.Sprintf("applying %d resource(s)", len(result.Resources)))
+		serverDryRun, clientDryRun := applyDryRunOptions(req.Options)
+		applyResult, err = kubernetes.Apply(ctx, req.K8sClient, result.Resources, name, kubernetes.ApplyOptions{
+			DryRun:              serverDryRun,
+			ClientDryRun:        clientDryRun,
+			Concurrency:         req.Options.Concurrency,
+			FailFast:            req.Options.FailFast,
+			ReportOwnership:     req.Options.ShowManagedFields,
+			Reconcile:           req.Options.Reconcile,
+			LabelConflictPolicy: req.Options.LabelConflictPolicy,
+			Progress:            req.Options.Progress,
+		})
 		if err != nil {
 			instanceLog.Error("apply failed", "error", err)
 			return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err, Printed: true}
@@ -147,9 +263,31 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 
 		if dryRun {
 			instanceLog.Info(fmt.Sprintf("dry run complete: %d resources would be applied", applyResult.Applied))
+			ReportServerDiff(req.Options, applyResult, instanceLog)
 		} else {
 			instanceLog.Info(FormatApplySummary(applyResult))
 		}
+		if req.Options.ShowManagedFields && len(applyResult.Ownership) > 0 {
+			output.Println(kubernetes.FormatOwnershipReport(applyResult.Ownership))
+		}
+	}
+
+	// A dry run reports what a real apply would prune, after the same
+	// protected split, so protected entries are not counted.
+	if dryRun && len(staleSet) > 0 && req.Options.NoPrune {
+		ReportUnpruned(staleSet, name, instanceLog)
+	}
+	if dryRun && len(staleSet) > 0 && !req.Options.NoPrune && (applyResult == nil || len(applyResult.Errors) == 0) {
+		if req.Options.DetachStale {
+			instanceLog.Info(fmt.Sprintf("dry run: %d stale resource(s) would be detached", len(staleSet)))
+		} else if prunable, _ := SkipProtected(ctx, req.K8sClient, staleSet, req.Options.ProtectedFinalizers, name, instanceLog); len(prunable) > 0 {
+			err := inventory.CheckPruneSafety(prunable, len(prevEntries), pruneSafetyRatio(req.Options))
+			if err != nil && !forcePrune(req.Options, len(result.Resources)) {
+				instanceLog.Warn("dry run: " + err.Error())
+			} else {
+				instanceLog.Info(fmt.Sprintf("dry run: %d stale resource(s) would be pruned", len(prunable)))
+			}
+		}
 	}
 
 	if !dryRun && instanceID != "" {
@@ -159,9 +297,42 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("%d resource(s) failed to apply", len(applyResult.Errors)), Printed: true}
 		}
 
+		if len(staleSet) > 0 && !req.Options.NoPrune && !req.Options.DetachStale {
+			var protected []inventory.InventoryEntry
+			staleSet, protected = SkipProtected(ctx, req.K8sClient, staleSet, req.Options.ProtectedFinalizers, name, instanceLog)
+			// Protected resources stay in the inventory so a later apply or
+			// delete still knows they belong to this instance.
+			currentEntries = append(slices.Clip(currentEntries), protected...)
+		}
+		var pruneRefused error
+		if len(staleSet) > 0 && !req.Options.NoPrune && !req.Options.DetachStale && !forcePrune(req.Options, len(result.Resources)) {
+			if err := inventory.CheckPruneSafety(staleSet, len(prevEntries), pruneSafetyRatio(req.Options)); err != nil {
+				// Refused resources stay tracked, so the re-run with
+				// --force-prune still finds them.
+				instanceLog.Error(err.Error())
+				currentEntries = append(slices.Clip(currentEntries), staleSet...)
+				staleSet = nil
+				pruneRefused = err
+			}
+		}
+		if len(staleSet) > 0 && req.Options.NoPrune {
+			// Unpruned resources stay tracked: dropping them from the
+			// inventory would hide them from every later prune.
+			ReportUnpruned(staleSet, name, instanceLog)
+			currentEntries = append(slices.Clip(currentEntries), staleSet...)
+		}
 		if len(staleSet) > 0 && !req.Options.NoPrune {
-			instanceLog.Info(fmt.Sprintf("pruning %d stale resource(s)", len(staleSet)))
-			if err := inventory.PruneStaleResources(ctx, req.K8sClient, staleSet); err != nil {
+			msg := fmt.Sprintf("pruning %d stale resource(s)", len(staleSet))
+			if req.Options.DetachStale {
+				msg = fmt.Sprintf("detaching %d stale resource(s) from OPM management", len(staleSet))
+			}
+			instanceLog.Info(msg)
+			output.EmitPhase(name, "prune", msg)
+			if err := inventory.PruneStaleResources(ctx, req.K8sClient, staleSet, inventory.PruneOptions{
+				Order:        req.Options.PruneOrder,
+				InstanceName: name,
+				Detach:       req.Options.DetachStale,
+			}); err != nil {
 				instanceLog.Warn("pruning stale resources failed", "error", err)
 			}
 		}
@@ -169,13 +340,18 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 		if err := WriteInstanceRecord(ctx, req, prevRecord, legacy, currentEntries, manifestDigest, instanceLog); err != nil {
 			return err
 		}
+		if pruneRefused != nil {
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: pruneRefused, Printed: true}
+		}
 	}
 
 	if applyResult != nil && len(applyResult.Errors) == 0 && !dryRun {
 		if applyResult.Unchanged == applyResult.Applied {
 			output.Println(output.FormatCheckmark(req.Options.SuccessUpToDateMessage))
+			output.EmitPhase(name, "complete", req.Options.SuccessUpToDateMessage)
 		} else {
 			output.Println(output.FormatCheckmark(req.Options.SuccessAppliedMessage))
+			output.EmitPhase(name, "complete", req.Options.SuccessAppliedMessage)
 		}
 
 		// Solo-cluster Platform seeding (0006 D12/D22): when the render fell
@@ -199,6 +375,69 @@ func Execute(ctx context.Context, req Request) error { //nolint:gocyclo // orche
 	return nil
 }
 
+// SkipProtected drops stale entries carrying a protective finalizer or the
+// opmodel.dev/prune: "false" annotation from the prune set, reporting each as
+// skipped (protected) for instance name. It returns the remaining prunable
+// entries and the protected ones.
+func SkipProtected(ctx context.Context, client *kubernetes.Client, stale []inventory.InventoryEntry, finalizers []string, name string, instanceLog *log.Logger) (prunable, protected []inventory.InventoryEntry) {
+	prunable, protected = inventory.SplitProtected(ctx, client, stale, finalizers)
+	for _, e := range protected {
+		instanceLog.Info(output.FormatResourceLine(e.Kind, e.Namespace, e.Name, output.StatusProtected))
+		output.EmitResource(name, e.Kind, e.Namespace, e.Name, output.StatusProtected, nil)
+	}
+	return prunable, protected
+}
+
+// pruneSafetyRatio returns the prune safety ratio opts asks for.
+func pruneSafetyRatio(opts Options) float64 {
+	if opts.PruneSafetyRatio <= 0 {
+		return inventory.DefaultPruneSafetyRatio
+	}
+	return opts.PruneSafetyRatio
+}
+
+// forcePrune reports whether the prune safety ratio is overridden: by
+// --force-prune, or by --force on an empty render, which already asked to
+// prune everything.
+func forcePrune(opts Options, resourceCount int) bool {
+	return opts.ForcePrune || (opts.Force && resourceCount == 0)
+}
+
+// ReportServerDiff prints, after a server-side dry run with
+// Options.ShowServerDiff, how the server's view of each resource differs from
+// the render.
+func ReportServerDiff(opts Options, applyResult *kubernetes.ApplyResult, instanceLog *log.Logger) {
+	if !opts.ShowServerDiff || applyResult == nil || len(applyResult.DryRunViews) == 0 {
+		return
+	}
+	diff, err := kubernetes.FormatServerDryRunDiff(applyResult.DryRunViews)
+	switch {
+	case err != nil:
+		instanceLog.Warn(fmt.Sprintf("comparing the server's view with the render: %v", err))
+	case diff == "":
+		instanceLog.Info("the server kept every resource as rendered")
+	default:
+		output.Println(diff)
+	}
+}
+
+// applyDryRunOptions returns the kubernetes.ApplyOptions dry-run settings
+// for a workflow dry run of the configured kind.
+func applyDryRunOptions(opts Options) (server, client bool) {
+	return opts.DryRun && !opts.ClientDryRun, opts.DryRun && opts.ClientDryRun
+}
+
+// ReportUnpruned reports the stale entries an apply with pruning off leaves
+// in place, each as skipped (pruning off) for instance name.
+func ReportUnpruned(stale []inventory.InventoryEntry, name string, instanceLog *log.Logger) {
+	instanceLog.Warn(fmt.Sprintf("pruning is off: %d stale resource(s) left in place and still tracked; "+
+		"the next apply with pruning on removes them", len(stale)))
+	for _, e := range stale {
+		instanceLog.Info(output.FormatResourceLine(e.Kind, e.Namespace, e.Name, output.StatusNotPruned))
+		output.EmitResource(name, e.Kind, e.Namespace, e.Name, output.StatusNotPruned, nil)
+	}
+}
+
 // RunClusterGates runs the read-only pre-apply cluster gates in order: CRD
 // presence, CRD field floor, operator-version ceiling.
 func RunClusterGates(ctx context.Context, client *kubernetes.Client) error {
@@ -211,15 +450,22 @@ func RunClusterGates(ctx context.Context, client *kubernetes.Client) error {
 	return inventory.GateOperatorVersionCeiling(ctx, client, version.Version)
 }
 
-func EnsureNamespaceIfRequested(ctx context.Context, k8sClient *kubernetes.Client, namespace string, createNS, dryRun bool, instanceLog *log.Logger) error {
+// EnsureNamespaceIfRequested creates the instance namespace when createNS is
+// set and it is missing, and reports whether it did. A terminating namespace
+// fails the apply before anything is written.
+func EnsureNamespaceIfRequested(ctx context.Context, k8sClient *kubernetes.Client, namespace string, createNS, dryRun bool, instanceLog *log.Logger) (bool, error) {
 	if !createNS || namespace == "" {
-		return nil
+		return false, nil
 	}
 
 	created, err := k8sClient.EnsureNamespace(ctx, namespace, dryRun)
 	if err != nil {
 		instanceLog.Error("ensuring namespace", "error", err)
-		return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err, Printed: true}
+		code := exitCodeFromK8sError(err)
+		if errors.Is(err, kubernetes.ErrNamespaceTerminating) {
+			code = opmexit.ExitValidationError
+		}
+		return false, &opmexit.ExitError{Code: code, Err: err, Printed: true}
 	}
 	if created {
 		if dryRun {
@@ -228,15 +474,34 @@ func EnsureNamespaceIfRequested(ctx context.Context, k8sClient *kubernetes.Clien
 			instanceLog.Info(fmt.Sprintf("namespace %q created", namespace))
 		}
 	}
-	return nil
+	return created, nil
+}
+
+// TrackCreatedNamespace adds the instance namespace to entries when this apply
+// created it (created) or a previous apply did (prevEntries tracks it), so a
+// delete removes only a namespace OPM created. Once tracked it stays tracked:
+// dropping it would make the instance's own namespace a stale resource to
+// prune. A namespace the render itself contains is not added twice.
+func TrackCreatedNamespace(entries, prevEntries []inventory.InventoryEntry, namespace string, created bool) []inventory.InventoryEntry {
+	if namespace == "" || (!created && !inventory.TracksCreatedNamespace(prevEntries, namespace)) {
+		return entries
+	}
+	nsEntry := inventory.CreatedNamespaceEntry(namespace)
+	if slices.ContainsFunc(entries, func(e inventory.InventoryEntry) bool {
+		return inventory.K8sIdentityEqual(e, nsEntry)
+	}) {
+		return entries
+	}
+	return append(slices.Clip(entries), nsEntry)
 }
 
 // LoadPreviousInventory reads the ModuleInstance CR for an instance. When no CR
 // exists, it looks for a legacy inventory Secret to migrate (enhancement 0006
-// D6). Returns (nil, nil) on dry-run, missing instance ID, or a first apply
-// with no legacy Secret.
+// D6). It only reads, so a dry run loads the same record a real apply would.
+// Returns (nil, nil) on a missing instance ID or a first apply with no legacy
+// Secret.
 func LoadPreviousInventory(ctx context.Context, k8sClient *kubernetes.Client, name, namespace, instanceID string, dryRun bool, instanceLog *log.Logger) (*inventory.Record, *inventory.LegacyInventory) {
-	if instanceID == "" || dryRun {
+	if instanceID == "" {
 		return nil, nil
 	}
 
@@ -257,7 +522,11 @@ func LoadPreviousInventory(ctx context.Context, k8sClient *kubernetes.Client, na
 	if legacy == nil {
 		return nil, nil
 	}
-	instanceLog.Info("migrating legacy inventory Secret to ModuleInstance CR")
+	if dryRun {
+		instanceLog.Info("dry run: legacy inventory Secret would be migrated to a ModuleInstance CR")
+	} else {
+		instanceLog.Info("migrating legacy inventory Secret to ModuleInstance CR")
+	}
 	return nil, legacy
 }
 
@@ -280,6 +549,8 @@ func WriteInstanceRecord(ctx context.Context, req Request, prevRecord *inventory
 		ModuleVersion: moduleVersion,
 		Values:        result.Values,
 		SourceLocal:   result.SourceLocal,
+		ModuleDigest:  result.ModuleDigest,
+		OperationID:   req.OperationID,
 	}); err != nil {
 		instanceLog.Warn("failed to write ModuleInstance spec", "error", err)
 		return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err, Printed: true}
@@ -305,7 +576,8 @@ func WriteInstanceRecord(ctx context.Context, req Request, prevRecord *inventory
 		statusInput.InstanceUUID = instanceID
 	}
 
-	if err := inventory.ApplyStatus(ctx, req.K8sClient, statusInput); err != nil {
+	base := pkginventory.Inventory{Revision: revision - 1, Entries: previousEntries(prevRecord, legacy)}
+	if err := inventory.UpdateStatus(ctx, req.K8sClient, statusInput, base, req.Options.InventoryWriteAttempts); err != nil {
 		instanceLog.Warn("failed to write ModuleInstance status", "error", err)
 		return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err, Printed: true}
 	}
@@ -380,13 +652,34 @@ func GuardEmptyRender(resourceCount int, prevEntries []inventory.InventoryEntry,
 	return nil
 }
 
-func RunPreApplyExistenceCheck(ctx context.Context, k8sClient *kubernetes.Client, hasPrevInventory, dryRun bool, currentEntries []inventory.InventoryEntry) error {
-	if hasPrevInventory || dryRun {
+// RunPreApplyExistenceCheck checks the rendered resources the instance does
+// not track yet (see inventory.PreApplyExistenceCheck). Those that exist
+// unmanaged are refused with an *inventory.UnmanagedResourcesError, or, with
+// adopt, logged as adopted: the apply then labels them like any rendered
+// resource and the inventory write records them.
+func RunPreApplyExistenceCheck(ctx context.Context, k8sClient *kubernetes.Client, prevEntries, currentEntries []inventory.InventoryEntry, dryRun, adopt bool, instanceLog *log.Logger) error {
+	if dryRun {
 		return nil
 	}
-	if err := inventory.PreApplyExistenceCheck(ctx, k8sClient, currentEntries); err != nil {
+	var added []inventory.InventoryEntry
+	for _, e := range currentEntries {
+		if !slices.ContainsFunc(prevEntries, func(p inventory.InventoryEntry) bool { return inventory.IdentityEqual(p, e) }) {
+			added = append(added, e)
+		}
+	}
+	unmanaged, err := inventory.PreApplyExistenceCheck(ctx, k8sClient, added)
+	if err != nil {
 		return fmt.Errorf("pre-apply existence check failed: %w", err)
 	}
+	if len(unmanaged) == 0 {
+		return nil
+	}
+	if !adopt {
+		return fmt.Errorf("pre-apply existence check failed: %w", &inventory.UnmanagedResourcesError{Entries: unmanaged})
+	}
+	for _, e := range unmanaged {
+		instanceLog.Info(output.FormatResourceLine(e.Kind, e.Namespace, e.Name, output.StatusAdopted))
+	}
 	return nil
 }
 
@@ -401,6 +694,12 @@ func FormatApplySummary(r *kubernetes.ApplyResult) string {
 	if r.Unchanged > 0 {
 		parts = append(parts, fmt.Sprintf("%d unchanged", r.Unchanged))
 	}
+	if r.Reconciled > 0 {
+		parts = append(parts, fmt.Sprintf("%d stale field(s) removed", r.Reconciled))
+	}
+	if r.Retries > 0 {
+		parts = append(parts, fmt.Sprintf("retries: %d", r.Retries))
+	}
 	summary := fmt.Sprintf("applied %d resources successfully", r.Applied)
 	if len(parts) > 0 {
 		summary += fmt.Sprintf(" (%s)", strings.Join(parts, ", "))
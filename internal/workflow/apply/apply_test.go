@@ -1,14 +1,20 @@
 package apply
 
 import (
+	"bytes"
+	"context"
+	"os"
 	"testing"
 
 	"github.com/open-platform-model/cli/internal/inventory"
 	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
+	workflowrender "github.com/open-platform-model/cli/internal/workflow/render"
 	pkginventory "github.com/open-platform-model/cli/pkg/inventory"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -21,6 +27,23 @@ func TestCurrentInventoryEntries(t *testing.T) {
 	assert.Equal(t, "apps", entries[0].Namespace)
 }
 
+func TestTrackCreatedNamespace(t *testing.T) {
+	web := inventory.InventoryEntry{Kind: "ConfigMap", Version: "v1", Namespace: "apps", Name: "web"}
+	nsEntry := inventory.CreatedNamespaceEntry("apps")
+
+	assert.Equal(t, []inventory.InventoryEntry{web},
+		TrackCreatedNamespace([]inventory.InventoryEntry{web}, nil, "apps", false), "an existing namespace is not tracked")
+	assert.Equal(t, []inventory.InventoryEntry{web, nsEntry},
+		TrackCreatedNamespace([]inventory.InventoryEntry{web}, nil, "apps", true))
+	assert.Equal(t, []inventory.InventoryEntry{web, nsEntry},
+		TrackCreatedNamespace([]inventory.InventoryEntry{web}, []inventory.InventoryEntry{web, nsEntry}, "apps", false),
+		"a namespace a previous apply created stays tracked")
+
+	rendered := inventory.InventoryEntry{Kind: "Namespace", Version: "v1", Name: "apps", Component: "base"}
+	assert.Equal(t, []inventory.InventoryEntry{rendered},
+		TrackCreatedNamespace([]inventory.InventoryEntry{rendered}, nil, "apps", true), "a rendered namespace is not added twice")
+}
+
 func TestPreviousEntries_FromCRRecord(t *testing.T) {
 	prev := &inventory.Record{Inventory: inventory.Inventory{Entries: []inventory.InventoryEntry{{Kind: "Service", Name: "web"}}}}
 	entries := previousEntries(prev, nil)
@@ -68,9 +91,151 @@ func TestSourceDigest(t *testing.T) {
 func TestFormatApplySummary(t *testing.T) {
 	summary := FormatApplySummary(&kubernetes.ApplyResult{Applied: 5, Created: 2, Configured: 1, Unchanged: 2})
 	assert.Equal(t, "applied 5 resources successfully (2 created, 1 configured, 2 unchanged)", summary)
+
+	summary = FormatApplySummary(&kubernetes.ApplyResult{Applied: 1, Configured: 1, Retries: 2})
+	assert.Equal(t, "applied 1 resources successfully (1 configured, retries: 2)", summary)
+
+	summary = FormatApplySummary(&kubernetes.ApplyResult{Applied: 1, Configured: 1, Reconciled: 3})
+	assert.Equal(t, "applied 1 resources successfully (1 configured, 3 stale field(s) removed)", summary)
 }
 
 // Ownership refusal is unit-tested at the resolver (inventory.ResolveOwnership)
 // and exercised end-to-end with a live CRD in the e2e gate tests; the full
 // gate+ownership Execute path needs a seeded CRD/Platform/CR fixture that the
 // e2e suite provides.
+
+func TestSkipProtected_EventsCarryInstance(t *testing.T) {
+	pvc := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata": map[string]any{
+			"name":       "data",
+			"namespace":  "media",
+			"finalizers": []any{"kubernetes.io/pvc-protection"},
+		},
+	}}
+	client, _ := recordingDynamicClient(pvc)
+	var events bytes.Buffer
+	output.SetEventWriter(&events)
+	t.Cleanup(func() { output.SetEventWriter(nil) })
+
+	stale := []inventory.InventoryEntry{
+		{Version: "v1", Kind: "PersistentVolumeClaim", Namespace: "media", Name: "data"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "media", Name: "gone"},
+	}
+	prunable, protected := SkipProtected(context.Background(), client, stale,
+		[]string{"kubernetes.io/pvc-protection"}, "jellyfin", output.InstanceLogger("jellyfin"))
+	require.Len(t, protected, 1)
+	require.Len(t, prunable, 1)
+	assert.Equal(t, "gone", prunable[0].Name)
+	assert.Contains(t, events.String(), `"instance":"jellyfin"`)
+	assert.Contains(t, events.String(), `"name":"data"`)
+	assert.NotContains(t, events.String(), `"name":"gone"`)
+}
+
+func TestReportUnpruned_EventsCarryStatus(t *testing.T) {
+	var events bytes.Buffer
+	output.SetEventWriter(&events)
+	t.Cleanup(func() { output.SetEventWriter(nil) })
+
+	ReportUnpruned([]inventory.InventoryEntry{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "media", Name: "old"},
+	}, "jellyfin", output.InstanceLogger("jellyfin"))
+	assert.Contains(t, events.String(), `"name":"old"`)
+	assert.Contains(t, events.String(), `"status":"`+output.StatusNotPruned+`"`)
+}
+
+// A ConfigMap created with kubectl has no OPM labels. Applying a render that
+// includes it is refused unless --adopt is set; once tracked it is no longer
+// checked.
+func TestRunPreApplyExistenceCheck_AdoptsPlainConfigMap(t *testing.T) {
+	plain := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "settings", "namespace": "media"},
+		"data":       map[string]any{"mode": "kubectl"},
+	}}
+	client, _ := recordingDynamicClient(plain)
+	ctx := context.Background()
+	instanceLog := output.InstanceLogger("jellyfin")
+	current := []inventory.InventoryEntry{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "media", Name: "settings"},
+		{Version: "v1", Kind: "Service", Namespace: "media", Name: "web"},
+	}
+
+	err := RunPreApplyExistenceCheck(ctx, client, nil, current, false, false, instanceLog)
+	var unmanaged *inventory.UnmanagedResourcesError
+	require.ErrorAs(t, err, &unmanaged)
+	assert.Equal(t, current[:1], unmanaged.Entries)
+	assert.Contains(t, err.Error(), "ConfigMap/settings (media)")
+
+	require.NoError(t, RunPreApplyExistenceCheck(ctx, client, nil, current, false, true, instanceLog), "--adopt takes it over")
+	require.NoError(t, RunPreApplyExistenceCheck(ctx, client, current[:1], current, false, false, instanceLog),
+		"a resource the inventory already tracks is not re-checked")
+	require.NoError(t, RunPreApplyExistenceCheck(ctx, client, nil, current, true, false, instanceLog), "a dry run skips the check")
+}
+
+// A dry run reads the previous inventory like a real apply, so it reports what
+// that apply would do with each stale entry.
+func TestExecute_DryRunReportsStaleEntries(t *testing.T) {
+	configMap := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "media"},
+		}}
+	}
+	cr := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": inventory.APIVersionModuleInstance,
+		"kind":       inventory.KindModuleInstance,
+		"metadata":   map[string]any{"name": "jellyfin", "namespace": "media"},
+		"spec":       map[string]any{"owner": inventory.OwnerCLI},
+		"status": map[string]any{
+			"instanceUUID": "uuid-1",
+			"inventory": map[string]any{
+				"revision": int64(1),
+				"count":    int64(2),
+				"entries": []any{
+					map[string]any{"v": "v1", "kind": "ConfigMap", "namespace": "media", "name": "kept"},
+					map[string]any{"v": "v1", "kind": "ConfigMap", "namespace": "media", "name": "old"},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{name: "prune", opts: Options{}, want: "dry run: 1 stale resource(s) would be pruned"},
+		{name: "detach", opts: Options{DetachStale: true}, want: "dry run: 1 stale resource(s) would be detached"},
+		{name: "pruning off", opts: Options{NoPrune: true}, want: "pruning is off: 1 stale resource(s) left in place"},
+		{name: "over the safety ratio", opts: Options{PruneSafetyRatio: 0.25}, want: "dry run: refusing to prune"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			output.SetLogWriter(&logs)
+			t.Cleanup(func() { output.SetLogWriter(os.Stderr) })
+
+			client, _ := recordingDynamicClient(cr.DeepCopy(), configMap("kept"), configMap("old"))
+			opts := tt.opts
+			opts.DryRun, opts.ClientDryRun = true, true
+			err := Execute(context.Background(), Request{
+				Result: &workflowrender.Result{
+					Resources: []*unstructured.Unstructured{configMap("kept")},
+					Instance:  pkgmodule.InstanceMetadata{Name: "jellyfin", Namespace: "media", UUID: "uuid-1"},
+				},
+				K8sClient: client,
+				Log:       output.InstanceLogger("jellyfin"),
+				Options:   opts,
+			})
+			require.NoError(t, err)
+			assert.Contains(t, logs.String(), tt.want)
+
+			_, err = client.ResourceClient(kubernetes.GVRFromUnstructured(configMap("old")), "media").Get(context.Background(), "old", metav1.GetOptions{})
+			assert.NoError(t, err, "a dry run prunes nothing")
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+
+	"github.com/charmbracelet/log"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	workflowrender "github.com/open-platform-model/cli/internal/workflow/render"
+)
+
+// Preview diffs a render against the cluster. Orphans are found the way
+// apply finds what to prune: from the ModuleInstance inventory, or by a scan
+// of the instance's identity labels under inventory.ReadIgnore (--no-inventory)
+// or, under inventory.ReadPrefer, when the inventory cannot be read. comparer
+// presents each modified resource (see kubernetes.NewComparerFor). opts
+// supplies the filter; its InventoryLive and ForeignOwned are filled in here.
+// A missing inventory, or a failed lookup, is logged at debug level and
+// yields no orphans, not an error; only an unreadable inventory under
+// inventory.ReadRequire fails.
+func Preview(ctx context.Context, client *kubernetes.Client, result *workflowrender.Result, mode inventory.ReadMode,
+	comparer kubernetes.Comparer, opts kubernetes.DiffOptions, instanceLog *log.Logger) (*kubernetes.DiffResult, error) {
+	name, namespace := result.Instance.Name, result.Instance.Namespace
+	if mode == inventory.ReadIgnore {
+		liveResources, err := inventory.DiscoverResourcesByLabels(ctx, client,
+			inventory.InstanceLabelSelector(name, namespace), namespace)
+		if err != nil {
+			instanceLog.Debug("label scan failed", "error", err)
+		} else {
+			opts.InventoryLive = liveResources
+		}
+	} else if result.Instance.UUID != "" {
+		rec, err := inventory.GetRecord(ctx, client, name, namespace)
+		if err != nil {
+			instanceLog.Debug("could not read inventory for diff", "error", err)
+		} else if rec != nil {
+			liveResources, _, err := inventory.DiscoverResources(ctx, client, rec, mode)
+			var unreadable *inventory.UnreadableInventoryError
+			switch {
+			case errors.As(err, &unreadable):
+				return nil, err
+			case err != nil:
+				instanceLog.Debug("inventory discovery failed", "error", err)
+			default:
+				opts.InventoryLive = liveResources
+				if rec.InventoryProblem == "" {
+					opts.ForeignOwned = inventory.VerifyOwnership(rec, liveResources)
+				}
+			}
+		}
+	}
+	return kubernetes.Diff(ctx, client, result.Resources, name, comparer, opts)
+}
+
+// FormatPreview renders a diff as the short pre-apply summary: the summary
+// line, then one line per resource the apply would touch. Orphans are
+// described by what this apply does with them under opts.
+func FormatPreview(diff *kubernetes.DiffResult, opts Options) string {
+	if diff.IsEmpty() {
+		return "No differences found"
+	}
+	orphanAction := "will be pruned"
+	switch {
+	case opts.NoPrune:
+		orphanAction = "stale, kept (pruning off)"
+	case opts.DetachStale:
+		orphanAction = "will be detached"
+	}
+
+	var b strings.Builder
+	b.WriteString(diff.SummaryLine())
+	for _, rd := range diff.Resources {
+		var marker, action string
+		switch rd.State {
+		case kubernetes.ResourceAdded:
+			marker, action = "+", "new resource"
+		case kubernetes.ResourceModified:
+			marker, action = "~", "modified"
+		case kubernetes.ResourceRecreate:
+			marker, action = "!", "recreate - immutable "+strings.Join(rd.Immutable, ", ")+" changed"
+		case kubernetes.ResourceOrphaned:
+			marker, action = "-", orphanAction
+		default:
+			continue
+		}
+		ref := rd.Kind + "/" + rd.Name
+		if rd.Namespace != "" {
+			ref += " (" + rd.Namespace + ")"
+		}
+		fmt.Fprintf(&b, "\n  %s %s [%s]", marker, ref, action)
+	}
+	return b.String()
+}
+
+// Confirm previews req's apply, prints the summary, and asks for
+// confirmation through prompt. It reports true without asking when there is
+// nothing to change or yes is set. Callers run it before Execute and skip the
+// apply on false.
+func Confirm(ctx context.Context, req Request, yes bool, prompt func(string) bool) (bool, error) {
+	mode := inventory.ReadPrefer
+	if req.Options.NoInventory {
+		mode = inventory.ReadIgnore
+	}
+	diff, err := Preview(ctx, req.K8sClient, req.Result, mode, kubernetes.NewComparer(), kubernetes.DiffOptions{}, req.Log)
+	if err != nil {
+		req.Log.Error("previewing apply", "error", err)
+		return false, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+	}
+	for _, w := range diff.Warnings {
+		req.Log.Warn(w)
+	}
+
+	output.Println(FormatPreview(diff, req.Options))
+	if diff.IsEmpty() || yes {
+		return true, nil
+	}
+	return prompt("Apply these changes? [y/N]: "), nil
+}
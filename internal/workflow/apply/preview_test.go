@@ -0,0 +1,54 @@
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/output"
+	workflowrender "github.com/open-platform-model/cli/internal/workflow/render"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
+)
+
+func confirmRequest(resources ...*unstructured.Unstructured) Request {
+	client, _ := recordingDynamicClient()
+	return Request{
+		Result: &workflowrender.Result{
+			Resources: resources,
+			Instance:  pkgmodule.InstanceMetadata{Name: "demo", Namespace: "apps"},
+		},
+		K8sClient: client,
+		Log:       output.InstanceLogger("demo"),
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	ctx := context.Background()
+	cm := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1", "kind": "ConfigMap",
+		"metadata": map[string]any{"name": "web", "namespace": "apps"},
+	}}
+
+	t.Run("asks when the apply changes something", func(t *testing.T) {
+		var asked string
+		ok, err := Confirm(ctx, confirmRequest(cm), false, func(p string) bool { asked = p; return false })
+		require.NoError(t, err)
+		assert.False(t, ok, "the prompt's answer decides")
+		assert.Equal(t, "Apply these changes? [y/N]: ", asked)
+	})
+
+	t.Run("yes skips the prompt", func(t *testing.T) {
+		ok, err := Confirm(ctx, confirmRequest(cm), true, func(string) bool { t.Fatal("prompted"); return false })
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("nothing to change needs no confirmation", func(t *testing.T) {
+		ok, err := Confirm(ctx, confirmRequest(), false, func(string) bool { t.Fatal("prompted"); return false })
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,140 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// executeStateless is apply with --no-inventory: no ModuleInstance CR is read
+// or written, for setups where state lives elsewhere (a GitOps controller).
+// The CRD gates and the ownership branch are skipped — there is no record to
+// protect or to resolve ownership from — and previously applied resources are
+// found by label scan instead of the inventory, so pruning only sees resources
+// that still carry the instance's identity labels in the instance namespace.
+func executeStateless(ctx context.Context, req Request) error {
+	result := req.Result
+	instanceLog := req.Log
+	name := result.Instance.Name
+	namespace := result.Instance.Namespace
+	dryRun := req.Options.DryRun
+
+	currentEntries := CurrentInventoryEntries(result.Resources)
+
+	var stale []inventory.InventoryEntry
+	var tracked int
+	if !req.Options.NoPrune {
+		live, err := inventory.DiscoverResourcesByLabels(ctx, req.K8sClient, inventory.InstanceLabelSelector(name, namespace), namespace)
+		if err != nil {
+			instanceLog.Error("label scan for previously applied resources failed", "error", err)
+			return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err, Printed: true}
+		}
+		prevEntries := CurrentInventoryEntries(live)
+		tracked = len(prevEntries)
+		if err := GuardEmptyRender(len(result.Resources), prevEntries, req.Options.Force, instanceLog); err != nil {
+			return err
+		}
+		stale = ComputeStaleInventorySet(prevEntries, currentEntries)
+	} else if len(result.Resources) == 0 {
+		instanceLog.Info("no resources to apply")
+	}
+	if len(result.Resources) == 0 && len(stale) == 0 {
+		return nil
+	}
+
+	// Without an inventory every rendered resource is checked; those applied
+	// before carry OPM's labels and pass.
+	if err := RunPreApplyExistenceCheck(ctx, req.K8sClient, nil, currentEntries, dryRun, req.Options.Adopt, instanceLog); err != nil {
+		return err
+	}
+
+	if dryRun {
+		instanceLog.Info("dry run - no changes will be made")
+	}
+
+	var applyResult *kubernetes.ApplyResult
+	if len(result.Resources) > 0 {
+		instanceLog.Info(fmt.Sprintf("applying %d resources", len(result.Resources)))
+		output.EmitPhase(name, "apply", fmt.Sprintf("applying %d resource(s)", len(result.Resources)))
+		var err error
+		serverDryRun, clientDryRun := applyDryRunOptions(req.Options)
+		applyResult, err = kubernetes.Apply(ctx, req.K8sClient, result.Resources, name, kubernetes.ApplyOptions{
+			DryRun:              serverDryRun,
+			ClientDryRun:        clientDryRun,
+			Concurrency:         req.Options.Concurrency,
+			FailFast:            req.Options.FailFast,
+			ReportOwnership:     req.Options.ShowManagedFields,
+			Reconcile:           req.Options.Reconcile,
+			LabelConflictPolicy: req.Options.LabelConflictPolicy,
+			Progress:            req.Options.Progress,
+		})
+		if err != nil {
+			instanceLog.Error("apply failed", "error", err)
+			return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err, Printed: true}
+		}
+		if len(applyResult.Errors) > 0 {
+			instanceLog.Warn(fmt.Sprintf("%d resource(s) had errors — skipping pruning", len(applyResult.Errors)))
+			for _, e := range applyResult.Errors {
+				instanceLog.Error(e.Error())
+			}
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("%d resource(s) failed to apply", len(applyResult.Errors)), Printed: true}
+		}
+		if dryRun {
+			instanceLog.Info(fmt.Sprintf("dry run complete: %d resources would be applied", applyResult.Applied))
+			ReportServerDiff(req.Options, applyResult, instanceLog)
+		} else {
+			instanceLog.Info(FormatApplySummary(applyResult))
+		}
+		if req.Options.ShowManagedFields && len(applyResult.Ownership) > 0 {
+			output.Println(kubernetes.FormatOwnershipReport(applyResult.Ownership))
+		}
+	}
+
+	if len(stale) > 0 && !req.Options.DetachStale {
+		stale, _ = SkipProtected(ctx, req.K8sClient, stale, req.Options.ProtectedFinalizers, name, instanceLog)
+	}
+	if len(stale) > 0 && !req.Options.DetachStale && !forcePrune(req.Options, len(result.Resources)) {
+		if err := inventory.CheckPruneSafety(stale, tracked, pruneSafetyRatio(req.Options)); err != nil {
+			if dryRun {
+				instanceLog.Warn("dry run: " + err.Error())
+				return nil
+			}
+			instanceLog.Error(err.Error())
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+		}
+	}
+	if len(stale) > 0 {
+		verb, msg := "pruned", fmt.Sprintf("pruning %d stale resource(s)", len(stale))
+		if req.Options.DetachStale {
+			verb, msg = "detached", fmt.Sprintf("detaching %d stale resource(s) from OPM management", len(stale))
+		}
+		if dryRun {
+			instanceLog.Info(fmt.Sprintf("dry run: %d stale resource(s) would be %s", len(stale), verb))
+			return nil
+		}
+		instanceLog.Info(msg)
+		output.EmitPhase(name, "prune", msg)
+		if err := inventory.PruneStaleResources(ctx, req.K8sClient, stale, inventory.PruneOptions{
+			Order:        req.Options.PruneOrder,
+			InstanceName: name,
+			Detach:       req.Options.DetachStale,
+		}); err != nil {
+			instanceLog.Warn("pruning stale resources failed", "error", err)
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+	msg := req.Options.SuccessAppliedMessage
+	if len(stale) == 0 && (applyResult == nil || applyResult.Unchanged == applyResult.Applied) {
+		msg = req.Options.SuccessUpToDateMessage
+	}
+	output.Println(output.FormatCheckmark(msg))
+	output.EmitPhase(name, "complete", msg)
+	return nil
+}
@@ -0,0 +1,180 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	workflowrender "github.com/open-platform-model/cli/internal/workflow/render"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// statelessConfigMap is a ConfigMap in namespace media, labelled as the
+// jellyfin instance's when labelled is set.
+func statelessConfigMap(name string, labelled bool) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": name, "namespace": "media"},
+	}}
+	if labelled {
+		obj.SetLabels(map[string]string{
+			pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+			pkgcore.LabelModuleInstanceName:      "jellyfin",
+			pkgcore.LabelModuleInstanceNamespace: "media",
+		})
+	}
+	return obj
+}
+
+// statelessClient builds a client whose discovery serves ConfigMaps and
+// ModuleInstances and whose dynamic client holds objs. It returns the verbs
+// sent for ModuleInstances, which --no-inventory must never touch.
+func statelessClient(objs ...*unstructured.Unstructured) (*kubernetes.Client, *[]string) {
+	runtimeObjs := make([]runtime.Object, len(objs))
+	for i, o := range objs {
+		runtimeObjs[i] = o
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			configMapGVR:                "ConfigMapList",
+			inventory.ModuleInstanceGVR: "ModuleInstanceList",
+		}, runtimeObjs...)
+	var crVerbs []string
+	dyn.PrependReactor("*", inventory.ResourceModuleInstances, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		crVerbs = append(crVerbs, action.GetVerb())
+		return false, nil, nil
+	})
+	// The fake tracker cannot server-side apply; answer the patch with the
+	// object as sent.
+	dyn.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		obj.SetResourceVersion("2")
+		return true, obj, nil
+	})
+
+	list := []string{"get", "list", "delete"}
+	cs := k8sfake.NewClientset()
+	cs.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: list},
+		}},
+		{GroupVersion: inventory.APIVersionModuleInstance, APIResources: []metav1.APIResource{
+			{Name: inventory.ResourceModuleInstances, Kind: inventory.KindModuleInstance, Namespaced: true, Verbs: list},
+		}},
+	}
+	return &kubernetes.Client{Dynamic: dyn, Clientset: cs}, &crVerbs
+}
+
+func statelessRequest(client *kubernetes.Client, opts Options, rendered ...*unstructured.Unstructured) Request {
+	opts.NoInventory = true
+	return Request{
+		Result: &workflowrender.Result{
+			Resources: rendered,
+			Instance:  pkgmodule.InstanceMetadata{Name: "jellyfin", Namespace: "media", UUID: "uuid-1"},
+		},
+		K8sClient: client,
+		Log:       output.InstanceLogger("jellyfin"),
+		Options:   opts,
+	}
+}
+
+// Without an inventory every rendered resource is checked: one that exists
+// without OPM's labels is refused unless --adopt is set, and nothing is
+// applied.
+func TestExecuteStateless_ExistenceCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    *unstructured.Unstructured
+		adopt   bool
+		refused bool
+	}{
+		{name: "unmanaged resource is refused", live: statelessConfigMap("web", false), refused: true},
+		{name: "unmanaged resource is adopted with --adopt", live: statelessConfigMap("web", false), adopt: true},
+		{name: "resource applied before passes", live: statelessConfigMap("web", true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			output.SetLogWriter(&logs)
+			t.Cleanup(func() { output.SetLogWriter(os.Stderr) })
+
+			client, crVerbs := statelessClient(tt.live)
+			err := Execute(context.Background(), statelessRequest(client, Options{Adopt: tt.adopt}, statelessConfigMap("web", true)))
+
+			var unmanaged *inventory.UnmanagedResourcesError
+			if tt.refused {
+				require.Error(t, err)
+				require.True(t, errors.As(err, &unmanaged))
+				assert.Equal(t, "web", unmanaged.Entries[0].Name)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Empty(t, *crVerbs, "no ModuleInstance CR is read or written")
+		})
+	}
+}
+
+// Previously applied resources are found by label scan. With pruning off the
+// one the render dropped stays in place and nothing about it is reported; with
+// pruning on it is deleted, or only reported on a dry run.
+func TestExecuteStateless_Prune(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		wantPruned bool
+		wantLog    string
+	}{
+		{name: "pruning off", opts: Options{NoPrune: true}},
+		{name: "pruning off on a dry run", opts: Options{NoPrune: true, DryRun: true, ClientDryRun: true}},
+		{name: "prune", wantPruned: true, wantLog: "pruning 1 stale resource(s)"},
+		{name: "prune on a dry run", opts: Options{DryRun: true, ClientDryRun: true}, wantLog: "dry run: 1 stale resource(s) would be pruned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			output.SetLogWriter(&logs)
+			t.Cleanup(func() { output.SetLogWriter(os.Stderr) })
+
+			client, crVerbs := statelessClient(statelessConfigMap("kept", true), statelessConfigMap("old", true))
+			err := Execute(context.Background(), statelessRequest(client, tt.opts, statelessConfigMap("kept", true)))
+			require.NoError(t, err)
+
+			_, err = client.ResourceClient(configMapGVR, "media").Get(context.Background(), "old", metav1.GetOptions{})
+			if tt.wantPruned {
+				assert.True(t, apierrors.IsNotFound(err), "the stale resource is deleted")
+			} else {
+				assert.NoError(t, err, "the stale resource stays")
+			}
+			if tt.wantLog != "" {
+				assert.Contains(t, logs.String(), tt.wantLog)
+			} else {
+				assert.NotContains(t, logs.String(), "stale")
+			}
+			assert.Empty(t, *crVerbs, "no ModuleInstance CR is read or written")
+		})
+	}
+}
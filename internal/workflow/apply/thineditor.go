@@ -48,7 +48,9 @@ func executeThinEditor(ctx context.Context, req Request, rec *inventory.Record)
 		Values:        result.Values,
 		// A local render was refused above, so the provenance annotation must
 		// not be stamped; any stale one is correctly cleared with it.
-		SourceLocal: false,
+		SourceLocal:  false,
+		ModuleDigest: result.ModuleDigest,
+		OperationID:  req.OperationID,
 	})
 	if err != nil {
 		return &opmexit.ExitError{Code: exitCodeFromK8sError(err), Err: err}
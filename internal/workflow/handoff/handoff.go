@@ -99,7 +99,8 @@ func Execute(ctx context.Context, req Request) error {
 		Values:        rec.SpecValues,
 		// Gate 3 refused a local-provenance instance, so the annotation is
 		// already absent and omitting it is a no-op.
-		SourceLocal: false,
+		SourceLocal:  false,
+		ModuleDigest: rec.ModuleDigest,
 	})
 	if err != nil {
 		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
@@ -83,7 +83,7 @@ func WatchEvents(ctx context.Context, client *kubernetes.Client, opts kubernetes
 	children, err := kubernetes.DiscoverChildren(ctx, client, opts.InventoryLive, opts.Namespace)
 	if err != nil {
 		instanceLog.Error("discovering children", "error", err)
-		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("discovering children: %w", err)}
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: fmt.Errorf("discovering children: %w", err), Printed: true}
 	}
 
 	uidSet := make(map[types.UID]bool)
@@ -97,7 +97,7 @@ func WatchEvents(ctx context.Context, client *kubernetes.Client, opts kubernetes
 	watcher, err := client.Clientset.CoreV1().Events(opts.Namespace).Watch(ctx, metav1.ListOptions{})
 	if err != nil {
 		instanceLog.Error("starting event watch", "error", err)
-		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: fmt.Errorf("starting event watch: %w", err)}
+		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: fmt.Errorf("starting event watch: %w", err), Printed: true}
 	}
 	defer watcher.Stop()
 
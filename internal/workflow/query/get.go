@@ -0,0 +1,113 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// jsonPathFormatPrefix introduces a JSONPath expression in instance get's
+// --output value: "jsonpath=<expr>".
+const jsonPathFormatPrefix = "jsonpath="
+
+// GetOutput is how instance get prints the resource: as a YAML or JSON
+// document, or as the fields a JSONPath expression selects.
+type GetOutput struct {
+	Format   output.Format
+	JSONPath *jsonpath.JSONPath
+}
+
+// ParseGetOutput parses instance get's --output: yaml, json, or
+// jsonpath=<expr>. The expression is kubectl's JSONPath; braces around it are
+// optional ("jsonpath=.spec.replicas").
+func ParseGetOutput(value string) (GetOutput, error) {
+	if expr, ok := strings.CutPrefix(value, jsonPathFormatPrefix); ok {
+		if expr == "" {
+			return GetOutput{}, &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+				Err: fmt.Errorf("--output jsonpath= needs an expression, e.g. -o jsonpath='{.spec.replicas}'")}
+		}
+		if !strings.Contains(expr, "{") {
+			expr = "{" + expr + "}"
+		}
+		jp := jsonpath.New("output")
+		if err := jp.Parse(expr); err != nil {
+			return GetOutput{}, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid --output jsonpath: %w", err)}
+		}
+		return GetOutput{JSONPath: jp}, nil
+	}
+	switch format := output.Format(strings.ToLower(value)); format {
+	case output.FormatYAML, output.FormatJSON:
+		return GetOutput{Format: format}, nil
+	}
+	return GetOutput{}, &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+		Err: fmt.Errorf("invalid output format %q (valid: yaml, json, jsonpath=<expr>)", value)}
+}
+
+// Write prints obj to w in the selected output.
+func (o GetOutput) Write(obj *unstructured.Unstructured, w io.Writer) error {
+	if o.JSONPath == nil {
+		return output.WriteResource(obj, o.Format, w)
+	}
+	var buf bytes.Buffer
+	if err := o.JSONPath.Execute(&buf, obj.Object); err != nil {
+		return fmt.Errorf("evaluating jsonpath: %w", err)
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// GetTrackedResource returns the live resource ref names, which must be one
+// the instance tracks (see FindTrackedResource). A tracked resource missing
+// from the cluster is not found.
+func GetTrackedResource(opts kubernetes.StatusOptions, ref string) (*unstructured.Unstructured, error) {
+	res, missing, err := FindTrackedResource(opts, ref)
+	if err != nil {
+		return nil, err
+	}
+	if missing != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitNotFound,
+			Err: fmt.Errorf("%s is tracked by instance %q but missing from the cluster", qualifiedRef(missing.Group, missing.Kind, missing.Namespace, missing.Name), opts.InstanceName)}
+	}
+	return res, nil
+}
+
+// GetAnyResource fetches the resource ref names whether or not an instance
+// tracks it. A namespaced resource without a namespace in ref is looked up in
+// namespace.
+func GetAnyResource(ctx context.Context, client *kubernetes.Client, ref, namespace string) (*unstructured.Unstructured, error) {
+	r, err := parseResourceRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	gvr, namespaced, err := client.ResourceForKind(r.kind, r.group)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	ns := ""
+	if namespaced {
+		ns = namespace
+		if r.namespace != "" {
+			ns = r.namespace
+		}
+	}
+	obj, err := client.ResourceClient(gvr, ns).Get(ctx, r.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: fmt.Errorf("%s not found", ref)}
+	}
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("fetching %s: %w", ref, err)}
+	}
+	return obj, nil
+}
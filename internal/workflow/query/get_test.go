@@ -0,0 +1,86 @@
+package query
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+func TestParseGetOutput(t *testing.T) {
+	for _, value := range []string{"yaml", "json", "JSON"} {
+		out, err := ParseGetOutput(value)
+		require.NoError(t, err, value)
+		assert.True(t, output.IsManifestFormat(out.Format), value)
+		assert.Nil(t, out.JSONPath)
+	}
+
+	for _, value := range []string{"table", "wide", "jsonpath=", "jsonpath={.spec"} {
+		_, err := ParseGetOutput(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestGetOutput_Write(t *testing.T) {
+	deploy := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web"},
+		"spec":       map[string]any{"replicas": int64(3)},
+	}}
+
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{output: "jsonpath={.spec.replicas}", want: "3\n"},
+		{output: "jsonpath=.metadata.name", want: "web\n"},
+		{output: "json", want: `"replicas": 3`},
+		{output: "yaml", want: "replicas: 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			out, err := ParseGetOutput(tt.output)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, out.Write(deploy, &buf))
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+
+	out, err := ParseGetOutput("jsonpath={.status.readyReplicas}")
+	require.NoError(t, err)
+	assert.Error(t, out.Write(deploy, &bytes.Buffer{}), "a missing field is an error, as in kubectl")
+}
+
+func TestGetTrackedResource(t *testing.T) {
+	deploy := &unstructured.Unstructured{}
+	deploy.SetKind("Deployment")
+	deploy.SetName("web")
+	opts := kubernetes.StatusOptions{
+		InstanceName:     "demo",
+		InventoryLive:    []*unstructured.Unstructured{deploy},
+		MissingResources: []kubernetes.MissingResource{{Kind: "ConfigMap", Namespace: "apps", Name: "cfg"}},
+	}
+
+	res, err := GetTrackedResource(opts, "deployments/web")
+	require.NoError(t, err)
+	assert.Same(t, deploy, res)
+
+	for ref, want := range map[string]string{
+		"ConfigMap/cfg":  "missing from the cluster",
+		"Deployment/api": `not tracked by instance "demo"`,
+	} {
+		_, err := GetTrackedResource(opts, ref)
+		var exitErr *opmexit.ExitError
+		require.ErrorAs(t, err, &exitErr, ref)
+		assert.Equal(t, opmexit.ExitNotFound, exitErr.Code, ref)
+		assert.Contains(t, err.Error(), want, ref)
+	}
+}
@@ -3,6 +3,9 @@ package query
 import (
 	"context"
 	"errors"
+	"net"
+	"net/url"
+	"syscall"
 	"testing"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
@@ -13,10 +16,12 @@ import (
 	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func makeCRClient(objs ...*unstructured.Unstructured) *kubernetes.Client {
@@ -68,7 +73,7 @@ func TestResolveInventory_ByInstanceName_Success(t *testing.T) {
 	client := makeCRClient(cr)
 	ctx := context.Background()
 	rsf := &cmdutil.InstanceSelectorFlags{InstanceName: "myapp", Namespace: "default"}
-	inv, live, missing, err := ResolveInventory(ctx, client, rsf, "default", silentLogger())
+	inv, live, missing, err := ResolveInventory(ctx, client, rsf, "default", inventory.ReadPrefer, silentLogger())
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 	assert.Equal(t, "myapp", inv.Name)
@@ -82,7 +87,7 @@ func TestResolveInventory_ByInstanceID_Success(t *testing.T) {
 	client := makeCRClient(cr)
 	ctx := context.Background()
 	rsf := &cmdutil.InstanceSelectorFlags{InstanceName: "myapp", InstanceID: "uuid-xyz-789", Namespace: "production"}
-	inv, live, missing, err := ResolveInventory(ctx, client, rsf, "production", silentLogger())
+	inv, live, missing, err := ResolveInventory(ctx, client, rsf, "production", inventory.ReadPrefer, silentLogger())
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 	assert.Equal(t, "uuid-xyz-789", inv.InstanceUUID)
@@ -95,7 +100,7 @@ func TestResolveInventory_ByInstanceID_NoInstanceName(t *testing.T) {
 	client := makeCRClient(cr)
 	ctx := context.Background()
 	rsf := &cmdutil.InstanceSelectorFlags{InstanceID: "uuid-nnn-000", Namespace: "default"}
-	inv, _, _, err := ResolveInventory(ctx, client, rsf, "default", silentLogger())
+	inv, _, _, err := ResolveInventory(ctx, client, rsf, "default", inventory.ReadPrefer, silentLogger())
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 	assert.Equal(t, "uuid-nnn-000", inv.InstanceUUID)
@@ -105,7 +110,7 @@ func TestResolveInventory_NotFound(t *testing.T) {
 	client := makeCRClient()
 	ctx := context.Background()
 	rsf := &cmdutil.InstanceSelectorFlags{InstanceName: "nonexistent", Namespace: "default"}
-	inv, live, missing, err := ResolveInventory(ctx, client, rsf, "default", silentLogger())
+	inv, live, missing, err := ResolveInventory(ctx, client, rsf, "default", inventory.ReadPrefer, silentLogger())
 	require.Error(t, err)
 	assert.Nil(t, inv)
 	assert.Nil(t, live)
@@ -114,3 +119,49 @@ func TestResolveInventory_NotFound(t *testing.T) {
 	require.True(t, errors.As(err, &exitErr))
 	assert.Equal(t, opmexit.ExitNotFound, exitErr.Code)
 }
+
+// Each way ResolveInventory fails exits with its documented code, and is
+// marked printed because ResolveInventory has already logged it.
+func TestResolveInventory_FailureExitCodes(t *testing.T) {
+	failing := func(err error) *kubernetes.Client {
+		client := makeCRClient()
+		fake, ok := client.Dynamic.(*dynamicfake.FakeDynamicClient)
+		require.True(t, ok)
+		fake.PrependReactor("get", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, err
+		})
+		return client
+	}
+	unreadable := makeModuleInstanceCR("myapp", "default", "uuid-abc-123")
+	unreadable.Object["status"].(map[string]any)["inventory"] = "corrupted"
+	refused := &url.Error{Op: "Get", URL: "https://cluster.example.com:6443",
+		Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+
+	tests := []struct {
+		name     string
+		client   *kubernetes.Client
+		mode     inventory.ReadMode
+		wantCode int
+	}{
+		{name: "not found", client: makeCRClient(), wantCode: opmexit.ExitNotFound},
+		{name: "forbidden", client: failing(apierrors.NewForbidden(inventory.ModuleInstanceGVR.GroupResource(), "myapp", nil)), wantCode: opmexit.ExitPermissionDenied},
+		{name: "cluster unreachable", client: failing(refused), wantCode: opmexit.ExitConnectivityError},
+		{name: "server error", client: failing(apierrors.NewInternalError(errors.New("etcd down"))), wantCode: opmexit.ExitGeneralError},
+		{name: "unreadable inventory", client: makeCRClient(unreadable), mode: inventory.ReadRequire, wantCode: opmexit.ExitGeneralError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := tt.mode
+			if mode == "" {
+				mode = inventory.ReadPrefer
+			}
+			rsf := &cmdutil.InstanceSelectorFlags{InstanceName: "myapp", Namespace: "default"}
+			_, _, _, err := ResolveInventory(context.Background(), tt.client, rsf, "default", mode, silentLogger())
+
+			var exitErr *opmexit.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, tt.wantCode, exitErr.Code)
+			assert.True(t, exitErr.Printed, "ResolveInventory logs the failure itself")
+		})
+	}
+}
@@ -17,13 +17,16 @@ import (
 )
 
 type InstanceSummary struct {
-	Name        string `json:"name" yaml:"name"`
-	Module      string `json:"module" yaml:"module"`
-	Namespace   string `json:"namespace" yaml:"namespace"`
-	Version     string `json:"version" yaml:"version"`
-	Status      string `json:"status" yaml:"status"`
-	ReadyCount  int    `json:"readyCount" yaml:"readyCount"`
-	TotalCount  int    `json:"totalCount" yaml:"totalCount"`
+	Name       string `json:"name" yaml:"name"`
+	Module     string `json:"module" yaml:"module"`
+	Namespace  string `json:"namespace" yaml:"namespace"`
+	Version    string `json:"version" yaml:"version"`
+	Status     string `json:"status" yaml:"status"`
+	ReadyCount int    `json:"readyCount" yaml:"readyCount"`
+	TotalCount int    `json:"totalCount" yaml:"totalCount"`
+	// Resources is the inventory's resource count, read from the record —
+	// unlike TotalCount, it does not depend on reaching the resources.
+	Resources   int    `json:"resources" yaml:"resources"`
 	InstanceID  string `json:"instanceID" yaml:"instanceID"`
 	LastApplied string `json:"lastApplied" yaml:"lastApplied"`
 	Age         string `json:"age" yaml:"age"`
@@ -85,6 +88,7 @@ func BuildInstanceSummary(inv *inventory.Record) InstanceSummary {
 		Namespace:  inv.Namespace,
 		InstanceID: inv.InstanceUUID,
 		Owner:      inventory.DisplayOwner(inv.Owner),
+		Resources:  len(inv.Inventory.Entries),
 	}
 	if inv.ModuleVersion != "" {
 		s.Version = inv.ModuleVersion
@@ -19,6 +19,10 @@ func TestBuildInstanceSummary(t *testing.T) {
 		ModulePath:    "module-a",
 		ModuleVersion: "0.1.0",
 		LastAppliedAt: now,
+		Inventory: inventory.Inventory{Entries: []inventory.InventoryEntry{
+			{Kind: "Deployment", Namespace: "apps", Name: "demo"},
+			{Kind: "Service", Namespace: "apps", Name: "demo"},
+		}},
 	}
 	summary := BuildInstanceSummary(inv)
 	assert.Equal(t, "demo", summary.Name)
@@ -26,6 +30,7 @@ func TestBuildInstanceSummary(t *testing.T) {
 	assert.Equal(t, "cli", summary.Owner)
 	assert.Equal(t, "0.1.0", summary.Version)
 	assert.Equal(t, "uuid-1", summary.InstanceID)
+	assert.Equal(t, 2, summary.Resources)
 	assert.NotEmpty(t, summary.Age)
 }
 
@@ -2,7 +2,14 @@ package query
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
@@ -16,20 +23,26 @@ import (
 
 func ParseStatusOutputFormat(outputFmt string) (output.Format, error) {
 	outputFormat, valid := output.ParseFormat(outputFmt)
+	if outputFormat == output.FormatPrometheus {
+		return outputFormat, nil
+	}
 	if !valid || outputFormat == output.FormatDir {
 		return "", &opmexit.ExitError{
 			Code: opmexit.ExitGeneralError,
-			Err:  fmt.Errorf("invalid output format %q (valid: table, wide, yaml, json)", outputFmt),
+			Err:  fmt.Errorf("invalid output format %q (valid: table, wide, yaml, json, prometheus)", outputFmt),
 		}
 	}
 	return outputFormat, nil
 }
 
+// ResolveInventory reads the selected instance's ModuleInstance and finds its
+// live resources as mode directs (see inventory.DiscoverResources).
 func ResolveInventory(
 	ctx context.Context,
 	client *kubernetes.Client,
 	rsf *cmdutil.InstanceSelectorFlags,
 	namespace string,
+	mode inventory.ReadMode,
 	instanceLog *log.Logger,
 ) (inv *inventory.Record, live []*unstructured.Unstructured, missing []inventory.InventoryEntry, err error) {
 	var invErr error
@@ -45,7 +58,7 @@ func ResolveInventory(
 
 	if invErr != nil {
 		instanceLog.Error("reading inventory", "error", invErr)
-		err = &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("reading inventory: %w", invErr)}
+		err = &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(invErr), Err: fmt.Errorf("reading inventory: %w", invErr), Printed: true}
 		return nil, nil, nil, err
 	}
 
@@ -60,10 +73,10 @@ func ResolveInventory(
 		return nil, nil, nil, err
 	}
 
-	liveResources, missingEntries, discoverErr := inventory.DiscoverResourcesFromInventory(ctx, client, inv)
+	liveResources, missingEntries, discoverErr := inventory.DiscoverResources(ctx, client, inv, mode)
 	if discoverErr != nil {
 		instanceLog.Error("discovering resources from inventory", "error", discoverErr)
-		err = &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("discovering resources: %w", discoverErr)}
+		err = &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(discoverErr), Err: fmt.Errorf("discovering resources: %w", discoverErr), Printed: true}
 		return nil, nil, nil, err
 	}
 
@@ -84,15 +97,19 @@ func BuildStatusOptions(namespace string, rsf *cmdutil.InstanceSelectorFlags, ou
 		InstanceName:  rsf.InstanceName,
 		InstanceID:    rsf.InstanceID,
 		Version:       inv.ModuleVersion,
+		ModuleDigest:  inv.ModuleDigest,
+		LastOperation: inv.LastOperation,
 		Owner:         inventory.DisplayOwner(inv.Owner),
 		ComponentMap:  componentMap,
 		OutputFormat:  outputFormat,
 		InventoryLive: liveResources,
+		ForeignOwned:  inventory.VerifyOwnership(inv, liveResources),
 		Wide:          outputFormat == output.FormatWide,
 		Verbose:       verbose,
 	}
 	for _, m := range missingEntries {
 		statusOpts.MissingResources = append(statusOpts.MissingResources, kubernetes.MissingResource{
+			Group:     m.Group,
 			Kind:      m.Kind,
 			Namespace: m.Namespace,
 			Name:      m.Name,
@@ -106,12 +123,7 @@ func PrintInstanceStatus(ctx context.Context, client *kubernetes.Client, opts ku
 
 	result, err := kubernetes.GetInstanceStatus(ctx, client, opts)
 	if err != nil {
-		if kubernetes.IsNoResourcesFound(err) {
-			instanceLog.Error("getting status", "error", err)
-			return &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: err, Printed: true}
-		}
-		instanceLog.Error("getting status", "error", err)
-		return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err, Printed: true}
+		return statusError(instanceLog, err)
 	}
 
 	formatted, err := kubernetes.FormatStatus(result, opts.OutputFormat)
@@ -120,9 +132,363 @@ func PrintInstanceStatus(ctx context.Context, client *kubernetes.Client, opts ku
 		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
 	}
 	output.Println(formatted)
+	if opts.OutputFormat == output.FormatPrometheus {
+		// The gauges carry readiness; a collector writing them to a file
+		// must not see a failed run for an unhealthy instance.
+		return nil
+	}
+	return notReadyError(result)
+}
+
+// statusError reports a failed status evaluation and maps it to an exit
+// code. Errors that are already exit errors (from ResolveInventory) pass
+// through; they have been logged.
+func statusError(instanceLog *log.Logger, err error) error {
+	var exitErr *opmexit.ExitError
+	if errors.As(err, &exitErr) {
+		return err
+	}
+	instanceLog.Error("getting status", "error", err)
+	if kubernetes.IsNoResourcesFound(err) {
+		return &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: err, Printed: true}
+	}
+	return &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err, Printed: true}
+}
+
+// statusReady reports whether an aggregate status counts as healthy.
+func statusReady(result *kubernetes.StatusResult) bool {
+	return result.AggregateStatus == kubernetes.HealthReady || result.AggregateStatus == kubernetes.HealthComplete
+}
+
+// notReadyError is the exit for a status that is not healthy: the status
+// itself was printed, so the error is marked printed too.
+func notReadyError(result *kubernetes.StatusResult) error {
+	if statusReady(result) {
+		return nil
+	}
+	return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("instance %q: %d resource(s) not ready", result.InstanceName, result.Summary.NotReady), Printed: true}
+}
+
+// StatusWatchInterval is how often status --watch re-evaluates.
+const StatusWatchInterval = 2 * time.Second
+
+// StatusEvaluator evaluates an instance's status once.
+type StatusEvaluator func(ctx context.Context) (*kubernetes.StatusResult, error)
+
+// InstanceStatusEvaluator returns a StatusEvaluator that re-reads the
+// inventory and the live resources on every call, so a resource that was
+// Missing is picked up once it exists.
+func InstanceStatusEvaluator(client *kubernetes.Client, rsf *cmdutil.InstanceSelectorFlags, namespace string, mode inventory.ReadMode, outputFormat output.Format, verbose bool, instanceLog *log.Logger) StatusEvaluator {
+	return func(ctx context.Context) (*kubernetes.StatusResult, error) {
+		inv, live, missing, err := ResolveInventory(ctx, client, rsf, namespace, mode, instanceLog)
+		if err != nil {
+			return nil, err
+		}
+		opts := BuildStatusOptions(namespace, rsf, outputFormat, verbose, inv, live, missing)
+		return kubernetes.GetInstanceStatus(ctx, client, opts)
+	}
+}
+
+// WatchInstanceStatus re-evaluates status every interval (status --watch)
+// until the instance is Ready or Complete, then exits 0. Table and wide
+// output are redrawn in place on a terminal and appended otherwise; JSON is
+// one compact object per evaluation (newline-delimited). On SIGINT or
+// SIGTERM it prints a final snapshot and exits as a one-shot status would.
+func WatchInstanceStatus(ctx context.Context, evaluate StatusEvaluator, outputFormat output.Format, interval time.Duration, logName string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return watchStatus(ctx, evaluate, outputFormat, interval, output.StdoutIsTerminal(), output.Print, output.InstanceLogger(logName))
+}
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+func watchStatus(ctx context.Context, evaluate StatusEvaluator, outputFormat output.Format, interval time.Duration,
+	redraw bool, print func(string), instanceLog *log.Logger) error {
+	show := func(result *kubernetes.StatusResult) error {
+		if outputFormat == output.FormatJSON {
+			b, err := json.Marshal(result)
+			if err != nil {
+				return err
+			}
+			print(string(b) + "\n")
+			return nil
+		}
+		formatted, err := kubernetes.FormatStatus(result, outputFormat)
+		if err != nil {
+			return err
+		}
+		if redraw {
+			formatted = clearScreen + formatted
+		}
+		print(formatted + "\n")
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := evaluate(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			return statusError(instanceLog, err)
+		}
+		if err := show(result); err != nil {
+			instanceLog.Error("formatting status", "error", err)
+			return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+		}
+		if statusReady(result) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+			continue
+		}
+		break
+	}
+
+	// Interrupted: one last evaluation, outside the cancelled context.
+	result, err := evaluate(context.WithoutCancel(ctx))
+	if err != nil {
+		return statusError(instanceLog, err)
+	}
+	if err := show(result); err != nil {
+		instanceLog.Error("formatting status", "error", err)
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+	}
+	return notReadyError(result)
+}
+
+// ParseWaitForStatus validates a status --wait-for value: the aggregate
+// status to wait for, Ready or NotReady (case-insensitive).
+func ParseWaitForStatus(value string) (kubernetes.HealthStatus, error) {
+	for _, s := range []kubernetes.HealthStatus{kubernetes.HealthReady, kubernetes.HealthNotReady} {
+		if strings.EqualFold(value, string(s)) {
+			return s, nil
+		}
+	}
+	return "", &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("invalid --wait-for %q (valid: Ready, NotReady)", value)}
+}
+
+// reachedStatus reports whether an evaluation satisfies a wait target. Ready
+// is satisfied by every healthy aggregate, as in the one-shot status exit.
+func reachedStatus(result *kubernetes.StatusResult, want kubernetes.HealthStatus) bool {
+	if want == kubernetes.HealthReady {
+		return statusReady(result)
+	}
+	return result.AggregateStatus == want
+}
+
+// WaitForInstanceStatus blocks until the instance's aggregate status reaches
+// want, re-evaluating every interval, and prints the final status. It exits 0
+// once the status is reached and non-zero, naming the resources that are not
+// ready, when timeout passes first. Every evaluation re-reads the inventory,
+// so a tracked resource that is Missing counts as not ready until it exists.
+// It is the readiness gate for any command that waits on rollout health.
+func WaitForInstanceStatus(ctx context.Context, evaluate StatusEvaluator, want kubernetes.HealthStatus, outputFormat output.Format, timeout, interval time.Duration, logName string) error {
+	instanceLog := output.InstanceLogger(logName)
+	instanceLog.Info(fmt.Sprintf("waiting up to %s for status %s", timeout, want))
+
+	result, reached, err := waitForStatus(ctx, evaluate, want, timeout, interval)
+	if err != nil {
+		return statusError(instanceLog, err)
+	}
+
+	formatted, err := kubernetes.FormatStatus(result, outputFormat)
+	if err != nil {
+		instanceLog.Error("formatting status", "error", err)
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+	}
+	output.Println(formatted)
+
+	if reached {
+		instanceLog.Info(fmt.Sprintf("status %s", result.AggregateStatus))
+		return nil
+	}
+	err = fmt.Errorf("instance %q: timed out after %s waiting for status %s (currently %s)", result.InstanceName, timeout, want, result.AggregateStatus)
+	if notReady := result.NotReadyResources(); len(notReady) > 0 && want == kubernetes.HealthReady {
+		err = fmt.Errorf("%w; not ready: %s", err, strings.Join(notReady, ", "))
+	}
+	instanceLog.Error("wait failed", "error", err)
+	return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+}
+
+// waitForStatus polls evaluate until the status reaches want or timeout
+// passes. It returns the last evaluation and whether it reached want; an
+// evaluation error ends the wait.
+func waitForStatus(ctx context.Context, evaluate StatusEvaluator, want kubernetes.HealthStatus, timeout, interval time.Duration) (*kubernetes.StatusResult, bool, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *kubernetes.StatusResult
+	for {
+		result, err := evaluate(waitCtx)
+		if waitCtx.Err() != nil {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		last = result
+		if reachedStatus(result, want) {
+			return result, true, nil
+		}
+		output.Debug("status not reached", "want", want, "status", result.AggregateStatus,
+			"ready", result.Summary.Ready, "total", result.Summary.Total)
+
+		select {
+		case <-waitCtx.Done():
+		case <-ticker.C:
+			continue
+		}
+		break
+	}
+
+	if last == nil {
+		// The deadline cut the first evaluation short: take one outside it so
+		// the timeout still reports what is not ready.
+		result, err := evaluate(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		return result, reachedStatus(result, want), nil
+	}
+	return last, false, nil
+}
+
+// FindTrackedResource picks the resource named by ref out of an instance's
+// status options: the live resource when it exists, otherwise the inventory
+// entry that is missing from the cluster. ref is kind/name or
+// kind/namespace/name, where kind may carry an API group as kind.group
+// (Certificate.cert-manager.io/web), like kubectl's resource arguments. Kind
+// matches case-insensitively, as either the Kind or its plural resource name
+// ("deployment", "deployments").
+func FindTrackedResource(opts kubernetes.StatusOptions, ref string) (*unstructured.Unstructured, *kubernetes.MissingResource, error) {
+	r, err := parseResourceRef(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var live []*unstructured.Unstructured
+	var candidates []string
+	for _, res := range opts.InventoryLive {
+		if g := res.GroupVersionKind().Group; r.matches(g, res.GetKind(), res.GetNamespace(), res.GetName()) {
+			live = append(live, res)
+			candidates = append(candidates, qualifiedRef(g, res.GetKind(), res.GetNamespace(), res.GetName()))
+		}
+	}
+	var missing []kubernetes.MissingResource
+	for _, m := range opts.MissingResources {
+		if r.matches(m.Group, m.Kind, m.Namespace, m.Name) {
+			missing = append(missing, m)
+			candidates = append(candidates, qualifiedRef(m.Group, m.Kind, m.Namespace, m.Name))
+		}
+	}
+
+	switch {
+	case len(candidates) > 1:
+		return nil, nil, &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("%q matches %d tracked resources (%s); qualify it as kind.group/namespace/name",
+				ref, len(candidates), strings.Join(candidates, ", ")),
+		}
+	case len(live) == 1:
+		return live[0], nil, nil
+	case len(missing) == 1:
+		return nil, &missing[0], nil
+	}
+	return nil, nil, &opmexit.ExitError{
+		Code: opmexit.ExitNotFound,
+		Err:  fmt.Errorf("%s is not tracked by instance %q — run 'opm instance status' to list its resources", ref, opts.InstanceName),
+	}
+}
+
+// resourceRef is a parsed resource argument (see FindTrackedResource).
+type resourceRef struct {
+	kind, group, namespace, name string
+	hasGroup                     bool
+}
+
+// parseResourceRef parses kind[.group]/name or kind[.group]/namespace/name.
+func parseResourceRef(ref string) (resourceRef, error) {
+	parts := strings.Split(ref, "/")
+	var r resourceRef
+	switch len(parts) {
+	case 2:
+		r.kind, r.name = parts[0], parts[1]
+	case 3:
+		r.kind, r.namespace, r.name = parts[0], parts[1], parts[2]
+	}
+	if r.kind == "" || r.name == "" || (len(parts) == 3 && r.namespace == "") {
+		return resourceRef{}, &opmexit.ExitError{
+			Code: opmexit.ExitGeneralError,
+			Err:  fmt.Errorf("invalid resource %q: expected kind/name or kind[.group]/namespace/name (e.g. Deployment/web, Deployment.apps/media/web)", ref),
+		}
+	}
+	r.kind, r.group, r.hasGroup = strings.Cut(r.kind, ".")
+	return r, nil
+}
+
+// matches reports whether a resource of group g and kind k named ns/n is the
+// one r names.
+func (r resourceRef) matches(g, k, ns, n string) bool {
+	return (strings.EqualFold(k, r.kind) || strings.EqualFold(kubernetes.KindToResource(k), r.kind)) &&
+		(!r.hasGroup || strings.EqualFold(g, r.group)) &&
+		(r.namespace == "" || ns == r.namespace) &&
+		n == r.name
+}
+
+// qualifiedRef formats a resource in the fully qualified --resource syntax,
+// kind[.group]/namespace/name, omitting what is empty.
+func qualifiedRef(group, kind, namespace, name string) string {
+	ref := kind
+	if group != "" {
+		ref += "." + group
+	}
+	if namespace != "" {
+		ref += "/" + namespace
+	}
+	return ref + "/" + name
+}
+
+// PrintResourceDetail prints the drill-down status of the resource named by
+// ref. Like PrintInstanceStatus, it exits non-zero when the resource is not
+// ready.
+func PrintResourceDetail(ctx context.Context, client *kubernetes.Client, opts kubernetes.StatusOptions, ref, logName string) error {
+	instanceLog := output.InstanceLogger(logName)
+
+	res, missing, err := FindTrackedResource(opts, ref)
+	if err != nil {
+		instanceLog.Error("selecting resource", "error", err)
+		var exitErr *opmexit.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Printed = true
+		}
+		return err
+	}
+
+	var detail *kubernetes.ResourceDetail
+	if missing != nil {
+		detail = kubernetes.MissingResourceDetail(*missing, opts)
+	} else {
+		detail = kubernetes.GetResourceDetail(ctx, client, res, opts)
+	}
+
+	formatted, err := kubernetes.FormatResourceDetail(detail, opts.OutputFormat)
+	if err != nil {
+		instanceLog.Error("formatting resource status", "error", err)
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err, Printed: true}
+	}
+	output.Println(formatted)
 
-	if result.AggregateStatus != "Ready" && result.AggregateStatus != "Complete" {
-		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("instance %q: %d resource(s) not ready", opts.InstanceName, result.Summary.NotReady), Printed: true}
+	if !detail.IsHealthy() {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("%s/%s is %s", detail.Kind, detail.Name, detail.Status), Printed: true}
 	}
 	return nil
 }
@@ -1,10 +1,16 @@
 package query
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/open-platform-model/cli/internal/cmdutil"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
 	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +21,9 @@ func TestParseStatusOutputFormat(t *testing.T) {
 	format, err := ParseStatusOutputFormat("wide")
 	require.NoError(t, err)
 	assert.Equal(t, output.FormatWide, format)
+	format, err = ParseStatusOutputFormat("prometheus")
+	require.NoError(t, err)
+	assert.Equal(t, output.FormatPrometheus, format)
 	_, err = ParseStatusOutputFormat("dir")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid output format")
@@ -39,3 +48,170 @@ func TestBuildStatusOptions(t *testing.T) {
 	require.Len(t, opts.MissingResources, 1)
 	assert.Equal(t, "ConfigMap", opts.MissingResources[0].Kind)
 }
+
+func TestFindTrackedResource(t *testing.T) {
+	deploy := &unstructured.Unstructured{}
+	deploy.SetKind("Deployment")
+	deploy.SetName("web")
+	svc := &unstructured.Unstructured{}
+	svc.SetKind("Service")
+	svc.SetName("web")
+	opts := kubernetes.StatusOptions{
+		InstanceName:     "demo",
+		InventoryLive:    []*unstructured.Unstructured{deploy, svc},
+		MissingResources: []kubernetes.MissingResource{{Kind: "ConfigMap", Namespace: "apps", Name: "cfg"}},
+	}
+
+	for _, ref := range []string{"Deployment/web", "deployment/web", "deployments/web"} {
+		res, missing, err := FindTrackedResource(opts, ref)
+		require.NoError(t, err, ref)
+		assert.Same(t, deploy, res, ref)
+		assert.Nil(t, missing)
+	}
+
+	res, missing, err := FindTrackedResource(opts, "ConfigMap/cfg")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+	require.NotNil(t, missing)
+	assert.Equal(t, "cfg", missing.Name)
+
+	tests := []struct {
+		ref      string
+		wantCode int
+		wantMsg  string
+	}{
+		{ref: "web", wantCode: opmexit.ExitGeneralError, wantMsg: "expected kind/name"},
+		{ref: "Deployment/", wantCode: opmexit.ExitGeneralError, wantMsg: "expected kind/name"},
+		{ref: "Deployment/a/b/web", wantCode: opmexit.ExitGeneralError, wantMsg: "expected kind/name"},
+		{ref: "Deployment//web", wantCode: opmexit.ExitGeneralError, wantMsg: "expected kind/name"},
+		{ref: "Deployment/api", wantCode: opmexit.ExitNotFound, wantMsg: `not tracked by instance "demo"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			_, _, err := FindTrackedResource(opts, tt.ref)
+			var exitErr *opmexit.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, tt.wantCode, exitErr.Code)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestFindTrackedResource_Qualified(t *testing.T) {
+	resource := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	certManager := resource("cert-manager.io/v1", "Certificate", "media", "web")
+	other := resource("certs.example.com/v1", "Certificate", "media", "web")
+	cmMedia := resource("v1", "ConfigMap", "media", "cfg")
+	cmOther := resource("v1", "ConfigMap", "other", "cfg")
+	opts := kubernetes.StatusOptions{
+		InstanceName:  "demo",
+		InventoryLive: []*unstructured.Unstructured{certManager, other, cmMedia, cmOther},
+	}
+
+	_, _, err := FindTrackedResource(opts, "Certificate/web")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Certificate.cert-manager.io/media/web, Certificate.certs.example.com/media/web")
+	assert.Contains(t, err.Error(), "kind.group/namespace/name")
+
+	res, _, err := FindTrackedResource(opts, "certificate.cert-manager.io/web")
+	require.NoError(t, err)
+	assert.Same(t, certManager, res)
+
+	_, _, err = FindTrackedResource(opts, "ConfigMap/cfg")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ConfigMap/media/cfg, ConfigMap/other/cfg")
+
+	res, _, err = FindTrackedResource(opts, "ConfigMap/other/cfg")
+	require.NoError(t, err)
+	assert.Same(t, cmOther, res)
+}
+
+func TestWatchStatus_UntilReady(t *testing.T) {
+	results := []*kubernetes.StatusResult{
+		{InstanceName: "demo", AggregateStatus: kubernetes.HealthNotReady},
+		{InstanceName: "demo", AggregateStatus: kubernetes.HealthReady},
+	}
+	calls := 0
+	evaluate := func(context.Context) (*kubernetes.StatusResult, error) {
+		r := results[calls]
+		calls++
+		return r, nil
+	}
+	var printed []string
+	err := watchStatus(context.Background(), evaluate, output.FormatJSON, time.Millisecond, false,
+		func(s string) { printed = append(printed, s) }, output.InstanceLogger("demo"))
+	require.NoError(t, err)
+	require.Len(t, printed, 2)
+	assert.Equal(t, `{"instanceName":"demo"`, printed[0][:len(`{"instanceName":"demo"`)])
+	assert.Contains(t, printed[1], `"aggregateStatus":"Ready"`)
+	assert.True(t, strings.HasSuffix(printed[1], "}\n"))
+}
+
+func TestWatchStatus_InterruptPrintsFinalSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	evaluate := func(context.Context) (*kubernetes.StatusResult, error) {
+		calls++
+		return &kubernetes.StatusResult{InstanceName: "demo", AggregateStatus: kubernetes.HealthNotReady}, nil
+	}
+	var printed []string
+	err := watchStatus(ctx, evaluate, output.FormatTable, time.Hour, true,
+		func(s string) { printed = append(printed, s) }, output.InstanceLogger("demo"))
+	var exitErr *opmexit.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, opmexit.ExitValidationError, exitErr.Code)
+	assert.Equal(t, 2, calls)
+	require.Len(t, printed, 1)
+	assert.True(t, strings.HasPrefix(printed[0], clearScreen))
+}
+
+func TestParseWaitForStatus(t *testing.T) {
+	want, err := ParseWaitForStatus("ready")
+	require.NoError(t, err)
+	assert.Equal(t, kubernetes.HealthReady, want)
+	_, err = ParseWaitForStatus("Missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "valid: Ready, NotReady")
+}
+
+func TestWaitForStatus_MissingThenReady(t *testing.T) {
+	// The first evaluations see the tracked resource Missing; it then appears.
+	statuses := []kubernetes.HealthStatus{kubernetes.HealthNotReady, kubernetes.HealthNotReady, kubernetes.HealthReady}
+	calls := 0
+	evaluate := func(context.Context) (*kubernetes.StatusResult, error) {
+		s := statuses[calls]
+		calls++
+		return &kubernetes.StatusResult{InstanceName: "demo", AggregateStatus: s}, nil
+	}
+	result, reached, err := waitForStatus(context.Background(), evaluate, kubernetes.HealthReady, time.Minute, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, reached)
+	assert.Equal(t, kubernetes.HealthReady, result.AggregateStatus)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitForStatus_Timeout(t *testing.T) {
+	evaluate := func(context.Context) (*kubernetes.StatusResult, error) {
+		return &kubernetes.StatusResult{InstanceName: "demo", AggregateStatus: kubernetes.HealthNotReady}, nil
+	}
+	result, reached, err := waitForStatus(context.Background(), evaluate, kubernetes.HealthReady, 20*time.Millisecond, time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, reached)
+	assert.Equal(t, kubernetes.HealthNotReady, result.AggregateStatus)
+}
+
+func TestWaitForStatus_EvaluationError(t *testing.T) {
+	evaluate := func(context.Context) (*kubernetes.StatusResult, error) {
+		return nil, errors.New("forbidden")
+	}
+	_, _, err := waitForStatus(context.Background(), evaluate, kubernetes.HealthReady, time.Minute, time.Millisecond)
+	require.EqualError(t, err, "forbidden")
+}
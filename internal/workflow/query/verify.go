@@ -0,0 +1,176 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// RenderDigestFunc re-renders an instance and returns its render digest.
+type RenderDigestFunc func(ctx context.Context) (string, error)
+
+// InventoryCheck is one check of an inventory verification. Skipped carries
+// the reason a check did not run; Problems lists its discrepancies.
+type InventoryCheck struct {
+	Name     string
+	Skipped  string
+	Problems []string
+}
+
+// InventoryVerification is the result of VerifyInventory.
+type InventoryVerification struct {
+	Instance  string
+	Namespace string
+	Checks    []InventoryCheck
+}
+
+// Failed reports whether any check found a discrepancy.
+func (v *InventoryVerification) Failed() bool {
+	for _, c := range v.Checks {
+		if len(c.Problems) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyInventory checks an instance's inventory record for corruption and for
+// drift from the cluster: the entries against their stored count and digest,
+// every entry against the live resources, the CR's identity labels, and —
+// when renderDigest is set — the recorded render digest against a re-render.
+// It reads only; nothing is repaired.
+func VerifyInventory(ctx context.Context, rec *inventory.Record, live []*unstructured.Unstructured, missing []inventory.InventoryEntry, renderDigest RenderDigestFunc) *InventoryVerification {
+	v := &InventoryVerification{Instance: rec.Name, Namespace: rec.Namespace}
+	v.Checks = append(v.Checks,
+		InventoryCheck{Name: "entries", Problems: inventory.VerifyEntries(rec)},
+		InventoryCheck{Name: "live resources", Problems: liveResourceProblems(rec, live, missing)},
+		InventoryCheck{Name: "labels", Problems: inventory.VerifyLabels(rec)},
+		renderDigestCheck(ctx, rec, renderDigest),
+	)
+	return v
+}
+
+// liveResourceProblems reports entries that are missing from the cluster, and
+// entries that are neither live nor missing because reading them failed.
+func liveResourceProblems(rec *inventory.Record, live []*unstructured.Unstructured, missing []inventory.InventoryEntry) []string {
+	var problems []string
+	for _, e := range rec.Inventory.Entries {
+		if entryIn(e, missing) {
+			problems = append(problems, inventory.DescribeEntry(e)+": tracked but not found in the cluster")
+			continue
+		}
+		found := false
+		for _, obj := range live {
+			if inventory.K8sIdentityEqual(e, inventory.NewEntryFromResource(obj)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, inventory.DescribeEntry(e)+": could not be read from the cluster")
+		}
+	}
+	return problems
+}
+
+func entryIn(e inventory.InventoryEntry, entries []inventory.InventoryEntry) bool {
+	for _, other := range entries {
+		if inventory.K8sIdentityEqual(e, other) {
+			return true
+		}
+	}
+	return false
+}
+
+func renderDigestCheck(ctx context.Context, rec *inventory.Record, renderDigest RenderDigestFunc) InventoryCheck {
+	check := InventoryCheck{Name: "render digest"}
+	switch {
+	case rec.LastAppliedRenderDigest == "":
+		check.Skipped = "no status.lastAppliedRenderDigest recorded"
+	case rec.SourceLocal:
+		check.Skipped = "last applied from local module source, which the registry cannot reproduce"
+	case renderDigest == nil:
+		check.Skipped = "re-render disabled"
+	default:
+		digest, err := renderDigest(ctx)
+		switch {
+		case err != nil:
+			check.Skipped = fmt.Sprintf("re-render unavailable: %v", err)
+		case digest != rec.LastAppliedRenderDigest:
+			check.Problems = []string{fmt.Sprintf("recorded %s, re-render of %s@%s gives %s",
+				rec.LastAppliedRenderDigest, rec.ModulePath, rec.ModuleVersion, digest)}
+		}
+	}
+	return check
+}
+
+// PrintInventoryVerification prints a verification as a table, one row per
+// check or discrepancy, and exits non-zero when any check failed.
+func PrintInventoryVerification(v *InventoryVerification, logName string) error {
+	tbl := output.NewTable("CHECK", "RESULT", "DETAIL")
+	for _, c := range v.Checks {
+		switch {
+		case c.Skipped != "":
+			tbl.Row(c.Name, "skipped", c.Skipped)
+		case len(c.Problems) == 0:
+			tbl.Row(c.Name, "ok", "")
+		default:
+			for i, p := range c.Problems {
+				name := c.Name
+				if i > 0 {
+					name = ""
+				}
+				tbl.Row(name, "failed", p)
+			}
+		}
+	}
+	output.Println(tbl.String())
+
+	if v.Failed() {
+		err := fmt.Errorf("instance %q: inventory does not match the recorded or actual state", v.Instance)
+		output.InstanceLogger(logName).Error("inventory verification failed")
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+	}
+	output.InstanceLogger(logName).Info("inventory verified")
+	return nil
+}
+
+// PrintGarbageCollection prints GarbageCollect's outcomes as a table, one row
+// per ModuleInstance, followed by a summary of what was (or would be)
+// reclaimed.
+func PrintGarbageCollection(outcomes []inventory.GCOutcome, dryRun bool) {
+	if len(outcomes) == 0 {
+		output.Println("No instances found")
+		return
+	}
+
+	orphanResult := "deleted"
+	if dryRun {
+		orphanResult = "would delete"
+	}
+	tbl := output.NewTable("NAMESPACE", "NAME", "ENTRIES", "RESULT", "DETAIL")
+	orphans := 0
+	for _, o := range outcomes {
+		result, detail := "kept", o.Reason
+		if o.Orphaned {
+			orphans++
+			result, detail = orphanResult, "no tracked resource exists"
+		}
+		tbl.Row(o.Namespace, o.Name, fmt.Sprintf("%d", o.Entries), result, detail)
+	}
+	output.Println(tbl.String())
+
+	switch {
+	case orphans == 0:
+		output.Info("no orphaned inventories found")
+	case dryRun:
+		output.Info(fmt.Sprintf("%d orphaned inventories would be deleted; rerun with --dry-run=false to delete them", orphans))
+	default:
+		output.Info(fmt.Sprintf("reclaimed %d orphaned inventories", orphans))
+	}
+}
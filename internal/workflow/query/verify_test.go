@@ -0,0 +1,99 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/inventory"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func verifyTestRecord() *inventory.Record {
+	entries := []inventory.InventoryEntry{
+		{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "apps", Name: "web"},
+		{Version: "v1", Kind: "Service", Namespace: "apps", Name: "web"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "apps", Name: "cfg"},
+	}
+	return &inventory.Record{
+		Name:      "demo",
+		Namespace: "apps",
+		Labels: map[string]string{
+			pkgcore.LabelManagedBy:               pkgcore.LabelManagedByValue,
+			pkgcore.LabelModuleInstanceName:      "demo",
+			pkgcore.LabelModuleInstanceNamespace: "apps",
+		},
+		ModulePath:              "example.com/web",
+		ModuleVersion:           "1.0.0",
+		LastAppliedRenderDigest: "sha256:render",
+		Inventory:               inventory.Inventory{Entries: entries, Count: 3, Digest: inventory.ComputeDigest(entries)},
+	}
+}
+
+func liveObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace("apps")
+	obj.SetName(name)
+	return obj
+}
+
+func checkByName(t *testing.T, v *InventoryVerification, name string) InventoryCheck {
+	t.Helper()
+	for _, c := range v.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	require.Failf(t, "check not found", "%s", name)
+	return InventoryCheck{}
+}
+
+func TestVerifyInventory_Consistent(t *testing.T) {
+	rec := verifyTestRecord()
+	live := []*unstructured.Unstructured{
+		liveObject("apps/v1", "Deployment", "web"),
+		liveObject("v1", "Service", "web"),
+		liveObject("v1", "ConfigMap", "cfg"),
+	}
+	v := VerifyInventory(context.Background(), rec, live, nil, func(context.Context) (string, error) {
+		return "sha256:render", nil
+	})
+	assert.False(t, v.Failed())
+	for _, c := range v.Checks {
+		assert.Empty(t, c.Skipped, c.Name)
+	}
+}
+
+func TestVerifyInventory_Discrepancies(t *testing.T) {
+	rec := verifyTestRecord()
+	// The ConfigMap is missing; the Service could not be read at all.
+	live := []*unstructured.Unstructured{liveObject("apps/v1", "Deployment", "web")}
+	missing := []inventory.InventoryEntry{rec.Inventory.Entries[2]}
+	v := VerifyInventory(context.Background(), rec, live, missing, func(context.Context) (string, error) {
+		return "sha256:other", nil
+	})
+	require.True(t, v.Failed())
+	assert.Equal(t, []string{
+		"Service/apps/web: could not be read from the cluster",
+		"ConfigMap/apps/cfg: tracked but not found in the cluster",
+	}, checkByName(t, v, "live resources").Problems)
+	assert.Contains(t, checkByName(t, v, "render digest").Problems[0], "recorded sha256:render, re-render of example.com/web@1.0.0 gives sha256:other")
+}
+
+func TestVerifyInventory_RenderDigestSkipped(t *testing.T) {
+	rec := verifyTestRecord()
+	v := VerifyInventory(context.Background(), rec, nil, nil, func(context.Context) (string, error) {
+		return "", errors.New("module not found")
+	})
+	assert.Equal(t, "re-render unavailable: module not found", checkByName(t, v, "render digest").Skipped)
+
+	rec.SourceLocal = true
+	v = VerifyInventory(context.Background(), rec, nil, nil, nil)
+	assert.Contains(t, checkByName(t, v, "render digest").Skipped, "local module source")
+}
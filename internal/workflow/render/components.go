@@ -0,0 +1,214 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+
+	"github.com/open-platform-model/library/opm/compile"
+	"github.com/open-platform-model/library/opm/schema"
+
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+// ReadComponentList reads component names from a file, one per line. Blank
+// lines and lines starting with '#' are ignored; duplicates are dropped.
+func ReadComponentList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading component list: %w", err)
+	}
+	defer f.Close()
+
+	var names []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading component list %s: %w", path, err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("component list %s names no components", path)
+	}
+	return names, nil
+}
+
+// selectComponents returns the instance package with its components narrowed
+// to the named ones, ahead of compile: the kernel then matches and transforms
+// only those, so a large module skips the rest of the compile and a CUE error
+// in an unlisted component cannot fail the build. Every name must be a
+// component of the module; unknown names are reported together so a stale
+// list can be fixed in one pass.
+//
+// CUE values cannot drop a field, so the package is rebuilt field by field
+// with a narrowed components struct in place of the original.
+func selectComponents(pkg cue.Value, names []string) (cue.Value, error) {
+	comps := pkg.LookupPath(schema.Components)
+	available := componentNames(comps)
+	known := make(map[string]bool, len(available))
+	for _, n := range available {
+		known[n] = true
+	}
+	var unknown []string
+	for _, n := range names {
+		if !known[n] {
+			unknown = append(unknown, n)
+		}
+	}
+	if len(unknown) > 0 {
+		return cue.Value{}, fmt.Errorf("unknown component(s) %s (available: %s)",
+			strings.Join(unknown, ", "), strings.Join(available, ", "))
+	}
+
+	cueCtx := pkg.Context()
+	selected := cueCtx.CompileString("{}")
+	for _, n := range names {
+		sel := cue.MakePath(cue.Str(n))
+		selected = selected.FillPath(sel, comps.LookupPath(sel))
+	}
+
+	out := cueCtx.CompileString("{}")
+	iter, err := pkg.Fields(cue.Definitions(true), cue.Hidden(true), cue.Optional(true))
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("reading instance package: %w", err)
+	}
+	for iter.Next() {
+		path := cue.MakePath(iter.Selector())
+		if path.String() == schema.Components.String() {
+			out = out.FillPath(path, selected)
+			continue
+		}
+		out = out.FillPath(path, iter.Value())
+	}
+	if err := out.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("narrowing components: %w", err)
+	}
+	return out, nil
+}
+
+// componentEnabled is the per-component toggle, relative to a component:
+// metadata.enabled, usually derived from values.
+var componentEnabled = cue.ParsePath("metadata.enabled")
+
+// disabledComponents lists, sorted, the components whose metadata.enabled is
+// concretely false. A component without the field, or with a value that is
+// not a concrete bool, is enabled.
+func disabledComponents(comps cue.Value) []string {
+	var disabled []string
+	iter, err := comps.Fields()
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		enabled, err := iter.Value().LookupPath(componentEnabled).Bool()
+		if err == nil && !enabled {
+			disabled = append(disabled, iter.Selector().Unquoted())
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
+
+// enabledSelection returns the components to narrow the compile to once
+// disabled components are dropped: the requested names, or every enabled
+// component when none were requested. Requesting a disabled component by name
+// is an error, since it would silently render nothing.
+func enabledSelection(comps cue.Value, requested, disabled []string) ([]string, error) {
+	off := make(map[string]bool, len(disabled))
+	for _, n := range disabled {
+		off[n] = true
+	}
+	if len(requested) > 0 {
+		var asked []string
+		for _, n := range requested {
+			if off[n] {
+				asked = append(asked, n)
+			}
+		}
+		if len(asked) > 0 {
+			return nil, fmt.Errorf("component(s) %s are disabled (metadata.enabled: false); enable them in values to render them",
+				strings.Join(asked, ", "))
+		}
+		return requested, nil
+	}
+	keep := []string{}
+	for _, n := range componentNames(comps) {
+		if !off[n] {
+			keep = append(keep, n)
+		}
+	}
+	return keep, nil
+}
+
+// requireMatched fails when a named component matched no transformer: it
+// would render nothing, which is never what a narrowed build asks for.
+func requireMatched(plan *compile.MatchPlan, names []string) error {
+	if plan == nil {
+		return nil
+	}
+	unmatched := make(map[string]bool, len(plan.Unmatched))
+	for _, n := range plan.Unmatched {
+		unmatched[n] = true
+	}
+	var empty []string
+	for _, n := range names {
+		if unmatched[n] {
+			empty = append(empty, n)
+		}
+	}
+	if len(empty) > 0 {
+		return fmt.Errorf("component(s) %s match no transformer and render nothing", strings.Join(empty, ", "))
+	}
+	return nil
+}
+
+// applyComponentSets fills each --set-component override into its component
+// of the synthesized instance, ahead of compile. An override unifies with the
+// component rather than replacing it: it can settle a default or fill an open
+// field, but a field the module already fixes — typically one derived from
+// values — conflicts, and that value is overridden upstream with --set.
+func applyComponentSets(pkg cue.Value, sets []loader.ComponentSet) (cue.Value, error) {
+	comps := pkg.LookupPath(schema.Components)
+	for _, s := range sets {
+		if !comps.LookupPath(cue.MakePath(cue.Str(s.Component))).Exists() {
+			return cue.Value{}, fmt.Errorf("--set-component %q: unknown component %q (available: %s)",
+				s.Expr, s.Component, strings.Join(componentNames(comps), ", "))
+		}
+		var sels []cue.Selector
+		sels = append(sels, schema.Components.Selectors()...)
+		sels = append(sels, cue.Str(s.Component))
+		sels = append(sels, s.Path.Selectors()...)
+		path := cue.MakePath(sels...)
+		filled := pkg.FillPath(path, s.Value)
+		if err := filled.LookupPath(path).Err(); err != nil {
+			return cue.Value{}, fmt.Errorf("--set-component %q: %w; the module sets this field itself — override the value it is derived from with --set", s.Expr, err)
+		}
+		pkg = filled
+	}
+	return pkg, nil
+}
+
+// componentNames lists the component names of a components struct, sorted.
+func componentNames(comps cue.Value) []string {
+	var names []string
+	iter, err := comps.Fields()
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		names = append(names, iter.Selector().Unquoted())
+	}
+	sort.Strings(names)
+	return names
+}
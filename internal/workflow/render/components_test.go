@@ -0,0 +1,157 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/open-platform-model/library/opm/compile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/pkg/loader"
+)
+
+func TestReadComponentList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "components.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# frontend only\nweb\n\n  api  \nweb\n"), 0o600))
+
+	names, err := ReadComponentList(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web", "api"}, names)
+}
+
+func TestReadComponentList_Empty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "components.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# nothing here\n\n"), 0o600))
+
+	_, err := ReadComponentList(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "names no components")
+}
+
+func TestReadComponentList_Missing(t *testing.T) {
+	_, err := ReadComponentList(filepath.Join(t.TempDir(), "absent.txt"))
+	require.Error(t, err)
+}
+
+func TestSelectComponents(t *testing.T) {
+	ctx := cuecontext.New()
+	pkg := ctx.CompileString(`
+#module: metadata: name: "demo"
+metadata: name: "demo-debug"
+values: replicas: 2
+components: {
+	web: spec: {replicas: values.replicas, image: "web:1"}
+	api: spec: image:    "api:1"
+	db: spec: storage:   _|_ // broken component
+}`)
+
+	t.Run("keeps listed components and the rest of the package", func(t *testing.T) {
+		out, err := selectComponents(pkg, []string{"web", "api"})
+		require.NoError(t, err, "the broken, unlisted component is dropped before it is evaluated")
+
+		assert.Equal(t, []string{"api", "web"}, componentNames(out.LookupPath(cue.ParsePath("components"))))
+		replicas, err := out.LookupPath(cue.ParsePath("components.web.spec.replicas")).Int64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), replicas, "references into the package still resolve")
+		name, err := out.LookupPath(cue.ParsePath("#module.metadata.name")).String()
+		require.NoError(t, err)
+		assert.Equal(t, "demo", name, "definitions are carried over")
+	})
+
+	t.Run("rejects unknown names", func(t *testing.T) {
+		_, err := selectComponents(pkg, []string{"web", "cache", "queue"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache, queue")
+		assert.Contains(t, err.Error(), "available: api, db, web")
+	})
+}
+
+func TestDisabledComponents(t *testing.T) {
+	ctx := cuecontext.New()
+	pkg := ctx.CompileString(`
+values: {cache: false, worker: true}
+components: {
+	web: spec: image: "web:1"
+	cache: metadata: enabled: values.cache
+	worker: metadata: enabled: values.worker
+	debug: metadata: enabled: false
+	pending: metadata: enabled: bool
+}`)
+	comps := pkg.LookupPath(cue.ParsePath("components"))
+
+	disabled := disabledComponents(comps)
+	assert.Equal(t, []string{"cache", "debug"}, disabled, "only a concrete false disables")
+
+	t.Run("drops disabled components when none are requested", func(t *testing.T) {
+		keep, err := enabledSelection(comps, nil, disabled)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pending", "web", "worker"}, keep)
+	})
+
+	t.Run("keeps an explicit selection of enabled components", func(t *testing.T) {
+		keep, err := enabledSelection(comps, []string{"web"}, disabled)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"web"}, keep)
+	})
+
+	t.Run("rejects requesting a disabled component", func(t *testing.T) {
+		_, err := enabledSelection(comps, []string{"web", "cache"}, disabled)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache are disabled")
+	})
+}
+
+func TestRequireMatched(t *testing.T) {
+	plan := &compile.MatchPlan{Unmatched: []string{"sidecar", "job"}}
+
+	require.NoError(t, requireMatched(plan, []string{"web"}))
+	require.NoError(t, requireMatched(nil, []string{"web"}))
+
+	err := requireMatched(plan, []string{"web", "job"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job match no transformer")
+	assert.NotContains(t, err.Error(), "sidecar")
+}
+
+func TestApplyComponentSets(t *testing.T) {
+	ctx := cuecontext.New()
+	pkg := ctx.CompileString(`
+values: replicas: 2
+components: {
+	web: spec: {replicas: *1 | int, image: "web:1"}
+	api: spec: {replicas: *1 | int, image: "api:1", scale: values.replicas}
+}`)
+	require.NoError(t, pkg.Err())
+
+	sets, err := loader.ParseComponentSets([]string{"web.spec.replicas=3"})
+	require.NoError(t, err)
+	out, err := applyComponentSets(pkg, sets)
+	require.NoError(t, err)
+
+	web, _ := out.LookupPath(cue.ParsePath("components.web.spec.replicas")).Int64()
+	api, _ := out.LookupPath(cue.ParsePath("components.api.spec.replicas")).Int64()
+	assert.Equal(t, int64(3), web, "the override settles the default on its component")
+	assert.Equal(t, int64(1), api, "other components keep their default")
+
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"db.spec.replicas=3", `unknown component "db" (available: api, web)`},
+		{"api.spec.scale=5", "override the value it is derived from with --set"},
+		{"web.spec.image=web:2", "conflicting values"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			sets, err := loader.ParseComponentSets([]string{tt.expr})
+			require.NoError(t, err)
+			_, err = applyComponentSets(pkg, sets)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
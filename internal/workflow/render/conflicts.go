@@ -0,0 +1,42 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// resourceKey identifies a rendered resource the way the API server does.
+type resourceKey struct {
+	group, kind, namespace, name string
+}
+
+// checkResourceConflicts fails a render in which two transformers produced the
+// same resource. With several catalogs subscribed — a base platform plus an
+// overlay — two of them can each offer a transformer that matches a component
+// and emits the same object; applying both would let whichever came last win
+// silently. The error names both transformers and their components, in render
+// order, so the platform or module can be fixed.
+func checkResourceConflicts(resources []*pkgcore.Resource) error {
+	first := make(map[resourceKey]*pkgcore.Resource, len(resources))
+	var conflicts []string
+	for _, r := range resources {
+		key := resourceKey{group: r.GVK().Group, kind: r.Kind(), namespace: r.Namespace(), name: r.Name()}
+		prev, seen := first[key]
+		if !seen {
+			first[key] = r
+			continue
+		}
+		id := key.kind + " " + key.name
+		if key.namespace != "" {
+			id = key.kind + " " + key.namespace + "/" + key.name
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s is rendered by both %s (component %s) and %s (component %s)",
+			id, prev.Transformer, prev.Component, r.Transformer, r.Component))
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("conflicting transformers: %s", strings.Join(conflicts, "; "))
+}
@@ -0,0 +1,33 @@
+package render
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func TestCheckResourceConflicts(t *testing.T) {
+	ctx := cuecontext.New()
+	resource := func(src, component, transformer string) *pkgcore.Resource {
+		return &pkgcore.Resource{Value: ctx.CompileString(src), Component: component, Transformer: transformer}
+	}
+	deployment := `apiVersion: "apps/v1", kind: "Deployment", metadata: {name: "web", namespace: "demo"}`
+	service := `apiVersion: "v1", kind: "Service", metadata: {name: "web", namespace: "demo"}`
+
+	require.NoError(t, checkResourceConflicts([]*pkgcore.Resource{
+		resource(deployment, "web", tfDeployment),
+		resource(service, "web", tfService),
+	}), "same name, different kinds do not conflict")
+
+	err := checkResourceConflicts([]*pkgcore.Resource{
+		resource(deployment, "web", tfDeployment),
+		resource(service, "web", tfService),
+		resource(deployment, "web", "acme.example.com/transformers/deployment@v1"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Deployment demo/web is rendered by both "+tfDeployment+" (component web) and acme.example.com/transformers/deployment@v1 (component web)")
+}
@@ -0,0 +1,242 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cueerrors "cuelang.org/go/cue/errors"
+
+	"github.com/open-platform-model/library/opm/compile"
+	oerrors "github.com/open-platform-model/library/opm/errors"
+
+	pkgerrors "github.com/open-platform-model/cli/pkg/errors"
+)
+
+// Diagnostic severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is one problem found while validating a module: a CUE error at a
+// source position, or a render-matching finding for a component.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Path     string `json:"path,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	// Related lists further positions that contribute to the error, as
+	// file:line:column: the other side of a conflict, for instance.
+	Related     []string `json:"related,omitempty"`
+	Component   string   `json:"component,omitempty"`
+	Transformer string   `json:"transformer,omitempty"`
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorDiagnostics breaks err down into one error diagnostic per problem it
+// carries: each CUE error with its path and positions, each transformer that
+// failed with its component, each component no transformer matched, each
+// trait strict mode refused. An error with none of these structures becomes a
+// single diagnostic with its message.
+func ErrorDiagnostics(err error) []Diagnostic {
+	if err == nil {
+		return nil
+	}
+	var configErr *pkgerrors.ConfigError
+	if errors.As(err, &configErr) {
+		return groupedDiagnostics(configErr.GroupedErrors())
+	}
+	return collectErrorDiagnostics(err, "", "", nil)
+}
+
+func collectErrorDiagnostics(err error, component, transformer string, out []Diagnostic) []Diagnostic {
+	switch e := err.(type) {
+	case *oerrors.TransformError:
+		return collectErrorDiagnostics(e.Cause, e.ComponentName, e.TransformerFQN, out)
+	case *compile.UnmatchedComponentsError:
+		for _, name := range e.Components {
+			out = append(out, Diagnostic{
+				Severity:  SeverityError,
+				Message:   "component matches no transformer on the platform and renders nothing",
+				Component: name,
+			})
+		}
+		return out
+	case *UnhandledTraitsError:
+		for _, t := range e.Traits {
+			out = append(out, Diagnostic{
+				Severity:  SeverityError,
+				Message:   fmt.Sprintf("strict traits: trait %s is not handled by any matched transformer", t.Trait),
+				Component: t.Component,
+			})
+		}
+		return out
+	case cueerrors.Error:
+		return append(out, cueDiagnostics(e, component, transformer)...)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			out = collectErrorDiagnostics(child, component, transformer, out)
+		}
+		return out
+	}
+	if inner := errors.Unwrap(err); inner != nil && structuredError(inner) {
+		return collectErrorDiagnostics(inner, component, transformer, out)
+	}
+	return append(out, Diagnostic{
+		Severity:    SeverityError,
+		Message:     err.Error(),
+		Component:   component,
+		Transformer: transformer,
+	})
+}
+
+// structuredError reports whether err's chain holds something
+// collectErrorDiagnostics breaks down further than its message.
+func structuredError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch err.(type) {
+		case *oerrors.TransformError, *compile.UnmatchedComponentsError, *UnhandledTraitsError, cueerrors.Error, interface{ Unwrap() []error }:
+			return true
+		}
+	}
+	return false
+}
+
+func cueDiagnostics(err cueerrors.Error, component, transformer string) []Diagnostic {
+	var out []Diagnostic
+	for _, ce := range cueerrors.Errors(err) {
+		format, args := ce.Msg()
+		msg := format
+		if len(args) > 0 {
+			msg = fmt.Sprintf(format, args...)
+		}
+		// Disjunction summaries add noise without an actionable position.
+		if strings.Contains(msg, "errors in empty disjunction") {
+			continue
+		}
+		d := Diagnostic{
+			Severity:    SeverityError,
+			Message:     msg,
+			Path:        strings.Join(ce.Path(), "."),
+			Component:   component,
+			Transformer: transformer,
+		}
+		for _, pos := range cueerrors.Positions(ce) {
+			if !pos.IsValid() {
+				continue
+			}
+			if d.Line == 0 {
+				d.File, d.Line, d.Column = pos.Filename(), pos.Line(), pos.Column()
+				continue
+			}
+			d.Related = append(d.Related, fmt.Sprintf("%s:%d:%d", pos.Filename(), pos.Line(), pos.Column()))
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func groupedDiagnostics(groups []pkgerrors.GroupedError) []Diagnostic {
+	out := make([]Diagnostic, 0, len(groups))
+	for _, g := range groups {
+		d := Diagnostic{Severity: SeverityError, Message: g.Message}
+		for _, loc := range g.Locations {
+			if d.Path == "" {
+				d.Path = loc.Path
+			}
+			if loc.Line == 0 {
+				continue
+			}
+			if d.Line == 0 {
+				d.File, d.Line, d.Column = loc.File, loc.Line, loc.Column
+				continue
+			}
+			d.Related = append(d.Related, fmt.Sprintf("%s:%d:%d", loc.File, loc.Line, loc.Column))
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// MatchDiagnostics reports what a successful render still left unhandled:
+// declared primitives no transformer on the platform requires and component
+// bodies that conflict with a transformer's (errors), and the render's
+// warnings, such as traits no matched transformer consumes.
+func MatchDiagnostics(result *Result) []Diagnostic {
+	var out []Diagnostic
+	if plan := result.MatchPlan; plan != nil {
+		for _, m := range plan.Missing {
+			msg := fmt.Sprintf("no transformer on the platform requires %s", m.FQN)
+			if len(m.Alternatives) > 0 {
+				msg += fmt.Sprintf(" (available versions: %s)", strings.Join(m.Alternatives, ", "))
+			}
+			out = append(out, Diagnostic{Severity: SeverityError, Message: msg, Component: m.Component})
+		}
+		for _, u := range plan.Unify {
+			for _, d := range collectErrorDiagnostics(u.Cause, u.Component, "", nil) {
+				d.Message = fmt.Sprintf("%s conflicts with the transformer's required body: %s", u.FQN, d.Message)
+				out = append(out, d)
+			}
+		}
+	}
+	for _, w := range result.Warnings {
+		out = append(out, Diagnostic{Severity: SeverityWarning, Message: w})
+	}
+	return out
+}
+
+// WriteDiagnosticsJSON writes diags to w as an indented JSON array.
+func WriteDiagnosticsJSON(w io.Writer, diags []Diagnostic) error {
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+	data, err := json.MarshalIndent(diags, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling diagnostics: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// WriteDiagnostics writes diags to w, one block per diagnostic: severity and
+// message, then the path, component, and source positions it concerns.
+func WriteDiagnostics(w io.Writer, diags []Diagnostic) error {
+	var b strings.Builder
+	for i, d := range diags {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s: %s\n", d.Severity, d.Message)
+		if d.Path != "" {
+			fmt.Fprintf(&b, "  path: %s\n", d.Path)
+		}
+		if d.Component != "" {
+			fmt.Fprintf(&b, "  component: %s\n", d.Component)
+		}
+		if d.Transformer != "" {
+			fmt.Fprintf(&b, "  transformer: %s\n", d.Transformer)
+		}
+		if d.Line > 0 {
+			fmt.Fprintf(&b, "    > %s:%d:%d\n", d.File, d.Line, d.Column)
+		}
+		for _, r := range d.Related {
+			fmt.Fprintf(&b, "    > %s\n", r)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/library/opm/compile"
+	oerrors "github.com/open-platform-model/library/opm/errors"
+)
+
+func TestErrorDiagnostics_CUEErrorsCarryPathAndPosition(t *testing.T) {
+	v := cuecontext.New().CompileString(`
+replicas: int & "three"
+image: tag: 1 & 2
+`, cue.Filename("module.cue"))
+	err := v.Validate()
+	require.Error(t, err)
+
+	diags := ErrorDiagnostics(fmt.Errorf("loading module: %w", err))
+	require.Len(t, diags, 2, "every CUE error is reported, not just the first")
+	paths := []string{diags[0].Path, diags[1].Path}
+	assert.ElementsMatch(t, []string{"replicas", "image.tag"}, paths)
+	for _, d := range diags {
+		assert.Equal(t, SeverityError, d.Severity)
+		assert.Equal(t, "module.cue", d.File)
+		assert.Positive(t, d.Line)
+		assert.Positive(t, d.Column)
+	}
+}
+
+func TestErrorDiagnostics_RenderErrors(t *testing.T) {
+	err := errors.Join(
+		&compile.UnmatchedComponentsError{Components: []string{"db"}},
+		fmt.Errorf("executing transforms: %w", errors.Join(&oerrors.TransformError{
+			ComponentName:  "web",
+			TransformerFQN: tfDeployment,
+			Cause:          errors.New("image is required"),
+		})),
+	)
+
+	diags := ErrorDiagnostics(err)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "db", diags[0].Component)
+	assert.Contains(t, diags[0].Message, "matches no transformer")
+	assert.Equal(t, Diagnostic{
+		Severity: SeverityError, Message: "image is required", Component: "web", Transformer: tfDeployment,
+	}, diags[1])
+}
+
+func TestErrorDiagnostics_PlainError(t *testing.T) {
+	diags := ErrorDiagnostics(fmt.Errorf("staging module source: %w", errors.New("permission denied")))
+	assert.Equal(t, []Diagnostic{{Severity: SeverityError, Message: "staging module source: permission denied"}}, diags)
+}
+
+func TestMatchDiagnostics(t *testing.T) {
+	result := explainFixture()
+	result.Warnings = []string{`component "web": trait "opmodel.dev/traits/expose@v1" is not handled by any matched transformer (values will be ignored)`}
+
+	diags := MatchDiagnostics(result)
+	require.Len(t, diags, 2)
+	assert.Equal(t, SeverityError, diags[0].Severity)
+	assert.Equal(t, "db", diags[0].Component)
+	assert.Contains(t, diags[0].Message, "opmodel.dev/resources/volume@v2")
+	assert.Contains(t, diags[0].Message, "opmodel.dev/resources/volume@v1")
+	assert.Equal(t, SeverityWarning, diags[1].Severity)
+	assert.True(t, HasErrors(diags))
+	assert.False(t, HasErrors(diags[1:]))
+}
+
+func TestWriteDiagnosticsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiagnosticsJSON(&buf, nil))
+	assert.JSONEq(t, `[]`, buf.String(), "no diagnostics is an empty list, not null")
+
+	buf.Reset()
+	require.NoError(t, WriteDiagnosticsJSON(&buf, []Diagnostic{{
+		Severity: SeverityError, Message: "conflicting values", Path: "values.replicas",
+		File: "values.cue", Line: 3, Column: 11,
+	}}))
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, map[string]any{
+		"severity": "error", "message": "conflicting values", "path": "values.replicas",
+		"file": "values.cue", "line": float64(3), "column": float64(11),
+	}, got[0])
+}
@@ -0,0 +1,234 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/open-platform-model/library/opm/schema"
+)
+
+// TransformerMatch is one transformer's verdict on a component.
+type TransformerMatch struct {
+	Transformer string `json:"transformer"`
+	// MissingLabels lists the transformer's required labels the component
+	// does not carry. Empty for a matched transformer.
+	MissingLabels []string `json:"missingLabels,omitempty"`
+}
+
+// MissingPrimitive is a resource or trait the component declares that no
+// transformer on the platform requires.
+type MissingPrimitive struct {
+	FQN string `json:"fqn"`
+	// Alternatives are the same primitive at other versions the platform
+	// does handle.
+	Alternatives []string `json:"alternatives,omitempty"`
+}
+
+// ExplainedResource is one resource the component rendered.
+type ExplainedResource struct {
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	Transformer string `json:"transformer"`
+}
+
+// TransformerContext is the #context every matched transformer received for
+// the component.
+type TransformerContext struct {
+	ModuleInstance schema.ModuleInstanceContextData `json:"moduleInstanceMetadata"`
+	Component      schema.ComponentContextData      `json:"componentMetadata"`
+	RuntimeName    string                           `json:"runtimeName"`
+}
+
+// Explanation is why a component rendered what it did: its match inputs,
+// every transformer's verdict, and the values a matched transformer received.
+type Explanation struct {
+	Component string            `json:"component"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Resources []string          `json:"resources,omitempty"`
+	Traits    []string          `json:"traits,omitempty"`
+
+	Matched    []TransformerMatch `json:"matched"`
+	NotMatched []TransformerMatch `json:"notMatched,omitempty"`
+	// Missing lists declared primitives no transformer requires: a component
+	// whose primitives are all missing can match nothing on this platform.
+	Missing []MissingPrimitive `json:"missing,omitempty"`
+	// UnhandledTraits lists declared traits no matched transformer consumes.
+	UnhandledTraits []string `json:"unhandledTraits,omitempty"`
+
+	Context  TransformerContext  `json:"context"`
+	Spec     map[string]any      `json:"spec,omitempty"`
+	Rendered []ExplainedResource `json:"rendered"`
+}
+
+// Explain builds the Explanation for one component of a render. The render
+// must have compiled the component; an unknown name is an error listing the
+// components that were compiled.
+func Explain(result *Result, component string) (*Explanation, error) {
+	var labels map[string]string
+	var resources, traits []string
+	found := false
+	names := make([]string, 0, len(result.Components))
+	for _, c := range result.Components {
+		names = append(names, c.Name)
+		if c.Name == component {
+			found = true
+			labels, resources, traits = c.Labels, c.ResourceFQNs, c.TraitFQNs
+		}
+	}
+	if !found {
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown component %q (available: %s)", component, strings.Join(names, ", "))
+	}
+
+	e := &Explanation{
+		Component: component,
+		Labels:    labels,
+		Resources: resources,
+		Traits:    traits,
+		Matched:   []TransformerMatch{},
+		Context: TransformerContext{
+			ModuleInstance: schema.ModuleInstanceContextData{
+				Name:        result.Instance.Name,
+				Namespace:   result.Instance.Namespace,
+				FQN:         result.Module.FQN,
+				Version:     result.Module.Version,
+				UUID:        result.Instance.UUID,
+				Labels:      result.Instance.Labels,
+				Annotations: result.Instance.Annotations,
+			},
+			Component:   schema.ComponentContextData{Name: component, Labels: labels},
+			RuntimeName: RuntimeName,
+		},
+		Spec:     result.ComponentSpecs[component],
+		Rendered: []ExplainedResource{},
+	}
+
+	if plan := result.MatchPlan; plan != nil {
+		for _, p := range plan.MatchedPairs() {
+			if p.ComponentName == component {
+				e.Matched = append(e.Matched, TransformerMatch{Transformer: p.TransformerFQN})
+			}
+		}
+		for _, p := range plan.NonMatchedPairs() {
+			if p.ComponentName == component {
+				e.NotMatched = append(e.NotMatched, TransformerMatch{Transformer: p.TransformerFQN, MissingLabels: p.MissingLabels})
+			}
+		}
+		for _, m := range plan.Missing {
+			if m.Component == component {
+				e.Missing = append(e.Missing, MissingPrimitive{FQN: m.FQN, Alternatives: m.Alternatives})
+			}
+		}
+		e.UnhandledTraits = plan.UnhandledTraits[component]
+	}
+
+	for i, r := range result.Typed {
+		if r.Component != component {
+			continue
+		}
+		u := result.Resources[i]
+		e.Rendered = append(e.Rendered, ExplainedResource{
+			Kind: u.GetKind(), Namespace: u.GetNamespace(), Name: u.GetName(), Transformer: r.Transformer,
+		})
+	}
+	return e, nil
+}
+
+// WriteExplanationJSON writes e to w as indented JSON.
+func WriteExplanationJSON(w io.Writer, e *Explanation) error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling explanation: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// WriteExplanation writes e to w as a human-readable report.
+func WriteExplanation(w io.Writer, e *Explanation) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Component: %s\n", e.Component)
+	writeExplainList(&b, "Labels", labelLines(e.Labels))
+	writeExplainList(&b, "Resources", e.Resources)
+	writeExplainList(&b, "Traits", e.Traits)
+
+	b.WriteString("\nMatched transformers:\n")
+	if len(e.Matched) == 0 {
+		b.WriteString("  (none: this component renders nothing)\n")
+	}
+	for _, m := range e.Matched {
+		fmt.Fprintf(&b, "  %s\n", m.Transformer)
+	}
+	if len(e.NotMatched) > 0 {
+		b.WriteString("\nTransformers that did not match:\n")
+		for _, m := range e.NotMatched {
+			fmt.Fprintf(&b, "  %s\n", m.Transformer)
+			if len(m.MissingLabels) > 0 {
+				fmt.Fprintf(&b, "    missing labels: %s\n", strings.Join(m.MissingLabels, ", "))
+			}
+		}
+	}
+	if len(e.Missing) > 0 {
+		b.WriteString("\nPrimitives no transformer requires:\n")
+		for _, m := range e.Missing {
+			fmt.Fprintf(&b, "  %s\n", m.FQN)
+			if len(m.Alternatives) > 0 {
+				fmt.Fprintf(&b, "    available versions: %s\n", strings.Join(m.Alternatives, ", "))
+			}
+		}
+	}
+	writeExplainList(&b, "\nTraits no matched transformer consumes", e.UnhandledTraits)
+
+	ctx, err := json.MarshalIndent(e.Context, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling #context: %w", err)
+	}
+	fmt.Fprintf(&b, "\n#context:\n  %s\n", ctx)
+	if e.Spec != nil {
+		spec, err := json.MarshalIndent(e.Spec, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling component spec: %w", err)
+		}
+		fmt.Fprintf(&b, "\n#component.spec:\n  %s\n", spec)
+	}
+
+	if len(e.Matched) > 0 {
+		b.WriteString("\nRendered:\n")
+		if len(e.Rendered) == 0 {
+			b.WriteString("  (nothing)\n")
+		}
+		for _, r := range e.Rendered {
+			name := r.Name
+			if r.Namespace != "" {
+				name = r.Namespace + "/" + name
+			}
+			fmt.Fprintf(&b, "  %s %s (by %s)\n", r.Kind, name, r.Transformer)
+		}
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func writeExplainList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", title)
+	for _, it := range items {
+		fmt.Fprintf(b, "  %s\n", it)
+	}
+}
+
+func labelLines(labels map[string]string) []string {
+	lines := make([]string, 0, len(labels))
+	for k, v := range labels {
+		lines = append(lines, k+"="+v)
+	}
+	sort.Strings(lines)
+	return lines
+}
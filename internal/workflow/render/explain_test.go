@@ -0,0 +1,95 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/library/opm/compile"
+	oerrors "github.com/open-platform-model/library/opm/errors"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
+)
+
+const (
+	tfDeployment  = "opmodel.dev/transformers/deployment@v1"
+	tfStatefulSet = "opmodel.dev/transformers/statefulset@v1"
+)
+
+func explainFixture() *Result {
+	deploy := &unstructured.Unstructured{}
+	deploy.SetKind("Deployment")
+	deploy.SetNamespace("demo")
+	deploy.SetName("web")
+	return &Result{
+		Resources: []*unstructured.Unstructured{deploy},
+		Typed:     []*pkgcore.Resource{{Component: "web", Transformer: tfDeployment}},
+		Instance:  pkgmodule.InstanceMetadata{Name: "app", Namespace: "demo", UUID: "uuid-1"},
+		Module:    pkgmodule.ModuleMetadata{FQN: "opmodel.dev/modules/app:0.1.0", Version: "0.1.0"},
+		Components: []compile.ComponentSummary{
+			{Name: "web", Labels: map[string]string{"core.opmodel.dev/workload-type": "stateless"}},
+			{Name: "db", ResourceFQNs: []string{"opmodel.dev/resources/volume@v2"}},
+		},
+		MatchPlan: &compile.MatchPlan{
+			Matches: map[string]map[string]compile.MatchResult{
+				"web": {
+					tfDeployment:  {Matched: true},
+					tfStatefulSet: {MissingLabels: []string{"core.opmodel.dev/workload-type=stateful"}},
+				},
+				"db": {},
+			},
+			Unmatched: []string{"db"},
+			Missing: []oerrors.MissingFQN{{
+				Component: "db", FQN: "opmodel.dev/resources/volume@v2",
+				Alternatives: []string{"opmodel.dev/resources/volume@v1"},
+			}},
+		},
+		ComponentSpecs: map[string]map[string]any{"web": {"replicas": float64(2)}},
+	}
+}
+
+func TestExplain_Matched(t *testing.T) {
+	e, err := Explain(explainFixture(), "web")
+	require.NoError(t, err)
+
+	assert.Equal(t, []TransformerMatch{{Transformer: tfDeployment}}, e.Matched)
+	assert.Equal(t, []TransformerMatch{{
+		Transformer: tfStatefulSet, MissingLabels: []string{"core.opmodel.dev/workload-type=stateful"},
+	}}, e.NotMatched)
+	assert.Equal(t, []ExplainedResource{{Kind: "Deployment", Namespace: "demo", Name: "web", Transformer: tfDeployment}}, e.Rendered)
+	assert.Equal(t, "app", e.Context.ModuleInstance.Name)
+	assert.Equal(t, "web", e.Context.Component.Name)
+	assert.Equal(t, RuntimeName, e.Context.RuntimeName)
+	assert.Equal(t, float64(2), e.Spec["replicas"])
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExplanation(&buf, e))
+	assert.Contains(t, buf.String(), "missing labels: core.opmodel.dev/workload-type=stateful")
+	assert.Contains(t, buf.String(), "Deployment demo/web (by "+tfDeployment+")")
+}
+
+func TestExplain_Unmatched(t *testing.T) {
+	e, err := Explain(explainFixture(), "db")
+	require.NoError(t, err)
+
+	assert.Empty(t, e.Matched)
+	assert.Empty(t, e.Rendered)
+	assert.Equal(t, []MissingPrimitive{{
+		FQN: "opmodel.dev/resources/volume@v2", Alternatives: []string{"opmodel.dev/resources/volume@v1"},
+	}}, e.Missing)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExplanation(&buf, e))
+	assert.Contains(t, buf.String(), "(none: this component renders nothing)")
+	assert.Contains(t, buf.String(), "available versions: opmodel.dev/resources/volume@v1")
+}
+
+func TestExplain_UnknownComponent(t *testing.T) {
+	_, err := Explain(explainFixture(), "cache")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: db, web")
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// validateInstanceUUID checks an --instance-uuid value, if any: it must be a
+// UUID in its canonical, lower-case form, as the kernel computes them.
+func validateInstanceUUID(s string) error {
+	if s == "" {
+		return nil
+	}
+	u, err := uuid.Parse(s)
+	if err != nil || u.String() != s {
+		return fmt.Errorf("invalid --instance-uuid %q: expected a lower-case UUID (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)", s)
+	}
+	return nil
+}
+
+// overrideInstanceUUID replaces the instance UUID the kernel computed, from,
+// with to on every rendered resource: wherever a label map carries
+// pkgcore.LabelModuleInstanceUUID with the value from — metadata.labels, a
+// pod template's labels, a selector. The kernel derives the UUID inside the
+// schema, so the override cannot reach it earlier.
+func overrideInstanceUUID(resources []*pkgcore.Resource, from, to string) error {
+	for _, r := range resources {
+		u, err := r.ToUnstructured()
+		if err != nil {
+			return err
+		}
+		if !replaceUUIDLabel(u.Object, from, to) {
+			continue
+		}
+		if err := setResourceObject(r, u.Object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceUUIDLabel walks v and rewrites the instance UUID label from from to
+// to, reporting whether it changed anything.
+func replaceUUIDLabel(v any, from, to string) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]any:
+		if s, ok := t[pkgcore.LabelModuleInstanceUUID].(string); ok && s == from {
+			t[pkgcore.LabelModuleInstanceUUID] = to
+			changed = true
+		}
+		for _, child := range t {
+			changed = replaceUUIDLabel(child, from, to) || changed
+		}
+	case []any:
+		for _, child := range t {
+			changed = replaceUUIDLabel(child, from, to) || changed
+		}
+	}
+	return changed
+}
+
+// warnInstanceUUIDOverride notes that an overridden UUID is no longer derived
+// from the instance's name and namespace.
+func warnInstanceUUIDOverride(computed, override string) {
+	output.Warn("--instance-uuid replaces the instance UUID derived from its module, name, and namespace; "+
+		"every later render of this instance must pass the same value", "derived", computed, "uuid", override)
+}
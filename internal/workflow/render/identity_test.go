@@ -0,0 +1,53 @@
+package render
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func TestValidateInstanceUUID(t *testing.T) {
+	assert.NoError(t, validateInstanceUUID(""))
+	assert.NoError(t, validateInstanceUUID("6f1c3d2e-8a4b-5c9d-9e0f-1a2b3c4d5e6f"))
+	assert.Error(t, validateInstanceUUID("not-a-uuid"))
+	assert.Error(t, validateInstanceUUID("6F1C3D2E-8A4B-5C9D-9E0F-1A2B3C4D5E6F"), "upper case is not canonical")
+	assert.Error(t, validateInstanceUUID("{6f1c3d2e-8a4b-5c9d-9e0f-1a2b3c4d5e6f}"))
+}
+
+func TestOverrideInstanceUUID(t *testing.T) {
+	const derived, override = "11111111-1111-5111-8111-111111111111", "22222222-2222-5222-8222-222222222222"
+	ctx := cuecontext.New()
+	deployment := &pkgcore.Resource{Value: ctx.CompileString(`
+apiVersion: "apps/v1"
+kind:       "Deployment"
+metadata: {name: "web", labels: "module-instance.opmodel.dev/uuid": "` + derived + `"}
+spec: {
+	replicas: 2
+	selector: matchLabels: "module-instance.opmodel.dev/uuid": "` + derived + `"
+	template: metadata: labels: "module-instance.opmodel.dev/uuid": "` + derived + `"
+	template: spec: containers: [{name: "web", env: [{name: "ID", value: "` + derived + `"}]}]
+}`)}
+	configMap := &pkgcore.Resource{Value: ctx.CompileString(`apiVersion: "v1", kind: "ConfigMap", metadata: name: "plain"`)}
+
+	require.NoError(t, overrideInstanceUUID([]*pkgcore.Resource{deployment, configMap}, derived, override))
+
+	u, err := deployment.ToUnstructured()
+	require.NoError(t, err)
+	assert.Equal(t, override, u.GetLabels()[pkgcore.LabelModuleInstanceUUID])
+	for _, path := range [][]string{
+		{"spec", "selector", "matchLabels", pkgcore.LabelModuleInstanceUUID},
+		{"spec", "template", "metadata", "labels", pkgcore.LabelModuleInstanceUUID},
+	} {
+		got, _, _ := unstructured.NestedString(u.Object, path...) //nolint:errcheck // asserted below
+		assert.Equal(t, override, got, "%v", path)
+	}
+	b, err := deployment.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"value":"`+derived+`"`, "only the label changes, not other fields holding the value")
+	assert.Contains(t, string(b), `"replicas":2`)
+}
@@ -3,6 +3,7 @@ package render
 import (
 	"context"
 	"fmt"
+	"time"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/open-platform-model/library/opm/materialize"
 	"github.com/open-platform-model/library/opm/schema"
 
+	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/output"
 	"github.com/open-platform-model/cli/internal/platform"
@@ -41,6 +43,21 @@ type renderEnv struct {
 	// Result so the apply workflow can seed the cluster Platform without
 	// re-reading the file (no second I/O, no TOCTOU).
 	input synth.PlatformInput
+	// deferErrors leaves compile errors unprinted for the caller to report.
+	deferErrors bool
+	// strictTraits is the --strict-traits override; nil defers to the
+	// module's AnnotationStrictTraits.
+	strictTraits *bool
+	// strictNamespace fails the render on resources outside the --namespace
+	// target instead of moving them into it.
+	strictNamespace bool
+	// instanceUUID, when set, replaces the instance UUID the kernel
+	// computes (--instance-uuid).
+	instanceUUID string
+	// renderTimeout bounds the kernel compile; zero is unbounded. started is
+	// when the render began, for the timing a timeout reports.
+	renderTimeout time.Duration
+	started       time.Time
 }
 
 // resolvePlatformEnv resolves the platform by precedence (D11/D21), reports
@@ -48,14 +65,15 @@ type renderEnv struct {
 // instance is loaded and its values validated, so cheap validation failures
 // surface before any platform/registry work. clusterGetter is nil for
 // offline commands (build/render — D17: they never read the cluster).
-func resolvePlatformEnv(ctx context.Context, k *kernel.Kernel, cfg *config.GlobalConfig, platformFlag string, clusterGetter platform.ClusterSpecGetter) (*renderEnv, error) {
+func resolvePlatformEnv(ctx context.Context, k *kernel.Kernel, cfg *config.GlobalConfig, platformFiles []string, clusterGetter platform.ClusterSpecGetter) (*renderEnv, error) {
 	in, res, err := platform.Resolve(ctx, platform.ResolveOptions{
-		PlatformFlag: platformFlag,
-		ConfigPath:   cfg.ConfigPath,
-		Cluster:      clusterGetter,
+		PlatformFiles: platformFiles,
+		ConfigPath:    cfg.ConfigPath,
+		Cluster:       clusterGetter,
 	})
 	if err != nil {
-		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		// A cluster Platform read that failed keeps its API exit code.
+		return nil, &opmexit.ExitError{Code: cmdutil.ExitCodeFromK8sError(err), Err: err}
 	}
 	output.Info(res.Describe())
 
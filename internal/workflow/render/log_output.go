@@ -19,6 +19,21 @@ func formatFQNList(fqns []string) string {
 	return strings.Join(formatted, ", ")
 }
 
+// sortedLabelAttrs flattens labels into key-value log attributes in key order,
+// so verbose output is identical across runs.
+func sortedLabelAttrs(labels map[string]string) []any {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]any, 0, 2*len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, k, labels[k])
+	}
+	return attrs
+}
+
 func writeTransformerMatches(result *Result) {
 	if result.MatchPlan == nil {
 		return
@@ -47,9 +62,7 @@ func writeVerboseMatchLog(result *Result) {
 		if traits := formatFQNList(comp.TraitFQNs); traits != "" {
 			attrs = append(attrs, "traits", traits)
 		}
-		for k, v := range comp.Labels {
-			attrs = append(attrs, k, v)
-		}
+		attrs = append(attrs, sortedLabelAttrs(comp.Labels)...)
 		instanceLog.Info(fmt.Sprintf("component: %s", comp.Name), attrs...)
 	}
 
@@ -27,3 +27,9 @@ func TestFormatFQNList_Single(t *testing.T) {
 	assert.NotEmpty(t, result)
 	assert.Contains(t, result, "container")
 }
+
+func TestSortedLabelAttrs(t *testing.T) {
+	attrs := sortedLabelAttrs(map[string]string{"zone": "b", "app": "web", "tier": "front"})
+	assert.Equal(t, []any{"app", "web", "tier", "front", "zone", "b"}, attrs)
+	assert.Empty(t, sortedLabelAttrs(nil))
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/open-platform-model/cli/internal/inventory"
+)
+
+// BuildMetadata identifies a render the way apply records it on the
+// ModuleInstance, so a built artifact can be matched to a later apply
+// without a cluster (build --print-metadata).
+type BuildMetadata struct {
+	Instance InstanceIdentity `json:"instance"`
+	Module   ModuleIdentity   `json:"module"`
+	// RenderDigest is what apply writes to status.lastAppliedRenderDigest.
+	RenderDigest string `json:"renderDigest"`
+	// InventoryDigest is the inventory digest apply writes to
+	// status.inventory.digest — unless apply also tracks a namespace it
+	// created (--create-namespace), which adds an entry.
+	InventoryDigest string `json:"inventoryDigest"`
+	ResourceCount   int    `json:"resourceCount"`
+}
+
+// InstanceIdentity is the instance part of BuildMetadata.
+type InstanceIdentity struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UUID      string `json:"uuid"`
+}
+
+// ModuleIdentity is the module part of BuildMetadata.
+type ModuleIdentity struct {
+	Name    string `json:"name"`
+	FQN     string `json:"fqn"`
+	Version string `json:"version"`
+	UUID    string `json:"uuid"`
+	// Path and PinnedVersion are the canonical registry import path and
+	// version apply records as spec.module (see
+	// ModuleMetadata.CanonicalModuleRef).
+	Path          string `json:"path"`
+	PinnedVersion string `json:"pinnedVersion"`
+	// Digest is the OCI manifest digest the module was pinned to
+	// (--module-digest), if any.
+	Digest string `json:"digest,omitempty"`
+}
+
+// NewBuildMetadata collects the BuildMetadata of a render result.
+func NewBuildMetadata(r *Result) BuildMetadata {
+	path, version := r.Module.CanonicalModuleRef()
+	entries := make([]inventory.InventoryEntry, 0, len(r.Resources))
+	for _, res := range r.Resources {
+		entries = append(entries, inventory.NewEntryFromResource(res))
+	}
+	return BuildMetadata{
+		Instance: InstanceIdentity{
+			Name:      r.Instance.Name,
+			Namespace: r.Instance.Namespace,
+			UUID:      r.Instance.UUID,
+		},
+		Module: ModuleIdentity{
+			Name:          r.Module.Name,
+			FQN:           r.Module.FQN,
+			Version:       r.Module.Version,
+			UUID:          r.Module.UUID,
+			Path:          path,
+			PinnedVersion: version,
+			Digest:        r.ModuleDigest,
+		},
+		RenderDigest:    r.RenderDigest,
+		InventoryDigest: inventory.ComputeDigest(entries),
+		ResourceCount:   len(r.Resources),
+	}
+}
+
+// WriteBuildMetadata writes m to w as indented JSON.
+func WriteBuildMetadata(w io.Writer, m BuildMetadata) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling build metadata: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
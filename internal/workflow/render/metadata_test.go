@@ -0,0 +1,52 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/cli/internal/inventory"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
+)
+
+func TestNewBuildMetadata(t *testing.T) {
+	deploy := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "media"},
+	}}
+	result := &Result{
+		Resources: []*unstructured.Unstructured{deploy},
+		Instance:  pkgmodule.InstanceMetadata{Name: "web", Namespace: "media", UUID: "0b4b7c1e-6f1f-5f6a-9a55-2f0c3a8f1d2e"},
+		Module: pkgmodule.ModuleMetadata{
+			Name:          "web-app",
+			ModulePath:    "example.com/modules",
+			FQN:           "example.com/modules/web-app:1.2.0",
+			Version:       "1.2.0",
+			NameSnakeCase: "web_app",
+			UUID:          "5f8e0a52-3c0e-5d76-8b9b-7c7a2d6c9e11",
+		},
+		RenderDigest: "sha256:render",
+		ModuleDigest: "sha256:module",
+	}
+
+	meta := NewBuildMetadata(result)
+	assert.Equal(t, InstanceIdentity{Name: "web", Namespace: "media", UUID: result.Instance.UUID}, meta.Instance)
+	assert.Equal(t, "example.com/modules/web_app@v1", meta.Module.Path)
+	assert.Equal(t, "v1.2.0", meta.Module.PinnedVersion)
+	assert.Equal(t, "sha256:module", meta.Module.Digest)
+	assert.Equal(t, "sha256:render", meta.RenderDigest)
+	assert.Equal(t, inventory.ComputeDigest([]inventory.InventoryEntry{inventory.NewEntryFromResource(deploy)}), meta.InventoryDigest,
+		"the digest apply records for the same resources")
+	assert.Equal(t, 1, meta.ResourceCount)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBuildMetadata(&buf, meta))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "sha256:render", decoded["renderDigest"])
+}
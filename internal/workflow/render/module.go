@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/pkg/loader"
+	"github.com/open-platform-model/cli/pkg/validate"
 )
 
 // FromModule synthesizes an instance from a module-package directory through
@@ -28,6 +31,7 @@ import (
 // the last #ModuleRelease application — 0002 carryover). Values come from
 // `-f` files when supplied, else from the module's `debugValues`.
 func FromModule(ctx context.Context, opts ModuleOpts) (*Result, error) {
+	started := time.Now()
 	if opts.Config == nil {
 		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("configuration not loaded")}
 	}
@@ -40,21 +44,46 @@ func FromModule(ctx context.Context, opts ModuleOpts) (*Result, error) {
 	if pathErr := cmdutil.ValidateModuleInputPath(opts.ModulePath); pathErr != nil {
 		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: pathErr}
 	}
+	if err := validateInstanceUUID(opts.InstanceUUID); err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if err := CheckCoreCompatibility(opts.ModulePath); err != nil {
+		return nil, err
+	}
+	if err := CheckOfflineCache(opts.ModulePath, opts.Config.Offline); err != nil {
+		return nil, err
+	}
+
+	componentSets, err := loader.ParseComponentSets(opts.ComponentSets)
+	if err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	}
 
 	namespace := opts.K8sConfig.Namespace.Value
 	output.Debug("rendering from module", "path", opts.ModulePath, "namespace", namespace)
 
+	// A caller that reports validation errors itself (module vet) gets them
+	// back unprinted.
+	reportErr := printValidationError
+	if opts.DeferErrors {
+		reportErr = func(error) {}
+	}
+	printed := !opts.DeferErrors
+
 	k := NewKernel(opts.Config)
 
 	modVal, err := k.LoadModulePackage(ctx, opts.ModulePath, loaderfile.LoadOptions{Registry: opts.Config.Registry})
 	if err != nil {
-		printValidationError(err)
-		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+		if regErr := RegistryLoadError(err, opts.Config.Registry); regErr != nil {
+			return nil, regErr
+		}
+		reportErr(err)
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: printed}
 	}
 	mod, err := k.NewModuleFromValue(modVal)
 	if err != nil {
-		printValidationError(err)
-		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+		reportErr(err)
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: printed}
 	}
 
 	// Stage the local directory as the module's source tree: synthesis
@@ -68,14 +97,21 @@ func FromModule(ctx context.Context, opts ModuleOpts) (*Result, error) {
 	}
 	mod.Source = src
 
-	values, err := resolveModuleValues(k.CueContext(), modVal, opts.ValuesFiles)
+	values, err := resolveModuleValues(k.CueContext(), modVal, opts.valuesSources(), opts.SetValues)
 	if err != nil {
-		printValidationError(err)
-		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+		reportErr(err)
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: printed}
 	}
 
 	modName, synthName, synthNamespace := syntheticIdentity(mod, opts, namespace)
 
+	if opts.StrictValues {
+		if cfgErr := validate.UnknownFields(modVal.LookupPath(schema.Config), values, "module", modName); cfgErr != nil {
+			cmdutil.PrintValidationError("values set fields #config does not declare", cfgErr)
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: cfgErr, Printed: true}
+		}
+	}
+
 	output.Info(fmt.Sprintf("Building synthetic instance %q for module %q", synthName, modName))
 
 	inst, err := k.SynthesizeInstance(ctx, synth.InstanceInput{
@@ -85,20 +121,32 @@ func FromModule(ctx context.Context, opts ModuleOpts) (*Result, error) {
 		Values:    values,
 	})
 	if err != nil {
-		printValidationError(err)
-		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+		reportErr(err)
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: printed}
+	}
+
+	if len(componentSets) > 0 {
+		if inst.Package, err = applyComponentSets(inst.Package, componentSets); err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
 	}
 
 	// Platform resolution + materialization only after synthesis validated
 	// the values: cheap failures never hit the cluster or registry.
-	env, err := resolvePlatformEnv(ctx, k, opts.Config, opts.PlatformFlag, opts.ClusterPlatform)
+	env, err := resolvePlatformEnv(ctx, k, opts.Config, opts.PlatformFiles, opts.ClusterPlatform)
 	if err != nil {
 		return nil, err
 	}
+	env.deferErrors = opts.DeferErrors
+	env.strictTraits = opts.StrictTraits
+	env.strictNamespace = opts.StrictNamespace
+	env.instanceUUID = opts.InstanceUUID
+	env.renderTimeout = opts.RenderTimeout
+	env.started = started
 
 	// A module apply always renders a local module directory (the main module is
 	// local), so render provenance is local (enhancement 0006 D7).
-	return compileInstance(ctx, env, inst, opts.K8sConfig, true)
+	return compileInstance(ctx, env, inst, opts.K8sConfig, true, opts.Components, opts.AllowUnmatched)
 }
 
 // defaultNamespace is the synthetic-instance namespace when no
@@ -174,16 +222,24 @@ func stageLocalModuleSource(dir string) (*module.Source, error) {
 	return &module.Source{Root: absDir, Overlay: overlay}, nil
 }
 
-// resolveModuleValues mirrors `opm module vet`: -f files override debugValues.
-// The returned value is a single unified cue.Value (the kernel's synthesis
-// takes one values input).
-func resolveModuleValues(cueCtx *cue.Context, modVal cue.Value, valuesFiles []string) (cue.Value, error) {
-	if len(valuesFiles) > 0 {
-		return unifyValuesFiles(cueCtx, valuesFiles)
+// resolveModuleValues mirrors `opm module vet`: values files (and cluster
+// values documents) override debugValues, and --set/--set-string override both. The returned value is a single
+// unified cue.Value (the kernel's synthesis takes one values input).
+func resolveModuleValues(cueCtx *cue.Context, modVal cue.Value, src valuesSources, sets loader.SetValues) (cue.Value, error) {
+	var base cue.Value
+	if !src.empty() {
+		var err error
+		if base, err = unifyValuesFiles(cueCtx, src); err != nil {
+			return cue.Value{}, err
+		}
+	} else {
+		base = modVal.LookupPath(schema.DebugValues)
+		if !base.Exists() && sets.IsEmpty() {
+			return cue.Value{}, fmt.Errorf("module does not define debugValues - add debugValues or provide values with -f or --set")
+		}
 	}
-	debugVal := modVal.LookupPath(schema.DebugValues)
-	if !debugVal.Exists() {
-		return cue.Value{}, fmt.Errorf("module does not define debugValues - add debugValues or provide values with -f")
+	if sets.IsEmpty() {
+		return base, nil
 	}
-	return debugVal, nil
+	return loader.ApplySetValues(cueCtx, base, sets)
 }
@@ -7,12 +7,14 @@ import (
 	"path/filepath"
 	"testing"
 
+	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-platform-model/cli/internal/config"
 	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/pkg/loader"
 )
 
 func TestFromModule_NilConfig(t *testing.T) {
@@ -61,7 +63,7 @@ func TestResolveModuleValues_UsesValuesFile(t *testing.T) {
 	modVal := ctx.CompileString(`{debugValues: {replicas: 1}}`)
 	require.NoError(t, modVal.Err())
 
-	values, err := resolveModuleValues(ctx, modVal, []string{valuesFile})
+	values, err := resolveModuleValues(ctx, modVal, valuesSources{Files: []string{valuesFile}}, loader.SetValues{})
 	require.NoError(t, err)
 	assert.True(t, values.Exists())
 }
@@ -72,7 +74,7 @@ func TestResolveModuleValues_FallbackDebugValues(t *testing.T) {
 	modVal := ctx.CompileString(`{debugValues: {replicas: 5}}`)
 	require.NoError(t, modVal.Err())
 
-	values, err := resolveModuleValues(ctx, modVal, nil)
+	values, err := resolveModuleValues(ctx, modVal, valuesSources{}, loader.SetValues{})
 	require.NoError(t, err)
 	assert.True(t, values.Exists())
 }
@@ -84,7 +86,24 @@ func TestResolveModuleValues_NoDebugValues(t *testing.T) {
 	modVal := ctx.CompileString(`{metadata: name: "x"}`)
 	require.NoError(t, modVal.Err())
 
-	_, err := resolveModuleValues(ctx, modVal, nil)
+	_, err := resolveModuleValues(ctx, modVal, valuesSources{}, loader.SetValues{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "debugValues")
 }
+
+// TestResolveModuleValues_SetOverridesDebugValues asserts --set replaces a
+// concrete debugValues field instead of conflicting with it.
+func TestResolveModuleValues_SetOverridesDebugValues(t *testing.T) {
+	ctx := cuecontext.New()
+	modVal := ctx.CompileString(`{debugValues: {replicas: 1, image: "nginx"}}`)
+	require.NoError(t, modVal.Err())
+
+	values, err := resolveModuleValues(ctx, modVal, valuesSources{}, loader.SetValues{Set: []string{"replicas=3"}})
+	require.NoError(t, err)
+	replicas, err := values.LookupPath(cue.ParsePath("replicas")).Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replicas)
+	image, err := values.LookupPath(cue.ParsePath("image")).String()
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", image)
+}
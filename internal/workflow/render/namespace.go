@@ -0,0 +1,134 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	"github.com/open-platform-model/cli/internal/output"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+// NamespaceMismatchError fails a render under --strict-namespace: a
+// transformer put resources in a namespace other than the --namespace target.
+type NamespaceMismatchError struct {
+	Namespace string
+	// Resources are the mismatched resources, as Kind/namespace/name.
+	Resources []string
+}
+
+func (e *NamespaceMismatchError) Error() string {
+	return fmt.Sprintf("strict namespace: %d resource(s) rendered outside target namespace %q: %s",
+		len(e.Resources), e.Namespace, strings.Join(e.Resources, ", "))
+}
+
+// namespaceOverride returns the namespace --namespace (or its environment
+// variable) sets, or "" when the namespace comes from elsewhere.
+func namespaceOverride(k8sCfg *config.ResolvedKubernetesConfig) string {
+	if k8sCfg == nil {
+		return ""
+	}
+	if s := k8sCfg.Namespace.Source; s == config.SourceFlag || s == config.SourceEnv {
+		return k8sCfg.Namespace.Value
+	}
+	return ""
+}
+
+// enforceNamespace puts every namespaced resource in namespace, overriding
+// whatever namespace its transformer chose — a `*"default"` fallback that
+// never saw the target, typically. A resource is namespaced when the render
+// gave it a namespace and its kind is not a known cluster-scoped one; a
+// resource rendered without a namespace is left alone. With strict set it
+// changes nothing and returns a *NamespaceMismatchError instead.
+func enforceNamespace(resources []*pkgcore.Resource, namespace string, strict bool) error {
+	var mismatched []*pkgcore.Resource
+	for _, r := range resources {
+		ns := r.Namespace()
+		if ns == "" || ns == namespace || kubernetes.IsKnownClusterScoped(r.GVK().GroupKind()) {
+			continue
+		}
+		mismatched = append(mismatched, r)
+	}
+	if len(mismatched) == 0 {
+		return nil
+	}
+
+	if strict {
+		refs := make([]string, 0, len(mismatched))
+		for _, r := range mismatched {
+			refs = append(refs, r.Kind()+"/"+r.Namespace()+"/"+r.Name())
+		}
+		return &NamespaceMismatchError{Namespace: namespace, Resources: refs}
+	}
+
+	for _, r := range mismatched {
+		output.Debug("overriding rendered namespace", "resource", r.String(), "from", r.Namespace(), "to", namespace)
+		if err := setResourceNamespace(r, namespace); err != nil {
+			return err
+		}
+	}
+	output.Warn(fmt.Sprintf("moved %d resource(s) a transformer rendered outside namespace %q into it", len(mismatched), namespace))
+	return nil
+}
+
+// stripClusterScopedNamespaces clears the namespace a transformer gave a
+// well-known cluster-scoped kind, such as a ClusterRole that picked up a
+// `*"default"` fallback. Apply repeats the check with the cluster's own scope
+// information, for the kinds a CRD serves. With strict set it changes nothing
+// and returns a *kubernetes.ClusterScopedNamespaceError instead.
+func stripClusterScopedNamespaces(resources []*pkgcore.Resource, strict bool) error {
+	var stray []*pkgcore.Resource
+	for _, r := range resources {
+		if r.Namespace() != "" && kubernetes.IsKnownClusterScoped(r.GVK().GroupKind()) {
+			stray = append(stray, r)
+		}
+	}
+	if len(stray) == 0 {
+		return nil
+	}
+
+	if strict {
+		refs := make([]string, 0, len(stray))
+		for _, r := range stray {
+			refs = append(refs, r.Kind()+"/"+r.Namespace()+"/"+r.Name())
+		}
+		return &kubernetes.ClusterScopedNamespaceError{Resources: refs}
+	}
+
+	for _, r := range stray {
+		output.Debug("removing namespace from cluster-scoped resource", "resource", r.String(), "namespace", r.Namespace())
+		if err := setResourceNamespace(r, ""); err != nil {
+			return err
+		}
+	}
+	output.Warn(fmt.Sprintf("removed the namespace a transformer gave %d cluster-scoped resource(s)", len(stray)))
+	return nil
+}
+
+// setResourceNamespace re-encodes r's value with its namespace set to
+// namespace, or removed when namespace is empty.
+func setResourceNamespace(r *pkgcore.Resource, namespace string) error {
+	u, err := r.ToUnstructured()
+	if err != nil {
+		return err
+	}
+	u.SetNamespace(namespace)
+	return setResourceObject(r, u.Object)
+}
+
+// setResourceObject replaces r's value with obj, compiled in the same CUE
+// context.
+func setResourceObject(r *pkgcore.Resource, obj map[string]any) error {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("resource %s: %w", r.String(), err)
+	}
+	v := r.Value.Context().CompileBytes(b)
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("resource %s: %w", r.String(), err)
+	}
+	r.Value = v
+	return nil
+}
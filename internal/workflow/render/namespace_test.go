@@ -0,0 +1,98 @@
+package render
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/kubernetes"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func TestEnforceNamespace(t *testing.T) {
+	ctx := cuecontext.New()
+	resources := func() []*pkgcore.Resource {
+		var out []*pkgcore.Resource
+		for _, src := range []string{
+			`apiVersion: "apps/v1", kind: "Deployment", metadata: {name: "web", namespace: "default"}, spec: replicas: 2`,
+			`apiVersion: "v1", kind: "Service", metadata: {name: "web", namespace: "media"}`,
+			`apiVersion: "rbac.authorization.k8s.io/v1", kind: "ClusterRole", metadata: {name: "web", namespace: "default"}`,
+			`apiVersion: "v1", kind: "Namespace", metadata: name: "media"`,
+		} {
+			out = append(out, &pkgcore.Resource{Value: ctx.CompileString(src)})
+		}
+		return out
+	}
+
+	t.Run("override", func(t *testing.T) {
+		rs := resources()
+		require.NoError(t, enforceNamespace(rs, "media", false))
+		assert.Equal(t, "media", rs[0].Namespace(), "the transformer's default fallback is overridden")
+		assert.Equal(t, "media", rs[1].Namespace())
+		assert.Equal(t, "default", rs[2].Namespace(), "cluster-scoped kinds are left alone")
+		assert.Empty(t, rs[3].Namespace(), "a cluster-scoped resource does not gain a namespace")
+
+		b, err := rs[0].MarshalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"replicas":2`, "integers survive the rewrite")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		rs := resources()
+		err := enforceNamespace(rs, "media", true)
+		var mismatch *NamespaceMismatchError
+		require.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, []string{"Deployment/default/web"}, mismatch.Resources)
+		assert.Equal(t, "default", rs[0].Namespace(), "strict mode changes nothing")
+	})
+
+	t.Run("no mismatch", func(t *testing.T) {
+		require.NoError(t, enforceNamespace(resources()[1:], "media", true))
+	})
+}
+
+func TestStripClusterScopedNamespaces(t *testing.T) {
+	ctx := cuecontext.New()
+	resources := func() []*pkgcore.Resource {
+		var out []*pkgcore.Resource
+		for _, src := range []string{
+			`apiVersion: "rbac.authorization.k8s.io/v1", kind: "ClusterRole", metadata: {name: "web", namespace: "default"}, rules: []`,
+			`apiVersion: "v1", kind: "ConfigMap", metadata: {name: "web", namespace: "default"}`,
+		} {
+			out = append(out, &pkgcore.Resource{Value: ctx.CompileString(src)})
+		}
+		return out
+	}
+
+	t.Run("strip", func(t *testing.T) {
+		rs := resources()
+		require.NoError(t, stripClusterScopedNamespaces(rs, false))
+		assert.Empty(t, rs[0].Namespace(), "the ClusterRole loses its stray namespace")
+		assert.Equal(t, "web", rs[0].Name())
+		assert.Equal(t, "default", rs[1].Namespace(), "namespaced kinds keep theirs")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		rs := resources()
+		err := stripClusterScopedNamespaces(rs, true)
+		var stray *kubernetes.ClusterScopedNamespaceError
+		require.ErrorAs(t, err, &stray)
+		assert.Equal(t, []string{"ClusterRole/default/web"}, stray.Resources)
+		assert.Equal(t, "default", rs[0].Namespace(), "strict mode changes nothing")
+	})
+}
+
+func TestNamespaceOverride(t *testing.T) {
+	cfg := func(source config.Source) *config.ResolvedKubernetesConfig {
+		c := &config.ResolvedKubernetesConfig{}
+		c.Namespace.Value, c.Namespace.Source = "media", source
+		return c
+	}
+	assert.Equal(t, "media", namespaceOverride(cfg(config.SourceFlag)))
+	assert.Equal(t, "media", namespaceOverride(cfg(config.SourceEnv)))
+	assert.Empty(t, namespaceOverride(cfg(config.SourceDefault)))
+	assert.Empty(t, namespaceOverride(nil))
+}
@@ -1,6 +1,8 @@
 package render
 
 import (
+	"text/template"
+
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/output"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -10,6 +12,14 @@ func ParseManifestOutputFormat(outputFmt string) (output.Format, error) {
 	return cmdutil.ParseManifestOutputFormat(outputFmt)
 }
 
-func WriteManifestOutput(resources []*unstructured.Unstructured, outputFormat output.Format, split bool, outDir, instanceName string) error {
-	return cmdutil.WriteManifestOutput(resources, outputFormat, split, outDir, instanceName)
+func WriteManifestOutput(resources []*unstructured.Unstructured, outputFormat output.Format, split, list bool, outDir, instanceName string) error {
+	return cmdutil.WriteManifestOutput(resources, outputFormat, split, list, outDir, instanceName)
+}
+
+func WriteManifestDir(resources []*unstructured.Unstructured, outputFormat output.Format, dir string, force bool, instanceName string) error {
+	return cmdutil.WriteManifestDir(resources, outputFormat, dir, force, instanceName)
+}
+
+func WriteManifestTemplate(resources []*unstructured.Unstructured, tmpl *template.Template, perResource bool) error {
+	return cmdutil.WriteManifestTemplate(resources, tmpl, perResource)
 }
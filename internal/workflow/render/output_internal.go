@@ -15,5 +15,6 @@ func showOutput(result *Result, opts ShowOutputOpts) {
 	instanceLog := output.InstanceLogger(result.Instance.Name)
 	for _, w := range result.Warnings {
 		instanceLog.Warn(w)
+		output.EmitWarning(result.Instance.Name, w)
 	}
 }
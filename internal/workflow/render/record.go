@@ -0,0 +1,84 @@
+package render
+
+import (
+	"context"
+	"fmt"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+
+	"github.com/open-platform-model/library/opm/helper/synth"
+
+	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/internal/platform"
+)
+
+// RecordOpts configures re-rendering what an instance's ModuleInstance CR
+// records as last applied: its spec.module reference and spec.values.
+type RecordOpts struct {
+	Name      string
+	Namespace string
+
+	ModulePath    string
+	ModuleVersion string
+
+	// Values is the CR's spec.values, replayed verbatim. Nil renders with
+	// an empty values struct, as the recorded render did.
+	Values map[string]any
+
+	// PlatformFiles and ClusterPlatform resolve the platform as for
+	// InstanceFileOpts, so both sides of a comparison see the same one.
+	PlatformFiles   []string
+	ClusterPlatform platform.ClusterSpecGetter
+
+	Config *config.GlobalConfig
+}
+
+// FromRecord renders the module version an inventory record names, fetched
+// from the registry, with the values it records: the render the last apply
+// produced, as far as the current platform goes. The module must still be
+// published at that version; a module applied from a local replacement
+// cannot be reproduced.
+func FromRecord(ctx context.Context, opts RecordOpts) (*Result, error) {
+	if opts.Config == nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("configuration not loaded")}
+	}
+	if opts.ModulePath == "" || opts.ModuleVersion == "" {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("the inventory of %q records no module version to render", opts.Name)}
+	}
+
+	k := NewKernel(opts.Config)
+	mod, err := k.AcquireModuleFromRegistry(ctx, opts.ModulePath, opts.ModuleVersion)
+	if err != nil {
+		if regErr := RegistryLoadError(err, opts.Config.Registry); regErr != nil {
+			return nil, regErr
+		}
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError,
+			Err: fmt.Errorf("resolving last-applied module %s@%s from the registry: %w", opts.ModulePath, opts.ModuleVersion, err)}
+	}
+
+	values := k.CueContext().Encode(map[string]any{})
+	if len(opts.Values) > 0 {
+		values = k.CueContext().Encode(opts.Values)
+	}
+	if err := values.Err(); err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf("encoding recorded values: %w", err)}
+	}
+
+	inst, err := k.SynthesizeInstance(ctx, synth.InstanceInput{
+		Module:    mod,
+		Name:      opts.Name,
+		Namespace: opts.Namespace,
+		Values:    values,
+	})
+	if err != nil {
+		printValidationError(err)
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+	}
+
+	env, err := resolvePlatformEnv(ctx, k, opts.Config, opts.PlatformFiles, opts.ClusterPlatform)
+	if err != nil {
+		return nil, err
+	}
+	return compileInstance(ctx, env, inst, nil, false, nil, false)
+}
@@ -0,0 +1,68 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/mod/modregistry"
+
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+)
+
+// registryAuthHint tells the user every way to authenticate a module pull.
+const registryAuthHint = "authenticate with --registry-username/--registry-password or --registry-token, " +
+	"'cue login', or 'docker login'"
+
+// Markers of a registry refusing a pull. CUE flattens the OCI client's
+// errors into its own load errors, so the status survives only as text.
+var (
+	registryUnauthorizedMarkers = []string{"401 unauthorized", "unauthorized:", "authentication required"}
+	registryForbiddenMarkers    = []string{"403 forbidden", "denied:", "requested access to the resource is denied"}
+)
+
+// RegistryLoadError classifies a CUE package load error caused by the module
+// registry. A 401/403 becomes ExitPermissionDenied and a missing module
+// ExitNotFound, each with a message that says which it is — registries
+// answer both with similar-looking errors, and "not found" sends users
+// hunting for typos when the real problem is missing credentials. It returns
+// nil for any other error, which the caller reports as before.
+func RegistryLoadError(err error, registry string) error {
+	if err == nil {
+		return nil
+	}
+	hosts := strings.Join(config.RegistryHosts(registry), ", ")
+	msg := strings.ToLower(err.Error())
+
+	switch {
+	case containsAny(msg, registryUnauthorizedMarkers):
+		return &opmexit.ExitError{
+			Code: opmexit.ExitPermissionDenied,
+			Err: fmt.Errorf("registry %s rejected the request as unauthorized (401) — the module may exist, but no valid credentials were sent; %s: %w",
+				hosts, registryAuthHint, err),
+		}
+	case containsAny(msg, registryForbiddenMarkers):
+		return &opmexit.ExitError{
+			Code: opmexit.ExitPermissionDenied,
+			Err: fmt.Errorf("registry %s denied access (403) — the credentials were accepted but lack permission to pull this module: %w",
+				hosts, err),
+		}
+	case errors.Is(err, modregistry.ErrNotFound) || strings.Contains(msg, modregistry.ErrNotFound.Error()):
+		return &opmexit.ExitError{
+			Code: opmexit.ExitNotFound,
+			Err: fmt.Errorf("module not found in registry %s — check the module path and version (a private module that is not visible without credentials can also appear as not found): %w",
+				hosts, err),
+		}
+	}
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
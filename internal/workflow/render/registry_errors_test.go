@@ -0,0 +1,57 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"cuelang.org/go/mod/modregistry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+)
+
+func TestRegistryLoadError(t *testing.T) {
+	const registry = "opmodel.dev=ghcr.io/acme"
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantMsg  string
+	}{
+		{
+			name:     "401",
+			err:      errors.New(`cannot fetch opmodel.dev/acme/app@v0.1.0: 401 Unauthorized: unauthorized: authentication required`),
+			wantCode: opmexit.ExitPermissionDenied,
+			wantMsg:  "registry ghcr.io rejected the request as unauthorized (401)",
+		},
+		{
+			name:     "403",
+			err:      errors.New(`cannot fetch opmodel.dev/acme/app@v0.1.0: denied: requested access to the resource is denied`),
+			wantCode: opmexit.ExitPermissionDenied,
+			wantMsg:  "registry ghcr.io denied access (403)",
+		},
+		{
+			name:     "not found",
+			err:      fmt.Errorf("module opmodel.dev/acme/app@v0.9.0: %w", modregistry.ErrNotFound),
+			wantCode: opmexit.ExitNotFound,
+			wantMsg:  "module not found in registry ghcr.io",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegistryLoadError(tt.err, registry)
+			var exitErr *opmexit.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, tt.wantCode, exitErr.Code)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+			assert.ErrorIs(t, err, tt.err, "the registry error is kept for context")
+		})
+	}
+}
+
+func TestRegistryLoadError_OtherErrors(t *testing.T) {
+	assert.NoError(t, RegistryLoadError(nil, ""))
+	assert.NoError(t, RegistryLoadError(errors.New("values.replicas: conflicting values 1 and 2"), ""))
+}
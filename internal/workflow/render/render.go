@@ -3,8 +3,11 @@ package render
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"strings"
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/open-platform-model/cli/internal/cmdutil"
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/inventory"
+	"github.com/open-platform-model/cli/internal/kubernetes"
 	"github.com/open-platform-model/cli/internal/output"
 	pkgcore "github.com/open-platform-model/cli/pkg/core"
 	"github.com/open-platform-model/cli/pkg/loader"
@@ -43,6 +47,9 @@ func FromInstanceFile(ctx context.Context, opts InstanceFileOpts) (*Result, erro
 	if pathErr := cmdutil.ValidateInstanceInputPath(opts.InstanceFilePath); pathErr != nil {
 		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: pathErr}
 	}
+	if err := validateInstanceUUID(opts.InstanceUUID); err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
 
 	output.Debug("rendering from instance file", "file", opts.InstanceFilePath, "namespace", opts.K8sConfig.Namespace.Value)
 
@@ -53,8 +60,17 @@ func FromInstanceFile(ctx context.Context, opts InstanceFileOpts) (*Result, erro
 	if err != nil {
 		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
 	}
+	if err := CheckCoreCompatibility(instanceDir); err != nil {
+		return nil, err
+	}
+	if err := CheckOfflineCache(instanceDir, opts.Config.Offline); err != nil {
+		return nil, err
+	}
 	instVal, err := k.LoadInstancePackage(ctx, instanceDir, loaderfile.LoadOptions{Registry: opts.Config.Registry})
 	if err != nil {
+		if regErr := RegistryLoadError(err, opts.Config.Registry); regErr != nil {
+			return nil, regErr
+		}
 		printValidationError(err)
 		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
 	}
@@ -70,10 +86,10 @@ func FromInstanceFile(ctx context.Context, opts InstanceFileOpts) (*Result, erro
 		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
 	}
 
-	// Values: -f files (unified) win; otherwise the package's own values
+	// Values: -f, --values-yaml, and --values-json files and cluster values documents (unified) win; otherwise the package's own values
 	// (values.cue / inline) already live in the loaded package and
 	// ProcessModuleInstance enforces concreteness.
-	values, err := unifyValuesFiles(k.CueContext(), opts.ValuesFiles)
+	values, err := unifyValuesFiles(k.CueContext(), opts.valuesSources())
 	if err != nil {
 		printValidationError(err)
 		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
@@ -93,33 +109,105 @@ func FromInstanceFile(ctx context.Context, opts InstanceFileOpts) (*Result, erro
 		sourceLocal = loader.HasLocalModuleReplacement(loader.ModuleRootFrom(filepath.Dir(abs)))
 	}
 
+	// The imported module declares its own core dependency, which the check
+	// on the instance's module above does not see.
+	if err := CheckModuleCoreCompatibility(decodeModuleMetadata(moduleVal), sourceLocal); err != nil {
+		return nil, err
+	}
+
+	// Digest pin: checked before the platform and compile so a wrong artifact
+	// never renders.
+	var moduleDigest string
+	if opts.ExpectedDigest != "" {
+		moduleDigest, err = VerifyModuleDigest(ctx, opts.Config, decodeModuleMetadata(moduleVal), sourceLocal, opts.ExpectedDigest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Platform resolution + materialization only after the instance itself
 	// validated: cheap failures never hit the cluster or registry.
-	env, err := resolvePlatformEnv(ctx, k, opts.Config, opts.PlatformFlag, opts.ClusterPlatform)
+	env, err := resolvePlatformEnv(ctx, k, opts.Config, opts.PlatformFiles, opts.ClusterPlatform)
 	if err != nil {
 		return nil, err
 	}
+	env.strictTraits = opts.StrictTraits
+	env.strictNamespace = opts.StrictNamespace
+	env.instanceUUID = opts.InstanceUUID
 
-	return compileInstance(ctx, env, inst, opts.K8sConfig, sourceLocal)
+	result, err := compileInstance(ctx, env, inst, opts.K8sConfig, sourceLocal, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	result.ModuleDigest = moduleDigest
+	return result, nil
 }
 
 // compileInstance runs the kernel compile on a processed instance and adapts
-// the result to the workflow Result.
+// the result to the workflow Result. A non-empty components list narrows the
+// instance to those components before compile; each must match a transformer
+// unless allowUnmatched is set.
+// Components disabled with metadata.enabled: false are dropped before compile
+// either way: they are never matched and render nothing.
 func compileInstance(
 	ctx context.Context,
 	env *renderEnv,
 	inst *module.Instance,
 	k8sCfg *config.ResolvedKubernetesConfig,
 	sourceLocal bool,
+	components []string,
+	allowUnmatched bool,
 ) (*Result, error) {
-	out, err := env.kernel.Compile(ctx, kernel.CompileInput{
+	narrow := components
+	if disabled := disabledComponents(inst.Package.LookupPath(schema.Components)); len(disabled) > 0 {
+		var err error
+		narrow, err = enabledSelection(inst.Package.LookupPath(schema.Components), components, disabled)
+		if err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
+		output.Info("skipping disabled components", "components", strings.Join(disabled, ", "))
+	}
+
+	if narrow != nil {
+		narrowed, err := selectComponents(inst.Package, narrow)
+		if err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
+		selected := *inst
+		selected.Package = narrowed
+		inst = &selected
+	}
+
+	out, err := compileWithTimeout(ctx, env, kernel.CompileInput{
 		ModuleInstance: inst,
 		Platform:       env.platform,
 		RuntimeName:    RuntimeName,
 	})
+	var timedOut *RenderTimeoutError
+	if errors.As(err, &timedOut) {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
 	if err != nil {
-		printValidationError(err)
-		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: true}
+		if !env.deferErrors {
+			printValidationError(err)
+		}
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: !env.deferErrors}
+	}
+
+	if len(components) > 0 && !allowUnmatched {
+		if err := requireMatched(out.MatchPlan, components); err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
+	}
+
+	moduleMeta := decodeModuleMetadata(inst.Package.LookupPath(schema.Module))
+	if strictTraitsEnabled(env.strictTraits, moduleMeta.Annotations) {
+		if err := checkUnhandledTraits(out.MatchPlan); err != nil {
+			if !env.deferErrors {
+				printUnhandledTraits(err)
+			}
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err, Printed: !env.deferErrors}
+		}
 	}
 
 	converted := make([]*pkgcore.Resource, 0, len(out.Compiled))
@@ -132,12 +220,42 @@ func compileInstance(
 		})
 	}
 
+	if err := stripClusterScopedNamespaces(converted, env.strictNamespace); err != nil {
+		var stray *kubernetes.ClusterScopedNamespaceError
+		if errors.As(err, &stray) {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
+		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+
+	if ns := namespaceOverride(k8sCfg); ns != "" {
+		if err := enforceNamespace(converted, ns, env.strictNamespace); err != nil {
+			var mismatch *NamespaceMismatchError
+			if errors.As(err, &mismatch) {
+				return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+			}
+			return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+	}
+
+	if override := env.instanceUUID; override != "" && inst.Metadata != nil && inst.Metadata.UUID != override {
+		warnInstanceUUIDOverride(inst.Metadata.UUID, override)
+		if err := overrideInstanceUUID(converted, inst.Metadata.UUID, override); err != nil {
+			return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+		}
+	}
+
+	if err := checkResourceConflicts(converted); err != nil {
+		return nil, &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	}
+
 	renderDigest, err := inventory.ComputeRenderDigest(converted)
 	if err != nil {
 		return nil, &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
 	}
 
 	result := &Result{
+		Typed:        converted,
 		Components:   out.Components,
 		MatchPlan:    out.MatchPlan,
 		Warnings:     out.Warnings,
@@ -145,6 +263,8 @@ func compileInstance(
 		RenderDigest: renderDigest,
 		Values:       decodeUnifiedValues(inst.Package.LookupPath(schema.Values)),
 		SourceLocal:  sourceLocal,
+
+		ComponentSpecs: decodeComponentSpecs(inst.Package.LookupPath(schema.Components)),
 	}
 
 	for _, r := range converted {
@@ -165,15 +285,20 @@ func compileInstance(
 			Labels:    inst.Metadata.Labels,
 		}
 	}
-	if k8sCfg != nil {
-		if s := k8sCfg.Namespace.Source; s == config.SourceFlag || s == config.SourceEnv {
-			result.Instance.Namespace = k8sCfg.Namespace.Value
+	if ns := namespaceOverride(k8sCfg); ns != "" {
+		result.Instance.Namespace = ns
+	}
+	if override := env.instanceUUID; override != "" && result.Instance.UUID != override {
+		labels := maps.Clone(result.Instance.Labels)
+		if _, ok := labels[pkgcore.LabelModuleInstanceUUID]; ok {
+			labels[pkgcore.LabelModuleInstanceUUID] = override
 		}
+		result.Instance.UUID, result.Instance.Labels = override, labels
 	}
 
 	// Module metadata decoded from the embedded #module value (carries
 	// nameSnakeCase for the canonical spec.module reference — D6/D37).
-	result.Module = decodeModuleMetadata(inst.Package.LookupPath(schema.Module))
+	result.Module = moduleMeta
 
 	return result, nil
 }
@@ -214,6 +339,37 @@ func decodeUnifiedValues(v cue.Value) map[string]any {
 	return m
 }
 
+// componentSpec is a component's spec, relative to the component.
+var componentSpec = cue.ParsePath("spec")
+
+// decodeComponentSpecs decodes each component's spec to a JSON-shaped map.
+// Components whose spec is absent or cannot be encoded are skipped.
+func decodeComponentSpecs(comps cue.Value) map[string]map[string]any {
+	iter, err := comps.Fields()
+	if err != nil {
+		return nil
+	}
+	specs := map[string]map[string]any{}
+	for iter.Next() {
+		spec := iter.Value().LookupPath(componentSpec)
+		if !spec.Exists() {
+			continue
+		}
+		data, err := spec.MarshalJSON()
+		if err != nil {
+			output.Debug("could not encode component spec", "component", iter.Selector().Unquoted(), "err", err)
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal(data, &m); err != nil {
+			output.Debug("could not decode component spec", "component", iter.Selector().Unquoted(), "err", err)
+			continue
+		}
+		specs[iter.Selector().Unquoted()] = m
+	}
+	return specs
+}
+
 func ShowOutput(result *Result, opts ShowOutputOpts) {
 	showOutput(result, opts)
 }
@@ -9,11 +9,13 @@ import (
 
 	opmexit "github.com/open-platform-model/cli/internal/exit"
 
+	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-platform-model/cli/internal/config"
+	"github.com/open-platform-model/cli/pkg/loader"
 	"github.com/open-platform-model/cli/pkg/module"
 )
 
@@ -74,7 +76,7 @@ func TestRenderFromInstanceFile_RejectsModulePackagePath(t *testing.T) {
 }
 
 func TestUnifyValuesFiles_Empty(t *testing.T) {
-	v, err := unifyValuesFiles(cuecontext.New(), nil)
+	v, err := unifyValuesFiles(cuecontext.New(), valuesSources{})
 	require.NoError(t, err)
 	assert.False(t, v.Exists(), "zero value signals no files given")
 }
@@ -85,7 +87,7 @@ func TestUnifyValuesFiles_SingleFile(t *testing.T) {
 	valuesFile := filepath.Join(dir, "values.cue")
 	require.NoError(t, os.WriteFile(valuesFile, []byte("package test\nvalues: {replicas: 3}\n"), 0o644))
 
-	v, err := unifyValuesFiles(ctx, []string{valuesFile})
+	v, err := unifyValuesFiles(ctx, valuesSources{Files: []string{valuesFile}})
 	require.NoError(t, err)
 	require.True(t, v.Exists())
 	assert.NoError(t, v.Validate())
@@ -99,12 +101,46 @@ func TestUnifyValuesFiles_MultipleFilesUnify(t *testing.T) {
 	require.NoError(t, os.WriteFile(f1, []byte("package test\nvalues: {replicas: 3}\n"), 0o644))
 	require.NoError(t, os.WriteFile(f2, []byte("package test\nvalues: {image: \"nginx\"}\n"), 0o644))
 
-	v, err := unifyValuesFiles(ctx, []string{f1, f2})
+	v, err := unifyValuesFiles(ctx, valuesSources{Files: []string{f1, f2}})
 	require.NoError(t, err)
 	require.True(t, v.Exists())
 	assert.NoError(t, v.Validate())
 }
 
+func TestUnifyValuesFiles_WithClusterDocument(t *testing.T) {
+	ctx := cuecontext.New()
+	dir := t.TempDir()
+	f1 := filepath.Join(dir, "a.cue")
+	require.NoError(t, os.WriteFile(f1, []byte("package test\nvalues: {replicas: 3}\n"), 0o644))
+	doc := loader.ValuesDocument{Name: "configmap demo/app:values.yaml", Data: []byte("image: nginx\n")}
+
+	v, err := unifyValuesFiles(ctx, valuesSources{Files: []string{f1}, Documents: []loader.ValuesDocument{doc}})
+	require.NoError(t, err)
+	image, err := v.LookupPath(cue.ParsePath("image")).String()
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", image)
+}
+
+func TestUnifyValuesFiles_WithYAMLFiles(t *testing.T) {
+	ctx := cuecontext.New()
+	dir := t.TempDir()
+	f1 := filepath.Join(dir, "a.cue")
+	y1 := filepath.Join(dir, "base.yaml")
+	y2 := filepath.Join(dir, "prod.yaml")
+	require.NoError(t, os.WriteFile(f1, []byte("package test\nvalues: {replicas: 3}\n"), 0o644))
+	require.NoError(t, os.WriteFile(y1, []byte("image: nginx\n"), 0o644))
+	require.NoError(t, os.WriteFile(y2, []byte("image: caddy\n"), 0o644))
+
+	v, err := unifyValuesFiles(ctx, valuesSources{Files: []string{f1}, YAMLFiles: []string{y1, y2}})
+	require.NoError(t, err)
+	image, err := v.LookupPath(cue.ParsePath("image")).String()
+	require.NoError(t, err)
+	assert.Equal(t, "caddy", image, "later YAML files win")
+	replicas, err := v.LookupPath(cue.ParsePath("replicas")).Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replicas)
+}
+
 func TestUnifyValuesFiles_ConflictFails(t *testing.T) {
 	ctx := cuecontext.New()
 	dir := t.TempDir()
@@ -113,6 +149,6 @@ func TestUnifyValuesFiles_ConflictFails(t *testing.T) {
 	require.NoError(t, os.WriteFile(f1, []byte("package test\nvalues: {replicas: 3}\n"), 0o644))
 	require.NoError(t, os.WriteFile(f2, []byte("package test\nvalues: {replicas: 4}\n"), 0o644))
 
-	_, err := unifyValuesFiles(ctx, []string{f1, f2})
+	_, err := unifyValuesFiles(ctx, valuesSources{Files: []string{f1, f2}})
 	require.Error(t, err)
 }
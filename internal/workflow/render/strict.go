@@ -0,0 +1,83 @@
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-platform-model/library/opm/compile"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// AnnotationStrictTraits, set to "true" (or "false") in a module's
+// metadata.annotations, is the module's default for strict trait handling.
+// An explicit --strict-traits flag overrides it.
+const AnnotationStrictTraits = "opmodel.dev/strict-traits"
+
+// UnhandledTrait is a trait a component declares that none of the
+// transformers matched to it consumes: its values would be ignored.
+type UnhandledTrait struct {
+	Component string
+	Trait     string
+}
+
+// UnhandledTraitsError fails a render in strict trait mode. It is kept apart
+// from transform errors: the render itself succeeded, and what fails it is
+// the policy that every declared trait must be honored.
+type UnhandledTraitsError struct {
+	Traits []UnhandledTrait
+}
+
+func (e *UnhandledTraitsError) Error() string {
+	lines := make([]string, 0, len(e.Traits))
+	for _, t := range e.Traits {
+		lines = append(lines, fmt.Sprintf("component %q: trait %q is not handled by any matched transformer", t.Component, t.Trait))
+	}
+	return fmt.Sprintf("strict traits: %d unhandled trait(s): %s", len(e.Traits), strings.Join(lines, "; "))
+}
+
+// printUnhandledTraits reports a strict-mode failure under its own heading,
+// so it is not mistaken for a transform error.
+func printUnhandledTraits(e *UnhandledTraitsError) {
+	output.Error(fmt.Sprintf("strict traits: %d trait(s) not handled by any matched transformer", len(e.Traits)))
+	var b strings.Builder
+	for _, t := range e.Traits {
+		fmt.Fprintf(&b, "  %s: %s\n", t.Component, t.Trait)
+	}
+	b.WriteString("Add a transformer that consumes each trait to the platform, or pass --strict-traits=false to render with a warning.")
+	output.Details(b.String())
+}
+
+// strictTraitsEnabled resolves strict trait mode: the explicit flag when
+// given, else the module's AnnotationStrictTraits, else off.
+func strictTraitsEnabled(flag *bool, moduleAnnotations map[string]string) bool {
+	if flag != nil {
+		return *flag
+	}
+	return moduleAnnotations[AnnotationStrictTraits] == "true"
+}
+
+// checkUnhandledTraits returns an *UnhandledTraitsError listing every trait
+// no matched transformer consumes, sorted by component and trait, or nil.
+func checkUnhandledTraits(plan *compile.MatchPlan) *UnhandledTraitsError {
+	if plan == nil || len(plan.UnhandledTraits) == 0 {
+		return nil
+	}
+	var traits []UnhandledTrait
+	for component, fqns := range plan.UnhandledTraits {
+		for _, fqn := range fqns {
+			traits = append(traits, UnhandledTrait{Component: component, Trait: fqn})
+		}
+	}
+	if len(traits) == 0 {
+		return nil
+	}
+	sort.Slice(traits, func(i, j int) bool {
+		if traits[i].Component != traits[j].Component {
+			return traits[i].Component < traits[j].Component
+		}
+		return traits[i].Trait < traits[j].Trait
+	})
+	return &UnhandledTraitsError{Traits: traits}
+}
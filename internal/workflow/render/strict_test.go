@@ -0,0 +1,42 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/library/opm/compile"
+)
+
+func TestStrictTraitsEnabled(t *testing.T) {
+	on, off := true, false
+	strictModule := map[string]string{AnnotationStrictTraits: "true"}
+
+	assert.False(t, strictTraitsEnabled(nil, nil), "off by default")
+	assert.True(t, strictTraitsEnabled(nil, strictModule), "the module's annotation is the default")
+	assert.False(t, strictTraitsEnabled(&off, strictModule), "an explicit flag overrides the module")
+	assert.True(t, strictTraitsEnabled(&on, nil))
+}
+
+func TestCheckUnhandledTraits(t *testing.T) {
+	assert.Nil(t, checkUnhandledTraits(nil))
+	assert.Nil(t, checkUnhandledTraits(&compile.MatchPlan{}))
+
+	err := checkUnhandledTraits(&compile.MatchPlan{UnhandledTraits: map[string][]string{
+		"web": {"opmodel.dev/traits/expose@v1", "opmodel.dev/traits/backup@v1"},
+		"db":  {"opmodel.dev/traits/backup@v1"},
+	}})
+	require.NotNil(t, err)
+	assert.Equal(t, []UnhandledTrait{
+		{Component: "db", Trait: "opmodel.dev/traits/backup@v1"},
+		{Component: "web", Trait: "opmodel.dev/traits/backup@v1"},
+		{Component: "web", Trait: "opmodel.dev/traits/expose@v1"},
+	}, err.Traits)
+	assert.Contains(t, err.Error(), "strict traits: 3 unhandled trait(s)")
+
+	diags := ErrorDiagnostics(err)
+	require.Len(t, diags, 3, "vet reports each refused trait on its own")
+	assert.Equal(t, "db", diags[0].Component)
+	assert.Contains(t, diags[0].Message, "strict traits")
+}
@@ -0,0 +1,50 @@
+package render
+
+import (
+	"sort"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// ComponentCount is the number of resources one component rendered.
+type ComponentCount struct {
+	Component string `json:"component"`
+	Resources int    `json:"resources"`
+}
+
+// BuildSummary counts a render's resources per component.
+type BuildSummary struct {
+	Components []ComponentCount `json:"components"`
+	Total      int              `json:"total"`
+}
+
+// Summarize counts result's resources per component, sorted by component
+// name. Every compiled component is listed, including one that rendered
+// nothing — a zero is often exactly what a module author is looking for.
+func Summarize(result *Result) BuildSummary {
+	counts := map[string]int{}
+	for _, c := range result.Components {
+		counts[c.Name] = 0
+	}
+	for _, r := range result.Typed {
+		counts[r.Component]++
+	}
+
+	summary := BuildSummary{Components: make([]ComponentCount, 0, len(counts)), Total: len(result.Resources)}
+	for name, n := range counts {
+		summary.Components = append(summary.Components, ComponentCount{Component: name, Resources: n})
+	}
+	sort.Slice(summary.Components, func(i, j int) bool {
+		return summary.Components[i].Component < summary.Components[j].Component
+	})
+	return summary
+}
+
+// LogSummary logs s for an instance, one line per component and a total.
+func LogSummary(instanceName string, s BuildSummary) {
+	instanceLog := output.InstanceLogger(instanceName)
+	for _, c := range s.Components {
+		instanceLog.Info("rendered component", "component", c.Component, "resources", c.Resources)
+	}
+	instanceLog.Info("rendered total", "components", len(s.Components), "resources", s.Total)
+}
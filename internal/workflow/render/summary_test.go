@@ -0,0 +1,38 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/library/opm/compile"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+)
+
+func TestSummarize(t *testing.T) {
+	result := &Result{
+		Resources: make([]*unstructured.Unstructured, 3),
+		Typed: []*pkgcore.Resource{
+			{Component: "web"}, {Component: "db"}, {Component: "web"},
+		},
+		Components: []compile.ComponentSummary{{Name: "web"}, {Name: "db"}, {Name: "cron"}},
+	}
+
+	assert.Equal(t, BuildSummary{
+		Components: []ComponentCount{
+			{Component: "cron", Resources: 0},
+			{Component: "db", Resources: 1},
+			{Component: "web", Resources: 2},
+		},
+		Total: 3,
+	}, Summarize(result))
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	s := Summarize(&Result{})
+	assert.Empty(t, s.Components)
+	assert.NotNil(t, s.Components, "an empty summary marshals components as [], not null")
+	assert.Zero(t, s.Total)
+}
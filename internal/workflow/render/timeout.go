@@ -0,0 +1,75 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-platform-model/library/opm/kernel"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// compilePhase names the kernel compile — transformer matching and execution
+// — in a RenderTimeoutError.
+const compilePhase = "compile (match and execute)"
+
+// RenderTimeoutError reports a render stopped by its timeout
+// (--render-timeout).
+type RenderTimeoutError struct {
+	Timeout time.Duration
+	// Phase is the render phase the deadline interrupted.
+	Phase string
+	// Before is how long the render ran, loading the module and resolving
+	// the platform, before Phase started.
+	Before time.Duration
+}
+
+func (e *RenderTimeoutError) Error() string {
+	return fmt.Sprintf("render timed out after %s in phase %s (loading took %s before it); "+
+		"a component or transformer may not terminate — render with --show-only to narrow it down",
+		e.Timeout, e.Phase, e.Before.Round(time.Millisecond))
+}
+
+// compileWithTimeout runs the kernel compile under env.renderTimeout, when
+// set. CUE evaluation cannot be interrupted: the kernel checks the deadline
+// only between (component, transformer) pairs, so a pair that never finishes
+// is abandoned to its goroutine, and the process is expected to exit on the
+// returned *RenderTimeoutError.
+func compileWithTimeout(ctx context.Context, env *renderEnv, in kernel.CompileInput) (*kernel.CompileResult, error) {
+	if env.renderTimeout <= 0 {
+		return env.kernel.Compile(ctx, in)
+	}
+
+	var before time.Duration
+	if !env.started.IsZero() {
+		before = time.Since(env.started)
+	}
+	timedOut := &RenderTimeoutError{Timeout: env.renderTimeout, Phase: compilePhase, Before: before}
+
+	ctx, cancel := context.WithTimeout(ctx, env.renderTimeout)
+	defer cancel()
+
+	type outcome struct {
+		out *kernel.CompileResult
+		err error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		out, err := env.kernel.Compile(ctx, in)
+		done <- outcome{out, err}
+	}()
+
+	select {
+	case o := <-done:
+		if o.err != nil && (errors.Is(o.err, context.DeadlineExceeded) || ctx.Err() != nil) {
+			return nil, timedOut
+		}
+		output.Debug("compile finished", "phase", compilePhase, "elapsed", time.Since(start).Round(time.Millisecond))
+		return o.out, o.err
+	case <-ctx.Done():
+		return nil, timedOut
+	}
+}
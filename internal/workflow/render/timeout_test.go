@@ -0,0 +1,31 @@
+package render
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-platform-model/library/opm/kernel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWithTimeout(t *testing.T) {
+	t.Run("no timeout passes the compile through", func(t *testing.T) {
+		env := &renderEnv{kernel: kernel.New()}
+		_, err := compileWithTimeout(context.Background(), env, kernel.CompileInput{})
+		require.Error(t, err)
+		var timedOut *RenderTimeoutError
+		assert.NotErrorAs(t, err, &timedOut)
+	})
+
+	t.Run("an expired deadline is a timeout", func(t *testing.T) {
+		env := &renderEnv{kernel: kernel.New(), renderTimeout: time.Nanosecond, started: time.Now().Add(-time.Second)}
+		_, err := compileWithTimeout(context.Background(), env, kernel.CompileInput{})
+		var timedOut *RenderTimeoutError
+		require.ErrorAs(t, err, &timedOut)
+		assert.Equal(t, compilePhase, timedOut.Phase)
+		assert.GreaterOrEqual(t, timedOut.Before, time.Second)
+		assert.Contains(t, err.Error(), "render timed out after 1ns in phase compile (match and execute)")
+	})
+}
@@ -0,0 +1,141 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-platform-model/cli/internal/output"
+)
+
+// Tree chrome, matching instance tree.
+const (
+	treeConnMid  = "├── "
+	treeConnLast = "└── "
+	treePipe     = "│   "
+	treeSpace    = "    "
+)
+
+// TreeResource is one rendered resource under its component.
+type TreeResource struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	Transformer string `json:"transformer"`
+}
+
+// TreeComponent is one component and every resource it rendered, across all
+// the transformers it matched.
+type TreeComponent struct {
+	Name         string         `json:"name"`
+	Transformers []string       `json:"transformers"`
+	Resources    []TreeResource `json:"resources"`
+}
+
+// ModuleTree maps a render: module → components → resources.
+type ModuleTree struct {
+	Module     string          `json:"module"`
+	Version    string          `json:"version,omitempty"`
+	Instance   string          `json:"instance"`
+	Namespace  string          `json:"namespace"`
+	Components []TreeComponent `json:"components"`
+}
+
+// BuildModuleTree groups a render's resources by the component that produced
+// them. Components are sorted by name and every compiled component is listed,
+// including one that rendered nothing; resources keep render order.
+func BuildModuleTree(result *Result) ModuleTree {
+	tree := ModuleTree{
+		Module:     result.Module.Name,
+		Version:    result.Module.Version,
+		Instance:   result.Instance.Name,
+		Namespace:  result.Instance.Namespace,
+		Components: []TreeComponent{},
+	}
+
+	byName := map[string]*TreeComponent{}
+	component := func(name string) *TreeComponent {
+		if c, ok := byName[name]; ok {
+			return c
+		}
+		c := &TreeComponent{Name: name, Transformers: []string{}, Resources: []TreeResource{}}
+		byName[name] = c
+		return c
+	}
+	for _, c := range result.Components {
+		component(c.Name)
+	}
+	if result.MatchPlan != nil {
+		for _, p := range result.MatchPlan.MatchedPairs() {
+			c := component(p.ComponentName)
+			c.Transformers = append(c.Transformers, p.TransformerFQN)
+		}
+	}
+	for i, r := range result.Typed {
+		u := result.Resources[i]
+		c := component(r.Component)
+		c.Resources = append(c.Resources, TreeResource{
+			Kind: u.GetKind(), Name: u.GetName(), Namespace: u.GetNamespace(), Transformer: r.Transformer,
+		})
+	}
+
+	for _, c := range byName {
+		tree.Components = append(tree.Components, *c)
+	}
+	sort.Slice(tree.Components, func(i, j int) bool { return tree.Components[i].Name < tree.Components[j].Name })
+	return tree
+}
+
+// FormatModuleTreeJSON serializes a ModuleTree to indented JSON.
+func FormatModuleTreeJSON(tree ModuleTree) (string, error) {
+	data, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling module tree to JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatModuleTree renders a ModuleTree as a terminal tree.
+func FormatModuleTree(tree ModuleTree) string {
+	var sb strings.Builder
+	header := tree.Module
+	if tree.Version != "" {
+		header += "@" + tree.Version
+	}
+	fmt.Fprintf(&sb, "%s (instance %s, namespace %s)\n", output.StyleNoun(header), tree.Instance, tree.Namespace)
+
+	for i, c := range tree.Components {
+		conn, indent := treeConnMid, treePipe
+		if i == len(tree.Components)-1 {
+			conn, indent = treeConnLast, treeSpace
+		}
+		resourceWord := "resources"
+		if len(c.Resources) == 1 {
+			resourceWord = "resource"
+		}
+		fmt.Fprintf(&sb, "%s%s   %d %s\n", output.Dim(conn), output.FormatComponent(c.Name), len(c.Resources), resourceWord)
+
+		if len(c.Resources) == 0 {
+			note := "(no matching transformer)"
+			if len(c.Transformers) > 0 {
+				note = "(rendered nothing)"
+			}
+			fmt.Fprintf(&sb, "%s%s%s\n", output.Dim(indent), output.Dim(treeConnLast), output.Dim(note))
+			continue
+		}
+		for j, r := range c.Resources {
+			rconn := treeConnMid
+			if j == len(c.Resources)-1 {
+				rconn = treeConnLast
+			}
+			line := r.Kind + "/" + r.Name
+			// Name the transformer only when several share the component.
+			if len(c.Transformers) > 1 {
+				line += "   " + output.Dim(r.Transformer)
+			}
+			fmt.Fprintf(&sb, "%s%s%s\n", output.Dim(indent), output.Dim(rconn), line)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
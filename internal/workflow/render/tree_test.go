@@ -0,0 +1,67 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-platform-model/library/opm/compile"
+
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
+)
+
+const tfService = "opmodel.dev/transformers/service@v1"
+
+func treeFixture() *Result {
+	obj := func(kind, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetKind(kind)
+		u.SetName(name)
+		u.SetNamespace("demo")
+		return u
+	}
+	return &Result{
+		Resources: []*unstructured.Unstructured{obj("Deployment", "web"), obj("Service", "web")},
+		Typed: []*pkgcore.Resource{
+			{Component: "web", Transformer: tfDeployment},
+			{Component: "web", Transformer: tfService},
+		},
+		Instance:   pkgmodule.InstanceMetadata{Name: "app", Namespace: "demo"},
+		Module:     pkgmodule.ModuleMetadata{Name: "app", Version: "0.1.0"},
+		Components: []compile.ComponentSummary{{Name: "web"}, {Name: "db"}},
+		MatchPlan: &compile.MatchPlan{Matches: map[string]map[string]compile.MatchResult{
+			"web": {tfDeployment: {Matched: true}, tfService: {Matched: true}},
+			"db":  {},
+		}},
+	}
+}
+
+func TestBuildModuleTree(t *testing.T) {
+	tree := BuildModuleTree(treeFixture())
+
+	require.Len(t, tree.Components, 2)
+	assert.Equal(t, "db", tree.Components[0].Name)
+	assert.Empty(t, tree.Components[0].Resources, "a component that rendered nothing is still listed")
+
+	web := tree.Components[1]
+	assert.Equal(t, []string{tfDeployment, tfService}, web.Transformers)
+	assert.Equal(t, []TreeResource{
+		{Kind: "Deployment", Name: "web", Namespace: "demo", Transformer: tfDeployment},
+		{Kind: "Service", Name: "web", Namespace: "demo", Transformer: tfService},
+	}, web.Resources, "resources from every matched transformer sit under the component")
+}
+
+func TestFormatModuleTree(t *testing.T) {
+	text := FormatModuleTree(BuildModuleTree(treeFixture()))
+	assert.Contains(t, text, "app@0.1.0")
+	assert.Contains(t, text, "(no matching transformer)")
+	assert.Contains(t, text, "Deployment/web")
+	assert.Contains(t, text, tfService, "with several transformers each resource names its own")
+
+	data, err := FormatModuleTreeJSON(BuildModuleTree(treeFixture()))
+	require.NoError(t, err)
+	assert.Contains(t, data, `"resources": []`)
+}
@@ -1,6 +1,8 @@
 package render
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/open-platform-model/library/opm/compile"
@@ -9,12 +11,19 @@ import (
 
 	"github.com/open-platform-model/cli/internal/config"
 	"github.com/open-platform-model/cli/internal/platform"
+	pkgcore "github.com/open-platform-model/cli/pkg/core"
+	"github.com/open-platform-model/cli/pkg/loader"
 	pkgmodule "github.com/open-platform-model/cli/pkg/module"
 )
 
 // Result is the output of the shared render workflow.
 type Result struct {
-	Resources  []*unstructured.Unstructured
+	Resources []*unstructured.Unstructured
+	// Typed holds the same resources as Resources, in the same order, as the
+	// kernel produced them: the CUE value plus the component and transformer
+	// that rendered it. Callers that need provenance per resource read it
+	// here instead of re-deriving it from labels.
+	Typed      []*pkgcore.Resource
 	Instance   pkgmodule.InstanceMetadata // Was: Release (enhancement 0002 D8/D9)
 	Module     pkgmodule.ModuleMetadata
 	Components []compile.ComponentSummary
@@ -49,6 +58,17 @@ type Result struct {
 	// a replaceWith. The apply workflow stamps
 	// module-instance.opmodel.dev/source: local on the CR accordingly.
 	SourceLocal bool
+
+	// ModuleDigest is the OCI manifest digest the module resolved to, set when
+	// the render verified it against InstanceFileOpts.ExpectedDigest. The apply
+	// workflow records it on the ModuleInstance CR.
+	ModuleDigest string
+
+	// ComponentSpecs holds each compiled component's spec, decoded to a
+	// JSON-shaped map and keyed by component name: the values a transformer
+	// receives as #component.spec. A component whose spec could not be
+	// decoded is absent.
+	ComponentSpecs map[string]map[string]any
 }
 
 func (r *Result) HasWarnings() bool {
@@ -62,13 +82,48 @@ func (r *Result) ResourceCount() int {
 type InstanceFileOpts struct {
 	InstanceFilePath string
 	ValuesFiles      []string
-
-	// PlatformFlag is the --platform local override file (0006 D21).
-	PlatformFlag string
+	// ValuesYAMLFiles are --values-yaml files, merged last-wins among
+	// themselves and unified with ValuesFiles.
+	ValuesYAMLFiles []string
+	// ValuesJSONFiles are --values-json files, merged last-wins among
+	// themselves and unified with ValuesFiles.
+	ValuesJSONFiles []string
+	// ValuesDocuments are values read from the cluster
+	// (--values-from-configmap, --values-from-secret), unified with
+	// ValuesFiles.
+	ValuesDocuments []loader.ValuesDocument
+	// ExpandEnv interpolates ${VAR} in the values files before they are
+	// compiled (--expand-env); the zero value leaves them untouched.
+	ExpandEnv loader.EnvExpansion
+
+	// PlatformFiles are the --platform local override files, a base and its
+	// overlays (0006 D21; see platform.DecodeFiles).
+	PlatformFiles []string
 	// ClusterPlatform reads the cluster Platform CR spec. nil marks the
 	// command offline: the cluster is never consulted (D17/D21).
 	ClusterPlatform platform.ClusterSpecGetter
 
+	// ExpectedDigest, when set, pins the instance's module to an OCI manifest
+	// digest (--module-digest): the render fails unless the module resolves
+	// from the registry to exactly that artifact.
+	ExpectedDigest string
+
+	// StrictTraits, when set, overrides the module's AnnotationStrictTraits:
+	// true fails the render on any trait no matched transformer consumes,
+	// false logs those as warnings. Nil uses the module's default.
+	StrictTraits *bool
+
+	// StrictNamespace fails the render when a transformer puts a namespaced
+	// resource outside the --namespace target, or gives a cluster-scoped
+	// resource a namespace, instead of correcting it.
+	StrictNamespace bool
+
+	// InstanceUUID, when set, replaces the instance UUID the kernel derives
+	// from the module, name, and namespace: on the rendered resources' UUID
+	// labels and on Result.Instance, so the inventory uses it too. It must
+	// be a lower-case UUID.
+	InstanceUUID string
+
 	K8sConfig *config.ResolvedKubernetesConfig
 	Config    *config.GlobalConfig
 }
@@ -82,12 +137,75 @@ type ModuleOpts struct {
 	// ValuesFiles, when non-empty, override the module's debugValues.
 	ValuesFiles []string
 
+	// ValuesYAMLFiles are --values-yaml files, merged last-wins among
+	// themselves. Like ValuesFiles they replace debugValues, and are unified
+	// with any -f files.
+	ValuesYAMLFiles []string
+
+	// ValuesJSONFiles are --values-json files, merged last-wins among
+	// themselves, like ValuesYAMLFiles.
+	ValuesJSONFiles []string
+
+	// ValuesDocuments are values read from the cluster
+	// (--values-from-configmap, --values-from-secret). Like ValuesFiles they
+	// replace debugValues, and are unified with any files.
+	ValuesDocuments []loader.ValuesDocument
+
+	// ExpandEnv interpolates ${VAR} in the values files before they are
+	// compiled (--expand-env); the zero value leaves them untouched.
+	ExpandEnv loader.EnvExpansion
+
+	// SetValues override individual values on top of ValuesFiles (or
+	// debugValues).
+	SetValues loader.SetValues
+
+	// ComponentSets are --set-component "<component>.<path>=value" overrides,
+	// filled into one component of the synthesized instance rather than the
+	// module-wide values.
+	ComponentSets []string
+
 	// Name overrides the synthetic metadata.name. Empty falls back to
 	// "<module.metadata.name>-debug".
 	Name string
 
-	// PlatformFlag is the --platform local override file (0006 D21).
-	PlatformFlag string
+	// Components, when non-empty, restricts the render to the named
+	// components. Unknown names, and names that match no transformer, fail
+	// the render.
+	Components []string
+
+	// AllowUnmatched keeps a narrowed render going when a named component
+	// matches no transformer: the component is reported in
+	// MatchPlan.Unmatched instead of failing the render. Used by diagnostics
+	// that explain why a component rendered nothing.
+	AllowUnmatched bool
+
+	// StrictValues fails the render, before synthesis, on any values field
+	// the module's #config does not declare. Without it a field an open
+	// struct admits is accepted silently.
+	StrictValues bool
+
+	// StrictTraits overrides the module's strict trait default; see
+	// InstanceFileOpts.StrictTraits.
+	StrictTraits *bool
+
+	// StrictNamespace: see InstanceFileOpts.StrictNamespace.
+	StrictNamespace bool
+
+	// InstanceUUID: see InstanceFileOpts.InstanceUUID.
+	InstanceUUID string
+
+	// RenderTimeout bounds the kernel compile — transformer matching and
+	// execution — and fails the render with a *RenderTimeoutError when it
+	// runs longer. Zero is unbounded.
+	RenderTimeout time.Duration
+
+	// DeferErrors returns validation and compile errors without printing
+	// them, for a caller that reports them itself (see ErrorDiagnostics).
+	DeferErrors bool
+
+	// PlatformFiles are the --platform local override files, a base and its
+	// overlays (0006 D21; see platform.DecodeFiles).
+	PlatformFiles []string
 	// ClusterPlatform reads the cluster Platform CR spec. nil marks the
 	// command offline: the cluster is never consulted (D17/D21).
 	ClusterPlatform platform.ClusterSpecGetter
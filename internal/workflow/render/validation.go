@@ -1,7 +1,17 @@
 package render
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
 	"github.com/open-platform-model/cli/internal/cmdutil"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
+	"github.com/open-platform-model/cli/internal/output"
+	"github.com/open-platform-model/cli/pkg/loader"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
 )
 
 func printValidationError(err error) {
@@ -10,3 +20,121 @@ func printValidationError(err error) {
 	}
 	cmdutil.PrintValidationError("render failed", err)
 }
+
+// CheckCoreCompatibility checks the opmodel.dev/core version the CUE module
+// containing dir declares (its minimum, see loader.CoreVersion) against the
+// range this CLI supports, before the (much slower) load: version skew
+// otherwise surfaces as confusing schema errors deep in the render.
+func CheckCoreCompatibility(dir string) error {
+	abs, absErr := filepath.Abs(dir)
+	if absErr != nil {
+		return nil // best-effort: the load reports bad paths
+	}
+	version, err := loader.CoreVersion(loader.ModuleRootFrom(abs))
+	if err != nil {
+		output.Debug("could not read core version", "error", err)
+		return nil
+	}
+	warning, err := loader.CheckCoreCompatibility(version)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+	}
+	if warning != "" {
+		output.Warn(warning)
+	}
+	return nil
+}
+
+// CheckModuleCoreCompatibility checks the opmodel.dev/core version the
+// registry module mod declares — the module an instance file imports — the
+// same way CheckCoreCompatibility checks the instance's own module. A module
+// replaced with local source, or whose module file is not in the cache, is
+// not checked.
+func CheckModuleCoreCompatibility(mod pkgmodule.ModuleMetadata, sourceLocal bool) error {
+	if sourceLocal {
+		return nil
+	}
+	cacheDir, err := loader.CUECacheDir()
+	if err != nil {
+		return nil // best-effort, like CheckCoreCompatibility
+	}
+	modulePath, version := mod.CanonicalModuleRef()
+	core, err := loader.CachedModuleCoreVersion(cacheDir, modulePath, version)
+	if err != nil {
+		output.Debug("could not read module core version", "module", modulePath+"@"+version, "error", err)
+		return nil
+	}
+	warning, err := loader.CheckCoreCompatibility(core)
+	if err != nil {
+		return &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf("%s@%s: %w", modulePath, version, err)}
+	}
+	if warning != "" {
+		output.Warn(fmt.Sprintf("%s@%s: %s", modulePath, version, warning))
+	}
+	return nil
+}
+
+// CheckOfflineCache fails an offline run before the load when the CUE module
+// containing dir depends on a module@version that is not in the local CUE
+// module cache; the load itself would only report that the module path does
+// not resolve to a registry. It does nothing unless offline is set.
+func CheckOfflineCache(dir string, offline bool) error {
+	if !offline {
+		return nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil // best-effort: the load reports bad paths
+	}
+	if err := loader.CheckOfflineDependencies(loader.ModuleRootFrom(abs)); err != nil {
+		var uncached *loader.UncachedDependencyError
+		if errors.As(err, &uncached) {
+			return &opmexit.ExitError{Code: opmexit.ExitNotFound, Err: fmt.Errorf(
+				"--offline: %w; run 'opm module vendor' with registry access to populate the cache", err)}
+		}
+		return &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	return nil
+}
+
+// VerifyModuleDigest checks that the registry module described by mod, as
+// loaded from the CUE module cache, is the artifact pinned by expected
+// (--module-digest). The check reads the cached content the load used and
+// the pinned manifest by digest, so neither a re-tag nor an edited cache entry
+// slips through. It returns the verified digest. A module rendered from local
+// source has no registry artifact to pin, and offline mode cannot reach the
+// registry that serves the pinned manifest, so both are refused rather than
+// silently skipped.
+func VerifyModuleDigest(ctx context.Context, cfg *config.GlobalConfig, mod pkgmodule.ModuleMetadata, sourceLocal bool, expected string) (string, error) {
+	if err := loader.ValidateDigest(expected); err != nil {
+		return "", &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	if sourceLocal {
+		return "", &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: fmt.Errorf(
+			"--module-digest pins a registry artifact, but module %s is replaced with local source in cue.mod/local-module.cue", mod.Name)}
+	}
+	if cfg.Offline {
+		return "", &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: fmt.Errorf(
+			"--module-digest cannot be verified with --offline: the pinned manifest is only served by the registry")}
+	}
+	cacheDir, err := loader.CUECacheDir()
+	if err != nil {
+		return "", &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+
+	modulePath, version := mod.CanonicalModuleRef()
+	err = loader.VerifyCachedModule(ctx, cfg.Registry, cacheDir, modulePath, version, expected)
+	if err != nil {
+		var mismatch *loader.DigestMismatchError
+		var cached *loader.CachedContentMismatchError
+		if errors.As(err, &mismatch) || errors.As(err, &cached) {
+			return "", &opmexit.ExitError{Code: opmexit.ExitValidationError, Err: err}
+		}
+		if regErr := RegistryLoadError(err, cfg.Registry); regErr != nil {
+			return "", regErr
+		}
+		return "", &opmexit.ExitError{Code: opmexit.ExitGeneralError, Err: err}
+	}
+	output.Debug("module digest verified", "module", modulePath+"@"+version, "digest", expected)
+	return expected, nil
+}
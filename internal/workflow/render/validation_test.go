@@ -2,13 +2,18 @@ package render
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	"github.com/open-platform-model/cli/internal/config"
+	opmexit "github.com/open-platform-model/cli/internal/exit"
 	"github.com/open-platform-model/cli/internal/output"
+	pkgmodule "github.com/open-platform-model/cli/pkg/module"
 	"github.com/open-platform-model/cli/pkg/validate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -58,3 +63,30 @@ func TestPrintValidationError_UsesGroupedFormatting(t *testing.T) {
 	assert.Contains(t, string(details), "conflicting values \"test\"")
 	assert.NotContains(t, logBuf.String(), "values do not satisfy #config")
 }
+
+func TestVerifyModuleDigest_RefusedWithoutRegistryArtifact(t *testing.T) {
+	mod := pkgmodule.ModuleMetadata{Name: "podinfo", ModulePath: "opmodel.dev/modules", Version: "0.1.0"}
+	pin := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name        string
+		cfg         *config.GlobalConfig
+		sourceLocal bool
+		expected    string
+		code        int
+		msg         string
+	}{
+		{"malformed", &config.GlobalConfig{}, false, "abc", opmexit.ExitGeneralError, "invalid module digest"},
+		{"local source", &config.GlobalConfig{}, true, pin, opmexit.ExitValidationError, "replaced with local source"},
+		{"offline", &config.GlobalConfig{Offline: true, Registry: "none"}, false, pin, opmexit.ExitGeneralError, "cannot be verified with --offline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyModuleDigest(context.Background(), tt.cfg, mod, tt.sourceLocal, tt.expected)
+			var exitErr *opmexit.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, tt.code, exitErr.Code)
+			assert.Contains(t, err.Error(), tt.msg)
+		})
+	}
+}
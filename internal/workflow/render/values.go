@@ -10,25 +10,82 @@ import (
 	"github.com/open-platform-model/cli/pkg/loader"
 )
 
-// unifyValuesFiles loads every -f/--values file and unifies them in
-// declaration order into a single cue.Value — the kernel's synthesis and
-// processing take one values input. The zero cue.Value means "no files
-// given" (the caller's fallback applies).
-func unifyValuesFiles(cueCtx *cue.Context, valuesFiles []string) (cue.Value, error) {
-	if len(valuesFiles) == 0 {
+// valuesSources are the values inputs of a render, in unification order.
+type valuesSources struct {
+	// Files are the -f/--values files.
+	Files []string
+	// YAMLFiles and JSONFiles are the --values-yaml and --values-json
+	// files, each set merged last-wins among itself.
+	YAMLFiles []string
+	JSONFiles []string
+	// Documents are the cluster values documents.
+	Documents []loader.ValuesDocument
+	// Env is the --expand-env interpolation applied to every file.
+	Env loader.EnvExpansion
+}
+
+func (s valuesSources) empty() bool {
+	return len(s.Files) == 0 && len(s.YAMLFiles) == 0 && len(s.JSONFiles) == 0 && len(s.Documents) == 0
+}
+
+func (o InstanceFileOpts) valuesSources() valuesSources {
+	return valuesSources{
+		Files: o.ValuesFiles, YAMLFiles: o.ValuesYAMLFiles, JSONFiles: o.ValuesJSONFiles,
+		Documents: o.ValuesDocuments, Env: o.ExpandEnv,
+	}
+}
+
+func (o ModuleOpts) valuesSources() valuesSources {
+	return valuesSources{
+		Files: o.ValuesFiles, YAMLFiles: o.ValuesYAMLFiles, JSONFiles: o.ValuesJSONFiles,
+		Documents: o.ValuesDocuments, Env: o.ExpandEnv,
+	}
+}
+
+// unifyValuesFiles loads every -f/--values file, then the --values-yaml files
+// and the --values-json files (each set merged among itself, last wins), then
+// every cluster values document, and unifies them in that order into a single
+// cue.Value — the kernel's synthesis and processing take one values input.
+// The zero cue.Value means "no values given" (the caller's fallback applies).
+func unifyValuesFiles(cueCtx *cue.Context, src valuesSources) (cue.Value, error) {
+	if src.empty() {
 		return cue.Value{}, nil
 	}
-	var unified cue.Value
-	for i, valuesFile := range valuesFiles {
-		valuesVal, err := loader.LoadValuesFile(cueCtx, valuesFile)
+	if err := loader.CheckValuesPaths(src.Files); err != nil {
+		return cue.Value{}, err
+	}
+	var all []cue.Value
+	for _, valuesFile := range src.Files {
+		valuesVal, err := loader.LoadValuesFile(cueCtx, valuesFile, src.Env)
 		if err != nil {
 			return cue.Value{}, fmt.Errorf("loading values file %q: %w", valuesFile, err)
 		}
-		if i == 0 {
-			unified = valuesVal
-			continue
+		all = append(all, valuesVal)
+	}
+	if len(src.YAMLFiles) > 0 {
+		yamlVal, err := loader.LoadValuesYAMLFiles(cueCtx, src.YAMLFiles, src.Env)
+		if err != nil {
+			return cue.Value{}, err
+		}
+		all = append(all, yamlVal)
+	}
+	if len(src.JSONFiles) > 0 {
+		jsonVal, err := loader.LoadValuesJSONFiles(cueCtx, src.JSONFiles, src.Env)
+		if err != nil {
+			return cue.Value{}, err
+		}
+		all = append(all, jsonVal)
+	}
+	for _, doc := range src.Documents {
+		valuesVal, err := loader.LoadValuesDocument(cueCtx, doc)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("loading values %s: %w", doc.Name, err)
 		}
-		unified = unified.Unify(valuesVal)
+		all = append(all, valuesVal)
+	}
+	unified := all[0]
+	for _, v := range all[1:] {
+		unified = unified.Unify(v)
 	}
 	if err := unified.Err(); err != nil {
 		return cue.Value{}, fmt.Errorf("unifying values files: %w", err)
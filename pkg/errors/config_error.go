@@ -71,6 +71,42 @@ func GroupedErrorsFromError(err error) []GroupedError {
 	return groupCUEErrors(err)
 }
 
+// FieldErrorsFromError flattens the CUE errors in err into one FieldError per
+// source position: each grouped message (see GroupedErrorsFromError) once for
+// every location that reports it. An error that carries no CUE position is
+// returned as a single FieldError holding its full message.
+func FieldErrorsFromError(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+	groups := GroupedErrorsFromError(err)
+	positioned := false
+	for _, g := range groups {
+		for _, loc := range g.Locations {
+			if loc.Line > 0 {
+				positioned = true
+			}
+		}
+	}
+	if !positioned {
+		return []FieldError{{Message: err.Error()}}
+	}
+
+	var out []FieldError
+	for _, g := range groups {
+		for _, loc := range g.Locations {
+			out = append(out, FieldError{
+				File:    loc.File,
+				Line:    loc.Line,
+				Column:  loc.Column,
+				Path:    loc.Path,
+				Message: g.Message,
+			})
+		}
+	}
+	return out
+}
+
 // groupCUEErrors is the shared implementation for GroupedErrors and
 // GroupedErrorsFromError. It walks the CUE error tree obtained from err and
 // groups errors by message, collecting all source positions (primary +
@@ -49,22 +49,23 @@ func (e *ValidationError) Unwrap() error {
 }
 
 // FieldError is a single validation error tied to a specific source location
-// in a values file.
+// in a values file. It is also the record --error-format json writes, where an
+// error without CUE positions carries only its Message.
 type FieldError struct {
 	// File is the values file name where the error occurred.
-	File string
+	File string `json:"file,omitempty"`
 
 	// Line is the 1-based line number in File.
-	Line int
+	Line int `json:"line,omitempty"`
 
 	// Column is the 1-based column number in File.
-	Column int
+	Column int `json:"column,omitempty"`
 
 	// Path is the dot-joined field path from the values root (e.g. "values.db.port").
-	Path string
+	Path string `json:"path,omitempty"`
 
 	// Message is the human-readable error description.
-	Message string
+	Message string `json:"message"`
 }
 
 // ErrorLocation is a source position paired with its CUE field path.
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"testing"
 
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -95,3 +97,23 @@ func TestConfigError_Unwrap(t *testing.T) {
 	assert.Equal(t, raw, ce.Unwrap())
 	assert.True(t, errors.Is(ce, raw))
 }
+
+func TestFieldErrorsFromError(t *testing.T) {
+	v := cuecontext.New().CompileString("replicas: int & \"three\"\n", cue.Filename("values.cue"))
+	cueErr := v.Validate()
+	require.Error(t, cueErr)
+
+	records := oerrors.FieldErrorsFromError(fmt.Errorf("loading values: %w", cueErr))
+	require.NotEmpty(t, records)
+	for _, r := range records {
+		assert.Equal(t, "values.cue", r.File)
+		assert.Positive(t, r.Line)
+		assert.Positive(t, r.Column)
+		assert.Equal(t, "values.replicas", r.Path)
+		assert.NotEmpty(t, r.Message)
+	}
+
+	plain := oerrors.FieldErrorsFromError(errors.New("module path not found"))
+	assert.Equal(t, []oerrors.FieldError{{Message: "module path not found"}}, plain)
+	assert.Nil(t, oerrors.FieldErrorsFromError(nil))
+}
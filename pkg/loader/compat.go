@@ -0,0 +1,105 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// CoreModulePath is the dependency key of the OPM core schema in a module's
+// cue.mod/module.cue.
+const CoreModulePath = "opmodel.dev/core@v1"
+
+// The opmodel.dev/core versions this CLI understands: at least
+// MinSupportedCoreVersion and below CoreVersionCeiling. A newer core may
+// carry schema fields the CLI cannot handle; an older one predates fields it
+// relies on.
+const (
+	MinSupportedCoreVersion = "v1.0.0-alpha.1"
+	CoreVersionCeiling      = "v1.1.0"
+)
+
+// CoreVersion returns the opmodel.dev/core version the module rooted at
+// moduleRoot declares in its cue.mod/module.cue. That is the minimum the
+// module asks for; under minimal version selection the load may use a newer
+// core that another dependency requires. It returns "" when the module has no
+// cue.mod/module.cue or no core dependency.
+func CoreVersion(moduleRoot string) (string, error) {
+	if moduleRoot == "" {
+		return "", nil
+	}
+	return coreVersionFromFile(filepath.Join(moduleRoot, "cue.mod", "module.cue"))
+}
+
+// CachedModuleCoreVersion returns the opmodel.dev/core version declared by
+// the registry module modulePath@version, read from its module file in the
+// CUE module cache at cacheDir — the module an instance file imports, which
+// CoreVersion of the instance's own module does not cover. Like CoreVersion
+// it is the declared minimum, and "" when the module file is not cached or
+// has no core dependency.
+func CachedModuleCoreVersion(cacheDir, modulePath, version string) (string, error) {
+	mv, err := module.NewVersion(modulePath, version)
+	if err != nil {
+		return "", err
+	}
+	esc, err := module.EscapePath(mv.BasePath())
+	if err != nil {
+		return "", err
+	}
+	ver, err := module.EscapeVersion(mv.Version())
+	if err != nil {
+		return "", err
+	}
+	return coreVersionFromFile(filepath.Join(cacheDir, "mod", "download", esc, "@v", ver+".mod"))
+}
+
+// coreVersionFromFile reads the core dependency version from a module.cue
+// file at path; a missing file has none.
+func coreVersionFromFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	v := cuecontext.New().CompileBytes(data, cue.Filename(path))
+	if err := v.Err(); err != nil {
+		return "", fmt.Errorf("parsing %s: %w", path, err)
+	}
+	dep := v.LookupPath(cue.MakePath(cue.Str("deps"), cue.Str(CoreModulePath), cue.Str("v")))
+	if !dep.Exists() {
+		return "", nil
+	}
+	version, err := dep.String()
+	if err != nil {
+		return "", fmt.Errorf("reading %s version in %s: %w", CoreModulePath, path, err)
+	}
+	return version, nil
+}
+
+// CheckCoreCompatibility compares a module's core version against the range
+// this CLI supports. A newer core is an error — rendering it risks silently
+// dropping fields the CLI does not know — while an older core is only a
+// warning. An empty or unparseable version is not checked.
+func CheckCoreCompatibility(version string) (warning string, err error) {
+	if version == "" || !semver.IsValid(version) {
+		return "", nil
+	}
+	if semver.Compare(version, CoreVersionCeiling) >= 0 {
+		return "", fmt.Errorf(
+			"module requires %s %s, but this opm supports versions below %s — upgrade opm to build this module",
+			CoreModulePath, version, CoreVersionCeiling)
+	}
+	if semver.Compare(version, MinSupportedCoreVersion) < 0 {
+		return fmt.Sprintf(
+			"module depends on %s %s, older than the oldest version this opm supports (%s) — run 'cue mod get opmodel.dev/core@latest' in the module to update it",
+			CoreModulePath, version, MinSupportedCoreVersion), nil
+	}
+	return "", nil
+}
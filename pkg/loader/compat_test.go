@@ -0,0 +1,92 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeModuleCUE(t *testing.T, content string) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "cue.mod"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cue.mod", "module.cue"), []byte(content), 0o644))
+	return root
+}
+
+func TestCoreVersion(t *testing.T) {
+	root := writeModuleCUE(t, `module: "example.com/app@v0"
+language: version: "v0.17.0"
+deps: {
+	"opmodel.dev/catalogs/opm@v1": v: "v1.0.0-alpha.1"
+	"opmodel.dev/core@v1": v: "v1.0.0-alpha.3"
+}
+`)
+	version, err := CoreVersion(root)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0-alpha.3", version)
+
+	noCore := writeModuleCUE(t, `module: "example.com/app@v0"
+language: version: "v0.17.0"
+`)
+	version, err = CoreVersion(noCore)
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	version, err = CoreVersion(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, version, "no cue.mod is not an error")
+}
+
+func TestCheckCoreCompatibility(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantErr     bool
+		wantWarning bool
+	}{
+		{version: ""},
+		{version: "not-semver"},
+		{version: MinSupportedCoreVersion},
+		{version: "v1.0.5"},
+		{version: CoreVersionCeiling, wantErr: true},
+		{version: "v1.2.0", wantErr: true},
+		{version: "v1.0.0-alpha.0", wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			warning, err := CheckCoreCompatibility(tt.version)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "upgrade opm")
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantWarning {
+				assert.Contains(t, warning, "cue mod get")
+			} else {
+				assert.Empty(t, warning)
+			}
+		})
+	}
+}
+
+func TestCachedModuleCoreVersion(t *testing.T) {
+	cacheDir := t.TempDir()
+	modFile := filepath.Join(cacheDir, "mod", "download", "opmodel.dev", "modules", "jellyfin", "@v", "v1.2.0.mod")
+	require.NoError(t, os.MkdirAll(filepath.Dir(modFile), 0o755))
+	require.NoError(t, os.WriteFile(modFile, []byte(`module: "opmodel.dev/modules/jellyfin@v1"
+language: version: "v0.17.0"
+deps: "opmodel.dev/core@v1": v: "v1.4.0"
+`), 0o644))
+
+	version, err := CachedModuleCoreVersion(cacheDir, "opmodel.dev/modules/jellyfin@v1", "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", version)
+
+	version, err = CachedModuleCoreVersion(cacheDir, "opmodel.dev/modules/other@v1", "v1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, version, "an uncached module is not an error")
+}
@@ -0,0 +1,57 @@
+package loader
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// ComponentSet is one --set-component override: a value for a field of one
+// component, addressed relative to that component. "web.spec.replicas=3"
+// sets spec.replicas on component "web" and nowhere else.
+type ComponentSet struct {
+	// Component is the component name (the first path step).
+	Component string
+	// Path is the field within the component.
+	Path cue.Path
+	// Value is the typed value, inferred as for --set.
+	Value any
+	// Expr is the flag value as given, for errors.
+	Expr string
+}
+
+// ParseComponentSets parses --set-component expressions of the form
+// "<component>.<path>=value". Paths use the --set syntax; values are typed the
+// same way (bool, then int, else string).
+func ParseComponentSets(exprs []string) ([]ComponentSet, error) {
+	sets := make([]ComponentSet, 0, len(exprs))
+	for _, expr := range exprs {
+		key, raw, ok := strings.Cut(expr, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set-component %q: expected <component>.<path>=value", expr)
+		}
+		steps, err := parseSetPath(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --set-component %q: %w", expr, err)
+		}
+		if len(steps) < 2 || steps[0].isIndex || steps[1].isIndex {
+			return nil, fmt.Errorf("invalid --set-component %q: expected <component>.<path>=value", expr)
+		}
+		sels := make([]cue.Selector, 0, len(steps)-1)
+		for _, s := range steps[1:] {
+			if s.isIndex {
+				sels = append(sels, cue.Index(s.index))
+				continue
+			}
+			sels = append(sels, cue.Str(s.field))
+		}
+		sets = append(sets, ComponentSet{
+			Component: steps[0].field,
+			Path:      cue.MakePath(sels...),
+			Value:     inferSetValue(raw),
+			Expr:      expr,
+		})
+	}
+	return sets, nil
+}
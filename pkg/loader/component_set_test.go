@@ -0,0 +1,46 @@
+package loader
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComponentSets(t *testing.T) {
+	sets, err := ParseComponentSets([]string{"web.spec.replicas=3", "api.spec.ports[0].name=http", `web.metadata.labels.app\.kubernetes\.io/tier=front`})
+	require.NoError(t, err)
+	require.Len(t, sets, 3)
+
+	assert.Equal(t, "web", sets[0].Component)
+	assert.Equal(t, cue.ParsePath("spec.replicas"), sets[0].Path)
+	assert.Equal(t, int64(3), sets[0].Value)
+
+	assert.Equal(t, "api", sets[1].Component)
+	assert.Equal(t, "spec.ports[0].name", sets[1].Path.String())
+	assert.Equal(t, "http", sets[1].Value)
+
+	assert.Equal(t, `metadata.labels."app.kubernetes.io/tier"`, sets[2].Path.String())
+}
+
+func TestParseComponentSets_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"web.spec.replicas", "expected <component>.<path>=value"},
+		{"web=3", "expected <component>.<path>=value"},
+		{"web[0]=3", "expected <component>.<path>=value"},
+		{"[0].spec=3", "list index without a field"},
+		{"web..replicas=3", "empty field name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseComponentSets([]string{tt.expr})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), "--set-component")
+		})
+	}
+}
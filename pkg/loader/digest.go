@@ -0,0 +1,142 @@
+package loader
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"cuelabs.dev/go/oci/ociregistry"
+	"cuelang.org/go/mod/modconfig"
+	"cuelang.org/go/mod/module"
+)
+
+// digestPattern matches an OCI content digest in the algorithms registries
+// serve module manifests under.
+var digestPattern = regexp.MustCompile(`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+
+// ValidateDigest checks that digest is a well-formed OCI content digest
+// ("sha256:" followed by 64 lowercase hex digits, or sha512).
+func ValidateDigest(digest string) error {
+	if !digestPattern.MatchString(digest) {
+		return fmt.Errorf("invalid module digest %q: expected sha256:<64 hex digits>", digest)
+	}
+	return nil
+}
+
+// VerifyCachedModule checks that the module content a load read from the CUE
+// module cache at cacheDir is the artifact pinned by expected, an OCI manifest
+// digest. The manifest is fetched from the registry by that digest, never by
+// tag, so a re-tag cannot change what is checked; its zip and module-file
+// layer digests are then compared with the cached files themselves, so an
+// edited cache entry fails too. registry overrides CUE_REGISTRY when
+// non-empty. modulePath carries its major-version suffix, e.g.
+// "example.com/app@v0".
+func VerifyCachedModule(ctx context.Context, registry, cacheDir, modulePath, version, expected string) error {
+	mv, err := module.NewVersion(modulePath, version)
+	if err != nil {
+		return fmt.Errorf("module %s@%s: %w", modulePath, version, err)
+	}
+	resolver, err := modconfig.NewResolver(&modconfig.Config{CUERegistry: registry})
+	if err != nil {
+		return err
+	}
+	loc, err := resolver.ResolveToRegistry(mv.BasePath(), mv.Version())
+	if err != nil {
+		return fmt.Errorf("resolving registry of %s: %w", mv, err)
+	}
+	r, err := loc.Registry.GetManifest(ctx, loc.Repository, ociregistry.Digest(expected))
+	if err != nil {
+		return fmt.Errorf("fetching manifest %s of %s: %w", expected, mv, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading manifest %s of %s: %w", expected, mv, err)
+	}
+	if got := contentDigest(expected, data); got != expected {
+		return &DigestMismatchError{Module: mv.String(), Expected: expected, Actual: got}
+	}
+
+	var manifest struct {
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("decoding manifest %s of %s: %w", expected, mv, err)
+	}
+	if len(manifest.Layers) != 2 {
+		return fmt.Errorf("manifest %s is not a CUE module: expected 2 layers, got %d", expected, len(manifest.Layers))
+	}
+
+	esc, err := module.EscapePath(mv.BasePath())
+	if err != nil {
+		return err
+	}
+	ver, err := module.EscapeVersion(mv.Version())
+	if err != nil {
+		return err
+	}
+	base := filepath.Join(cacheDir, "mod", "download", esc, "@v", ver)
+	for i, suffix := range []string{".zip", ".mod"} {
+		cached, err := os.ReadFile(base + suffix)
+		if err != nil {
+			return fmt.Errorf("cannot verify %s against %s: reading cached %s: %w", mv, expected, suffix, err)
+		}
+		want := manifest.Layers[i].Digest
+		if got := contentDigest(want, cached); got != want {
+			return &CachedContentMismatchError{Module: mv.String(), Pinned: expected, File: base + suffix, Want: want, Got: got}
+		}
+	}
+	return nil
+}
+
+// contentDigest returns the digest of data in the algorithm of like
+// ("sha256:" or "sha512:").
+func contentDigest(like string, data []byte) string {
+	if strings.HasPrefix(like, "sha512:") {
+		sum := sha512.Sum512(data)
+		return "sha512:" + hex.EncodeToString(sum[:])
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// DigestMismatchError reports a registry manifest whose content does not
+// match the digest it was fetched by.
+type DigestMismatchError struct {
+	// Module is the module as path@version.
+	Module   string
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("module %s digest mismatch: expected %s, got %s", e.Module, e.Expected, e.Actual)
+}
+
+// CachedContentMismatchError reports a module whose cached content is not
+// the content of the artifact it was pinned to.
+type CachedContentMismatchError struct {
+	// Module is the module as path@version.
+	Module string
+	// Pinned is the manifest digest the module was pinned to.
+	Pinned string
+	// File is the cached file that differs.
+	File string
+	Want string
+	Got  string
+}
+
+func (e *CachedContentMismatchError) Error() string {
+	return fmt.Sprintf("module %s in the CUE cache is not artifact %s: %s is %s, the artifact has %s",
+		e.Module, e.Pinned, e.File, e.Got, e.Want)
+}
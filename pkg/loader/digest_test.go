@@ -0,0 +1,116 @@
+package loader
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelabs.dev/go/oci/ociregistry/ocimem"
+	"cuelabs.dev/go/oci/ociregistry/ociserver"
+	"cuelang.org/go/mod/modregistry"
+	"cuelang.org/go/mod/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// servePublishedModule publishes a minimal module to an in-memory registry
+// behind an HTTP server and returns the CUE_REGISTRY value that reaches it,
+// the module's manifest digest, and its zip and module file.
+func servePublishedModule(t *testing.T, mv module.Version) (registry, digest string, zipData, modFile []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("cue.mod/module.cue")
+	require.NoError(t, err)
+	modFile = []byte(`module: "` + mv.Path() + `"
+language: version: "v0.17.0"
+`)
+	_, err = w.Write(modFile)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	reg := ocimem.New()
+	client := modregistry.NewClient(reg)
+	require.NoError(t, client.PutModule(context.Background(), mv, bytes.NewReader(buf.Bytes()), int64(buf.Len())))
+	m, err := client.GetModule(context.Background(), mv)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(ociserver.New(reg, nil))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://") + "+insecure", string(m.ManifestDigest()), buf.Bytes(), modFile
+}
+
+// writeCachedModule places a module's zip and module file where the CUE
+// module cache keeps them.
+func writeCachedModule(t *testing.T, cacheDir string, mv module.Version, zipData, modFile []byte) {
+	t.Helper()
+	dir := filepath.Join(cacheDir, "mod", "download", mv.BasePath(), "@v")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mv.Version()+".zip"), zipData, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mv.Version()+".mod"), modFile, 0o644))
+}
+
+func TestVerifyCachedModule(t *testing.T) {
+	ctx := context.Background()
+	mv := module.MustNewVersion("example.com/app@v0", "v0.1.0")
+	registry, digest, zipData, modFile := servePublishedModule(t, mv)
+
+	t.Run("cached content matches the pinned artifact", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		writeCachedModule(t, cacheDir, mv, zipData, modFile)
+		require.NoError(t, VerifyCachedModule(ctx, registry, cacheDir, "example.com/app@v0", "v0.1.0", digest))
+	})
+
+	t.Run("edited cache entry", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		tampered := append(bytes.Clone(zipData), 0)
+		writeCachedModule(t, cacheDir, mv, tampered, modFile)
+		err := VerifyCachedModule(ctx, registry, cacheDir, "example.com/app@v0", "v0.1.0", digest)
+		var mismatch *CachedContentMismatchError
+		require.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, digest, mismatch.Pinned)
+		assert.True(t, strings.HasSuffix(mismatch.File, ".zip"))
+	})
+
+	t.Run("pinned digest the registry does not hold", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		writeCachedModule(t, cacheDir, mv, zipData, modFile)
+		err := VerifyCachedModule(ctx, registry, cacheDir, "example.com/app@v0", "v0.1.0", "sha256:"+strings.Repeat("0", 64))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fetching manifest")
+	})
+
+	t.Run("module not in the cache", func(t *testing.T) {
+		err := VerifyCachedModule(ctx, registry, t.TempDir(), "example.com/app@v0", "v0.1.0", digest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot verify")
+	})
+}
+
+func TestValidateDigest(t *testing.T) {
+	tests := []struct {
+		digest string
+		valid  bool
+	}{
+		{"sha256:" + strings.Repeat("a", 64), true},
+		{"sha512:" + strings.Repeat("0", 128), true},
+		{"sha256:" + strings.Repeat("A", 64), false},
+		{"sha256:abc", false},
+		{strings.Repeat("a", 64), false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.digest, func(t *testing.T) {
+			if tt.valid {
+				assert.NoError(t, ValidateDigest(tt.digest))
+			} else {
+				assert.Error(t, ValidateDigest(tt.digest))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// EnvExpansion configures ${VAR} interpolation in values files
+// (--expand-env). The zero value leaves values files untouched, keeping
+// them hermetic: the same files render the same values in any environment.
+type EnvExpansion struct {
+	// Enabled replaces each ${VAR} with the variable's value before the file
+	// is compiled. $${VAR} is written through as a literal ${VAR}.
+	Enabled bool
+	// AllowEmpty expands unset variables to "" instead of failing
+	// (--allow-empty-env).
+	AllowEmpty bool
+	// Lookup resolves a variable; nil means os.LookupEnv.
+	Lookup func(name string) (string, bool)
+}
+
+// envReference matches ${NAME}, and $${NAME} as its escape.
+var envReference = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Expand interpolates the environment into data, the content of the values
+// file name. Only the braced ${NAME} form is expanded — a bare $NAME is a
+// valid CUE identifier — and the value is substituted verbatim, so a
+// variable inside a string literal must not itself contain a quote. Every
+// unset variable is listed in one error unless AllowEmpty is set.
+func (e EnvExpansion) Expand(name string, data []byte) ([]byte, error) {
+	if !e.Enabled {
+		return data, nil
+	}
+	lookup := e.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	var unset []string
+	out := envReference.ReplaceAllFunc(data, func(ref []byte) []byte {
+		if bytes.HasPrefix(ref, []byte("$$")) {
+			return ref[1:]
+		}
+		varName := string(ref[2 : len(ref)-1])
+		value, ok := lookup(varName)
+		if !ok && !e.AllowEmpty {
+			unset = append(unset, varName)
+		}
+		return []byte(value)
+	})
+	if len(unset) > 0 {
+		return nil, fmt.Errorf("expanding %s: environment variable(s) not set: %s (set them, or pass --allow-empty-env to expand them to \"\")",
+			name, strings.Join(dedupe(unset), ", "))
+	}
+	return out, nil
+}
+
+// dedupe returns names without repeats, keeping first occurrences in order.
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := names[:0]
+	for _, n := range names {
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvExpansion_Expand(t *testing.T) {
+	env := map[string]string{"TAG": "1.2.3", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		name    string
+		exp     EnvExpansion
+		in      string
+		want    string
+		wantErr string
+	}{
+		{name: "disabled", exp: EnvExpansion{Lookup: lookup}, in: `tag: "${TAG}"`, want: `tag: "${TAG}"`},
+		{name: "braced", exp: EnvExpansion{Enabled: true, Lookup: lookup}, in: `tag: "${TAG}"`, want: `tag: "1.2.3"`},
+		{name: "set but empty", exp: EnvExpansion{Enabled: true, Lookup: lookup}, in: `tag: "${EMPTY}"`, want: `tag: ""`},
+		{name: "bare form untouched", exp: EnvExpansion{Enabled: true, Lookup: lookup}, in: `tag: $TAG`, want: `tag: $TAG`},
+		{name: "escaped", exp: EnvExpansion{Enabled: true, Lookup: lookup}, in: `tag: "$${TAG}"`, want: `tag: "${TAG}"`},
+		{
+			name:    "unset",
+			exp:     EnvExpansion{Enabled: true, Lookup: lookup},
+			in:      `a: "${NOPE}", b: "${ALSO}", c: "${NOPE}"`,
+			wantErr: "environment variable(s) not set: NOPE, ALSO",
+		},
+		{name: "unset allowed", exp: EnvExpansion{Enabled: true, AllowEmpty: true, Lookup: lookup}, in: `a: "${NOPE}"`, want: `a: ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.exp.Expand("values.cue", []byte(tt.in))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestLoadValuesFile_ExpandEnv(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "values.cue")
+	require.NoError(t, os.WriteFile(path, []byte("package values\nvalues: {image: \"nginx:${TAG}\"}\n"), 0o644))
+	exp := EnvExpansion{Enabled: true, Lookup: func(string) (string, bool) { return "1.27", true }}
+
+	val, err := LoadValuesFile(cuecontext.New(), path, exp)
+	require.NoError(t, err)
+	image, err := val.LookupPath(cue.ParsePath("image")).String()
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.27", image)
+
+	val, err = LoadValuesFile(cuecontext.New(), path, EnvExpansion{})
+	require.NoError(t, err)
+	image, err = val.LookupPath(cue.ParsePath("image")).String()
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:${TAG}", image, "expansion is opt-in")
+}
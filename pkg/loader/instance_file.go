@@ -2,6 +2,7 @@ package loader
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -114,7 +115,13 @@ func LoadModulePackage(ctx *cue.Context, dirPath string) (cue.Value, error) {
 //
 // This is used by module-only vet validation when -f is provided but there is
 // no instance.cue in the module directory.
-func LoadValuesFile(ctx *cue.Context, path string) (cue.Value, error) {
+//
+// A path of "-" reads the values from stdin (see StdinValuesPath). With env
+// enabled, the file is expanded first and compiled from the expanded bytes.
+func LoadValuesFile(ctx *cue.Context, path string, env EnvExpansion) (cue.Value, error) {
+	if path == StdinValuesPath {
+		return loadValuesStdin(ctx, env)
+	}
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return cue.Value{}, fmt.Errorf("resolving values file path: %w", err)
@@ -130,6 +137,17 @@ func LoadValuesFile(ctx *cue.Context, path string) (cue.Value, error) {
 	cfg := &load.Config{
 		Dir: parentDir,
 	}
+	if env.Enabled {
+		data, readErr := os.ReadFile(absPath)
+		if readErr != nil {
+			return cue.Value{}, fmt.Errorf("reading values file %q: %w", path, readErr)
+		}
+		expanded, expandErr := env.Expand(path, data)
+		if expandErr != nil {
+			return cue.Value{}, expandErr
+		}
+		cfg.Overlay = map[string]load.Source{absPath: load.FromBytes(expanded)}
+	}
 	instances := load.Instances([]string{filepath.Base(absPath)}, cfg)
 	if len(instances) == 0 {
 		return cue.Value{}, fmt.Errorf("no CUE instances found for %s", path)
@@ -142,14 +160,55 @@ func LoadValuesFile(ctx *cue.Context, path string) (cue.Value, error) {
 	if err := val.Err(); err != nil {
 		return cue.Value{}, fmt.Errorf("building values file: %w", err)
 	}
+	return valuesField(val), nil
+}
+
+// StdinValuesPath is the -f/--values path that reads values from stdin.
+const StdinValuesPath = "-"
+
+// stdin is the reader behind StdinValuesPath; replaced in tests.
+var stdin io.Reader = os.Stdin
+
+// loadValuesStdin compiles values piped on stdin. The source is compiled
+// standalone under the filename "<stdin>", so error positions still render;
+// unlike a values file on disk it cannot import other packages.
+func loadValuesStdin(ctx *cue.Context, env EnvExpansion) (cue.Value, error) {
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("reading values from stdin: %w", err)
+	}
+	if data, err = env.Expand("<stdin>", data); err != nil {
+		return cue.Value{}, err
+	}
+	val := ctx.CompileBytes(data, cue.Filename("<stdin>"))
+	if err := val.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("building values from stdin: %w", err)
+	}
+	return valuesField(val), nil
+}
 
-	// Standard OPM values files wrap values in a "values" field.
-	// Return that field when it exists so the caller gets the raw config value.
-	if valuesField := val.LookupPath(cue.ParsePath("values")); valuesField.Exists() && valuesField.Err() == nil {
-		return valuesField, nil
+// valuesField returns the "values" field of a loaded values file when it has
+// one — standard OPM values files wrap values in it — else the whole value.
+func valuesField(val cue.Value) cue.Value {
+	if field := val.LookupPath(cue.ParsePath("values")); field.Exists() && field.Err() == nil {
+		return field
 	}
+	return val
+}
 
-	return val, nil
+// CheckValuesPaths rejects a -f/--values list that reads stdin more than
+// once: the first read would drain it and the second see nothing.
+func CheckValuesPaths(paths []string) error {
+	stdinCount := 0
+	for _, p := range paths {
+		if p == StdinValuesPath {
+			stdinCount++
+		}
+	}
+	if stdinCount > 1 {
+		return fmt.Errorf("--values %s (stdin) may be given only once", StdinValuesPath)
+	}
+	return nil
 }
 
 // resolveInstanceFile resolves either an instance directory or direct file path.
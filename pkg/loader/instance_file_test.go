@@ -3,10 +3,12 @@ package loader
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -129,3 +131,33 @@ func TestLoadModulePackage(t *testing.T) {
 		assert.Contains(t, err.Error(), "not a directory")
 	})
 }
+
+func TestLoadValuesFile_Stdin(t *testing.T) {
+	orig := stdin
+	t.Cleanup(func() { stdin = orig })
+
+	t.Run("reads and unwraps values", func(t *testing.T) {
+		stdin = strings.NewReader("package values\nvalues: {replicas: 3}\n")
+		val, err := LoadValuesFile(cuecontext.New(), StdinValuesPath, EnvExpansion{})
+		require.NoError(t, err)
+		replicas, err := val.LookupPath(cue.ParsePath("replicas")).Int64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), replicas)
+	})
+
+	t.Run("error positions name stdin", func(t *testing.T) {
+		stdin = strings.NewReader("values: {replicas: 3\n")
+		_, err := LoadValuesFile(cuecontext.New(), StdinValuesPath, EnvExpansion{})
+		require.Error(t, err)
+		errs := cueerrors.Errors(err)
+		require.NotEmpty(t, errs)
+		assert.Equal(t, "<stdin>", errs[0].Position().Filename())
+	})
+}
+
+func TestCheckValuesPaths(t *testing.T) {
+	assert.NoError(t, CheckValuesPaths([]string{"base.cue", "-", "prod.cue"}))
+	err := CheckValuesPaths([]string{"-", "base.cue", "-"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only once")
+}
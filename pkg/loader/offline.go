@@ -0,0 +1,208 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/mod/modcache"
+	"cuelang.org/go/mod/modconfig"
+	"cuelang.org/go/mod/modfile"
+	"cuelang.org/go/mod/modregistry"
+	"cuelang.org/go/mod/module"
+)
+
+// ModuleDependencies returns the dependencies of the CUE module rooted at
+// moduleRoot, as pinned in cue.mod/module.cue — after `cue mod tidy` that is
+// the full transitive set a load needs. A cue.mod/local-module.cue replaces
+// that set, as it does for CUE itself: dependencies it replaces with a
+// local directory need no download and are left out, and ones it replaces
+// with another module@version are returned as that replacement. It returns
+// nil when there is no cue.mod/module.cue.
+func ModuleDependencies(moduleRoot string) ([]module.Version, error) {
+	if moduleRoot == "" {
+		return nil, nil
+	}
+	path := filepath.Join(moduleRoot, "cue.mod", "module.cue")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	mf, err := modfile.Parse(data, path)
+	if err != nil {
+		return nil, err
+	}
+
+	localPath := filepath.Join(moduleRoot, "cue.mod", "local-module.cue")
+	if localData, readErr := os.ReadFile(localPath); readErr == nil {
+		if mf, err = modfile.ParseLocal(localData, localPath, mf); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(readErr) {
+		return nil, fmt.Errorf("reading %s: %w", localPath, readErr)
+	}
+
+	var deps []module.Version
+	for mpath, dep := range mf.Deps {
+		var mv module.Version
+		switch {
+		case isLocalReplacement(dep.ReplaceWith):
+			continue
+		case dep.ReplaceWith != "":
+			mv, err = module.ParseVersion(dep.ReplaceWith)
+		case dep.Version == "":
+			continue
+		default:
+			mv, err = module.NewVersion(mpath, dep.Version)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("dependency %s in %s: %w", mpath, path, err)
+		}
+		deps = append(deps, mv)
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].String() < deps[j].String() })
+	return deps, nil
+}
+
+// isLocalReplacement reports whether a replaceWith value names a directory.
+func isLocalReplacement(replaceWith string) bool {
+	return strings.HasPrefix(replaceWith, "./") || strings.HasPrefix(replaceWith, "../") ||
+		filepath.IsAbs(replaceWith)
+}
+
+// CUECacheDir returns the CUE module cache directory: $CUE_CACHE_DIR, or
+// "cue" under the user cache directory — the same rule as the cue command.
+func CUECacheDir() (string, error) {
+	if dir := os.Getenv("CUE_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the CUE cache directory: %w", err)
+	}
+	return filepath.Join(dir, "cue"), nil
+}
+
+// UncachedDependencies returns the deps a load could not satisfy from the
+// module cache at cacheDir: those whose source is not fully extracted or
+// whose module file was never downloaded.
+func UncachedDependencies(cacheDir string, deps []module.Version) ([]module.Version, error) {
+	cache, err := modcache.New(nil, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	var missing []module.Version
+	for _, mv := range deps {
+		if _, err := cache.FetchFromCache(mv); err != nil {
+			if !errors.Is(err, modregistry.ErrNotFound) {
+				return nil, fmt.Errorf("checking cache for %s: %w", mv, err)
+			}
+			missing = append(missing, mv)
+			continue
+		}
+		if !cachedModFileExists(cacheDir, mv) {
+			missing = append(missing, mv)
+		}
+	}
+	return missing, nil
+}
+
+// cachedModFileExists reports whether the module file of mv is in the cache;
+// CUE reads it to resolve requirements before touching the source.
+func cachedModFileExists(cacheDir string, mv module.Version) bool {
+	esc, err := module.EscapePath(mv.BasePath())
+	if err != nil {
+		return false
+	}
+	ver, err := module.EscapeVersion(mv.Version())
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(cacheDir, "mod", "download", esc, "@v", ver+".mod"))
+	return err == nil
+}
+
+// CheckOfflineDependencies fails with the exact module@version of every
+// dependency of the module rooted at moduleRoot that is not in the CUE
+// module cache, so an offline load stops before CUE attempts a download.
+func CheckOfflineDependencies(moduleRoot string) error {
+	deps, err := ModuleDependencies(moduleRoot)
+	if err != nil || len(deps) == 0 {
+		return err
+	}
+	cacheDir, err := CUECacheDir()
+	if err != nil {
+		return err
+	}
+	missing, err := UncachedDependencies(cacheDir, deps)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, len(missing))
+	for i, mv := range missing {
+		names[i] = mv.String()
+	}
+	return &UncachedDependencyError{Missing: names, CacheDir: cacheDir}
+}
+
+// UncachedDependencyError reports dependencies an offline load needs that are
+// not in the CUE module cache.
+type UncachedDependencyError struct {
+	// Missing lists each absent dependency as module@version.
+	Missing  []string
+	CacheDir string
+}
+
+func (e *UncachedDependencyError) Error() string {
+	if len(e.Missing) == 1 {
+		return fmt.Sprintf("module %s is not in the CUE module cache (%s)", e.Missing[0], e.CacheDir)
+	}
+	return fmt.Sprintf("modules %s are not in the CUE module cache (%s)", strings.Join(e.Missing, ", "), e.CacheDir)
+}
+
+// FetchDependencies downloads every dependency of the module rooted at
+// moduleRoot into the CUE module cache, so later loads — including offline
+// ones — find them locally. registry overrides CUE_REGISTRY when non-empty.
+// progress is called once per dependency with whether it was already cached.
+func FetchDependencies(ctx context.Context, moduleRoot, registry string, progress func(mv module.Version, cached bool)) error {
+	deps, err := ModuleDependencies(moduleRoot)
+	if err != nil {
+		return err
+	}
+	cacheDir, err := CUECacheDir()
+	if err != nil {
+		return err
+	}
+	reg, err := modconfig.NewRegistry(&modconfig.Config{CUERegistry: registry})
+	if err != nil {
+		return err
+	}
+	for _, mv := range deps {
+		missing, err := UncachedDependencies(cacheDir, []module.Version{mv})
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			if _, err := reg.ModFile(ctx, mv); err != nil {
+				return fmt.Errorf("fetching %s: %w", mv, err)
+			}
+			if _, err := reg.Fetch(ctx, mv); err != nil {
+				return fmt.Errorf("fetching %s: %w", mv, err)
+			}
+		}
+		if progress != nil {
+			progress(mv, len(missing) == 0)
+		}
+	}
+	return nil
+}
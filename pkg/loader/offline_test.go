@@ -0,0 +1,92 @@
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/mod/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const offlineModuleCue = `module: "example.com/main@v0"
+language: version: "v0.17.0"
+deps: {
+	"opmodel.dev/core@v1": v: "v1.2.0"
+	"opmodel.dev/modules/podinfo@v0": v: "v0.3.1"
+}
+`
+
+// cacheModule lays out mv in cacheDir the way the CUE module cache stores a
+// completed download: the extracted source and the module file.
+func cacheModule(t *testing.T, cacheDir string, mv module.Version) {
+	t.Helper()
+	writeFile(t, filepath.Join(cacheDir, "mod", "extract", mv.BasePath()+"@"+mv.Version(), "cue.mod", "module.cue"),
+		`module: "`+mv.Path()+`"`)
+	writeFile(t, filepath.Join(cacheDir, "mod", "download", mv.BasePath(), "@v", mv.Version()+".mod"),
+		`module: "`+mv.Path()+`"`)
+}
+
+func TestModuleDependencies(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cue.mod", "module.cue"), offlineModuleCue)
+
+	deps, err := ModuleDependencies(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"opmodel.dev/core@v1.2.0", "opmodel.dev/modules/podinfo@v0.3.1"}, versionStrings(deps))
+
+	none, err := ModuleDependencies(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, none, "no cue.mod/module.cue means nothing to fetch")
+}
+
+func TestModuleDependencies_LocalModuleReplacements(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cue.mod", "module.cue"), offlineModuleCue)
+	writeFile(t, filepath.Join(root, "cue.mod", "local-module.cue"),
+		`deps: {
+	"opmodel.dev/core@v1": {}
+	"opmodel.dev/modules/podinfo@v0": replaceWith: "../podinfo"
+}`)
+
+	deps, err := ModuleDependencies(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"opmodel.dev/core@v1.2.0"}, versionStrings(deps), "a directory replacement needs no download")
+}
+
+func TestCheckOfflineDependencies(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cue.mod", "module.cue"), offlineModuleCue)
+	cacheDir := t.TempDir()
+	t.Setenv("CUE_CACHE_DIR", cacheDir)
+
+	cacheModule(t, cacheDir, module.MustNewVersion("opmodel.dev/core@v1", "v1.2.0"))
+
+	err := CheckOfflineDependencies(root)
+	var uncached *UncachedDependencyError
+	require.ErrorAs(t, err, &uncached)
+	assert.Equal(t, []string{"opmodel.dev/modules/podinfo@v0.3.1"}, uncached.Missing)
+	assert.Contains(t, err.Error(), "module opmodel.dev/modules/podinfo@v0.3.1 is not in the CUE module cache ("+cacheDir+")")
+
+	cacheModule(t, cacheDir, module.MustNewVersion("opmodel.dev/modules/podinfo@v0", "v0.3.1"))
+	assert.NoError(t, CheckOfflineDependencies(root))
+}
+
+func TestUncachedDependencies_PartialExtract(t *testing.T) {
+	cacheDir := t.TempDir()
+	mv := module.MustNewVersion("opmodel.dev/core@v1", "v1.2.0")
+	writeFile(t, filepath.Join(cacheDir, "mod", "extract", "opmodel.dev/core@v1.2.0", "cue.mod", "module.cue"),
+		`module: "opmodel.dev/core@v1"`)
+
+	missing, err := UncachedDependencies(cacheDir, []module.Version{mv})
+	require.NoError(t, err)
+	assert.Equal(t, []module.Version{mv}, missing, "source without its module file is not a usable cache entry")
+}
+
+func versionStrings(vs []module.Version) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = v.String()
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cuelabs.dev/go/oci/ociregistry/ocimem"
+	"cuelang.org/go/mod/modconfig"
+	"cuelang.org/go/mod/modfile"
+	"cuelang.org/go/mod/modregistry"
+	"cuelang.org/go/mod/module"
+	"cuelang.org/go/mod/modzip"
+	"golang.org/x/mod/semver"
+)
+
+// PackagedModule is a module directory packed the way `cue mod publish`
+// packs it: a module zip, published as an OCI manifest with the zip and
+// cue.mod/module.cue as its two layers.
+type PackagedModule struct {
+	Version module.Version
+	Zip     []byte
+	// Digest is the manifest digest the artifact has in any registry — the
+	// value --module-digest pins.
+	Digest string
+}
+
+// PackageModule packs the CUE module rooted at moduleRoot as version, which
+// must be a canonical semantic version matching the module's major version.
+// The digest is computed by publishing to an in-memory registry, so it is
+// the one a push produces, without contacting any registry.
+func PackageModule(ctx context.Context, moduleRoot, version string) (*PackagedModule, error) {
+	modPath := filepath.Join(moduleRoot, "cue.mod", "module.cue")
+	data, err := os.ReadFile(modPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", modPath, err)
+	}
+	mf, err := modfile.Parse(data, modPath)
+	if err != nil {
+		return nil, err
+	}
+	if !semver.IsValid(version) || semver.Canonical(version) != version {
+		return nil, fmt.Errorf("invalid module version %q: expected a canonical semantic version such as v1.2.3", version)
+	}
+	if major := mf.MajorVersion(); semver.Major(version) != major {
+		return nil, fmt.Errorf("module version %q does not match the major version %s of %s; must be %s.N.N", version, major, mf.QualifiedModule(), major)
+	}
+	mv, err := module.NewVersion(mf.QualifiedModule(), version)
+	if err != nil {
+		return nil, fmt.Errorf("module %s@%s: %w", mf.QualifiedModule(), version, err)
+	}
+
+	var buf bytes.Buffer
+	if err := modzip.CreateFromDir(&buf, mv, moduleRoot); err != nil {
+		return nil, err
+	}
+	p := &PackagedModule{Version: mv, Zip: buf.Bytes()}
+
+	digest, err := putModule(ctx, modregistry.NewClient(ocimem.New()), p)
+	if err != nil {
+		return nil, err
+	}
+	p.Digest = digest
+	return p, nil
+}
+
+// PushModule publishes p to the registry CUE resolves its module path to —
+// registry overrides CUE_REGISTRY when non-empty — and returns the pushed
+// artifact's reference, host/repository@digest.
+func PushModule(ctx context.Context, registry string, p *PackagedModule) (string, error) {
+	resolver, err := modconfig.NewResolver(&modconfig.Config{CUERegistry: registry})
+	if err != nil {
+		return "", err
+	}
+	loc, ok := resolver.ResolveToLocation(p.Version.BasePath(), p.Version.Version())
+	if !ok {
+		return "", fmt.Errorf("no registry configured for %s", p.Version.BasePath())
+	}
+	digest, err := putModule(ctx, modregistry.NewClientWithResolver(resolver), p)
+	if err != nil {
+		return "", fmt.Errorf("pushing %s to %s: %w", p.Version, loc.Host, err)
+	}
+	if digest != p.Digest {
+		return "", fmt.Errorf("registry %s stored %s as %s, expected %s", loc.Host, p.Version, digest, p.Digest)
+	}
+	return loc.Host + "/" + loc.Repository + "@" + digest, nil
+}
+
+// putModule publishes p through client and returns the manifest digest the
+// registry stored it under.
+func putModule(ctx context.Context, client *modregistry.Client, p *PackagedModule) (string, error) {
+	if err := client.PutModule(ctx, p.Version, bytes.NewReader(p.Zip), int64(len(p.Zip))); err != nil {
+		return "", err
+	}
+	m, err := client.GetModule(ctx, p.Version)
+	if err != nil {
+		return "", err
+	}
+	return string(m.ManifestDigest()), nil
+}
@@ -0,0 +1,64 @@
+package loader
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelabs.dev/go/oci/ociregistry/ocimem"
+	"cuelabs.dev/go/oci/ociregistry/ociserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeModuleDir lays out a minimal CUE module with one package file.
+func writeModuleDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "cue.mod"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cue.mod", "module.cue"), []byte(`module: "example.com/app@v1"
+language: version: "v0.17.0"
+source: kind: "self"
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "module.cue"), []byte("package app\n\nname: \"app\"\n"), 0o644))
+	return dir
+}
+
+func TestPackageModule(t *testing.T) {
+	ctx := context.Background()
+	dir := writeModuleDir(t)
+
+	p, err := PackageModule(ctx, dir, "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/app@v1", p.Version.Path())
+	assert.Equal(t, "v1.2.0", p.Version.Version())
+	require.NoError(t, ValidateDigest(p.Digest))
+
+	again, err := PackageModule(ctx, dir, "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, p.Digest, again.Digest, "packaging is reproducible")
+
+	t.Run("invalid versions", func(t *testing.T) {
+		for version, want := range map[string]string{
+			"1.2.0":  "canonical semantic version",
+			"v1.2":   "canonical semantic version",
+			"v2.0.0": "does not match the major version v1",
+		} {
+			_, err := PackageModule(ctx, dir, version)
+			assert.ErrorContains(t, err, want, version)
+		}
+	})
+
+	t.Run("push reports the packaged digest", func(t *testing.T) {
+		srv := httptest.NewServer(ociserver.New(ocimem.New(), nil))
+		t.Cleanup(srv.Close)
+		registry := strings.TrimPrefix(srv.URL, "http://") + "+insecure"
+
+		ref, err := PushModule(ctx, registry, p)
+		require.NoError(t, err)
+		assert.Equal(t, strings.TrimPrefix(srv.URL, "http://")+"/example.com/app@"+p.Digest, ref)
+	})
+}
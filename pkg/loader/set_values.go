@@ -0,0 +1,229 @@
+package loader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// SetValues are the --set and --set-string overrides of a render, in the
+// form "path=value". Paths are dotted (db.port) with list indices
+// (ports[0].port); a backslash escapes a literal '.' or '[' in a field name.
+type SetValues struct {
+	// Set values are typed by inference: true/false become bools, integers
+	// become ints, and everything else a string.
+	Set []string
+	// SetString values are always strings.
+	SetString []string
+}
+
+// IsEmpty reports whether no overrides were given.
+func (s SetValues) IsEmpty() bool {
+	return len(s.Set) == 0 && len(s.SetString) == 0
+}
+
+// ApplySetValues overrides base with the --set and --set-string values and
+// returns the result as a new value. Unlike a second values file, which
+// unifies and so conflicts with any concrete value it disagrees with, an
+// override replaces what base has at its path — the Helm semantics users
+// expect. --set is applied before --set-string, each in flag order.
+//
+// base must be concrete data (values files and debugValues are); the zero
+// value starts from an empty struct. The result is validated against #config
+// by the caller, exactly like file-based values.
+func ApplySetValues(ctx *cue.Context, base cue.Value, sets SetValues) (cue.Value, error) {
+	var tree any = map[string]any{}
+	if base.Exists() {
+		if err := base.Decode(&tree); err != nil {
+			return cue.Value{}, fmt.Errorf("decoding values for --set: %w", err)
+		}
+	}
+
+	apply := func(flag string, exprs []string, typed bool) error {
+		for _, expr := range exprs {
+			key, raw, ok := strings.Cut(expr, "=")
+			if !ok {
+				return fmt.Errorf("invalid %s %q: expected path=value", flag, expr)
+			}
+			steps, err := parseSetPath(key)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", flag, expr, err)
+			}
+			var val any = raw
+			if typed {
+				val = inferSetValue(raw)
+			}
+			if tree, err = setAtPath(tree, steps, nil, val); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", flag, expr, err)
+			}
+		}
+		return nil
+	}
+	if err := apply("--set", sets.Set, true); err != nil {
+		return cue.Value{}, err
+	}
+	if err := apply("--set-string", sets.SetString, false); err != nil {
+		return cue.Value{}, err
+	}
+
+	v := ctx.Encode(tree)
+	if err := v.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("encoding --set values: %w", err)
+	}
+	return v, nil
+}
+
+// inferSetValue types a --set value: bool, then int, else string.
+func inferSetValue(raw string) any {
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n
+	}
+	return raw
+}
+
+// setStep is one step of a --set path: a field name or a list index.
+type setStep struct {
+	field   string
+	index   int
+	isIndex bool
+}
+
+// parseSetPath splits a --set path into field and index steps.
+func parseSetPath(path string) ([]setStep, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var (
+		steps []setStep
+		field strings.Builder
+	)
+	flush := func() error {
+		if field.Len() == 0 {
+			return fmt.Errorf("empty field name in %q", path)
+		}
+		steps = append(steps, setStep{field: field.String()})
+		field.Reset()
+		return nil
+	}
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\\':
+			if i+1 < len(path) {
+				i++
+				field.WriteByte(path[i])
+			}
+		case '.':
+			if field.Len() == 0 && len(steps) > 0 && steps[len(steps)-1].isIndex {
+				continue // "ports[0].port": the index already ended the field
+			}
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case '[':
+			if field.Len() > 0 {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			} else if len(steps) == 0 || !steps[len(steps)-1].isIndex {
+				return nil, fmt.Errorf("list index without a field in %q", path)
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in %q", path)
+			}
+			idx, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid list index %q in %q", path[i+1:i+end], path)
+			}
+			steps = append(steps, setStep{index: idx, isIndex: true})
+			i += end
+			if i+1 < len(path) && path[i+1] != '.' && path[i+1] != '[' {
+				return nil, fmt.Errorf("unexpected %q after ']' in %q", path[i+1], path)
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if field.Len() > 0 || len(steps) == 0 || !steps[len(steps)-1].isIndex {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	return steps, nil
+}
+
+// setAtPath returns node with val written at steps, creating structs and
+// lists along the way. A list index may address an existing element or the
+// one just past the end (an append); anything further is an error rather
+// than a list padded with nulls. done is the path walked so far, for errors.
+func setAtPath(node any, steps, done []setStep, val any) (any, error) {
+	if len(steps) == 0 {
+		return val, nil
+	}
+	step := steps[0]
+	done = append(done, step)
+
+	if step.isIndex {
+		var list []any
+		switch n := node.(type) {
+		case nil:
+		case []any:
+			list = n
+		default:
+			return nil, fmt.Errorf("%s is not a list", formatSetPath(done[:len(done)-1]))
+		}
+		switch {
+		case step.index < len(list):
+		case step.index == len(list):
+			list = append(list, nil)
+		default:
+			return nil, fmt.Errorf("%s is out of range (length %d)", formatSetPath(done), len(list))
+		}
+		elem, err := setAtPath(list[step.index], steps[1:], done, val)
+		if err != nil {
+			return nil, err
+		}
+		list[step.index] = elem
+		return list, nil
+	}
+
+	var m map[string]any
+	switch n := node.(type) {
+	case nil:
+		m = map[string]any{}
+	case map[string]any:
+		m = n
+	default:
+		return nil, fmt.Errorf("%s is not a struct", formatSetPath(done[:len(done)-1]))
+	}
+	child, err := setAtPath(m[step.field], steps[1:], done, val)
+	if err != nil {
+		return nil, err
+	}
+	m[step.field] = child
+	return m, nil
+}
+
+// formatSetPath renders steps back into --set path syntax.
+func formatSetPath(steps []setStep) string {
+	var b strings.Builder
+	for i, s := range steps {
+		switch {
+		case s.isIndex:
+			fmt.Fprintf(&b, "[%d]", s.index)
+		case i > 0:
+			b.WriteString("." + s.field)
+		default:
+			b.WriteString(s.field)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package loader
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-platform-model/cli/pkg/validate"
+)
+
+func TestApplySetValues(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		sets SetValues
+		want string
+	}{
+		{
+			name: "dotted path overrides a concrete value",
+			base: `{db: {host: "pg", port: 5432}}`,
+			sets: SetValues{Set: []string{"db.port=6543"}},
+			want: `{db: {host: "pg", port: 6543}}`,
+		},
+		{
+			name: "types are inferred",
+			sets: SetValues{Set: []string{"replicas=3", "debug=true", "tag=v1.2", "ratio=0.5"}},
+			want: `{replicas: 3, debug: true, tag: "v1.2", ratio: "0.5"}`,
+		},
+		{
+			name: "set-string forces strings",
+			sets: SetValues{SetString: []string{"tag=1.10", "debug=true", "replicas=3"}},
+			want: `{tag: "1.10", debug: "true", replicas: "3"}`,
+		},
+		{
+			name: "list index replaces an element",
+			base: `{ports: [80, 443]}`,
+			sets: SetValues{Set: []string{"ports[0]=8080"}},
+			want: `{ports: [8080, 443]}`,
+		},
+		{
+			name: "list index appends and nests",
+			base: `{ports: [{port: 80}]}`,
+			sets: SetValues{Set: []string{"ports[0].port=8080", "ports[1].port=443"}},
+			want: `{ports: [{port: 8080}, {port: 443}]}`,
+		},
+		{
+			name: "escaped dot stays in the field name",
+			sets: SetValues{SetString: []string{`annotations.example\.com/team=media`}},
+			want: `{annotations: {"example.com/team": "media"}}`,
+		},
+		{
+			name: "value may contain '='",
+			sets: SetValues{SetString: []string{"args=--level=debug"}},
+			want: `{args: "--level=debug"}`,
+		},
+		{
+			name: "set-string applies after set",
+			sets: SetValues{Set: []string{"tag=1"}, SetString: []string{"tag=2"}},
+			want: `{tag: "2"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := cuecontext.New()
+			var base cue.Value
+			if tt.base != "" {
+				base = ctx.CompileString(tt.base)
+			}
+			got, err := ApplySetValues(ctx, base, tt.sets)
+			require.NoError(t, err)
+			assert.True(t, got.Equals(ctx.CompileString(tt.want)), "got %v", got)
+		})
+	}
+}
+
+func TestApplySetValues_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		set     string
+		wantErr string
+	}{
+		{name: "missing value", set: "replicas", wantErr: "expected path=value"},
+		{name: "empty path", set: "=3", wantErr: "empty path"},
+		{name: "empty field", set: "db..port=1", wantErr: "empty field name"},
+		{name: "unclosed index", set: "ports[0=1", wantErr: "unclosed"},
+		{name: "bad index", set: "ports[x]=1", wantErr: "invalid list index"},
+		{name: "index without field", set: "[0]=1", wantErr: "list index without a field"},
+		{name: "index out of range", base: `{ports: [80]}`, set: "ports[3]=1", wantErr: "ports[3] is out of range (length 1)"},
+		{name: "index into a struct", base: `{db: {port: 1}}`, set: "db[0]=1", wantErr: "db is not a list"},
+		{name: "field of a scalar", base: `{db: 1}`, set: "db.port=1", wantErr: "db is not a struct"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := cuecontext.New()
+			var base cue.Value
+			if tt.base != "" {
+				base = ctx.CompileString(tt.base)
+			}
+			_, err := ApplySetValues(ctx, base, SetValues{Set: []string{tt.set}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), "--set")
+		})
+	}
+}
+
+// A typo in a --set path is caught by #config validation exactly like a typo
+// in a values file.
+func TestApplySetValues_TypoReportsFieldNotAllowed(t *testing.T) {
+	ctx := cuecontext.New()
+	schema := ctx.CompileString(`close({replicas: int, image: string})`)
+	base := ctx.CompileString(`{replicas: 1, image: "nginx"}`)
+
+	values, err := ApplySetValues(ctx, base, SetValues{Set: []string{"replicsa=3"}})
+	require.NoError(t, err)
+
+	_, cfgErr := validate.Config(schema, []cue.Value{values}, "module", "demo")
+	require.NotNil(t, cfgErr)
+	groups := cfgErr.GroupedErrors()
+	require.NotEmpty(t, groups)
+	assert.Equal(t, "field not allowed", groups[0].Message)
+	assert.Equal(t, "values.replicsa", groups[0].Locations[0].Path)
+}
@@ -0,0 +1,55 @@
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueyaml "cuelang.org/go/encoding/yaml"
+)
+
+// ValuesDocument is a values source that is not a file on disk — a
+// ConfigMap or Secret key read from a cluster. Name identifies it in error
+// positions; a .yaml or .yml suffix marks the content as YAML.
+type ValuesDocument struct {
+	Name string
+	Data []byte
+}
+
+// LoadValuesDocument compiles a ValuesDocument the way LoadValuesFile
+// compiles a file: CUE (which includes JSON) or YAML, returning the "values"
+// field when the document wraps its values in one. A document whose name
+// does not say YAML is compiled as CUE first and read as YAML only if that
+// fails. Like values on stdin, a document cannot import other packages.
+func LoadValuesDocument(ctx *cue.Context, doc ValuesDocument) (cue.Value, error) {
+	ext := strings.ToLower(filepath.Ext(doc.Name))
+	if ext == ".yaml" || ext == ".yml" {
+		return loadValuesYAML(ctx, doc)
+	}
+
+	val := ctx.CompileBytes(doc.Data, cue.Filename(doc.Name))
+	if cueErr := val.Err(); cueErr != nil {
+		if ext == ".cue" || ext == ".json" {
+			return cue.Value{}, fmt.Errorf("building values from %s: %w", doc.Name, cueErr)
+		}
+		yamlVal, yamlErr := loadValuesYAML(ctx, doc)
+		if yamlErr != nil {
+			return cue.Value{}, fmt.Errorf("building values from %s: not CUE (%w) nor YAML (%w)", doc.Name, cueErr, yamlErr)
+		}
+		return yamlVal, nil
+	}
+	return valuesField(val), nil
+}
+
+func loadValuesYAML(ctx *cue.Context, doc ValuesDocument) (cue.Value, error) {
+	file, err := cueyaml.Extract(doc.Name, doc.Data)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("parsing YAML values from %s: %w", doc.Name, err)
+	}
+	val := ctx.BuildFile(file)
+	if err := val.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("building values from %s: %w", doc.Name, err)
+	}
+	return valuesField(val), nil
+}
@@ -0,0 +1,37 @@
+package loader
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadValuesDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  ValuesDocument
+	}{
+		{name: "cue", doc: ValuesDocument{Name: "demo/app:values.cue", Data: []byte(`values: replicas: 3`)}},
+		{name: "json", doc: ValuesDocument{Name: "demo/app:values.json", Data: []byte(`{"replicas": 3}`)}},
+		{name: "yaml by suffix", doc: ValuesDocument{Name: "demo/app:values.yaml", Data: []byte("values:\n  replicas: 3\n")}},
+		{name: "yaml by content", doc: ValuesDocument{Name: "demo/app:values", Data: []byte("replicas: 3\nports:\n  - 80\n")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := LoadValuesDocument(cuecontext.New(), tc.doc)
+			require.NoError(t, err)
+			n, err := val.LookupPath(cue.ParsePath("replicas")).Int64()
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), n)
+		})
+	}
+}
+
+func TestLoadValuesDocument_Invalid(t *testing.T) {
+	_, err := LoadValuesDocument(cuecontext.New(), ValuesDocument{Name: "demo/app:values", Data: []byte("a: [")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "demo/app:values")
+}
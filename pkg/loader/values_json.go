@@ -0,0 +1,27 @@
+package loader
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	cuejson "cuelang.org/go/encoding/json"
+)
+
+// LoadValuesJSONFiles reads --values-json files, one JSON object each, and
+// merges them like LoadValuesYAMLFiles: later files win, mappings merged key
+// by key. Positions point into the JSON. No paths returns the zero
+// cue.Value.
+func LoadValuesJSONFiles(ctx *cue.Context, paths []string, env EnvExpansion) (cue.Value, error) {
+	return loadMergedValuesFiles(ctx, paths, env, "JSON", func(path string, data []byte) ([][]ast.Decl, error) {
+		expr, err := cuejson.Extract(path, data)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := expr.(*ast.StructLit)
+		if !ok {
+			return nil, fmt.Errorf("not a JSON object")
+		}
+		return [][]ast.Decl{obj.Elts}, nil
+	})
+}
@@ -0,0 +1,50 @@
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadValuesJSONFiles(t *testing.T) {
+	ctx := cuecontext.New()
+	dir := t.TempDir()
+
+	t.Run("later files win", func(t *testing.T) {
+		base := writeValuesFile(t, dir, "base.json", `{"replicas": 1, "image": {"repository": "nginx", "tag": "1.0"}}`)
+		prod := writeValuesFile(t, dir, "prod.json", `{"values": {"image": {"tag": "2.0"}}}`)
+
+		val, err := LoadValuesJSONFiles(ctx, []string{base, prod}, EnvExpansion{})
+		require.NoError(t, err)
+		var got map[string]any
+		require.NoError(t, val.Decode(&got))
+		assert.Equal(t, map[string]any{
+			"replicas": int64(1),
+			"image":    map[string]any{"repository": "nginx", "tag": "2.0"},
+		}, got)
+	})
+
+	t.Run("expands the environment first", func(t *testing.T) {
+		path := writeValuesFile(t, dir, "env.json", `{"tag": "${TAG}"}`)
+		exp := EnvExpansion{Enabled: true, Lookup: func(string) (string, bool) { return "3.0", true }}
+		val, err := LoadValuesJSONFiles(ctx, []string{path}, exp)
+		require.NoError(t, err)
+		var got map[string]any
+		require.NoError(t, val.Decode(&got))
+		assert.Equal(t, map[string]any{"tag": "3.0"}, got)
+	})
+
+	t.Run("an array is rejected", func(t *testing.T) {
+		path := writeValuesFile(t, dir, "list.json", `[1, 2]`)
+		_, err := LoadValuesJSONFiles(ctx, []string{path}, EnvExpansion{})
+		assert.ErrorContains(t, err, "not a JSON object")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadValuesJSONFiles(ctx, []string{filepath.Join(dir, "nope.json")}, EnvExpansion{})
+		assert.ErrorContains(t, err, "not found")
+	})
+}
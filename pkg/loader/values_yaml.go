@@ -0,0 +1,167 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	cueyaml "cuelang.org/go/encoding/yaml"
+)
+
+// LoadValuesYAMLFiles reads --values-yaml files the way Helm reads values
+// files: each file may hold several documents, and every document is merged
+// over the ones before it — mappings key by key, any other value replaced
+// whole — rather than unified, so a later file can change a value an earlier
+// one set. A document that wraps its values in a top-level "values" key is
+// unwrapped first, as with -f. The merged result keeps each value's YAML
+// position, so a field #config rejects is reported where it was written. No
+// paths returns the zero cue.Value.
+func LoadValuesYAMLFiles(ctx *cue.Context, paths []string, env EnvExpansion) (cue.Value, error) {
+	return loadMergedValuesFiles(ctx, paths, env, "YAML", func(path string, data []byte) ([][]ast.Decl, error) {
+		file, err := cueyaml.Extract(path, data)
+		if err != nil {
+			return nil, err
+		}
+		return yamlDocuments(file)
+	})
+}
+
+// loadMergedValuesFiles reads each of paths, expands it per env, splits it
+// into documents with extract, and merges every document over the ones
+// before it (see LoadValuesYAMLFiles). format names the encoding in errors.
+func loadMergedValuesFiles(ctx *cue.Context, paths []string, env EnvExpansion, format string,
+	extract func(path string, data []byte) ([][]ast.Decl, error)) (cue.Value, error) {
+	if len(paths) == 0 {
+		return cue.Value{}, nil
+	}
+	var merged []ast.Decl
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return cue.Value{}, fmt.Errorf("values file %q not found", path)
+			}
+			return cue.Value{}, fmt.Errorf("reading values file %q: %w", path, err)
+		}
+		if data, err = env.Expand(path, data); err != nil {
+			return cue.Value{}, err
+		}
+		docs, err := extract(path, data)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("parsing %s values from %s: %w", format, path, err)
+		}
+		for _, doc := range docs {
+			merged = mergeYAMLFields(merged, unwrapValuesDecls(doc))
+		}
+	}
+
+	val := ctx.BuildFile(&ast.File{Decls: merged})
+	if err := val.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("building %s values: %w", format, err)
+	}
+	return val, nil
+}
+
+// yamlDocuments returns the top-level fields of each document of an
+// extracted YAML stream. Empty documents are skipped; a document that is not
+// a mapping is an error.
+func yamlDocuments(file *ast.File) ([][]ast.Decl, error) {
+	if len(file.Decls) != 1 {
+		return [][]ast.Decl{file.Decls}, nil
+	}
+	embed, ok := file.Decls[0].(*ast.EmbedDecl)
+	if !ok {
+		return [][]ast.Decl{file.Decls}, nil
+	}
+	exprs := []ast.Expr{embed.Expr}
+	if list, ok := embed.Expr.(*ast.ListLit); ok {
+		exprs = list.Elts
+	}
+	var docs [][]ast.Decl
+	for i, expr := range exprs {
+		if s, ok := expr.(*ast.StructLit); ok {
+			docs = append(docs, s.Elts)
+			continue
+		}
+		if !emptyYAMLDocument(expr) {
+			return nil, fmt.Errorf("document %d is not a mapping", i+1)
+		}
+	}
+	return docs, nil
+}
+
+// emptyYAMLDocument reports whether expr is how an empty YAML document
+// extracts: null, or the default *null | _ for an empty stream.
+func emptyYAMLDocument(expr ast.Expr) bool {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		return x.Value == "null"
+	case *ast.BinaryExpr:
+		return true
+	}
+	return false
+}
+
+// unwrapValuesDecls returns the fields of a top-level "values" mapping when
+// the document has one, else the document's own fields.
+func unwrapValuesDecls(decls []ast.Decl) []ast.Decl {
+	for _, d := range decls {
+		if f, ok := d.(*ast.Field); ok && yamlFieldName(f) == "values" {
+			if s, ok := f.Value.(*ast.StructLit); ok {
+				return s.Elts
+			}
+		}
+	}
+	return decls
+}
+
+// mergeYAMLFields merges over into base: a field over shares with base is
+// merged recursively when both are mappings and replaced otherwise; new
+// fields are appended. base is not modified.
+func mergeYAMLFields(base, over []ast.Decl) []ast.Decl {
+	out := make([]ast.Decl, len(base))
+	copy(out, base)
+	index := make(map[string]int, len(out))
+	for i, d := range out {
+		if f, ok := d.(*ast.Field); ok {
+			index[yamlFieldName(f)] = i
+		}
+	}
+	for _, d := range over {
+		f, ok := d.(*ast.Field)
+		if !ok {
+			out = append(out, d)
+			continue
+		}
+		name := yamlFieldName(f)
+		i, exists := index[name]
+		if !exists {
+			index[name] = len(out)
+			out = append(out, f)
+			continue
+		}
+		prev := out[i].(*ast.Field)
+		prevStruct, prevOK := prev.Value.(*ast.StructLit)
+		overStruct, overOK := f.Value.(*ast.StructLit)
+		if prevOK && overOK {
+			mergedField := *f
+			mergedStruct := *overStruct
+			mergedStruct.Elts = mergeYAMLFields(prevStruct.Elts, overStruct.Elts)
+			mergedField.Value = &mergedStruct
+			out[i] = &mergedField
+			continue
+		}
+		out[i] = f
+	}
+	return out
+}
+
+// yamlFieldName returns a field's label as a plain name.
+func yamlFieldName(f *ast.Field) string {
+	name, _, err := ast.LabelName(f.Label)
+	if err != nil {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeValuesFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadValuesYAMLFiles(t *testing.T) {
+	ctx := cuecontext.New()
+	dir := t.TempDir()
+
+	t.Run("later files and documents win", func(t *testing.T) {
+		base := writeValuesFile(t, dir, "base.yaml", "replicas: 1\nimage:\n  repository: nginx\n  tag: \"1.0\"\nports: [80, 443]\n")
+		prod := writeValuesFile(t, dir, "prod.yaml", "image:\n  tag: \"2.0\"\nports: [8080]\n---\nreplicas: 3\n---\n")
+
+		val, err := LoadValuesYAMLFiles(ctx, []string{base, prod}, EnvExpansion{})
+		require.NoError(t, err)
+		var got map[string]any
+		require.NoError(t, val.Decode(&got))
+		assert.Equal(t, map[string]any{
+			"replicas": int64(3),
+			"image":    map[string]any{"repository": "nginx", "tag": "2.0"},
+			"ports":    []any{int64(8080)},
+		}, got)
+	})
+
+	t.Run("values wrapper is unwrapped", func(t *testing.T) {
+		path := writeValuesFile(t, dir, "wrapped.yaml", "values:\n  replicas: 2\n")
+		val, err := LoadValuesYAMLFiles(ctx, []string{path}, EnvExpansion{})
+		require.NoError(t, err)
+		replicas, err := val.LookupPath(cue.ParsePath("replicas")).Int64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), replicas)
+	})
+
+	t.Run("errors point into the YAML", func(t *testing.T) {
+		path := writeValuesFile(t, dir, "typo.yaml", "replicas: 1\nreplcas: 3\n")
+		val, err := LoadValuesYAMLFiles(ctx, []string{path}, EnvExpansion{})
+		require.NoError(t, err)
+		schema := ctx.CompileString(`close({replicas: int})`)
+		unifyErr := schema.Unify(val).Validate()
+		require.Error(t, unifyErr)
+		var found bool
+		for _, e := range errors.Errors(unifyErr) {
+			for _, pos := range errors.Positions(e) {
+				if pos.Filename() == path && pos.Line() == 2 {
+					found = true
+				}
+			}
+		}
+		assert.True(t, found, "expected a position at %s:2 in %v", path, errors.Details(unifyErr, nil))
+	})
+
+	t.Run("a scalar document is rejected", func(t *testing.T) {
+		path := writeValuesFile(t, dir, "scalar.yaml", "replicas: 1\n---\n3\n")
+		_, err := LoadValuesYAMLFiles(ctx, []string{path}, EnvExpansion{})
+		assert.ErrorContains(t, err, "document 2 is not a mapping")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadValuesYAMLFiles(ctx, []string{filepath.Join(dir, "nope.yaml")}, EnvExpansion{})
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		val, err := LoadValuesYAMLFiles(ctx, nil, EnvExpansion{})
+		require.NoError(t, err)
+		assert.False(t, val.Exists())
+	})
+}
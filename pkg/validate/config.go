@@ -51,6 +51,62 @@ func Config(schema cue.Value, values []cue.Value, context, name string) (cue.Val
 	return merged, nil
 }
 
+// UnknownFields reports every field in value that schema does not declare, as
+// a ConfigError, or nil when there are none. A field is declared when schema
+// names it or a pattern constraint ([string]: T) covers it; a field admitted
+// only by an open struct (...) is reported like one a closed struct rejects.
+// Unlike Config it checks nothing else: types, constraints, and concreteness
+// are left to the caller's own validation.
+func UnknownFields(schema, value cue.Value, context, name string) *oerrors.ConfigError {
+	if !schema.Exists() || !value.Exists() {
+		return nil
+	}
+	if acc := walkUndeclared(schema, value, nil, nil); acc != nil {
+		return &oerrors.ConfigError{Context: context, Name: name, RawError: acc}
+	}
+	return nil
+}
+
+func walkUndeclared(schema, val cue.Value, pathPrefix []string, acc cueerrors.Error) cueerrors.Error {
+	iter, err := val.Fields(cue.Optional(true))
+	if err != nil {
+		return acc
+	}
+	for iter.Next() {
+		sel := iter.Selector()
+		child := iter.Value()
+		fieldPath := append(append([]string{}, pathPrefix...), sel.String())
+
+		childSchema, ok := declaredField(schema, sel)
+		if !ok {
+			acc = cueerrors.Append(acc, &fieldNotAllowedError{pos: child.Pos(), path: fieldPath})
+			continue
+		}
+		if child.IncompleteKind() == cue.StructKind {
+			acc = walkUndeclared(childSchema, child, fieldPath, acc)
+		}
+	}
+	return acc
+}
+
+// declaredField returns the schema for field sel of schema and whether schema
+// declares it, by name or through a pattern constraint. Filling the field with
+// top tells the two open cases apart: a pattern constrains it, ... does not.
+func declaredField(schema cue.Value, sel cue.Selector) (cue.Value, bool) {
+	if !schema.Allows(sel) {
+		return cue.Value{}, false
+	}
+	path := cue.MakePath(sel)
+	if field := schema.LookupPath(path); field.Exists() {
+		return field, true
+	}
+	field := schema.FillPath(path, schema.Context().CompileString("_")).LookupPath(path)
+	if !field.Exists() || field.IncompleteKind() == cue.TopKind {
+		return cue.Value{}, false
+	}
+	return field, true
+}
+
 func appendSchemaErrors(schema, value cue.Value, acc cueerrors.Error, requireConcrete bool) (cueerrors.Error, bool) {
 	beforeCount := len(cueerrors.Errors(acc))
 	changed := false
@@ -5,6 +5,7 @@ import (
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -177,3 +178,40 @@ func TestConfig_BundleContext(t *testing.T) {
 	assert.Equal(t, "my-bundle", cfgErr.Name)
 	assert.Contains(t, cfgErr.Error(), `bundle "my-bundle"`)
 }
+
+func TestUnknownFields(t *testing.T) {
+	ctx := cuecontext.New()
+	schema := ctx.CompileString(`close({
+		name: string
+		db: close({port: int})
+	})`)
+
+	t.Run("reports nested unknown fields", func(t *testing.T) {
+		values := ctx.CompileString(`{name: "app", db: {prot: 5432}, replcas: 2}`)
+		err := UnknownFields(schema, values, "module", "demo")
+		require.NotNil(t, err)
+		assert.Len(t, cueerrors.Errors(err.RawError), 2)
+	})
+
+	t.Run("ignores other schema violations", func(t *testing.T) {
+		values := ctx.CompileString(`{name: 3}`)
+		assert.Nil(t, UnknownFields(schema, values, "module", "demo"))
+	})
+
+	t.Run("reports fields only an open struct admits", func(t *testing.T) {
+		open := ctx.CompileString(`{name: string, ...}`)
+		values := ctx.CompileString(`{name: "app", extra: true}`)
+		assert.NotNil(t, UnknownFields(open, values, "module", "demo"))
+	})
+
+	t.Run("optional fields are declared", func(t *testing.T) {
+		optional := ctx.CompileString(`close({tls?: close({enabled: bool})})`)
+		assert.Nil(t, UnknownFields(optional, ctx.CompileString(`{tls: {enabled: true}}`), "module", "demo"))
+	})
+
+	t.Run("pattern constraints declare their fields", func(t *testing.T) {
+		patterned := ctx.CompileString(`close({labels: [string]: string, hosts: [string]: close({port: int})})`)
+		assert.Nil(t, UnknownFields(patterned, ctx.CompileString(`{labels: {team: "web"}, hosts: {a: {port: 1}}}`), "module", "demo"))
+		assert.NotNil(t, UnknownFields(patterned, ctx.CompileString(`{hosts: {a: {prot: 1}}}`), "module", "demo"))
+	})
+}
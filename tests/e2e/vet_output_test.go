@@ -61,12 +61,15 @@ func TestE2E_ModuleVet_Output(t *testing.T) {
 	require.True(t, errors.As(err, &exitErr))
 	assert.Equal(t, 2, exitErr.ExitCode())
 
-	// Assert grouped shape exists
-	assert.Contains(t, stderr, "values do not satisfy #config: 2 issues")
+	// Assert the one-pass report: a summary heading, then every diagnostic
+	// with its path and source position
+	assert.Contains(t, stderr, "module vet: 2 error(s), 0 warning(s)")
 	assert.Contains(t, stderr, "field not allowed")
-	assert.Contains(t, stderr, "values.test")
+	assert.Contains(t, stderr, "path: values.test")
 	assert.Contains(t, stderr, "conflicting values")
+	assert.Contains(t, stderr, "path: values.media.test")
+	assert.Contains(t, stderr, "values.cue:4:2")
 
 	// Anti-regression: Assert flattened shape does NOT exist
-	assert.NotContains(t, stderr, "ERRO values do not satisfy #config: - ")
+	assert.NotContains(t, stderr, "ERRO module vet: - ")
 }
@@ -165,7 +165,7 @@ func main() {
 	fmt.Printf("   OK: %d resources applied\n", applyResult3.Applied)
 
 	// Prune stale resources.
-	err = inventory.PruneStaleResources(ctx, client, stale58)
+	err = inventory.PruneStaleResources(ctx, client, stale58, inventory.PruneOptions{})
 	check("pruning stale resources", err)
 	fmt.Println("   OK: pruning complete")
 
@@ -77,7 +77,7 @@ func main() {
 	if len(stale) != 1 || stale[0].Name != "cm-stale" {
 		failf("expected stale set [cm-stale], got %v", stale)
 	}
-	check("pruning cm-stale", inventory.PruneStaleResources(ctx, client, stale))
+	check("pruning cm-stale", inventory.PruneStaleResources(ctx, client, stale, inventory.PruneOptions{}))
 	waitForConfigMap(ctx, client, "cm-stale", false)
 	fmt.Println("   OK: cm-stale pruned")
 